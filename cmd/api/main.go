@@ -3,16 +3,35 @@ package main
 import (
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/forum"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/notification"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/pricing"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/progress"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/session"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/analytics"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/apikey"
+	"github.com/highfive-compfest/seatudy-backend/internal/scheduler"
 	"log"
 	"os"
+	"time"
 
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/certificate"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/cohort"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/courseenroll"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/curriculum"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/drip"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/learningpath"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/livesession"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/revision"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/taxonomy"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/submission"
 
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/assignment"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/attachment"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/material"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/recommendation"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/review"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/trending"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/waitlist"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/wishlist"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/wallet"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 
@@ -21,6 +40,7 @@ import (
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/user"
 	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/password"
 	"github.com/joho/godotenv"
 )
 
@@ -37,7 +57,29 @@ func main() {
 		&schema.Wallet{},
 		&schema.MidtransTransaction{},
 		&schema.User{},
+		&schema.Session{},
+		&schema.APIKey{},
+		&schema.PasswordHistory{},
+		&schema.CourseSection{},
+		&schema.LessonProgress{},
+		&schema.ContentUnlockNotice{},
+		&schema.Certificate{},
+		&schema.Category{},
+		&schema.Tag{},
 		&schema.Course{},
+		&schema.CoursePrerequisite{},
+		&schema.LearningPath{},
+		&schema.LearningPathCourse{},
+		&schema.Revision{},
+		&schema.WaitlistEntry{},
+		&schema.WishlistItem{},
+		&schema.CourseSale{},
+		&schema.CoursePriceChange{},
+		&schema.CourseSimilarity{},
+		&schema.CourseTrend{},
+		&schema.Cohort{},
+		&schema.LiveSession{},
+		&schema.CalendarFeed{},
 		&schema.Material{},
 		&schema.Assignment{},
 		&schema.Submission{},
@@ -75,21 +117,95 @@ func main() {
 
 	// User
 	userRepo := user.NewRepository(db, walletRepo)
-	userUseCase := user.NewUseCase(userRepo,uploader)
+	userUseCase := user.NewUseCase(userRepo, walletRepo, uploader)
 	user.NewRestController(engine, userUseCase)
+	scheduler.Every(time.Hour, "purge deleted users", userUseCase.PurgeScheduledDeletions)
+
+	// Session
+	sessionRepo := session.NewRepository(db)
+	sessionUseCase := session.NewUseCase(sessionRepo)
+	middleware.SetSessionValidator(sessionUseCase)
+	session.NewRestController(engine, sessionUseCase)
+
+	// API Key
+	apiKeyRepo := apikey.NewRepository(db)
+	apiKeyUseCase := apikey.NewUseCase(apiKeyRepo)
+	middleware.SetAPIKeyValidator(apiKeyUseCase)
+	apikey.NewRestController(engine, apiKeyUseCase)
 
 	// Auth
 	authRepo := auth.NewRepository(rds)
-	authUseCase := auth.NewUseCase(authRepo, userRepo, mailDialer)
+	authUseCase := auth.NewUseCase(authRepo, userRepo, sessionUseCase, password.NewPolicyFromEnv(), mailDialer)
 	auth.NewRestController(engine, authUseCase)
 
 	courseEnrollRepo := courseenroll.NewRepository(db)
 	courseEnrollUseCase := courseenroll.NewUseCase(courseEnrollRepo)
 
+	// Taxonomy
+	taxonomyRepo := taxonomy.NewRepository(db)
+	taxonomyUseCase := taxonomy.NewUseCase(taxonomyRepo)
+	taxonomy.NewRestController(engine, taxonomyUseCase)
+
+	// Drip schedule
+	dripRepo := drip.NewRepository(db)
+	dripUseCase := drip.NewUseCase(dripRepo)
+	drip.NewRestController(engine, dripUseCase)
+	scheduler.Every(15*time.Minute, "notify unlocked content", dripUseCase.NotifyUnlocked)
+
+	// Revision history
+	revisionRepo := revision.NewRepository(db)
+	revisionUseCase := revision.NewUseCase(revisionRepo)
+	revision.NewRestController(engine, revisionUseCase)
+
+	// Waitlist
+	waitlistRepo := waitlist.NewRepository(db)
+	waitlistUseCase := waitlist.NewUseCase(waitlistRepo, notificationRepo)
+	waitlist.NewRestController(engine, waitlistUseCase)
+	scheduler.Every(5*time.Minute, "offer waitlist seats", waitlistUseCase.OfferSeats)
+
+	// Wishlist
+	wishlistRepo := wishlist.NewRepository(db)
+	wishlistUseCase := wishlist.NewUseCase(wishlistRepo, notificationRepo, mailDialer)
+	wishlist.NewRestController(engine, wishlistUseCase)
+
+	// Sales and price history
+	pricingRepo := pricing.NewRepository(db)
+	pricingUseCase := pricing.NewUseCase(pricingRepo)
+	pricing.NewRestController(engine, pricingUseCase)
+
 	// Course
 	courseRepo := course.NewRepository(db)
-	courseUseCase := course.NewUseCase(courseRepo, walletRepo, *courseEnrollUseCase, userRepo, notificationRepo, mailDialer,uploader)
-	course.NewRestController(engine, courseUseCase, walletUseCase)
+	courseUseCase := course.NewUseCase(courseRepo, walletRepo, *courseEnrollUseCase, userRepo, notificationRepo, mailDialer,uploader,
+		taxonomyUseCase, revisionUseCase, waitlistUseCase, wishlistUseCase, pricingUseCase)
+	course.NewRestController(engine, courseUseCase, walletUseCase, dripUseCase)
+
+	// Recommendations
+	recommendationRepo := recommendation.NewRepository(db, rds)
+	recommendationUseCase := recommendation.NewUseCase(recommendationRepo, pricingUseCase)
+	recommendation.NewRestController(engine, recommendationUseCase)
+	scheduler.Every(6*time.Hour, "refresh course similarities", recommendationUseCase.RefreshSimilarities)
+
+	// Trending
+	trendingRepo := trending.NewRepository(db)
+	trendingUseCase := trending.NewUseCase(trendingRepo, pricingUseCase)
+	trending.NewRestController(engine, trendingUseCase)
+	scheduler.Every(time.Hour, "refresh trending courses", trendingUseCase.Refresh)
+
+	// Instructor analytics
+	analyticsRepo := analytics.NewRepository(db)
+	analyticsUseCase := analytics.NewUseCase(analyticsRepo)
+	analytics.NewRestController(engine, analyticsUseCase)
+
+	// Cohorts
+	cohortRepo := cohort.NewRepository(db)
+	cohortUseCase := cohort.NewUseCase(cohortRepo)
+	cohort.NewRestController(engine, cohortUseCase)
+
+	// Live sessions and calendar feeds
+	liveSessionRepo := livesession.NewRepository(db)
+	liveSessionUseCase := livesession.NewUseCase(liveSessionRepo, notificationRepo)
+	livesession.NewRestController(engine, liveSessionUseCase)
+	scheduler.Every(5*time.Minute, "send live session reminders", liveSessionUseCase.SendReminders)
 
 	// Attachment
 	attachmentRepo := attachment.NewRepository(db)
@@ -99,17 +215,39 @@ func main() {
 	// Assignment
 	assignmentRepo := assignment.NewRepository(db)
 	assignmentUseCase := assignment.NewUseCase(assignmentRepo, attachmentUseCase)
-	assignment.NewRestController(engine, assignmentUseCase, courseUseCase)
+	assignment.NewRestController(engine, assignmentUseCase, courseUseCase, cohortUseCase)
+
+	// Certificate
+	certificateRepo := certificate.NewRepository(db)
+	certificateUseCase := certificate.NewUseCase(certificateRepo, userRepo, courseRepo, uploader)
+	certificate.NewRestController(engine, certificateUseCase)
+	// Certificates are issued as courses are completed; the job catches any that failed then
+	scheduler.Every(5*time.Minute, "issue certificates", certificateUseCase.IssuePending)
+
+	// Progress
+	progressRepo := progress.NewRepository(db)
+	progressUseCase := progress.NewUseCase(progressRepo, courseRepo, courseEnrollRepo, dripUseCase, certificateUseCase)
+	progress.NewRestController(engine, progressUseCase)
 
 	// Submission
 	submissionRepo := submission.NewRepository(db)
 	submissionUseCase := submission.NewUseCase(submissionRepo, assignmentRepo, *attachmentUseCase, courseRepo,
-		courseEnrollRepo, userRepo, notificationRepo, mailDialer)
+		courseEnrollRepo, userRepo, notificationRepo, mailDialer, progressUseCase)
 	submission.NewRestController(engine, submissionUseCase)
 	//Material
 	materialRepo := material.NewRepository(db)
-	materialUsecase := material.NewUseCase(materialRepo, attachmentUseCase)
-	material.NewRestController(engine, materialUsecase, courseUseCase)
+	materialUsecase := material.NewUseCase(materialRepo, attachmentUseCase, revisionUseCase)
+	material.NewRestController(engine, materialUsecase, courseUseCase, dripUseCase)
+
+	// Curriculum
+	curriculumRepo := curriculum.NewRepository(db)
+	curriculumUseCase := curriculum.NewUseCase(curriculumRepo, courseRepo)
+	curriculum.NewRestController(engine, curriculumUseCase)
+
+	// Learning paths
+	learningPathRepo := learningpath.NewRepository(db)
+	learningPathUseCase := learningpath.NewUseCase(learningPathRepo, courseRepo)
+	learningpath.NewRestController(engine, learningPathUseCase)
 
 	// Review
 	reviewRepo := review.NewRepository(db)
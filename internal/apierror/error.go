@@ -50,4 +50,12 @@ var (
 	ErrInsufficientBalance = NewApiErrorBuilder().
 				WithHttpStatus(http.StatusBadRequest).
 				WithMessage("INSUFFICIENT_BALANCE")
+
+	ErrAPIKeyNotAllowed = NewApiErrorBuilder().
+				WithHttpStatus(http.StatusForbidden).
+				WithMessage("API_KEY_NOT_ALLOWED")
+
+	ErrAPIKeyScope = NewApiErrorBuilder().
+			WithHttpStatus(http.StatusForbidden).
+			WithMessage("API_KEY_SCOPE_INSUFFICIENT")
 )
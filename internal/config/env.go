@@ -42,6 +42,18 @@ type environmentVariables struct {
 
 	MidtransServerKey   string
 	MidtransEnvironment midtrans.EnvironmentType
+
+	PasswordMinLength     int
+	PasswordRequireLower  bool
+	PasswordRequireUpper  bool
+	PasswordRequireDigit  bool
+	PasswordRequireSymbol bool
+	PasswordHistorySize   int
+
+	CourseReviewRequired bool
+
+	ProgressMaterialWeight   int
+	ProgressAssignmentWeight int
 }
 
 var Env *environmentVariables
@@ -106,5 +118,41 @@ func LoadEnv() {
 	//	env.MidtransEnvironment = midtrans.Production
 	//}
 
+	env.PasswordMinLength = intOrDefault("PASSWORD_MIN_LENGTH", 8)
+	env.PasswordRequireLower = boolOrDefault("PASSWORD_REQUIRE_LOWER", true)
+	env.PasswordRequireUpper = boolOrDefault("PASSWORD_REQUIRE_UPPER", true)
+	env.PasswordRequireDigit = boolOrDefault("PASSWORD_REQUIRE_DIGIT", true)
+	env.PasswordRequireSymbol = boolOrDefault("PASSWORD_REQUIRE_SYMBOL", false)
+	env.PasswordHistorySize = intOrDefault("PASSWORD_HISTORY_SIZE", 5)
+
+	env.CourseReviewRequired = boolOrDefault("COURSE_REVIEW_REQUIRED", false)
+
+	env.ProgressMaterialWeight = intOrDefault("PROGRESS_MATERIAL_WEIGHT", 1)
+	env.ProgressAssignmentWeight = intOrDefault("PROGRESS_ASSIGNMENT_WEIGHT", 2)
+
 	Env = env
 }
+
+func intOrDefault(key string, def int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatal("Fail to parse " + key)
+	}
+	return parsed
+}
+
+func boolOrDefault(key string, def bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return def
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatal("Fail to parse " + key)
+	}
+	return parsed
+}
@@ -53,6 +53,34 @@ func migratePostgresqlTables(db *gorm.DB, migrations ...any) error {
 		return err
 	}
 
+	// Admins cannot register themselves; the role is granted directly in the database
+	if err := db.Exec(`ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'admin';`).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`
+		DO $$ BEGIN
+			CREATE TYPE course_status AS ENUM (
+				'draft',
+				'in_review',
+				'published',
+				'unlisted',
+				'archived'
+			);
+		EXCEPTION
+			WHEN duplicate_object THEN null;
+		END $$;
+	`).Error; err != nil {
+		return err
+	}
+
+	// Courses created before statuses existed were already public, so they start out published
+	if err := db.Exec(`
+		ALTER TABLE IF EXISTS courses ADD COLUMN IF NOT EXISTS status course_status NOT NULL DEFAULT 'published';
+	`).Error; err != nil {
+		return err
+	}
+
 	if err := db.Exec(`
 		DO $$ BEGIN
 			CREATE TYPE course_difficulty AS ENUM (
@@ -96,33 +124,25 @@ func migratePostgresqlTables(db *gorm.DB, migrations ...any) error {
 		return err
 	}
 
+	// Course search uses trigram similarity to tolerate typos
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS pg_trgm;`).Error; err != nil {
+		return err
+	}
+
+	// Enrollments were once checked and inserted without a lock, so duplicates must go before the unique index
+	// on (user_id, course_id) can be created. The earliest enrollment of each pair is kept.
 	if err := db.Exec(`
-        DO $$ BEGIN
-            CREATE TYPE course_category AS ENUM (
-                'Web Development',
-                'Game Development',
-                'Cloud Computing',
-                'Data Science & Analytics',
-                'Programming Languages',
-                'Cybersecurity',
-                'Mobile App Development',
-                'Database Management',
-                'Software Development',
-                'DevOps & Automation',
-                'Networking',
-                'AI & Machine Learning',
-                'Internet of Things (IoT)',
-                'Blockchain & Cryptocurrency',
-                'Augmented Reality (AR) & Virtual Reality (VR)'
-            );
-        EXCEPTION
-            WHEN duplicate_object THEN null;
-        END $$;
-    `).Error; err != nil {
-        return err
-    }
-
-	
+		DO $$ BEGIN
+			IF to_regclass('course_enrolls') IS NOT NULL THEN
+				DELETE FROM course_enrolls ce
+				USING course_enrolls earlier
+				WHERE ce.user_id = earlier.user_id AND ce.course_id = earlier.course_id
+					AND (COALESCE(earlier.created_at, '-infinity'), earlier.id) < (COALESCE(ce.created_at, '-infinity'), ce.id);
+			END IF;
+		END $$;
+	`).Error; err != nil {
+		return err
+	}
 
 	if err := db.AutoMigrate(
 		migrations..., // BREAKING: entities should be passed from cmd/api/main.go due to circular dependency issue
@@ -130,5 +150,80 @@ func migratePostgresqlTables(db *gorm.DB, migrations ...any) error {
 		return err
 	}
 
-	return nil
+	// Trigram indexes let the search autocomplete run ILIKE '%...%' without scanning every row
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_courses_title_trgm ON courses USING gin (title gin_trgm_ops);`).Error; err != nil {
+		return err
+	}
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING gin (name gin_trgm_ops);`).Error; err != nil {
+		return err
+	}
+
+	return migrateCourseCategories(db)
+}
+
+// defaultCategories seeds an empty category table; they are the values of the former course_category enum
+var defaultCategories = []string{
+	"Web Development",
+	"Game Development",
+	"Cloud Computing",
+	"Data Science & Analytics",
+	"Programming Languages",
+	"Cybersecurity",
+	"Mobile App Development",
+	"Database Management",
+	"Software Development",
+	"DevOps & Automation",
+	"Networking",
+	"AI & Machine Learning",
+	"Internet of Things (IoT)",
+	"Blockchain & Cryptocurrency",
+	"Augmented Reality (AR) & Virtual Reality (VR)",
+}
+
+// migrateCourseCategories moves courses off the old course_category enum column onto the categories table.
+// It is a no-op once the enum column is gone.
+func migrateCourseCategories(db *gorm.DB) error {
+	var count int64
+	if err := db.Table("categories").Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		for i, name := range defaultCategories {
+			if err := db.Exec(`
+				INSERT INTO categories (id, name, slug, position, created_at, updated_at)
+				VALUES (gen_random_uuid(), @name, trim(both '-' from regexp_replace(lower(@name), '[^a-z0-9]+', '-', 'g')), @position, now(), now())
+				ON CONFLICT (slug) DO NOTHING
+			`, map[string]interface{}{"name": name, "position": i}).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if !db.Migrator().HasColumn("courses", "category") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO categories (id, name, slug, created_at, updated_at)
+			SELECT gen_random_uuid(), used.name,
+				trim(both '-' from regexp_replace(lower(used.name), '[^a-z0-9]+', '-', 'g')), now(), now()
+			FROM (SELECT DISTINCT category::text AS name FROM courses WHERE category IS NOT NULL) used
+			ON CONFLICT (slug) DO NOTHING
+		`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			UPDATE courses c SET category_id = cat.id
+			FROM categories cat
+			WHERE c.category IS NOT NULL AND c.category_id IS NULL
+				AND cat.slug = trim(both '-' from regexp_replace(lower(c.category::text), '[^a-z0-9]+', '-', 'g'))
+		`).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`ALTER TABLE courses DROP COLUMN category`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`DROP TYPE IF EXISTS course_category`).Error
+	})
 }
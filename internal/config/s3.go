@@ -1,10 +1,13 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 
 	"mime/multipart"
 	"net/url"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -13,6 +16,10 @@ import (
 )
 type FileUploader interface {
     UploadFile(key string, fileHeader *multipart.FileHeader) (string, error)
+    UploadBytes(key string, data []byte, contentType string) (string, error)
+    CopyFile(sourceURL, key string) (string, error)
+    DownloadFile(fileURL string) ([]byte, error)
+    DeleteFile(fileURL string) error
 }	
 type S3FileUploader struct {
 	S3Service *s3.S3
@@ -56,7 +63,90 @@ func (uploader *S3FileUploader) UploadFile(key string, fileHeader *multipart.Fil
 		return "", fmt.Errorf("unable to upload %q to %q: %v", fileHeader.Filename, Env.AwsBucketName, err)
 	}
 
-	// Construct the permanent URL
-	urlStr := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", Env.AwsBucketName, aws.StringValue(uploader.S3Service.Config.Region), encodedKey)
-	return urlStr, nil
+	return uploader.objectURL(encodedKey), nil
+}
+
+// UploadBytes uploads content generated by the server, such as certificates, to the S3 bucket
+func (uploader *S3FileUploader) UploadBytes(key string, data []byte, contentType string) (string, error) {
+	_, err := uploader.S3Service.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(Env.AwsBucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to upload %q to %q: %v", key, Env.AwsBucketName, err)
+	}
+
+	return uploader.objectURL(url.PathEscape(key)), nil
+}
+
+// CopyFile duplicates an object previously uploaded to the bucket under a new key
+func (uploader *S3FileUploader) CopyFile(sourceURL, key string) (string, error) {
+	sourceKey, err := objectKey(sourceURL)
+	if err != nil {
+		return "", err
+	}
+	copySource := (&url.URL{Path: Env.AwsBucketName + "/" + sourceKey}).EscapedPath()
+
+	_, err = uploader.S3Service.CopyObject(&s3.CopyObjectInput{
+		Bucket:     aws.String(Env.AwsBucketName),
+		CopySource: aws.String(copySource),
+		Key:        aws.String(key),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to copy %q to %q: %v", sourceURL, key, err)
+	}
+
+	return uploader.objectURL(url.PathEscape(key)), nil
+}
+
+// DownloadFile reads back an object previously uploaded to the bucket
+func (uploader *S3FileUploader) DownloadFile(fileURL string) ([]byte, error) {
+	key, err := objectKey(fileURL)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := uploader.S3Service.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(Env.AwsBucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to download %q: %v", fileURL, err)
+	}
+	defer output.Body.Close()
+
+	return io.ReadAll(output.Body)
+}
+
+// DeleteFile removes an object previously uploaded to the bucket
+func (uploader *S3FileUploader) DeleteFile(fileURL string) error {
+	key, err := objectKey(fileURL)
+	if err != nil {
+		return err
+	}
+
+	_, err = uploader.S3Service.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(Env.AwsBucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to delete %q: %v", fileURL, err)
+	}
+	return nil
+}
+
+// objectKey recovers the key of an object from the URL returned when it was uploaded
+func objectKey(fileURL string) (string, error) {
+	parsed, err := url.Parse(fileURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid object url %q: %v", fileURL, err)
+	}
+	return strings.TrimPrefix(parsed.Path, "/"), nil
+}
+
+// objectURL constructs the permanent URL of an uploaded object
+func (uploader *S3FileUploader) objectURL(encodedKey string) string {
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", Env.AwsBucketName, aws.StringValue(uploader.S3Service.Config.Region), encodedKey)
 }
@@ -0,0 +1,406 @@
+// Package contentpackage reads SCORM 1.2, SCORM 2004 and IMS Common Cartridge packages into a plain outline of
+// modules and lessons, listing whatever in the package has no counterpart in a Seatudy course.
+package contentpackage
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+const manifestName = "imsmanifest.xml"
+
+// maxManifestSize keeps a hostile package from making the parser read an unbounded manifest
+const maxManifestSize = 5 << 20
+
+type Standard string
+
+const (
+	StandardSCORM12         Standard = "scorm_1.2"
+	StandardSCORM2004       Standard = "scorm_2004"
+	StandardCommonCartridge Standard = "common_cartridge"
+)
+
+var (
+	ErrNoManifest        = errors.New("package has no " + manifestName)
+	ErrMalformedManifest = errors.New("malformed " + manifestName)
+	ErrUnknownStandard   = errors.New("package is neither SCORM nor Common Cartridge")
+	ErrEmptyPackage      = errors.New("package has no organization")
+)
+
+// Package is the outline of a content package. Lessons directly under the organization come first, followed by
+// modules grouping the rest.
+type Package struct {
+	Standard    Standard
+	Title       string
+	Description string
+	Lessons     []Lesson
+	Modules     []Module
+	Unsupported []Unsupported
+}
+
+type Module struct {
+	Title   string
+	Lessons []Lesson
+}
+
+// Lesson is one launchable item. Files are paths of entries in the zip the package was read from.
+type Lesson struct {
+	Title       string
+	Description string
+	Files       []string
+}
+
+// Unsupported describes an item or resource that was left out of the outline or only partially imported
+type Unsupported struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+type manifest struct {
+	Metadata struct {
+		Schema        string `xml:"schema"`
+		SchemaVersion string `xml:"schemaversion"`
+		LOM           struct {
+			Title       langStrings `xml:"general>title"`
+			Description langStrings `xml:"general>description"`
+		} `xml:"lom"`
+	} `xml:"metadata"`
+	Organizations struct {
+		Default       string         `xml:"default,attr"`
+		Organizations []organization `xml:"organization"`
+	} `xml:"organizations"`
+	Resources struct {
+		Base      string     `xml:"base,attr"`
+		Resources []resource `xml:"resource"`
+	} `xml:"resources"`
+}
+
+// langStrings covers both the SCORM <string> and the Common Cartridge <langstring> spellings of LOM text
+type langStrings struct {
+	Strings     []string `xml:"string"`
+	LangStrings []string `xml:"langstring"`
+}
+
+func (l langStrings) text() string {
+	for _, s := range append(l.Strings, l.LangStrings...) {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+type organization struct {
+	Identifier string `xml:"identifier,attr"`
+	Title      string `xml:"title"`
+	Items      []item `xml:"item"`
+}
+
+type item struct {
+	Identifier    string `xml:"identifier,attr"`
+	IdentifierRef string `xml:"identifierref,attr"`
+	Parameters    string `xml:"parameters,attr"`
+	IsVisible     string `xml:"isvisible,attr"`
+	Title         string `xml:"title"`
+	Items         []item `xml:"item"`
+}
+
+type resource struct {
+	Identifier string `xml:"identifier,attr"`
+	Type       string `xml:"type,attr"`
+	Href       string `xml:"href,attr"`
+	Base       string `xml:"base,attr"`
+	// SCORM 1.2 spells the attribute scormtype, SCORM 2004 scormType
+	SCORMType12   string `xml:"scormtype,attr"`
+	SCORMType2004 string `xml:"scormType,attr"`
+	Files         []struct {
+		Href string `xml:"href,attr"`
+	} `xml:"file"`
+}
+
+func (r resource) scormType() string {
+	if r.SCORMType2004 != "" {
+		return strings.ToLower(r.SCORMType2004)
+	}
+	return strings.ToLower(r.SCORMType12)
+}
+
+type weblink struct {
+	Title string `xml:"title"`
+	URL   struct {
+		Href string `xml:"href,attr"`
+	} `xml:"url"`
+}
+
+// Parse reads the manifest of a package. A manifest one folder deep is accepted as well, since zipping a folder
+// instead of its contents is a common mistake.
+func Parse(reader *zip.Reader) (*Package, error) {
+	entries := make(map[string]*zip.File, len(reader.File))
+	for _, file := range reader.File {
+		entries[file.Name] = file
+	}
+
+	root := ""
+	manifestFile, ok := entries[manifestName]
+	if !ok {
+		for name, file := range entries {
+			dir, base := path.Split(name)
+			if base == manifestName && strings.Count(dir, "/") == 1 {
+				if manifestFile != nil {
+					return nil, ErrNoManifest
+				}
+				root, manifestFile = dir, file
+			}
+		}
+		if manifestFile == nil {
+			return nil, ErrNoManifest
+		}
+	}
+
+	var m manifest
+	if err := decodeEntry(manifestFile, &m); err != nil {
+		return nil, err
+	}
+
+	p := &parser{entries: entries, root: root, manifest: &m, resources: make(map[string]resource)}
+	for _, res := range m.Resources.Resources {
+		p.resources[res.Identifier] = res
+	}
+	return p.parse()
+}
+
+func decodeEntry(file *zip.File, v any) error {
+	if file.UncompressedSize64 > maxManifestSize {
+		return ErrMalformedManifest
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return ErrMalformedManifest
+	}
+	defer rc.Close()
+
+	decoder := xml.NewDecoder(io.LimitReader(rc, maxManifestSize))
+	// Packages exported by older authoring tools declare encodings such as windows-1252, whose text has to be
+	// converted before it can be stored. An unknown encoding fails the decode.
+	decoder.CharsetReader = charset.NewReaderLabel
+	if err := decoder.Decode(v); err != nil {
+		return errors.Join(ErrMalformedManifest, err)
+	}
+	return nil
+}
+
+type parser struct {
+	entries   map[string]*zip.File
+	root      string
+	manifest  *manifest
+	resources map[string]resource
+	pkg       *Package
+}
+
+func (p *parser) standard() (Standard, error) {
+	schema := strings.ToLower(p.manifest.Metadata.Schema)
+	version := strings.ToLower(p.manifest.Metadata.SchemaVersion)
+	switch {
+	case strings.Contains(schema, "common cartridge"):
+		return StandardCommonCartridge, nil
+	case strings.Contains(schema, "scorm") && version == "1.2":
+		return StandardSCORM12, nil
+	case strings.Contains(schema, "scorm"):
+		return StandardSCORM2004, nil
+	}
+
+	// Some tools leave the metadata out, but their resources still carry the SCORM type attribute
+	for _, res := range p.manifest.Resources.Resources {
+		if res.SCORMType2004 != "" {
+			return StandardSCORM2004, nil
+		}
+		if res.SCORMType12 != "" {
+			return StandardSCORM12, nil
+		}
+	}
+	return "", ErrUnknownStandard
+}
+
+func (p *parser) parse() (*Package, error) {
+	standard, err := p.standard()
+	if err != nil {
+		return nil, err
+	}
+	org := p.defaultOrganization()
+	if org == nil {
+		return nil, ErrEmptyPackage
+	}
+
+	p.pkg = &Package{
+		Standard:    standard,
+		Title:       p.manifest.Metadata.LOM.Title.text(),
+		Description: p.manifest.Metadata.LOM.Description.text(),
+	}
+	if title := strings.TrimSpace(org.Title); title != "" {
+		p.pkg.Title = title
+	}
+
+	items := org.Items
+	// A cartridge wraps its whole outline in a single untitled root item
+	if len(items) == 1 && items[0].IdentifierRef == "" && strings.TrimSpace(items[0].Title) == "" {
+		items = items[0].Items
+	}
+
+	for _, it := range items {
+		if len(it.Items) == 0 {
+			if lesson, ok := p.lesson(it); ok {
+				p.pkg.Lessons = append(p.pkg.Lessons, lesson)
+			}
+			continue
+		}
+
+		module := Module{Title: strings.TrimSpace(it.Title)}
+		if module.Title == "" {
+			module.Title = it.Identifier
+		}
+		// An item with children may launch content of its own, which becomes the first lesson of its module
+		if it.IdentifierRef != "" {
+			if lesson, ok := p.lesson(item{Identifier: it.Identifier, IdentifierRef: it.IdentifierRef, Title: it.Title}); ok {
+				module.Lessons = append(module.Lessons, lesson)
+			}
+		}
+		// Courses are only one level deep, so deeper items are flattened into the module in document order
+		p.flatten(it.Items, &module)
+		p.pkg.Modules = append(p.pkg.Modules, module)
+	}
+
+	return p.pkg, nil
+}
+
+func (p *parser) defaultOrganization() *organization {
+	orgs := p.manifest.Organizations.Organizations
+	if len(orgs) == 0 {
+		return nil
+	}
+	for i := range orgs {
+		if orgs[i].Identifier == p.manifest.Organizations.Default {
+			return &orgs[i]
+		}
+	}
+	return &orgs[0]
+}
+
+func (p *parser) flatten(items []item, module *Module) {
+	for _, it := range items {
+		if it.IdentifierRef != "" || len(it.Items) == 0 {
+			if lesson, ok := p.lesson(it); ok {
+				module.Lessons = append(module.Lessons, lesson)
+			}
+		}
+		p.flatten(it.Items, module)
+	}
+}
+
+func (p *parser) unsupported(it item, resourceType, reason string) {
+	p.pkg.Unsupported = append(p.pkg.Unsupported, Unsupported{
+		Identifier: it.Identifier,
+		Title:      strings.TrimSpace(it.Title),
+		Type:       resourceType,
+		Reason:     reason,
+	})
+}
+
+// lesson turns a leaf item into a lesson, recording why when it cannot be imported
+func (p *parser) lesson(it item) (Lesson, bool) {
+	title := strings.TrimSpace(it.Title)
+	if title == "" {
+		title = it.Identifier
+	}
+	if it.IdentifierRef == "" {
+		p.unsupported(it, "", "item has no content")
+		return Lesson{}, false
+	}
+	res, ok := p.resources[it.IdentifierRef]
+	if !ok {
+		p.unsupported(it, "", "item refers to unknown resource "+it.IdentifierRef)
+		return Lesson{}, false
+	}
+
+	resourceType := strings.ToLower(res.Type)
+	switch {
+	case resourceType == "webcontent":
+		lesson := Lesson{Title: title, Files: p.files(it, res)}
+		if len(lesson.Files) == 0 {
+			p.unsupported(it, res.Type, "resource has no files in the package")
+			return Lesson{}, false
+		}
+		if res.scormType() == "sco" {
+			p.unsupported(it, res.Type, "SCORM runtime tracking is not supported; the files were imported as attachments")
+		}
+		return lesson, true
+	case strings.HasPrefix(resourceType, "imswl_"):
+		return p.weblinkLesson(it, res, title)
+	case strings.HasPrefix(resourceType, "imsdt_"):
+		p.unsupported(it, res.Type, "discussion topics are not supported")
+	case strings.HasPrefix(resourceType, "imsbasiclti_"):
+		p.unsupported(it, res.Type, "LTI links are not supported")
+	case strings.HasPrefix(resourceType, "imsqti_"):
+		p.unsupported(it, res.Type, "quizzes and question banks are not supported")
+	case strings.HasPrefix(resourceType, "assignment_"):
+		p.unsupported(it, res.Type, "cartridge assignments are not supported")
+	default:
+		p.unsupported(it, res.Type, "resource type is not supported")
+	}
+	return Lesson{}, false
+}
+
+func (p *parser) weblinkLesson(it item, res resource, title string) (Lesson, bool) {
+	files := p.files(it, res)
+	if len(files) == 0 {
+		p.unsupported(it, res.Type, "resource has no files in the package")
+		return Lesson{}, false
+	}
+	var link weblink
+	if err := decodeEntry(p.entries[files[0]], &link); err != nil || link.URL.Href == "" {
+		p.unsupported(it, res.Type, "web link could not be read")
+		return Lesson{}, false
+	}
+	return Lesson{Title: title, Description: link.URL.Href}, true
+}
+
+// files resolves the files of a resource to zip entries, the launch file first, reporting any the package lacks
+func (p *parser) files(it item, res resource) []string {
+	base := path.Join(p.root, p.manifest.Resources.Base, res.Base)
+	hrefs := make([]string, 0, len(res.Files)+1)
+	if res.Href != "" {
+		hrefs = append(hrefs, res.Href)
+	}
+	for _, file := range res.Files {
+		hrefs = append(hrefs, file.Href)
+	}
+
+	seen := make(map[string]bool, len(hrefs))
+	files := make([]string, 0, len(hrefs))
+	for _, href := range hrefs {
+		// A launch URL may carry a query or fragment that is not part of the file name
+		href, _, _ = strings.Cut(href, "?")
+		href, _, _ = strings.Cut(href, "#")
+		if href == "" {
+			continue
+		}
+		name := path.Join(base, href)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if _, ok := p.entries[name]; !ok {
+			p.unsupported(it, res.Type, "file "+href+" is missing from the package")
+			continue
+		}
+		files = append(files, name)
+	}
+	return files
+}
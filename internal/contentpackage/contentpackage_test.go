@@ -0,0 +1,350 @@
+package contentpackage
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildZip packs the given entries, keyed by name, into an in-memory zip
+func buildZip(t *testing.T, entries map[string]string) *zip.Reader {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	return reader
+}
+
+const scorm12Manifest = `<?xml version="1.0" encoding="windows-1252"?>
+<manifest identifier="course" xmlns="http://www.imsproject.org/xsd/imscp_rootv1p1p2"
+	xmlns:adlcp="http://www.adlnet.org/xsd/adlcp_rootv1p2">
+	<metadata>
+		<schema>ADL SCORM</schema>
+		<schemaversion>1.2</schemaversion>
+	</metadata>
+	<organizations default="org">
+		<organization identifier="org">
+			<title>Safety Basics</title>
+			<item identifier="intro" identifierref="res-intro">
+				<title>Introduction</title>
+			</item>
+			<item identifier="module-1">
+				<title>Module 1</title>
+				<item identifier="lesson-1" identifierref="res-lesson-1">
+					<title>Lesson 1</title>
+				</item>
+				<item identifier="nested">
+					<title>Nested</title>
+					<item identifier="lesson-2" identifierref="res-lesson-2">
+						<title>Lesson 2</title>
+					</item>
+				</item>
+			</item>
+		</organization>
+	</organizations>
+	<resources>
+		<resource identifier="res-intro" type="webcontent" adlcp:scormtype="asset" href="intro.html">
+			<file href="intro.html"/>
+		</resource>
+		<resource identifier="res-lesson-1" type="webcontent" adlcp:scormtype="sco" href="lesson1/index.html?page=1">
+			<file href="lesson1/index.html"/>
+			<file href="lesson1/style.css"/>
+		</resource>
+		<resource identifier="res-lesson-2" type="webcontent" adlcp:scormtype="asset" href="lesson2.html"/>
+	</resources>
+</manifest>`
+
+func TestParse_SCORM12(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"imsmanifest.xml":    scorm12Manifest,
+		"intro.html":         "<html></html>",
+		"lesson1/index.html": "<html></html>",
+		"lesson1/style.css":  "body {}",
+		"lesson2.html":       "<html></html>",
+	})
+
+	pkg, err := Parse(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, StandardSCORM12, pkg.Standard)
+	assert.Equal(t, "Safety Basics", pkg.Title)
+	assert.Equal(t, []Lesson{{Title: "Introduction", Files: []string{"intro.html"}}}, pkg.Lessons)
+	assert.Equal(t, []Module{{
+		Title: "Module 1",
+		Lessons: []Lesson{
+			{Title: "Lesson 1", Files: []string{"lesson1/index.html", "lesson1/style.css"}},
+			{Title: "Lesson 2", Files: []string{"lesson2.html"}},
+		},
+	}}, pkg.Modules)
+	// The SCO is imported, but its runtime tracking is reported as lost
+	assert.Equal(t, []Unsupported{{
+		Identifier: "lesson-1",
+		Title:      "Lesson 1",
+		Type:       "webcontent",
+		Reason:     "SCORM runtime tracking is not supported; the files were imported as attachments",
+	}}, pkg.Unsupported)
+}
+
+func TestParse_SCORM2004(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"imsmanifest.xml": `<manifest identifier="course" xmlns:adlcp="http://www.adlnet.org/xsd/adlcp_v1p3">
+			<metadata>
+				<schema>ADL SCORM</schema>
+				<schemaversion>2004 4th Edition</schemaversion>
+				<lom><general>
+					<title><string language="en">Fire Drills</string></title>
+					<description><string language="en">What to do when the alarm rings</string></description>
+				</general></lom>
+			</metadata>
+			<organizations default="second">
+				<organization identifier="first"><title>Ignored</title></organization>
+				<organization identifier="second">
+					<title> </title>
+					<item identifier="drill" identifierref="res-drill"><title>Drill</title></item>
+				</organization>
+			</organizations>
+			<resources xml:base="content/">
+				<resource identifier="res-drill" type="webcontent" adlcp:scormType="asset" href="drill.html"/>
+			</resources>
+		</manifest>`,
+		"content/drill.html": "<html></html>",
+	})
+
+	pkg, err := Parse(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, StandardSCORM2004, pkg.Standard)
+	assert.Equal(t, "Fire Drills", pkg.Title)
+	assert.Equal(t, "What to do when the alarm rings", pkg.Description)
+	assert.Equal(t, []Lesson{{Title: "Drill", Files: []string{"content/drill.html"}}}, pkg.Lessons)
+	assert.Empty(t, pkg.Modules)
+	assert.Empty(t, pkg.Unsupported)
+}
+
+const cartridgeManifest = `<manifest identifier="cartridge">
+	<metadata>
+		<schema>IMS Common Cartridge</schema>
+		<schemaversion>1.3.0</schemaversion>
+		<lom><general><title><langstring>Chemistry 101</langstring></title></general></lom>
+	</metadata>
+	<organizations>
+		<organization identifier="org" structure="rooted-hierarchy">
+			<item identifier="root">
+				<item identifier="week-1">
+					<title>Week 1</title>
+					<item identifier="reading" identifierref="res-reading"><title>Reading</title></item>
+					<item identifier="link" identifierref="res-link"><title>Periodic Table</title></item>
+					<item identifier="forum" identifierref="res-forum"><title>Introduce Yourself</title></item>
+					<item identifier="quiz" identifierref="res-quiz"><title>Quiz 1</title></item>
+					<item identifier="tool" identifierref="res-tool"><title>Lab Tool</title></item>
+				</item>
+			</item>
+		</organization>
+	</organizations>
+	<resources>
+		<resource identifier="res-reading" type="webcontent" href="week1/reading.html">
+			<file href="week1/reading.html"/>
+		</resource>
+		<resource identifier="res-link" type="imswl_xmlv1p3">
+			<file href="week1/link.xml"/>
+		</resource>
+		<resource identifier="res-forum" type="imsdt_xmlv1p3">
+			<file href="week1/forum.xml"/>
+		</resource>
+		<resource identifier="res-quiz" type="imsqti_xmlv1p2/imscc_xmlv1p3/assessment">
+			<file href="week1/quiz.xml"/>
+		</resource>
+		<resource identifier="res-tool" type="imsbasiclti_xmlv1p3">
+			<file href="week1/tool.xml"/>
+		</resource>
+	</resources>
+</manifest>`
+
+func TestParse_CommonCartridge(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"imsmanifest.xml":    cartridgeManifest,
+		"week1/reading.html": "<html></html>",
+		"week1/link.xml":     `<webLink><title>Periodic Table</title><url href="https://example.com/table"/></webLink>`,
+		"week1/forum.xml":    "<topic/>",
+		"week1/quiz.xml":     "<questestinterop/>",
+		"week1/tool.xml":     "<cartridge_basiclti_link/>",
+	})
+
+	pkg, err := Parse(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, StandardCommonCartridge, pkg.Standard)
+	assert.Equal(t, "Chemistry 101", pkg.Title)
+	assert.Empty(t, pkg.Lessons)
+	// The untitled root item is unwrapped, and the web link becomes a lesson pointing at its URL
+	assert.Equal(t, []Module{{
+		Title: "Week 1",
+		Lessons: []Lesson{
+			{Title: "Reading", Files: []string{"week1/reading.html"}},
+			{Title: "Periodic Table", Description: "https://example.com/table"},
+		},
+	}}, pkg.Modules)
+	assert.Equal(t, []Unsupported{
+		{Identifier: "forum", Title: "Introduce Yourself", Type: "imsdt_xmlv1p3", Reason: "discussion topics are not supported"},
+		{Identifier: "quiz", Title: "Quiz 1", Type: "imsqti_xmlv1p2/imscc_xmlv1p3/assessment", Reason: "quizzes and question banks are not supported"},
+		{Identifier: "tool", Title: "Lab Tool", Type: "imsbasiclti_xmlv1p3", Reason: "LTI links are not supported"},
+	}, pkg.Unsupported)
+}
+
+func TestParse_ManifestInFolder(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"safety/imsmanifest.xml":    scorm12Manifest,
+		"safety/intro.html":         "<html></html>",
+		"safety/lesson1/index.html": "<html></html>",
+		"safety/lesson1/style.css":  "body {}",
+		"safety/lesson2.html":       "<html></html>",
+	})
+
+	pkg, err := Parse(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Lesson{{Title: "Introduction", Files: []string{"safety/intro.html"}}}, pkg.Lessons)
+	assert.Equal(t, []string{"safety/lesson1/index.html", "safety/lesson1/style.css"}, pkg.Modules[0].Lessons[0].Files)
+}
+
+func TestParse_ManifestTooDeep(t *testing.T) {
+	reader := buildZip(t, map[string]string{"a/b/imsmanifest.xml": scorm12Manifest})
+
+	_, err := Parse(reader)
+
+	assert.ErrorIs(t, err, ErrNoManifest)
+}
+
+func TestParse_MissingFiles(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"imsmanifest.xml":    scorm12Manifest,
+		"intro.html":         "<html></html>",
+		"lesson1/index.html": "<html></html>",
+	})
+
+	pkg, err := Parse(reader)
+
+	assert.NoError(t, err)
+	// A lesson keeps the files that are there; one left with none is dropped
+	assert.Equal(t, []Lesson{{Title: "Lesson 1", Files: []string{"lesson1/index.html"}}}, pkg.Modules[0].Lessons)
+	assert.Contains(t, pkg.Unsupported, Unsupported{
+		Identifier: "lesson-1", Title: "Lesson 1", Type: "webcontent", Reason: "file lesson1/style.css is missing from the package",
+	})
+	assert.Contains(t, pkg.Unsupported, Unsupported{
+		Identifier: "lesson-2", Title: "Lesson 2", Type: "webcontent", Reason: "file lesson2.html is missing from the package",
+	})
+	assert.Contains(t, pkg.Unsupported, Unsupported{
+		Identifier: "lesson-2", Title: "Lesson 2", Type: "webcontent", Reason: "resource has no files in the package",
+	})
+}
+
+func TestParse_UnreadableWeblink(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"imsmanifest.xml":    cartridgeManifest,
+		"week1/reading.html": "<html></html>",
+		"week1/link.xml":     `<webLink><title>Periodic Table</title></webLink>`,
+	})
+
+	pkg, err := Parse(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Lesson{{Title: "Reading", Files: []string{"week1/reading.html"}}}, pkg.Modules[0].Lessons)
+	assert.Contains(t, pkg.Unsupported, Unsupported{
+		Identifier: "link", Title: "Periodic Table", Type: "imswl_xmlv1p3", Reason: "web link could not be read",
+	})
+}
+
+func TestParse_UnknownResourceAndUnsupportedType(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"imsmanifest.xml": `<manifest>
+			<metadata><schema>ADL SCORM</schema><schemaversion>1.2</schemaversion></metadata>
+			<organizations>
+				<organization identifier="org">
+					<title>Misc</title>
+					<item identifier="ghost" identifierref="res-ghost"><title>Ghost</title></item>
+					<item identifier="video" identifierref="res-video"><title>Video</title></item>
+				</organization>
+			</organizations>
+			<resources>
+				<resource identifier="res-video" type="application/x-shockwave-flash" href="video.swf"/>
+			</resources>
+		</manifest>`,
+		"video.swf": "FWS",
+	})
+
+	pkg, err := Parse(reader)
+
+	assert.NoError(t, err)
+	assert.Empty(t, pkg.Lessons)
+	assert.Equal(t, []Unsupported{
+		{Identifier: "ghost", Title: "Ghost", Reason: "item refers to unknown resource res-ghost"},
+		{Identifier: "video", Title: "Video", Type: "application/x-shockwave-flash", Reason: "resource type is not supported"},
+	}, pkg.Unsupported)
+}
+
+func TestParse_LegacyEncoding(t *testing.T) {
+	manifest := strings.Replace(scorm12Manifest, "<title>Safety Basics</title>", "<title>S\xe9curit\xe9</title>", 1)
+	reader := buildZip(t, map[string]string{"imsmanifest.xml": manifest, "intro.html": "<html></html>"})
+
+	pkg, err := Parse(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Sécurité", pkg.Title)
+}
+
+func TestParse_UnknownEncoding(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"imsmanifest.xml": `<?xml version="1.0" encoding="x-made-up"?><manifest/>`,
+	})
+
+	_, err := Parse(reader)
+
+	assert.ErrorIs(t, err, ErrMalformedManifest)
+}
+
+func TestParse_MalformedManifest(t *testing.T) {
+	reader := buildZip(t, map[string]string{"imsmanifest.xml": "<manifest><organizations>"})
+
+	_, err := Parse(reader)
+
+	assert.ErrorIs(t, err, ErrMalformedManifest)
+}
+
+func TestParse_NoManifest(t *testing.T) {
+	reader := buildZip(t, map[string]string{"index.html": "<html></html>"})
+
+	_, err := Parse(reader)
+
+	assert.ErrorIs(t, err, ErrNoManifest)
+}
+
+func TestParse_UnknownStandard(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"imsmanifest.xml": `<manifest><organizations><organization identifier="org"/></organizations></manifest>`,
+	})
+
+	_, err := Parse(reader)
+
+	assert.ErrorIs(t, err, ErrUnknownStandard)
+}
+
+func TestParse_NoOrganization(t *testing.T) {
+	reader := buildZip(t, map[string]string{
+		"imsmanifest.xml": `<manifest><metadata><schema>ADL SCORM</schema></metadata></manifest>`,
+	})
+
+	_, err := Parse(reader)
+
+	assert.ErrorIs(t, err, ErrEmptyPackage)
+}
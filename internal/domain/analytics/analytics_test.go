@@ -0,0 +1,268 @@
+package analytics
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) GetInstructorCourseIDs(instructorID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(instructorID)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) GetSales(courseIDs []uuid.UUID, from, to time.Time) ([]SalesRow, error) {
+	args := m.Called(courseIDs, from, to)
+	return args.Get(0).([]SalesRow), args.Error(1)
+}
+
+func (m *MockRepository) GetFunnel(courseIDs []uuid.UUID, from, to time.Time) (Funnel, error) {
+	args := m.Called(courseIDs, from, to)
+	return args.Get(0).(Funnel), args.Error(1)
+}
+
+func (m *MockRepository) GetAssignmentGrades(courseIDs []uuid.UUID, from, to time.Time) ([]AssignmentGrades, error) {
+	args := m.Called(courseIDs, from, to)
+	return args.Get(0).([]AssignmentGrades), args.Error(1)
+}
+
+func (m *MockRepository) GetRatingCounts(courseIDs []uuid.UUID, from, to time.Time) ([]RatingCount, error) {
+	args := m.Called(courseIDs, from, to)
+	return args.Get(0).([]RatingCount), args.Error(1)
+}
+
+func (m *MockRepository) GetForumActivity(courseIDs []uuid.UUID, from, to time.Time) ([]DailyForumActivity, error) {
+	args := m.Called(courseIDs, from, to)
+	return args.Get(0).([]DailyForumActivity), args.Error(1)
+}
+
+func date(s string) time.Time {
+	t, _ := time.Parse(dateLayout, s)
+	return t
+}
+
+func TestParseRange(t *testing.T) {
+	now := time.Date(2026, 3, 15, 18, 30, 0, 0, time.UTC)
+
+	period, err := parseRange(&ReportRequest{}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, date("2026-02-14"), period.from)
+	assert.Equal(t, date("2026-03-16"), period.to)
+	assert.Len(t, period.days(), defaultRangeDays)
+
+	period, err = parseRange(&ReportRequest{From: "2026-01-01", To: "2026-01-03"}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2026-01-01", "2026-01-02", "2026-01-03"}, period.days())
+	assert.Equal(t, "2026-01-03", period.last())
+
+	_, err = parseRange(&ReportRequest{From: "2026-01-04", To: "2026-01-03"}, now)
+	assert.Equal(t, "INVALID_DATE_RANGE", err.Error())
+
+	_, err = parseRange(&ReportRequest{From: "2024-01-01", To: "2026-01-03"}, now)
+	assert.Equal(t, "DATE_RANGE_TOO_LONG", err.Error())
+}
+
+func TestEncodeCSV(t *testing.T) {
+	courseID := uuid.New()
+	report := &RevenueReport{From: "2026-01-01", To: "2026-01-31", Rows: []SalesRow{
+		{Date: "2026-01-02", CourseID: courseID, CourseTitle: "=HYPERLINK(\"x\")", Sales: 2, Revenue: 15000},
+	}}
+
+	body, err := encodeCSV(report)
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	assert.Equal(t, "date,course_id,course_title,sales,revenue", lines[0])
+	assert.Equal(t, "2026-01-02,"+courseID.String()+",\"'=HYPERLINK(\"\"x\"\")\",2,15000", lines[1])
+}
+
+type AnalyticsUseCaseTestSuite struct {
+	suite.Suite
+	repo *MockRepository
+	uc   *UseCase
+
+	instructorID uuid.UUID
+	course       *schema.Course
+	req          *ReportRequest
+	from, to     time.Time
+}
+
+func (s *AnalyticsUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.uc = NewUseCase(s.repo)
+	s.instructorID = uuid.New()
+	s.course = &schema.Course{ID: uuid.New(), InstructorID: s.instructorID, Title: "Go"}
+	s.req = &ReportRequest{From: "2026-01-01", To: "2026-01-03", CourseID: s.course.ID.String()}
+	s.from, s.to = date("2026-01-01"), date("2026-01-04")
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil).Maybe()
+}
+
+func TestAnalyticsUseCase(t *testing.T) {
+	suite.Run(t, new(AnalyticsUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID, role schema.Role) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", string(role))
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestRevenue_AllCourses() {
+	other := uuid.New()
+	courseIDs := []uuid.UUID{s.course.ID, other}
+	s.repo.On("GetInstructorCourseIDs", s.instructorID).Return(courseIDs, nil)
+	s.repo.On("GetSales", courseIDs, s.from, s.to).Return([]SalesRow{
+		{Date: "2026-01-01", CourseID: s.course.ID, Sales: 2, Revenue: 20000},
+		{Date: "2026-01-03", CourseID: other, Sales: 1, Revenue: 7500},
+	}, nil)
+
+	res, err := s.uc.Revenue(userContext(s.instructorID, schema.RoleInstructor), &ReportRequest{From: "2026-01-01", To: "2026-01-03"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(3), res.TotalSales)
+	assert.Equal(s.T(), int64(27500), res.TotalRevenue)
+	assert.Len(s.T(), res.Rows, 2)
+	s.repo.AssertNotCalled(s.T(), "GetCourse", mock.Anything)
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestRevenue_NotYourCourse() {
+	res, err := s.uc.Revenue(userContext(uuid.New(), schema.RoleInstructor), s.req)
+
+	assert.Nil(s.T(), res)
+	assert.Equal(s.T(), http.StatusForbidden, apierror.GetHttpStatus(err))
+	s.repo.AssertNotCalled(s.T(), "GetSales", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestRevenue_Admin() {
+	s.repo.On("GetSales", []uuid.UUID{s.course.ID}, s.from, s.to).Return([]SalesRow{}, nil)
+
+	res, err := s.uc.Revenue(userContext(uuid.New(), schema.RoleAdmin), s.req)
+
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), res.Rows)
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestRevenue_AdminWithoutCourse() {
+	res, err := s.uc.Revenue(userContext(uuid.New(), schema.RoleAdmin), &ReportRequest{From: "2026-01-01", To: "2026-01-03"})
+
+	assert.Nil(s.T(), res)
+	assert.Equal(s.T(), ErrCourseIDRequired.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "GetInstructorCourseIDs", mock.Anything)
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestRevenue_CourseNotFound() {
+	missing := uuid.New()
+	s.repo.On("GetCourse", missing).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.uc.Revenue(userContext(s.instructorID, schema.RoleInstructor), &ReportRequest{CourseID: missing.String()})
+
+	assert.Equal(s.T(), "COURSE_NOT_FOUND", err.Error())
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestEnrollments_FillsEmptyDays() {
+	s.repo.On("GetSales", []uuid.UUID{s.course.ID}, s.from, s.to).Return([]SalesRow{
+		{Date: "2026-01-01", CourseID: s.course.ID, Sales: 2},
+		{Date: "2026-01-03", CourseID: s.course.ID, Sales: 4},
+	}, nil)
+
+	res, err := s.uc.Enrollments(userContext(s.instructorID, schema.RoleInstructor), s.req)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(6), res.Total)
+	assert.Equal(s.T(), []DailyEnrollments{
+		{Date: "2026-01-01", Enrollments: 2},
+		{Date: "2026-01-02", Enrollments: 0},
+		{Date: "2026-01-03", Enrollments: 4},
+	}, res.Days)
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestFunnel() {
+	s.repo.On("GetFunnel", []uuid.UUID{s.course.ID}, s.from, s.to).
+		Return(Funnel{Enrolled: 10, Started: 8, Halfway: 5, Completed: 2}, nil)
+
+	res, err := s.uc.Funnel(userContext(s.instructorID, schema.RoleInstructor), s.req)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []FunnelStage{
+		{Stage: "enrolled", Students: 10, Rate: 1},
+		{Stage: "started", Students: 8, Rate: 0.8},
+		{Stage: "halfway", Students: 5, Rate: 0.5},
+		{Stage: "completed", Students: 2, Rate: 0.2},
+	}, res.Stages)
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestFunnel_NoStudents() {
+	s.repo.On("GetFunnel", []uuid.UUID{s.course.ID}, s.from, s.to).Return(Funnel{}, nil)
+
+	res, err := s.uc.Funnel(userContext(s.instructorID, schema.RoleInstructor), s.req)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), res.Stages, 4)
+	assert.Zero(s.T(), res.Stages[3].Rate)
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestGrades() {
+	average := 82.5
+	grades := []AssignmentGrades{
+		{AssignmentID: uuid.New(), AssignmentTitle: "Essay", CourseID: s.course.ID, Submissions: 4, Graded: 2, AverageGrade: &average},
+		{AssignmentID: uuid.New(), AssignmentTitle: "Quiz", CourseID: s.course.ID},
+	}
+	s.repo.On("GetAssignmentGrades", []uuid.UUID{s.course.ID}, s.from, s.to).Return(grades, nil)
+
+	res, err := s.uc.Grades(userContext(s.instructorID, schema.RoleInstructor), s.req)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), grades, res.Assignments)
+	records := res.records()
+	assert.Equal(s.T(), "82.50", records[1][6])
+	assert.Equal(s.T(), "", records[2][6])
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestRatings() {
+	s.repo.On("GetRatingCounts", []uuid.UUID{s.course.ID}, s.from, s.to).
+		Return([]RatingCount{{Rating: 2, Reviews: 1}, {Rating: 5, Reviews: 3}}, nil)
+
+	res, err := s.uc.Ratings(userContext(s.instructorID, schema.RoleInstructor), s.req)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(4), res.Total)
+	assert.Equal(s.T(), 4.25, res.Average)
+	assert.Equal(s.T(), []RatingCount{{1, 0}, {2, 1}, {3, 0}, {4, 0}, {5, 3}}, res.Distribution)
+}
+
+func (s *AnalyticsUseCaseTestSuite) TestForum() {
+	s.repo.On("GetForumActivity", []uuid.UUID{s.course.ID}, s.from, s.to).
+		Return([]DailyForumActivity{{Date: "2026-01-02", Discussions: 1, Replies: 3, ActiveUsers: 2}}, nil)
+
+	res, err := s.uc.Forum(userContext(s.instructorID, schema.RoleInstructor), s.req)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), res.Discussions)
+	assert.Equal(s.T(), int64(3), res.Replies)
+	assert.Equal(s.T(), []DailyForumActivity{
+		{Date: "2026-01-01"},
+		{Date: "2026-01-02", Discussions: 1, Replies: 3, ActiveUsers: 2},
+		{Date: "2026-01-03"},
+	}, res.Days)
+}
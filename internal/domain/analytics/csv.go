@@ -0,0 +1,104 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+)
+
+// report is a result that can be exported as a CSV table, header first
+type report interface {
+	records() [][]string
+	period() (from, to string)
+}
+
+func encodeCSV(r report) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(r.records()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// text escapes a user-written value, such as a course title, that a spreadsheet would otherwise run as a formula
+func text(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func ftoa(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+func (r *RevenueReport) period() (string, string) { return r.From, r.To }
+
+func (r *RevenueReport) records() [][]string {
+	records := [][]string{{"date", "course_id", "course_title", "sales", "revenue"}}
+	for _, row := range r.Rows {
+		records = append(records, []string{row.Date, row.CourseID.String(), text(row.CourseTitle), itoa(row.Sales), itoa(row.Revenue)})
+	}
+	return records
+}
+
+func (r *EnrollmentReport) period() (string, string) { return r.From, r.To }
+
+func (r *EnrollmentReport) records() [][]string {
+	records := [][]string{{"date", "enrollments"}}
+	for _, day := range r.Days {
+		records = append(records, []string{day.Date, itoa(day.Enrollments)})
+	}
+	return records
+}
+
+func (r *FunnelReport) period() (string, string) { return r.From, r.To }
+
+func (r *FunnelReport) records() [][]string {
+	records := [][]string{{"stage", "students", "rate"}}
+	for _, stage := range r.Stages {
+		records = append(records, []string{stage.Stage, itoa(stage.Students), ftoa(stage.Rate)})
+	}
+	return records
+}
+
+func (r *GradeReport) period() (string, string) { return r.From, r.To }
+
+func (r *GradeReport) records() [][]string {
+	records := [][]string{{"course_id", "course_title", "assignment_id", "assignment_title", "submissions", "graded", "average_grade"}}
+	for _, a := range r.Assignments {
+		average := ""
+		if a.AverageGrade != nil {
+			average = ftoa(*a.AverageGrade)
+		}
+		records = append(records, []string{a.CourseID.String(), text(a.CourseTitle), a.AssignmentID.String(),
+			text(a.AssignmentTitle), itoa(a.Submissions), itoa(a.Graded), average})
+	}
+	return records
+}
+
+func (r *RatingReport) period() (string, string) { return r.From, r.To }
+
+func (r *RatingReport) records() [][]string {
+	records := [][]string{{"rating", "reviews"}}
+	for _, count := range r.Distribution {
+		records = append(records, []string{strconv.Itoa(count.Rating), itoa(count.Reviews)})
+	}
+	return records
+}
+
+func (r *ForumReport) period() (string, string) { return r.From, r.To }
+
+func (r *ForumReport) records() [][]string {
+	records := [][]string{{"date", "discussions", "replies", "active_users"}}
+	for _, day := range r.Days {
+		records = append(records, []string{day.Date, itoa(day.Discussions), itoa(day.Replies), itoa(day.ActiveUsers)})
+	}
+	return records
+}
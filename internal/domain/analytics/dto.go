@@ -0,0 +1,107 @@
+package analytics
+
+import (
+	"github.com/google/uuid"
+)
+
+// ReportRequest selects what a report covers: the days from From to To inclusive, in UTC, and either one course or
+// every course of the caller. Admins must name a course.
+type ReportRequest struct {
+	From     string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To       string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	CourseID string `form:"course_id" binding:"omitempty,uuid"`
+	Format   string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// SalesRow is one course's sales on one day
+type SalesRow struct {
+	Date        string    `json:"date"`
+	CourseID    uuid.UUID `json:"course_id"`
+	CourseTitle string    `json:"course_title"`
+	Sales       int64     `json:"sales"`
+	Revenue     int64     `json:"revenue"`
+}
+
+type RevenueReport struct {
+	From         string     `json:"from"`
+	To           string     `json:"to"`
+	TotalSales   int64      `json:"total_sales"`
+	TotalRevenue int64      `json:"total_revenue"`
+	Rows         []SalesRow `json:"rows"`
+}
+
+type DailyEnrollments struct {
+	Date        string `json:"date"`
+	Enrollments int64  `json:"enrollments"`
+}
+
+type EnrollmentReport struct {
+	From  string             `json:"from"`
+	To    string             `json:"to"`
+	Total int64              `json:"total"`
+	Days  []DailyEnrollments `json:"days"`
+}
+
+// Funnel counts the students enrolled in a period by how far they have got since
+type Funnel struct {
+	Enrolled  int64
+	Started   int64
+	Halfway   int64
+	Completed int64
+}
+
+type FunnelStage struct {
+	Stage    string  `json:"stage"`
+	Students int64   `json:"students"`
+	Rate     float64 `json:"rate"` // share of the enrolled students, from 0 to 1
+}
+
+type FunnelReport struct {
+	From   string        `json:"from"`
+	To     string        `json:"to"`
+	Stages []FunnelStage `json:"stages"`
+}
+
+type AssignmentGrades struct {
+	AssignmentID    uuid.UUID `json:"assignment_id"`
+	AssignmentTitle string    `json:"assignment_title"`
+	CourseID        uuid.UUID `json:"course_id"`
+	CourseTitle     string    `json:"course_title"`
+	Submissions     int64     `json:"submissions"`
+	Graded          int64     `json:"graded"`
+	AverageGrade    *float64  `json:"average_grade"` // nil while nothing is graded
+}
+
+type GradeReport struct {
+	From        string             `json:"from"`
+	To          string             `json:"to"`
+	Assignments []AssignmentGrades `json:"assignments"`
+}
+
+type RatingCount struct {
+	Rating  int   `json:"rating"`
+	Reviews int64 `json:"reviews"`
+}
+
+type RatingReport struct {
+	From         string        `json:"from"`
+	To           string        `json:"to"`
+	Total        int64         `json:"total"`
+	Average      float64       `json:"average"`
+	Distribution []RatingCount `json:"distribution"`
+}
+
+type DailyForumActivity struct {
+	Date        string `json:"date"`
+	Discussions int64  `json:"discussions"`
+	Replies     int64  `json:"replies"`
+	ActiveUsers int64  `json:"active_users"`
+}
+
+type ForumReport struct {
+	From        string               `json:"from"`
+	To          string               `json:"to"`
+	Discussions int64                `json:"discussions"`
+	Replies     int64                `json:"replies"`
+	Days        []DailyForumActivity `json:"days"`
+}
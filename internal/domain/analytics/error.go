@@ -0,0 +1,24 @@
+package analytics
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrCourseNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("COURSE_NOT_FOUND")
+
+	ErrInvalidDateRange = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("INVALID_DATE_RANGE")
+
+	ErrDateRangeTooLong = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("DATE_RANGE_TOO_LONG")
+
+	ErrCourseIDRequired = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("COURSE_ID_REQUIRED")
+)
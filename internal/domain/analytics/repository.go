@@ -0,0 +1,138 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+type IRepository interface {
+	GetCourse(courseID uuid.UUID) (*schema.Course, error)
+	GetInstructorCourseIDs(instructorID uuid.UUID) ([]uuid.UUID, error)
+	GetSales(courseIDs []uuid.UUID, from, to time.Time) ([]SalesRow, error)
+	GetFunnel(courseIDs []uuid.UUID, from, to time.Time) (Funnel, error)
+	GetAssignmentGrades(courseIDs []uuid.UUID, from, to time.Time) ([]AssignmentGrades, error)
+	GetRatingCounts(courseIDs []uuid.UUID, from, to time.Time) ([]RatingCount, error)
+	GetForumActivity(courseIDs []uuid.UUID, from, to time.Time) ([]DailyForumActivity, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db: db}
+}
+
+// rangeArgs are the named arguments every report query filters on. Days are bucketed in UTC and to is exclusive.
+func rangeArgs(courseIDs []uuid.UUID, from, to time.Time) map[string]any {
+	return map[string]any{"courses": courseIDs, "from": from, "to": to}
+}
+
+func (r *repository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	var course schema.Course
+	if err := r.db.First(&course, "id = ?", courseID).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *repository) GetInstructorCourseIDs(instructorID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Model(&schema.Course{}).Where("instructor_id = ?", instructorID).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// GetSales sums the enrollments of each course per day. Enrollments made before the amount paid was recorded count
+// as sales without revenue.
+func (r *repository) GetSales(courseIDs []uuid.UUID, from, to time.Time) ([]SalesRow, error) {
+	var rows []SalesRow
+	err := r.db.Raw(`
+		SELECT to_char(ce.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS date, c.id AS course_id, c.title AS course_title,
+			COUNT(*) AS sales, coalesce(SUM(ce.amount_paid), 0) AS revenue
+		FROM course_enrolls ce
+		JOIN courses c ON c.id = ce.course_id
+		WHERE ce.course_id IN @courses AND ce.created_at >= @from AND ce.created_at < @to
+		GROUP BY 1, c.id, c.title
+		ORDER BY 1, c.title, c.id`, rangeArgs(courseIDs, from, to)).Scan(&rows).Error
+	return rows, err
+}
+
+// GetFunnel follows the students who enrolled within the range: how many opened a lesson, completed at least half
+// of their course's lessons, and completed the course
+func (r *repository) GetFunnel(courseIDs []uuid.UUID, from, to time.Time) (Funnel, error) {
+	var funnel Funnel
+	err := r.db.Raw(`
+		WITH enrolls AS (
+			SELECT user_id, course_id, completed_at FROM course_enrolls
+			WHERE course_id IN @courses AND created_at >= @from AND created_at < @to
+		), lessons AS (
+			SELECT course_id, COUNT(*) AS total FROM materials
+			WHERE course_id IN @courses AND deleted_at IS NULL
+			GROUP BY course_id
+		), progress AS (
+			SELECT user_id, course_id, COUNT(completed_at) AS completed FROM lesson_progresses
+			WHERE course_id IN @courses
+			GROUP BY user_id, course_id
+		)
+		SELECT COUNT(*) AS enrolled,
+			COUNT(p.user_id) AS started,
+			COUNT(*) FILTER (WHERE l.total > 0 AND p.completed * 2 >= l.total) AS halfway,
+			COUNT(e.completed_at) AS completed
+		FROM enrolls e
+		LEFT JOIN progress p ON p.user_id = e.user_id AND p.course_id = e.course_id
+		LEFT JOIN lessons l ON l.course_id = e.course_id`, rangeArgs(courseIDs, from, to)).Scan(&funnel).Error
+	return funnel, err
+}
+
+// GetAssignmentGrades summarizes the submissions made within the range to every assignment of the courses. Grading
+// never accepts zero, so a zero grade is a submission still waiting to be graded.
+func (r *repository) GetAssignmentGrades(courseIDs []uuid.UUID, from, to time.Time) ([]AssignmentGrades, error) {
+	var grades []AssignmentGrades
+	err := r.db.Raw(`
+		SELECT a.id AS assignment_id, a.title AS assignment_title, c.id AS course_id, c.title AS course_title,
+			COUNT(s.id) AS submissions,
+			COUNT(s.id) FILTER (WHERE s.grade > 0) AS graded,
+			(AVG(s.grade) FILTER (WHERE s.grade > 0))::float8 AS average_grade
+		FROM assignments a
+		JOIN courses c ON c.id = a.course_id
+		LEFT JOIN submissions s ON s.assignment_id = a.id AND s.deleted_at IS NULL
+			AND s.created_at >= @from AND s.created_at < @to
+		WHERE a.course_id IN @courses AND a.deleted_at IS NULL
+		GROUP BY a.id, c.id
+		ORDER BY c.title, c.id, a.position, a.created_at`, rangeArgs(courseIDs, from, to)).Scan(&grades).Error
+	return grades, err
+}
+
+func (r *repository) GetRatingCounts(courseIDs []uuid.UUID, from, to time.Time) ([]RatingCount, error) {
+	var counts []RatingCount
+	err := r.db.Raw(`
+		SELECT rating, COUNT(*) AS reviews FROM reviews
+		WHERE course_id IN @courses AND created_at >= @from AND created_at < @to
+		GROUP BY rating
+		ORDER BY rating`, rangeArgs(courseIDs, from, to)).Scan(&counts).Error
+	return counts, err
+}
+
+// GetForumActivity counts the discussions and replies posted per day, and how many different users posted them.
+// Days without posts are left out.
+func (r *repository) GetForumActivity(courseIDs []uuid.UUID, from, to time.Time) ([]DailyForumActivity, error) {
+	var days []DailyForumActivity
+	err := r.db.Raw(`
+		SELECT to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS date,
+			COUNT(*) FILTER (WHERE kind = 'discussion') AS discussions,
+			COUNT(*) FILTER (WHERE kind = 'reply') AS replies,
+			COUNT(DISTINCT user_id) AS active_users
+		FROM (
+			SELECT 'discussion' AS kind, user_id, created_at FROM forum_discussions
+			WHERE course_id IN @courses AND created_at >= @from AND created_at < @to
+			UNION ALL
+			SELECT 'reply', user_id, created_at FROM forum_replies
+			WHERE course_id IN @courses AND created_at >= @from AND created_at < @to
+		) posts
+		GROUP BY 1
+		ORDER BY 1`, rangeArgs(courseIDs, from, to)).Scan(&days).Error
+	return days, err
+}
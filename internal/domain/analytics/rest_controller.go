@@ -0,0 +1,107 @@
+package analytics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"log"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	analyticsGroup := engine.Group("/v1/analytics",
+		middleware.APIKeyScope("courses:read"),
+		middleware.Authenticate(),
+		middleware.RequireRole("instructor", "admin"),
+	)
+	{
+		analyticsGroup.GET("/revenue", controller.Revenue())
+		analyticsGroup.GET("/enrollments", controller.Enrollments())
+		analyticsGroup.GET("/funnel", controller.Funnel())
+		analyticsGroup.GET("/grades", controller.Grades())
+		analyticsGroup.GET("/ratings", controller.Ratings())
+		analyticsGroup.GET("/forum", controller.Forum())
+	}
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+// serve binds the report parameters, builds the report and sends it as JSON, or as a CSV download named after
+// the report and its dates when format=csv
+func serve(name, message string, build func(ctx *gin.Context, req *ReportRequest) (report, error)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req ReportRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := build(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		if req.Format != "csv" {
+			response.NewRestResponse(http.StatusOK, message, res).Send(ctx)
+			return
+		}
+		body, err := encodeCSV(res)
+		if err != nil {
+			log.Println("Error encoding CSV: ", err)
+			err := apierror.ErrInternalServer.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+			return
+		}
+		from, to := res.period()
+		filename := name + "-" + from + "-" + to + ".csv"
+		ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		ctx.Data(http.StatusOK, "text/csv; charset=utf-8", body)
+	}
+}
+
+func (c *RestController) Revenue() gin.HandlerFunc {
+	return serve("revenue", "GET_REVENUE_REPORT_SUCCESS", func(ctx *gin.Context, req *ReportRequest) (report, error) {
+		return c.uc.Revenue(ctx, req)
+	})
+}
+
+func (c *RestController) Enrollments() gin.HandlerFunc {
+	return serve("enrollments", "GET_ENROLLMENT_REPORT_SUCCESS", func(ctx *gin.Context, req *ReportRequest) (report, error) {
+		return c.uc.Enrollments(ctx, req)
+	})
+}
+
+func (c *RestController) Funnel() gin.HandlerFunc {
+	return serve("funnel", "GET_FUNNEL_REPORT_SUCCESS", func(ctx *gin.Context, req *ReportRequest) (report, error) {
+		return c.uc.Funnel(ctx, req)
+	})
+}
+
+func (c *RestController) Grades() gin.HandlerFunc {
+	return serve("grades", "GET_GRADE_REPORT_SUCCESS", func(ctx *gin.Context, req *ReportRequest) (report, error) {
+		return c.uc.Grades(ctx, req)
+	})
+}
+
+func (c *RestController) Ratings() gin.HandlerFunc {
+	return serve("ratings", "GET_RATING_REPORT_SUCCESS", func(ctx *gin.Context, req *ReportRequest) (report, error) {
+		return c.uc.Ratings(ctx, req)
+	})
+}
+
+func (c *RestController) Forum() gin.HandlerFunc {
+	return serve("forum", "GET_FORUM_REPORT_SUCCESS", func(ctx *gin.Context, req *ReportRequest) (report, error) {
+		return c.uc.Forum(ctx, req)
+	})
+}
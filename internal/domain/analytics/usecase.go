@@ -0,0 +1,265 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// defaultRangeDays is how many days up to today a report covers when no range is given
+	defaultRangeDays = 30
+	maxRangeDays     = 366
+)
+
+type UseCase struct {
+	repo IRepository
+}
+
+func NewUseCase(repo IRepository) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+// dateRange is a run of whole UTC days; to is the start of the day after the last one
+type dateRange struct {
+	from, to time.Time
+}
+
+func (r dateRange) first() string {
+	return r.from.Format(dateLayout)
+}
+
+func (r dateRange) last() string {
+	return r.to.AddDate(0, 0, -1).Format(dateLayout)
+}
+
+// days lists every day of the range, so series can show the days nothing happened
+func (r dateRange) days() []string {
+	var days []string
+	for day := r.from; day.Before(r.to); day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format(dateLayout))
+	}
+	return days
+}
+
+func parseRange(req *ReportRequest, now time.Time) (dateRange, error) {
+	last := now.UTC().Truncate(24 * time.Hour)
+	if req.To != "" {
+		parsed, err := time.Parse(dateLayout, req.To)
+		if err != nil {
+			return dateRange{}, ErrInvalidDateRange.Build()
+		}
+		last = parsed
+	}
+	first := last.AddDate(0, 0, 1-defaultRangeDays)
+	if req.From != "" {
+		parsed, err := time.Parse(dateLayout, req.From)
+		if err != nil {
+			return dateRange{}, ErrInvalidDateRange.Build()
+		}
+		first = parsed
+	}
+
+	if first.After(last) {
+		return dateRange{}, ErrInvalidDateRange.Build()
+	}
+	if last.Sub(first) >= maxRangeDays*24*time.Hour {
+		return dateRange{}, ErrDateRangeTooLong.Build()
+	}
+	return dateRange{from: first, to: last.AddDate(0, 0, 1)}, nil
+}
+
+// scope resolves the courses a report covers: the requested one, which only its instructor or an admin may see, or
+// else every course the caller teaches. Admins teach no courses, so they have to name one.
+func (uc *UseCase) scope(ctx context.Context, req *ReportRequest) ([]uuid.UUID, dateRange, error) {
+	period, err := parseRange(req, time.Now())
+	if err != nil {
+		return nil, dateRange{}, err
+	}
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, dateRange{}, apierror.ErrTokenInvalid.Build()
+	}
+	role, _ := ctx.Value("user.role").(string)
+
+	if req.CourseID == "" {
+		if role == string(schema.RoleAdmin) {
+			return nil, dateRange{}, ErrCourseIDRequired.Build()
+		}
+		courseIDs, err := uc.repo.GetInstructorCourseIDs(userID)
+		if err != nil {
+			log.Println("Error getting instructor courses: ", err)
+			return nil, dateRange{}, apierror.ErrInternalServer.Build()
+		}
+		return courseIDs, period, nil
+	}
+
+	course, err := uc.repo.GetCourse(uuid.MustParse(req.CourseID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dateRange{}, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return nil, dateRange{}, apierror.ErrInternalServer.Build()
+	}
+	if course.InstructorID != userID && role != string(schema.RoleAdmin) {
+		return nil, dateRange{}, apierror.ErrNotYourResource.Build()
+	}
+	return []uuid.UUID{course.ID}, period, nil
+}
+
+// Revenue reports what each course earned per day
+func (uc *UseCase) Revenue(ctx context.Context, req *ReportRequest) (*RevenueReport, error) {
+	courseIDs, period, err := uc.scope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := uc.repo.GetSales(courseIDs, period.from, period.to)
+	if err != nil {
+		log.Println("Error getting sales: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	report := &RevenueReport{From: period.first(), To: period.last(), Rows: rows}
+	if report.Rows == nil {
+		report.Rows = []SalesRow{}
+	}
+	for _, row := range rows {
+		report.TotalSales += row.Sales
+		report.TotalRevenue += row.Revenue
+	}
+	return report, nil
+}
+
+// Enrollments reports how many students enrolled on each day of the range, across the courses
+func (uc *UseCase) Enrollments(ctx context.Context, req *ReportRequest) (*EnrollmentReport, error) {
+	courseIDs, period, err := uc.scope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := uc.repo.GetSales(courseIDs, period.from, period.to)
+	if err != nil {
+		log.Println("Error getting sales: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	perDay := make(map[string]int64)
+	for _, row := range rows {
+		perDay[row.Date] += row.Sales
+	}
+	report := &EnrollmentReport{From: period.first(), To: period.last(), Days: []DailyEnrollments{}}
+	for _, day := range period.days() {
+		report.Days = append(report.Days, DailyEnrollments{Date: day, Enrollments: perDay[day]})
+		report.Total += perDay[day]
+	}
+	return report, nil
+}
+
+// Funnel reports how far the students who enrolled within the range have got
+func (uc *UseCase) Funnel(ctx context.Context, req *ReportRequest) (*FunnelReport, error) {
+	courseIDs, period, err := uc.scope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	funnel, err := uc.repo.GetFunnel(courseIDs, period.from, period.to)
+	if err != nil {
+		log.Println("Error getting completion funnel: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	report := &FunnelReport{From: period.first(), To: period.last()}
+	for _, stage := range []FunnelStage{
+		{Stage: "enrolled", Students: funnel.Enrolled},
+		{Stage: "started", Students: funnel.Started},
+		{Stage: "halfway", Students: funnel.Halfway},
+		{Stage: "completed", Students: funnel.Completed},
+	} {
+		if funnel.Enrolled > 0 {
+			stage.Rate = float64(stage.Students) / float64(funnel.Enrolled)
+		}
+		report.Stages = append(report.Stages, stage)
+	}
+	return report, nil
+}
+
+// Grades reports the submissions and average grade of every assignment
+func (uc *UseCase) Grades(ctx context.Context, req *ReportRequest) (*GradeReport, error) {
+	courseIDs, period, err := uc.scope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	grades, err := uc.repo.GetAssignmentGrades(courseIDs, period.from, period.to)
+	if err != nil {
+		log.Println("Error getting assignment grades: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	report := &GradeReport{From: period.first(), To: period.last(), Assignments: grades}
+	if report.Assignments == nil {
+		report.Assignments = []AssignmentGrades{}
+	}
+	return report, nil
+}
+
+// Ratings reports how the reviews written within the range are spread over one to five stars
+func (uc *UseCase) Ratings(ctx context.Context, req *ReportRequest) (*RatingReport, error) {
+	courseIDs, period, err := uc.scope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := uc.repo.GetRatingCounts(courseIDs, period.from, period.to)
+	if err != nil {
+		log.Println("Error getting rating counts: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	perRating := make(map[int]int64, len(counts))
+	for _, count := range counts {
+		perRating[count.Rating] = count.Reviews
+	}
+	report := &RatingReport{From: period.first(), To: period.last()}
+	var sum int64
+	for rating := 1; rating <= 5; rating++ {
+		report.Distribution = append(report.Distribution, RatingCount{Rating: rating, Reviews: perRating[rating]})
+		report.Total += perRating[rating]
+		sum += int64(rating) * perRating[rating]
+	}
+	if report.Total > 0 {
+		report.Average = float64(sum) / float64(report.Total)
+	}
+	return report, nil
+}
+
+// Forum reports the discussions and replies posted on each day of the range
+func (uc *UseCase) Forum(ctx context.Context, req *ReportRequest) (*ForumReport, error) {
+	courseIDs, period, err := uc.scope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	activity, err := uc.repo.GetForumActivity(courseIDs, period.from, period.to)
+	if err != nil {
+		log.Println("Error getting forum activity: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	perDay := make(map[string]DailyForumActivity, len(activity))
+	for _, day := range activity {
+		perDay[day.Date] = day
+	}
+	report := &ForumReport{From: period.first(), To: period.last(), Days: []DailyForumActivity{}}
+	for _, date := range period.days() {
+		day := perDay[date]
+		day.Date = date
+		report.Days = append(report.Days, day)
+		report.Discussions += day.Discussions
+		report.Replies += day.Replies
+	}
+	return report, nil
+}
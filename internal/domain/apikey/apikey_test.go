@@ -0,0 +1,227 @@
+package apikey
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(key *schema.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetByID(id uuid.UUID) (*schema.APIKey, error) {
+	args := m.Called(id)
+	key, ok := args.Get(0).(*schema.APIKey)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return key, args.Error(1)
+}
+
+func (m *MockRepository) GetByHash(hash string) (*schema.APIKey, error) {
+	args := m.Called(hash)
+	key, ok := args.Get(0).(*schema.APIKey)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return key, args.Error(1)
+}
+
+func (m *MockRepository) GetActiveByUserID(userID uuid.UUID) ([]*schema.APIKey, error) {
+	args := m.Called(userID)
+	keys, ok := args.Get(0).([]*schema.APIKey)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return keys, args.Error(1)
+}
+
+func (m *MockRepository) UpdateLastUsed(id uuid.UUID, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Revoke(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type APIKeyUseCaseTestSuite struct {
+	suite.Suite
+	repo    *MockRepository
+	useCase *UseCase
+}
+
+func (suite *APIKeyUseCaseTestSuite) SetupTest() {
+	suite.repo = new(MockRepository)
+	suite.useCase = NewUseCase(suite.repo)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestCreate_Success() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	req := &CreateAPIKeyRequest{
+		Name:          "CI grader",
+		Scopes:        []string{"submissions:grade", "courses:read", "submissions:grade"},
+		ExpiresInDays: 30,
+	}
+
+	var stored *schema.APIKey
+	suite.repo.On("GetActiveByUserID", userID).Return([]*schema.APIKey{}, nil)
+	suite.repo.On("Create", mock.AnythingOfType("*schema.APIKey")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*schema.APIKey) }).
+		Return(nil)
+
+	res, err := suite.useCase.Create(ctx, req)
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), strings.HasPrefix(res.Key, keyPrefix))
+	assert.Equal(suite.T(), res.Key[:displayPrefixLen], res.Prefix)
+	assert.Equal(suite.T(), []string{"submissions:grade", "courses:read"}, res.Scopes)
+	assert.Equal(suite.T(), hashKey(res.Key), stored.KeyHash)
+	assert.NotNil(suite.T(), stored.ExpiresAt)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestCreate_TooManyKeys() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	active := make([]*schema.APIKey, maxActiveKeys)
+
+	suite.repo.On("GetActiveByUserID", userID).Return(active, nil)
+
+	res, err := suite.useCase.Create(ctx, &CreateAPIKeyRequest{Name: "x", Scopes: []string{"courses:read"}})
+
+	assert.Nil(suite.T(), res)
+	assert.Equal(suite.T(), "TOO_MANY_API_KEYS", err.Error())
+	suite.repo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestRevoke_NotYours() {
+	userID := uuid.New()
+	keyID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+
+	suite.repo.On("GetByID", keyID).Return(&schema.APIKey{ID: keyID, UserID: uuid.New()}, nil)
+
+	err := suite.useCase.Revoke(ctx, &RevokeAPIKeyRequest{ID: keyID.String()})
+
+	assert.Equal(suite.T(), ErrAPIKeyNotFound.Build(), err)
+	suite.repo.AssertNotCalled(suite.T(), "Revoke", mock.Anything)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestRevoke_Success() {
+	userID := uuid.New()
+	keyID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+
+	suite.repo.On("GetByID", keyID).Return(&schema.APIKey{ID: keyID, UserID: userID}, nil)
+	suite.repo.On("Revoke", keyID).Return(nil)
+
+	err := suite.useCase.Revoke(ctx, &RevokeAPIKeyRequest{ID: keyID.String()})
+
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestValidateAPIKey_Success() {
+	rawKey := keyPrefix + "abcdefghijklmnop"
+	userID := uuid.New()
+	key := &schema.APIKey{
+		ID:     uuid.New(),
+		UserID: userID,
+		User:   schema.User{ID: userID, Email: "a@b.c", Name: "Instructor", Role: schema.RoleInstructor},
+		Scopes: "courses:read,submissions:grade",
+	}
+
+	suite.repo.On("GetByHash", hashKey(rawKey)).Return(key, nil)
+	suite.repo.On("UpdateLastUsed", key.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	identity, err := suite.useCase.ValidateAPIKey(context.Background(), rawKey)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), userID.String(), identity.UserID)
+	assert.Equal(suite.T(), "instructor", identity.Role)
+	assert.Equal(suite.T(), []string{"courses:read", "submissions:grade"}, identity.Scopes)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestValidateAPIKey_Revoked() {
+	rawKey := keyPrefix + "abcdefghijklmnop"
+	revokedAt := time.Now().Add(-time.Hour)
+	userID := uuid.New()
+
+	suite.repo.On("GetByHash", hashKey(rawKey)).Return(&schema.APIKey{
+		UserID:    userID,
+		User:      schema.User{ID: userID},
+		RevokedAt: &revokedAt,
+	}, nil)
+
+	_, err := suite.useCase.ValidateAPIKey(context.Background(), rawKey)
+	assert.Equal(suite.T(), ErrAPIKeyInvalid.Build(), err)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestValidateAPIKey_Expired() {
+	rawKey := keyPrefix + "abcdefghijklmnop"
+	expiresAt := time.Now().Add(-time.Minute)
+	userID := uuid.New()
+
+	suite.repo.On("GetByHash", hashKey(rawKey)).Return(&schema.APIKey{
+		UserID:    userID,
+		User:      schema.User{ID: userID},
+		ExpiresAt: &expiresAt,
+	}, nil)
+
+	_, err := suite.useCase.ValidateAPIKey(context.Background(), rawKey)
+	assert.Equal(suite.T(), ErrAPIKeyInvalid.Build(), err)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestValidateAPIKey_DeletedUser() {
+	rawKey := keyPrefix + "abcdefghijklmnop"
+
+	suite.repo.On("GetByHash", hashKey(rawKey)).Return(&schema.APIKey{UserID: uuid.New()}, nil)
+
+	_, err := suite.useCase.ValidateAPIKey(context.Background(), rawKey)
+	assert.Equal(suite.T(), ErrAPIKeyInvalid.Build(), err)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestValidateAPIKey_Unknown() {
+	rawKey := keyPrefix + "abcdefghijklmnop"
+
+	suite.repo.On("GetByHash", hashKey(rawKey)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := suite.useCase.ValidateAPIKey(context.Background(), rawKey)
+	assert.Equal(suite.T(), ErrAPIKeyInvalid.Build(), err)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestValidateAPIKey_WrongFormat() {
+	_, err := suite.useCase.ValidateAPIKey(context.Background(), "not-a-key")
+
+	assert.Equal(suite.T(), ErrAPIKeyInvalid.Build(), err)
+	suite.repo.AssertNotCalled(suite.T(), "GetByHash", mock.Anything)
+}
+
+func (suite *APIKeyUseCaseTestSuite) TestValidateAPIKey_InternalError() {
+	rawKey := keyPrefix + "abcdefghijklmnop"
+
+	suite.repo.On("GetByHash", hashKey(rawKey)).Return(nil, gorm.ErrInvalidDB)
+
+	_, err := suite.useCase.ValidateAPIKey(context.Background(), rawKey)
+	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
+}
+
+func TestAPIKeyUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyUseCaseTestSuite))
+}
@@ -0,0 +1,20 @@
+package apikey
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrAPIKeyNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("API_KEY_NOT_FOUND")
+
+	ErrAPIKeyInvalid = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusUnauthorized).
+				WithMessage("API_KEY_INVALID")
+
+	ErrTooManyAPIKeys = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("TOO_MANY_API_KEYS")
+)
@@ -0,0 +1,70 @@
+package apikey
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+type IRepository interface {
+	Create(key *schema.APIKey) error
+	GetByID(id uuid.UUID) (*schema.APIKey, error)
+	GetByHash(hash string) (*schema.APIKey, error)
+	GetActiveByUserID(userID uuid.UUID) ([]*schema.APIKey, error)
+	UpdateLastUsed(id uuid.UUID, at time.Time) error
+	Revoke(id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db}
+}
+
+func (r *repository) Create(key *schema.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *repository) GetByID(id uuid.UUID) (*schema.APIKey, error) {
+	var key schema.APIKey
+	if err := r.db.Where("revoked_at IS NULL").First(&key, id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByHash also loads the owning user, which is left zero-valued if the account has been deleted
+func (r *repository) GetByHash(hash string) (*schema.APIKey, error) {
+	var key schema.APIKey
+	if err := r.db.Joins("User").Where("key_hash = ?", hash).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *repository) GetActiveByUserID(userID uuid.UUID) ([]*schema.APIKey, error) {
+	var keys []*schema.APIKey
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)",
+		userID, time.Now()).
+		Order("created_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+func (r *repository) UpdateLastUsed(id uuid.UUID, at time.Time) error {
+	return r.db.Model(&schema.APIKey{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
+
+func (r *repository) Revoke(id uuid.UUID) error {
+	tx := r.db.Model(&schema.APIKey{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
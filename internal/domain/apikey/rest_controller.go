@@ -0,0 +1,74 @@
+package apikey
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	apiKeyGroup := engine.Group("/v1/auth/api-keys")
+	apiKeyGroup.Use(middleware.Authenticate(), middleware.RequireRole("instructor"))
+	{
+		apiKeyGroup.POST("", controller.Create())
+		apiKeyGroup.GET("", controller.GetMy())
+		apiKeyGroup.DELETE("/:id", controller.Revoke())
+	}
+}
+
+func (c *RestController) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CreateAPIKeyRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.Create(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "CREATE_API_KEY_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) GetMy() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res, err := c.uc.GetMy(ctx)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_API_KEYS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Revoke() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req RevokeAPIKeyRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		if err := c.uc.Revoke(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "REVOKE_API_KEY_SUCCESS", nil).Send(ctx)
+	}
+}
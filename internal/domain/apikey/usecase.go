@@ -0,0 +1,205 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	keyPrefix        = "sty_"
+	displayPrefixLen = 12
+	maxActiveKeys    = 10
+)
+
+type UseCase struct {
+	repo IRepository
+}
+
+func NewUseCase(repo IRepository) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func toResponse(key *schema.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID.String(),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     strings.Split(key.Scopes, ","),
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+func (uc *UseCase) Create(ctx context.Context, req *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	active, err := uc.repo.GetActiveByUserID(userID)
+	if err != nil {
+		log.Println("Error getting api keys: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if len(active) >= maxActiveKeys {
+		return nil, ErrTooManyAPIKeys.WithPayload(map[string]int{"max_active_keys": maxActiveKeys}).Build()
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	rawKey, err := generateKey()
+	if err != nil {
+		log.Println("Error generating api key: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	var scopes []string
+	for _, scope := range req.Scopes {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	key := &schema.APIKey{
+		ID:        id,
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    rawKey[:displayPrefixLen],
+		KeyHash:   hashKey(rawKey),
+		Scopes:    strings.Join(scopes, ","),
+		CreatedAt: time.Now(),
+	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := uc.repo.Create(key); err != nil {
+		log.Println("Error creating api key: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return &CreateAPIKeyResponse{APIKeyResponse: toResponse(key), Key: rawKey}, nil
+}
+
+func (uc *UseCase) GetMy(ctx context.Context) ([]APIKeyResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	keys, err := uc.repo.GetActiveByUserID(userID)
+	if err != nil {
+		log.Println("Error getting api keys: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	res := make([]APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, toResponse(key))
+	}
+
+	return res, nil
+}
+
+func (uc *UseCase) Revoke(ctx context.Context, req *RevokeAPIKeyRequest) error {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		return apierror.ErrInvalidParamId.Build()
+	}
+
+	key, err := uc.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAPIKeyNotFound.Build()
+		}
+		log.Println("Error getting api key by id: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	if key.UserID != userID {
+		return ErrAPIKeyNotFound.Build()
+	}
+
+	if err := uc.repo.Revoke(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAPIKeyNotFound.Build()
+		}
+		log.Println("Error revoking api key: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	return nil
+}
+
+// ValidateAPIKey implements middleware.APIKeyValidator
+func (uc *UseCase) ValidateAPIKey(ctx context.Context, rawKey string) (*middleware.APIKeyIdentity, error) {
+	if !strings.HasPrefix(rawKey, keyPrefix) {
+		return nil, ErrAPIKeyInvalid.Build()
+	}
+
+	key, err := uc.repo.GetByHash(hashKey(rawKey))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyInvalid.Build()
+		}
+		log.Println("Error getting api key by hash: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && key.ExpiresAt.Before(now)) || key.User.ID == uuid.Nil {
+		return nil, ErrAPIKeyInvalid.Build()
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > middleware.LastUsedResolution {
+		if err := uc.repo.UpdateLastUsed(key.ID, now); err != nil {
+			log.Println("Error updating api key last used: ", err)
+		}
+	}
+
+	return &middleware.APIKeyIdentity{
+		UserID:          key.UserID.String(),
+		Email:           key.User.Email,
+		IsEmailVerified: key.User.IsEmailVerified,
+		Name:            key.User.Name,
+		Role:            string(key.User.Role),
+		Scopes:          strings.Split(key.Scopes, ","),
+	}, nil
+}
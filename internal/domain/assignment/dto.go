@@ -7,17 +7,21 @@ import (
 	"github.com/google/uuid"
 )
 
+// CreateAssignmentRequest takes a fixed Due date, and DueAfterDays for students in a cohort, whose deadline is that
+// many days after their cohort starts
 type CreateAssignmentRequest struct {
-	CourseID    string     `json:"course_id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Due         *time.Time `json:"due,omitempty"`
+	CourseID     string     `json:"course_id"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	Due          *time.Time `json:"due,omitempty"`
+	DueAfterDays *int       `json:"due_after_days,omitempty" binding:"omitempty,min=0,max=3650"`
 }
 
 type UpdateAssignmentRequest struct {
-	Title       *string    `json:"title,omitempty"`
-	Description *string    `json:"description,omitempty"`
-	Due         *time.Time `json:"due,omitempty"`
+	Title        *string    `json:"title,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	Due          *time.Time `json:"due,omitempty"`
+	DueAfterDays *int       `json:"due_after_days,omitempty" binding:"omitempty,min=0,max=3650"`
 }
 
 type AssignmentResponse struct {
@@ -6,27 +6,30 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/cohort"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
 	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
 	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 )
 
 type RestController struct {
 	useCase       *UseCase
 	courseUseCase *course.UseCase
+	cohortUseCase *cohort.UseCase
 }
 
-func NewRestController(r *gin.Engine, uc *UseCase, cuc *course.UseCase) {
-	c := &RestController{useCase: uc, courseUseCase: cuc}
+func NewRestController(r *gin.Engine, uc *UseCase, cuc *course.UseCase, chuc *cohort.UseCase) {
+	c := &RestController{useCase: uc, courseUseCase: cuc, cohortUseCase: chuc}
 
 	assignmentGroup := r.Group("/v1/assignments")
 	{
-		assignmentGroup.POST("", middleware.Authenticate(), middleware.RequireRole("instructor"), c.createAssignment)
+		assignmentGroup.POST("", middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"), c.createAssignment)
 		assignmentGroup.GET("/:id", c.getAssignmentByID)
-		assignmentGroup.PUT("/:id", middleware.Authenticate(), middleware.RequireRole("instructor"), c.updateAssignment)
-		assignmentGroup.POST("/addAttachment/:assignmentId", middleware.Authenticate(), middleware.RequireRole("instructor"), c.addAttachment)
-		assignmentGroup.DELETE("/:id", middleware.Authenticate(), middleware.RequireRole("instructor"), c.deleteAssignment)
-		assignmentGroup.GET("/course/:courseId", middleware.Authenticate(), c.getAssignmentsByCourse)
+		assignmentGroup.PUT("/:id", middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"), c.updateAssignment)
+		assignmentGroup.POST("/addAttachment/:assignmentId", middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"), c.addAttachment)
+		assignmentGroup.DELETE("/:id", middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"), c.deleteAssignment)
+		assignmentGroup.GET("/course/:courseId", middleware.APIKeyScope("courses:read"), middleware.Authenticate(), c.getAssignmentsByCourse)
 	}
 }
 
@@ -107,6 +110,10 @@ func (c *RestController) getAssignmentByID(ctx *gin.Context) {
 		response.NewRestResponse(http.StatusInternalServerError, "Failed to fetch assignment: "+err.Error(), nil).Send(ctx)
 		return
 	}
+	if err := c.cohortUseCase.ApplyDueDates(ctx, []*schema.Assignment{assignment}); err != nil {
+		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+		return
+	}
 	response.NewRestResponse(http.StatusOK, "Assignment retrieved successfully", assignment).Send(ctx)
 }
 
@@ -174,6 +181,10 @@ func (c *RestController) getAssignmentsByCourse(ctx *gin.Context) {
 		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
 		return
 	}
+	if err := c.cohortUseCase.ApplyDueDates(ctx, assignments); err != nil {
+		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+		return
+	}
 	response.NewRestResponse(http.StatusOK, "Assignments retrieved successfully", assignments).Send(ctx)
 }
 
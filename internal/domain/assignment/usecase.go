@@ -25,11 +25,12 @@ func (uc *UseCase) CreateAssignment(ctx context.Context, req CreateAssignmentReq
 		return apierror.ErrInternalServer.Build()
 	}
 	assignment := &schema.Assignment{
-		ID:          id,
-		CourseID:    courseId,
-		Title:       req.Title,
-		Description: req.Description,
-		Due:         req.Due,
+		ID:           id,
+		CourseID:     courseId,
+		Title:        req.Title,
+		Description:  req.Description,
+		Due:          req.Due,
+		DueAfterDays: req.DueAfterDays,
 	}
 	return uc.repo.Create(ctx, assignment)
 }
@@ -49,6 +50,9 @@ func (uc *UseCase) UpdateAssignment(ctx context.Context, id uuid.UUID, req Updat
 	if req.Due != nil {
 		assignment.Due = req.Due
 	}
+	if req.DueAfterDays != nil {
+		assignment.DueAfterDays = req.DueAfterDays
+	}
 
 	return uc.repo.Update(ctx, assignment)
 }
@@ -48,6 +48,29 @@ func (m *MockFileUploader) UploadFile(key string, fileHeader *multipart.FileHead
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockFileUploader) UploadBytes(key string, data []byte, contentType string) (string, error) {
+	args := m.Called(key, data, contentType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) CopyFile(sourceURL, key string) (string, error) {
+	args := m.Called(sourceURL, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) DownloadFile(fileURL string) ([]byte, error) {
+	args := m.Called(fileURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockFileUploader) DeleteFile(fileURL string) error {
+	args := m.Called(fileURL)
+	return args.Error(0)
+}
+
 type AttachmentUseCaseTestSuite struct {
 	suite.Suite
 	attachmentRepo *MockRepository
@@ -5,18 +5,22 @@ import (
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
 	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/session"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/user"
 	"github.com/highfive-compfest/seatudy-backend/internal/jwtoken"
+	"github.com/highfive-compfest/seatudy-backend/internal/password"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
 	"gopkg.in/gomail.v2"
 	"gorm.io/gorm"
 	"os"
 	"testing"
+	"time"
 )
 
 type MockAuthRepository struct {
@@ -31,6 +35,10 @@ type MockMailDialer struct {
 	mock.Mock
 }
 
+type MockSessionRepository struct {
+	mock.Mock
+}
+
 func (m *MockAuthRepository) SaveOTP(ctx context.Context, email string, otp string) error {
 	args := m.Called(ctx, email, otp)
 	return args.Error(0)
@@ -61,6 +69,26 @@ func (m *MockAuthRepository) DeleteResetPasswordToken(ctx context.Context, email
 	return args.Error(0)
 }
 
+func (m *MockAuthRepository) SaveEmailChange(ctx context.Context, userID string, newEmail string, otp string) error {
+	args := m.Called(ctx, userID, newEmail, otp)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) GetEmailChange(ctx context.Context, userID string) (string, string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockAuthRepository) DeleteEmailChange(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) AddEmailChangeAttempt(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockUserRepository) Create(user *schema.User) error {
 	args := m.Called(user)
 	return args.Error(0)
@@ -94,31 +122,115 @@ func (m *MockUserRepository) UpdateByEmail(email string, user *schema.User) erro
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) UpdatePassword(userID uuid.UUID, passwordHash string, historySize int) error {
+	args := m.Called(userID, passwordHash, historySize)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetPasswordHistory(userID uuid.UUID, limit int) ([]string, error) {
+	args := m.Called(userID, limit)
+	hashes, ok := args.Get(0).([]string)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return hashes, args.Error(1)
+}
+
+func (m *MockUserRepository) GetPersonalData(userID uuid.UUID) (*user.PersonalData, error) {
+	args := m.Called(userID)
+	data, ok := args.Get(0).(*user.PersonalData)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return data, args.Error(1)
+}
+
+func (m *MockUserRepository) CountOwnedCourses(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) SetDeletionSchedule(userID uuid.UUID, at *time.Time) error {
+	args := m.Called(userID, at)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetDueForDeletion(before time.Time) ([]*schema.User, error) {
+	args := m.Called(before)
+	users, ok := args.Get(0).([]*schema.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return users, args.Error(1)
+}
+
+func (m *MockUserRepository) Anonymize(userID, anonymousID uuid.UUID) error {
+	args := m.Called(userID, anonymousID)
+	return args.Error(0)
+}
+
 func (m *MockMailDialer) DialAndSend(msg ...*gomail.Message) error {
 	args := m.Called(msg)
 	return args.Error(0)
 }
 
+func (m *MockSessionRepository) Create(session *schema.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) GetByID(id uuid.UUID) (*schema.Session, error) {
+	args := m.Called(id)
+	sessionObj, ok := args.Get(0).(*schema.Session)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return sessionObj, args.Error(1)
+}
+
+func (m *MockSessionRepository) GetActiveByUserID(userID uuid.UUID) ([]*schema.Session, error) {
+	args := m.Called(userID)
+	sessions, ok := args.Get(0).([]*schema.Session)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return sessions, args.Error(1)
+}
+
+func (m *MockSessionRepository) Update(session *schema.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) Revoke(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 type AuthUseCaseTestSuite struct {
 	suite.Suite
-	authRepo   *MockAuthRepository
-	userRepo   *MockUserRepository
-	mailDialer *MockMailDialer
-	useCase    *UseCase
+	authRepo    *MockAuthRepository
+	userRepo    *MockUserRepository
+	sessionRepo *MockSessionRepository
+	mailDialer  *MockMailDialer
+	useCase     *UseCase
 }
 
 func (suite *AuthUseCaseTestSuite) SetupTest() {
 	suite.authRepo = new(MockAuthRepository)
 	suite.userRepo = new(MockUserRepository)
+	suite.sessionRepo = new(MockSessionRepository)
 	suite.mailDialer = new(MockMailDialer)
-	suite.useCase = NewUseCase(suite.authRepo, suite.userRepo, suite.mailDialer)
+	suite.useCase = NewUseCase(suite.authRepo, suite.userRepo, session.NewUseCase(suite.sessionRepo),
+		password.Policy{MinLength: 8, RequireLower: true, RequireUpper: true, RequireDigit: true, HistorySize: 5},
+		suite.mailDialer)
 }
 
 func (suite *AuthUseCaseTestSuite) TestRegister_Success() {
 	req := &RegisterRequest{
 		Email:    "test@example.com",
 		Name:     "Test User",
-		Password: "password123",
+		Password: "Str0ngPassphrase",
 		Role:     "student",
 	}
 
@@ -132,7 +244,7 @@ func (suite *AuthUseCaseTestSuite) TestRegister_EmailAlreadyRegistered() {
 	req := &RegisterRequest{
 		Email:    "test@example.com",
 		Name:     "Test User",
-		Password: "password123",
+		Password: "Str0ngPassphrase",
 		Role:     "student",
 	}
 
@@ -149,7 +261,7 @@ func (suite *AuthUseCaseTestSuite) TestRegister_InternalServerError() {
 	req := &RegisterRequest{
 		Email:    "test@example.com",
 		Name:     "Test User",
-		Password: "password123",
+		Password: "Str0ngPassphrase",
 		Role:     "student",
 	}
 
@@ -160,6 +272,36 @@ func (suite *AuthUseCaseTestSuite) TestRegister_InternalServerError() {
 	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
 }
 
+func (suite *AuthUseCaseTestSuite) TestRegister_PasswordPolicyViolation() {
+	req := &RegisterRequest{
+		Email:    "johnsmith@example.com",
+		Name:     "John Smith",
+		Password: "johnsmith",
+		Role:     "student",
+	}
+
+	err := suite.useCase.Register(req)
+	assert.Equal(suite.T(), "PASSWORD_POLICY_VIOLATION", err.Error())
+	payload := apierror.GetPayload(err).(map[string]any)
+	assert.ElementsMatch(suite.T(), []string{
+		password.RuleUppercase, password.RuleDigit, password.RuleContainsEmail, password.RuleContainsName,
+	}, payload["failed_rules"])
+	suite.userRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *AuthUseCaseTestSuite) TestRegister_BreachedPassword() {
+	req := &RegisterRequest{
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Password: "Password123",
+		Role:     "student",
+	}
+
+	err := suite.useCase.Register(req)
+	payload := apierror.GetPayload(err).(map[string]any)
+	assert.Equal(suite.T(), []string{password.RuleBreached}, payload["failed_rules"])
+}
+
 func (suite *AuthUseCaseTestSuite) TestLogin_Success() {
 	_ = os.Setenv("ENV", "test")
 	_ = os.Setenv("JWT_ACCESS_DURATION", "10m")
@@ -180,6 +322,7 @@ func (suite *AuthUseCaseTestSuite) TestLogin_Success() {
 	}
 
 	suite.userRepo.On("GetByEmail", req.Email).Return(userObj, nil)
+	suite.sessionRepo.On("Create", mock.Anything).Return(nil)
 
 	resp, err := suite.useCase.Login(req)
 	assert.NoError(suite.T(), err)
@@ -239,11 +382,19 @@ func (suite *AuthUseCaseTestSuite) TestRefresh_Success() {
 	_ = os.Setenv("JWT_REFRESH_DURATION", "720h")
 	config.LoadEnv()
 
-	token, _ := jwtoken.CreateRefreshJWT("01914b1c-4762-7d85-bc7a-6e81eda6f2c7")
+	userID := uuid.MustParse("01914b1c-4762-7d85-bc7a-6e81eda6f2c7")
+	sessionID := uuid.New()
+	token, _ := jwtoken.CreateRefreshJWT(userID.String(), sessionID.String())
 	req := &RefreshRequest{
 		RefreshToken: token,
 	}
 
+	suite.sessionRepo.On("GetByID", sessionID).Return(&schema.Session{
+		ID:        sessionID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	suite.sessionRepo.On("Update", mock.Anything).Return(nil)
 	suite.userRepo.On("GetByID", mock.Anything).Return(&schema.User{}, nil)
 
 	resp, err := suite.useCase.Refresh(req)
@@ -251,6 +402,32 @@ func (suite *AuthUseCaseTestSuite) TestRefresh_Success() {
 	assert.NotNil(suite.T(), resp)
 }
 
+func (suite *AuthUseCaseTestSuite) TestRefresh_SessionRevoked() {
+	_ = os.Setenv("ENV", "test")
+	_ = os.Setenv("JWT_REFRESH_DURATION", "720h")
+	config.LoadEnv()
+
+	userID := uuid.MustParse("01914b1c-4762-7d85-bc7a-6e81eda6f2c7")
+	sessionID := uuid.New()
+	revokedAt := time.Now()
+	token, _ := jwtoken.CreateRefreshJWT(userID.String(), sessionID.String())
+	req := &RefreshRequest{
+		RefreshToken: token,
+	}
+
+	suite.sessionRepo.On("GetByID", sessionID).Return(&schema.Session{
+		ID:        sessionID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour),
+		RevokedAt: &revokedAt,
+	}, nil)
+
+	resp, err := suite.useCase.Refresh(req)
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), resp)
+	assert.Equal(suite.T(), session.ErrSessionRevoked.Build(), err)
+}
+
 func (suite *AuthUseCaseTestSuite) TestRefresh_InvalidToken() {
 	req := &RefreshRequest{
 		RefreshToken: "invalid_refresh_token",
@@ -267,7 +444,7 @@ func (suite *AuthUseCaseTestSuite) TestRefresh_TokenExpired() {
 	_ = os.Setenv("JWT_REFRESH_DURATION", "-720h")
 	config.LoadEnv()
 
-	token, _ := jwtoken.CreateRefreshJWT("01914b1c-4762-7d85-bc7a-6e81eda6f2c7")
+	token, _ := jwtoken.CreateRefreshJWT("01914b1c-4762-7d85-bc7a-6e81eda6f2c7", uuid.NewString())
 	req := &RefreshRequest{
 		RefreshToken: token,
 	}
@@ -415,17 +592,41 @@ func (suite *AuthUseCaseTestSuite) TestResetPassword_Success() {
 	req := &ResetPasswordRequest{
 		Email:       "test@example.com",
 		Token:       "valid_token",
-		NewPassword: "newpassword123",
+		NewPassword: "N3wPassphrase",
 	}
+	userObj := &schema.User{ID: uuid.New(), Email: req.Email, Name: "Test User"}
 
 	suite.authRepo.On("GetResetPasswordToken", mock.Anything, req.Email).Return("valid_token", nil)
-	suite.userRepo.On("UpdateByEmail", req.Email, mock.Anything).Return(nil)
+	suite.userRepo.On("GetByEmail", req.Email).Return(userObj, nil)
+	suite.userRepo.On("GetPasswordHistory", userObj.ID, 5).Return([]string{}, nil)
+	suite.userRepo.On("UpdatePassword", userObj.ID, mock.AnythingOfType("string"), 5).Return(nil)
 	suite.authRepo.On("DeleteResetPasswordToken", mock.Anything, req.Email).Return(nil)
 
 	err := suite.useCase.ResetPassword(context.Background(), req)
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *AuthUseCaseTestSuite) TestResetPassword_RecentlyUsed() {
+	req := &ResetPasswordRequest{
+		Email:       "test@example.com",
+		Token:       "valid_token",
+		NewPassword: "0ldPassphrase",
+	}
+	oldHash, _ := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.MinCost)
+	currentHash, _ := bcrypt.GenerateFromPassword([]byte("Curr3ntPassphrase"), bcrypt.MinCost)
+	userObj := &schema.User{ID: uuid.New(), Email: req.Email, Name: "Test User", PasswordHash: string(currentHash)}
+
+	suite.authRepo.On("GetResetPasswordToken", mock.Anything, req.Email).Return("valid_token", nil)
+	suite.userRepo.On("GetByEmail", req.Email).Return(userObj, nil)
+	suite.userRepo.On("GetPasswordHistory", userObj.ID, 5).Return([]string{string(currentHash), string(oldHash)}, nil)
+
+	err := suite.useCase.ResetPassword(context.Background(), req)
+	payload := apierror.GetPayload(err).(map[string]any)
+	assert.Equal(suite.T(), []string{password.RuleRecentlyReused}, payload["failed_rules"])
+	suite.userRepo.AssertNotCalled(suite.T(), "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	suite.authRepo.AssertNotCalled(suite.T(), "DeleteResetPasswordToken", mock.Anything, mock.Anything)
+}
+
 func (suite *AuthUseCaseTestSuite) TestResetPassword_ExpiredToken() {
 	req := &ResetPasswordRequest{
 		Email:       "test@example.com",
@@ -471,16 +672,18 @@ func (suite *AuthUseCaseTestSuite) TestChangePassword_Success() {
 
 	req := &ChangePasswordRequest{
 		OldPassword: "password123",
-		NewPassword: "newpassword123",
+		NewPassword: "N3wPassphrase",
 	}
 
 	userObj := &schema.User{
+		ID:           uuid.New(),
 		Email:        "test@example.com",
 		PasswordHash: "$2a$10$JdUpBMZEt2gUid5JJU6XVuB6Mdiu.qs4r94cX28vB.Y7ovzg.PO9G",
 	}
 
 	suite.userRepo.On("GetByEmail", "test@example.com").Return(userObj, nil)
-	suite.userRepo.On("UpdateByEmail", "test@example.com", mock.Anything).Return(nil)
+	suite.userRepo.On("GetPasswordHistory", userObj.ID, 5).Return([]string{}, nil)
+	suite.userRepo.On("UpdatePassword", userObj.ID, mock.AnythingOfType("string"), 5).Return(nil)
 
 	err := suite.useCase.ChangePassword(ctx, req)
 	assert.NoError(suite.T(), err)
@@ -491,7 +694,7 @@ func (suite *AuthUseCaseTestSuite) TestChangePassword_InvalidCredentials() {
 
 	req := &ChangePasswordRequest{
 		OldPassword: "wrongpassword",
-		NewPassword: "newpassword123",
+		NewPassword: "N3wPassphrase",
 	}
 
 	userObj := &schema.User{
@@ -521,6 +724,155 @@ func (suite *AuthUseCaseTestSuite) TestChangePassword_InternalServerError() {
 	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
 }
 
+func (suite *AuthUseCaseTestSuite) TestRequestEmailChange_Success() {
+	_ = os.Setenv("ENV", "test")
+	_ = os.Setenv("SMTP_EMAIL", "noreply@example.com")
+	config.LoadEnv()
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.email", "old@example.com")
+	ctx = context.WithValue(ctx, "user.name", "Test User")
+
+	req := &RequestEmailChangeRequest{
+		NewEmail: "new@example.com",
+		Password: "password123",
+	}
+
+	suite.userRepo.On("GetByID", userID).Return(&schema.User{
+		ID:           userID,
+		PasswordHash: "$2a$10$JdUpBMZEt2gUid5JJU6XVuB6Mdiu.qs4r94cX28vB.Y7ovzg.PO9G",
+	}, nil)
+	suite.userRepo.On("GetByEmail", req.NewEmail).Return(nil, gorm.ErrRecordNotFound)
+	suite.authRepo.On("SaveEmailChange", ctx, userID.String(), req.NewEmail, mock.Anything).Return(nil)
+	suite.mailDialer.On("DialAndSend", mock.Anything).Return(nil)
+
+	err := suite.useCase.RequestEmailChange(ctx, req)
+	assert.NoError(suite.T(), err)
+	suite.authRepo.AssertExpectations(suite.T())
+}
+
+func (suite *AuthUseCaseTestSuite) TestRequestEmailChange_SameEmail() {
+	ctx := context.WithValue(context.Background(), "user.id", uuid.NewString())
+	ctx = context.WithValue(ctx, "user.email", "old@example.com")
+	ctx = context.WithValue(ctx, "user.name", "Test User")
+
+	err := suite.useCase.RequestEmailChange(ctx, &RequestEmailChangeRequest{
+		NewEmail: "OLD@example.com",
+		Password: "password123",
+	})
+	assert.Equal(suite.T(), ErrSameEmail.Build(), err)
+}
+
+func (suite *AuthUseCaseTestSuite) TestRequestEmailChange_WrongPassword() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.email", "old@example.com")
+	ctx = context.WithValue(ctx, "user.name", "Test User")
+
+	suite.userRepo.On("GetByID", userID).Return(&schema.User{
+		ID:           userID,
+		PasswordHash: "$2a$10$JdUpBMZEt2gUid5JJU6XVuB6Mdiu.qs4r94cX28vB.Y7ovzg.PO9G",
+	}, nil)
+
+	err := suite.useCase.RequestEmailChange(ctx, &RequestEmailChangeRequest{
+		NewEmail: "new@example.com",
+		Password: "wrongpassword",
+	})
+	assert.Equal(suite.T(), ErrInvalidCredentials.Build(), err)
+}
+
+func (suite *AuthUseCaseTestSuite) TestRequestEmailChange_EmailTaken() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.email", "old@example.com")
+	ctx = context.WithValue(ctx, "user.name", "Test User")
+
+	suite.userRepo.On("GetByID", userID).Return(&schema.User{
+		ID:           userID,
+		PasswordHash: "$2a$10$JdUpBMZEt2gUid5JJU6XVuB6Mdiu.qs4r94cX28vB.Y7ovzg.PO9G",
+	}, nil)
+	suite.userRepo.On("GetByEmail", "new@example.com").Return(&schema.User{ID: uuid.New()}, nil)
+
+	err := suite.useCase.RequestEmailChange(ctx, &RequestEmailChangeRequest{
+		NewEmail: "new@example.com",
+		Password: "password123",
+	})
+	assert.Equal(suite.T(), ErrEmailAlreadyRegistered.Build(), err)
+}
+
+func (suite *AuthUseCaseTestSuite) TestConfirmEmailChange_Success() {
+	_ = os.Setenv("ENV", "test")
+	_ = os.Setenv("JWT_ACCESS_DURATION", "10m")
+	_ = os.Setenv("JWT_REFRESH_DURATION", "720h")
+	config.LoadEnv()
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.session_id", uuid.NewString())
+
+	suite.authRepo.On("GetEmailChange", ctx, userID.String()).Return("new@example.com", "123456", nil)
+	suite.userRepo.On("Update", &schema.User{ID: userID, Email: "new@example.com", IsEmailVerified: true}).Return(nil)
+	suite.authRepo.On("DeleteEmailChange", ctx, userID.String()).Return(nil)
+	suite.userRepo.On("GetByID", userID).Return(&schema.User{
+		ID:              userID,
+		Email:           "new@example.com",
+		IsEmailVerified: true,
+	}, nil)
+
+	resp, err := suite.useCase.ConfirmEmailChange(ctx, &ConfirmEmailChangeRequest{OTP: "123456"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "new@example.com", resp.User.Email)
+
+	claims, err := jwtoken.DecodeAccessJWT(resp.AccessToken)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "new@example.com", claims.Email)
+	assert.True(suite.T(), claims.IsEmailVerified)
+}
+
+func (suite *AuthUseCaseTestSuite) TestConfirmEmailChange_InvalidOTP() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.session_id", uuid.NewString())
+
+	suite.authRepo.On("GetEmailChange", ctx, userID.String()).Return("new@example.com", "123456", nil)
+	suite.authRepo.On("AddEmailChangeAttempt", ctx, userID.String()).Return(int64(1), nil)
+
+	resp, err := suite.useCase.ConfirmEmailChange(ctx, &ConfirmEmailChangeRequest{OTP: "654321"})
+	assert.Nil(suite.T(), resp)
+	assert.Equal(suite.T(), ErrInvalidOTP.Build(), err)
+	suite.userRepo.AssertNotCalled(suite.T(), "Update", mock.Anything)
+	suite.authRepo.AssertNotCalled(suite.T(), "DeleteEmailChange", mock.Anything, mock.Anything)
+}
+
+func (suite *AuthUseCaseTestSuite) TestConfirmEmailChange_TooManyAttempts() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.session_id", uuid.NewString())
+
+	suite.authRepo.On("GetEmailChange", ctx, userID.String()).Return("new@example.com", "123456", nil)
+	suite.authRepo.On("AddEmailChangeAttempt", ctx, userID.String()).Return(int64(maxEmailChangeAttempts), nil)
+	suite.authRepo.On("DeleteEmailChange", ctx, userID.String()).Return(nil)
+
+	resp, err := suite.useCase.ConfirmEmailChange(ctx, &ConfirmEmailChangeRequest{OTP: "654321"})
+	assert.Nil(suite.T(), resp)
+	assert.Equal(suite.T(), ErrTooManyOTPAttempts.Build(), err)
+	suite.authRepo.AssertExpectations(suite.T())
+	suite.userRepo.AssertNotCalled(suite.T(), "Update", mock.Anything)
+}
+
+func (suite *AuthUseCaseTestSuite) TestConfirmEmailChange_Expired() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.session_id", uuid.NewString())
+
+	suite.authRepo.On("GetEmailChange", ctx, userID.String()).Return("", "", redis.Nil)
+
+	resp, err := suite.useCase.ConfirmEmailChange(ctx, &ConfirmEmailChangeRequest{OTP: "123456"})
+	assert.Nil(suite.T(), resp)
+	assert.Equal(suite.T(), ErrExpiredOTP.Build(), err)
+}
+
 func TestAuthUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthUseCaseTestSuite))
 }
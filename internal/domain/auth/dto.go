@@ -7,13 +7,16 @@ import (
 type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email,max=320"`
 	Name     string `json:"name" binding:"required,max=50"`
-	Password string `json:"password" binding:"required,max=72,min=8"`
+	Password string `json:"password" binding:"required,max=72"`
 	Role     string `json:"role" binding:"required,oneof=student instructor"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email,max=320"`
-	Password string `json:"password" binding:"required,max=72"`
+	Email      string `json:"email" binding:"required,email,max=320"`
+	Password   string `json:"password" binding:"required,max=72"`
+	DeviceName string `json:"device_name" binding:"max=100"`
+	UserAgent  string `json:"-"`
+	IPAddress  string `json:"-"`
 }
 
 type LoginResponse struct {
@@ -24,6 +27,8 @@ type LoginResponse struct {
 
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
+	UserAgent    string `json:"-"`
+	IPAddress    string `json:"-"`
 }
 
 type RefreshResponse struct {
@@ -45,10 +50,19 @@ type SendResetPasswordLinkRequest struct {
 type ResetPasswordRequest struct {
 	Email       string `json:"email" binding:"required,email,max=320"`
 	Token       string `json:"token" binding:"required"`
-	NewPassword string `json:"new_password" binding:"required,max=72,min=8"`
+	NewPassword string `json:"new_password" binding:"required,max=72"`
 }
 
 type ChangePasswordRequest struct {
-	OldPassword string `json:"old_password" binding:"required,max=72,min=8"`
-	NewPassword string `json:"new_password" binding:"required,max=72,min=8"`
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,max=72"`
+}
+
+type RequestEmailChangeRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email,max=320"`
+	Password string `json:"password" binding:"required,max=72"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	OTP string `json:"otp" binding:"required"`
 }
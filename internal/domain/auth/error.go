@@ -22,6 +22,10 @@ var (
 			WithHttpStatus(http.StatusUnauthorized).
 			WithMessage("EXPIRED_OTP")
 
+	ErrTooManyOTPAttempts = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusTooManyRequests).
+				WithMessage("TOO_MANY_OTP_ATTEMPTS")
+
 	ErrEmailAlreadyVerified = apierror.NewApiErrorBuilder().
 				WithHttpStatus(http.StatusForbidden).
 				WithMessage("EMAIL_ALREADY_VERIFIED")
@@ -33,4 +37,12 @@ var (
 	ErrExpiredResetPasswordLink = apierror.NewApiErrorBuilder().
 					WithHttpStatus(http.StatusUnauthorized).
 					WithMessage("EXPIRED_RESET_PASSWORD_LINK")
+
+	ErrSameEmail = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusBadRequest).
+			WithMessage("SAME_EMAIL")
+
+	ErrPasswordPolicy = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("PASSWORD_POLICY_VIOLATION")
 )
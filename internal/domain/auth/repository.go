@@ -13,6 +13,10 @@ type Repository interface {
 	SaveResetPasswordToken(ctx context.Context, email string, token string) error
 	GetResetPasswordToken(ctx context.Context, email string) (string, error)
 	DeleteResetPasswordToken(ctx context.Context, email string) error
+	SaveEmailChange(ctx context.Context, userID string, newEmail string, otp string) error
+	GetEmailChange(ctx context.Context, userID string) (string, string, error)
+	DeleteEmailChange(ctx context.Context, userID string) error
+	AddEmailChangeAttempt(ctx context.Context, userID string) (int64, error)
 }
 
 type repository struct {
@@ -46,3 +50,44 @@ func (r *repository) GetResetPasswordToken(ctx context.Context, email string) (s
 func (r *repository) DeleteResetPasswordToken(ctx context.Context, email string) error {
 	return r.rds.Del(ctx, "auth:"+email+":reset_password_token").Err()
 }
+
+func (r *repository) SaveEmailChange(ctx context.Context, userID string, newEmail string, otp string) error {
+	key := "auth:" + userID + ":email_change"
+	_, err := r.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "email", newEmail, "otp", otp, "attempts", 0)
+		pipe.Expire(ctx, key, 10*time.Minute)
+		return nil
+	})
+	return err
+}
+
+// GetEmailChange returns the pending new email and its OTP, or redis.Nil when there is no pending change
+func (r *repository) GetEmailChange(ctx context.Context, userID string) (string, string, error) {
+	values, err := r.rds.HGetAll(ctx, "auth:"+userID+":email_change").Result()
+	if err != nil {
+		return "", "", err
+	}
+	if len(values) == 0 {
+		return "", "", redis.Nil
+	}
+	return values["email"], values["otp"], nil
+}
+
+func (r *repository) DeleteEmailChange(ctx context.Context, userID string) error {
+	return r.rds.Del(ctx, "auth:"+userID+":email_change").Err()
+}
+
+// addAttemptScript only counts against a pending change, so a change that expired in the meantime
+// is not recreated without a TTL
+var addAttemptScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return false
+end
+return redis.call("HINCRBY", KEYS[1], "attempts", 1)
+`)
+
+// AddEmailChangeAttempt records a failed OTP attempt on the pending change and returns the number of failures so far,
+// or redis.Nil when there is no pending change
+func (r *repository) AddEmailChangeAttempt(ctx context.Context, userID string) (int64, error) {
+	return addAttemptScript.Run(ctx, r.rds, []string{"auth:" + userID + ":email_change"}).Int64()
+}
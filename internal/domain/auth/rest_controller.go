@@ -34,6 +34,14 @@ func NewRestController(engine *gin.Engine, uc *UseCase) {
 			middleware.Authenticate(),
 			controller.ChangePassword(),
 		)
+		authGroup.POST("/email/change/request",
+			middleware.Authenticate(),
+			controller.RequestEmailChange(),
+		)
+		authGroup.PATCH("/email/change/verify",
+			middleware.Authenticate(),
+			controller.ConfirmEmailChange(),
+		)
 	}
 
 }
@@ -65,6 +73,9 @@ func (c *RestController) Login() gin.HandlerFunc {
 			return
 		}
 
+		req.UserAgent = ctx.Request.UserAgent()
+		req.IPAddress = ctx.ClientIP()
+
 		resp, err := c.uc.Login(&req)
 		if err != nil {
 			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
@@ -84,6 +95,9 @@ func (c *RestController) Refresh() gin.HandlerFunc {
 			return
 		}
 
+		req.UserAgent = ctx.Request.UserAgent()
+		req.IPAddress = ctx.ClientIP()
+
 		resp, err := c.uc.Refresh(&req)
 		if err != nil {
 			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
@@ -152,7 +166,7 @@ func (c *RestController) ResetPassword() gin.HandlerFunc {
 		}
 
 		if err := c.uc.ResetPassword(ctx, &req); err != nil {
-			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
 			return
 		}
 
@@ -170,10 +184,47 @@ func (c *RestController) ChangePassword() gin.HandlerFunc {
 		}
 
 		if err := c.uc.ChangePassword(ctx, &req); err != nil {
-			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
 			return
 		}
 
 		response.NewRestResponse(http.StatusOK, "CHANGE_PASSWORD_SUCCESS", nil).Send(ctx)
 	}
 }
+
+func (c *RestController) RequestEmailChange() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req RequestEmailChangeRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		if err := c.uc.RequestEmailChange(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "EMAIL_CHANGE_REQUEST_SUCCESS", nil).Send(ctx)
+	}
+}
+
+func (c *RestController) ConfirmEmailChange() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req ConfirmEmailChangeRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		resp, err := c.uc.ConfirmEmailChange(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "EMAIL_CHANGE_SUCCESS", resp).Send(ctx)
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
 	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/session"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/user"
 	"github.com/highfive-compfest/seatudy-backend/internal/jwtoken"
 	"github.com/highfive-compfest/seatudy-backend/internal/mailer"
+	"github.com/highfive-compfest/seatudy-backend/internal/password"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/redis/go-redis/v9"
@@ -19,20 +21,67 @@ import (
 	"math/rand"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type UseCase struct {
-	authRepo   Repository
-	userRepo   user.IRepository
-	mailDialer config.IMailer
+	authRepo       Repository
+	userRepo       user.IRepository
+	sessionUc      *session.UseCase
+	passwordPolicy password.Policy
+	mailDialer     config.IMailer
 }
 
-func NewUseCase(authRepo Repository, userRepo user.IRepository, mailDialer config.IMailer) *UseCase {
-	return &UseCase{authRepo: authRepo, userRepo: userRepo, mailDialer: mailDialer}
+func NewUseCase(authRepo Repository, userRepo user.IRepository, sessionUc *session.UseCase,
+	passwordPolicy password.Policy, mailDialer config.IMailer) *UseCase {
+	return &UseCase{
+		authRepo:       authRepo,
+		userRepo:       userRepo,
+		sessionUc:      sessionUc,
+		passwordPolicy: passwordPolicy,
+		mailDialer:     mailDialer,
+	}
+}
+
+func (uc *UseCase) passwordPolicyError(failedRules []string) error {
+	return ErrPasswordPolicy.WithPayload(map[string]any{
+		"failed_rules": failedRules,
+		"policy":       uc.passwordPolicy,
+	}).Build()
+}
+
+// checkNewPassword validates a replacement password against the policy and the user's recent passwords
+func (uc *UseCase) checkNewPassword(usr *schema.User, newPassword string) error {
+	failedRules := uc.passwordPolicy.Validate(newPassword, usr.Email, usr.Name)
+
+	if uc.passwordPolicy.HistorySize > 0 {
+		history, err := uc.userRepo.GetPasswordHistory(usr.ID, uc.passwordPolicy.HistorySize)
+		if err != nil {
+			log.Println("Error getting password history: ", err)
+			return apierror.ErrInternalServer.Build()
+		}
+		// Accounts created before history was kept only have their current hash
+		history = append(history, usr.PasswordHash)
+		for _, hash := range history {
+			if bcrypt.CompareHashAndPassword([]byte(hash), []byte(newPassword)) == nil {
+				failedRules = append(failedRules, password.RuleRecentlyReused)
+				break
+			}
+		}
+	}
+
+	if len(failedRules) > 0 {
+		return uc.passwordPolicyError(failedRules)
+	}
+	return nil
 }
 
 func (uc *UseCase) Register(req *RegisterRequest) error {
+	if failedRules := uc.passwordPolicy.Validate(req.Password, req.Email, req.Name); len(failedRules) > 0 {
+		return uc.passwordPolicyError(failedRules)
+	}
+
 	// Hash & Salt password
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -85,15 +134,20 @@ func (uc *UseCase) Login(req *LoginRequest) (*LoginResponse, error) {
 		return nil, ErrInvalidCredentials.Build()
 	}
 
+	sess, err := uc.sessionUc.Create(usr.ID, req.DeviceName, req.UserAgent, req.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	accessToken, err := jwtoken.CreateAccessJWT(
-		usr.ID.String(), usr.Email, usr.IsEmailVerified, usr.Name, string(usr.Role),
+		usr.ID.String(), usr.Email, usr.IsEmailVerified, usr.Name, string(usr.Role), sess.ID.String(),
 	)
 	if err != nil {
 		log.Println("Error creating access token: ", err)
 		return nil, apierror.ErrInternalServer.Build()
 	}
 
-	refreshToken, err := jwtoken.CreateRefreshJWT(usr.ID.String())
+	refreshToken, err := jwtoken.CreateRefreshJWT(usr.ID.String(), sess.ID.String())
 	if err != nil {
 		log.Println("Error creating refresh token: ", err)
 		return nil, apierror.ErrInternalServer.Build()
@@ -125,6 +179,15 @@ func (uc *UseCase) Refresh(req *RefreshRequest) (*RefreshResponse, error) {
 		return nil, apierror.ErrTokenInvalid.Build()
 	}
 
+	sessionID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	if err := uc.sessionUc.Use(sessionID, id, req.UserAgent, req.IPAddress); err != nil {
+		return nil, err
+	}
+
 	userEntity, err := uc.userRepo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -136,6 +199,7 @@ func (uc *UseCase) Refresh(req *RefreshRequest) (*RefreshResponse, error) {
 
 	accessToken, err := jwtoken.CreateAccessJWT(
 		userEntity.ID.String(), userEntity.Email, userEntity.IsEmailVerified, userEntity.Name, string(userEntity.Role),
+		sessionID.String(),
 	)
 	if err != nil {
 		log.Println("Error creating access token: ", err)
@@ -288,13 +352,26 @@ func (uc *UseCase) ResetPassword(ctx context.Context, req *ResetPasswordRequest)
 		return ErrInvalidResetPasswordLink.Build()
 	}
 
+	userEntity, err := uc.userRepo.GetByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return user.ErrUserNotFound.Build()
+		}
+		log.Println("Error getting user by email: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	if err := uc.checkNewPassword(userEntity, req.NewPassword); err != nil {
+		return err
+	}
+
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
 		log.Println("Error hashing password: ", err)
 		return apierror.ErrInternalServer.Build()
 	}
 
-	err = uc.userRepo.UpdateByEmail(req.Email, &schema.User{PasswordHash: string(passwordHash)})
+	err = uc.userRepo.UpdatePassword(userEntity.ID, string(passwordHash), uc.passwordPolicy.HistorySize)
 	if err != nil {
 		log.Println("Error updating user password: ", err)
 		return apierror.ErrInternalServer.Build()
@@ -325,13 +402,17 @@ func (uc *UseCase) ChangePassword(ctx context.Context, req *ChangePasswordReques
 		return ErrInvalidCredentials.Build()
 	}
 
+	if err := uc.checkNewPassword(userEntity, req.NewPassword); err != nil {
+		return err
+	}
+
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
 	if err != nil {
 		log.Println("Error hashing password: ", err)
 		return apierror.ErrInternalServer.Build()
 	}
 
-	err = uc.userRepo.UpdateByEmail(email, &schema.User{PasswordHash: string(passwordHash)})
+	err = uc.userRepo.UpdatePassword(userEntity.ID, string(passwordHash), uc.passwordPolicy.HistorySize)
 	if err != nil {
 		log.Println("Error updating user password: ", err)
 		return apierror.ErrInternalServer.Build()
@@ -339,3 +420,168 @@ func (uc *UseCase) ChangePassword(ctx context.Context, req *ChangePasswordReques
 
 	return nil
 }
+
+//go:embed email_change_otp_email_template.html
+var emailChangeOTPEmailTemplate string
+
+//go:embed email_change_notice_email_template.html
+var emailChangeNoticeEmailTemplate string
+
+func (uc *UseCase) RequestEmailChange(ctx context.Context, req *RequestEmailChangeRequest) error {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+	email := ctx.Value("user.email").(string)
+	name := ctx.Value("user.name").(string)
+
+	if strings.EqualFold(req.NewEmail, email) {
+		return ErrSameEmail.Build()
+	}
+
+	userEntity, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return user.ErrUserNotFound.Build()
+		}
+		log.Println("Error getting user by ID: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(userEntity.PasswordHash), []byte(req.Password))
+	if err != nil {
+		return ErrInvalidCredentials.Build()
+	}
+
+	if _, err := uc.userRepo.GetByEmail(req.NewEmail); err == nil {
+		return ErrEmailAlreadyRegistered.Build()
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Println("Error getting user by email: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	otp := strconv.Itoa(generateOTP())
+
+	if err := uc.authRepo.SaveEmailChange(ctx, userID.String(), req.NewEmail, otp); err != nil {
+		log.Println("Error saving email change: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	// Send confirmation code to the new email
+	mail, err := mailer.GenerateMail(req.NewEmail, "Confirm Your New Seatudy Email", emailChangeOTPEmailTemplate,
+		map[string]any{
+			"recipient_name": name,
+			"otp":            otp,
+		})
+	if err != nil {
+		log.Println("Error generating email change OTP email: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	if err = uc.mailDialer.DialAndSend(mail); err != nil {
+		log.Println("Error sending email change OTP email: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	// Notify the old email
+	go func() {
+		mail, err := mailer.GenerateMail(email, "Your Seatudy Email Is Being Changed", emailChangeNoticeEmailTemplate,
+			map[string]any{
+				"recipient_name": name,
+				"new_email":      req.NewEmail,
+			})
+		if err != nil {
+			log.Println("Error generating email change notice email: ", err)
+			return
+		}
+
+		if err = uc.mailDialer.DialAndSend(mail); err != nil {
+			log.Println("Error sending email change notice email: ", err)
+		}
+	}()
+
+	return nil
+}
+
+const maxEmailChangeAttempts = 5
+
+// ConfirmEmailChange applies the pending email change and reissues tokens for the current session,
+// since the old access token still carries the previous email
+func (uc *UseCase) ConfirmEmailChange(ctx context.Context, req *ConfirmEmailChangeRequest) (*LoginResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+	sessionID := ctx.Value("user.session_id").(string)
+
+	newEmail, savedOTP, err := uc.authRepo.GetEmailChange(ctx, userID.String())
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrExpiredOTP.Build()
+		}
+		log.Println("Error getting email change: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	if req.OTP != savedOTP {
+		attempts, err := uc.authRepo.AddEmailChangeAttempt(ctx, userID.String())
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				return nil, ErrExpiredOTP.Build()
+			}
+			log.Println("Error counting email change attempt: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		// A six digit code must not be guessable within its lifetime, so the change has to be requested again
+		if attempts >= maxEmailChangeAttempts {
+			if err = uc.authRepo.DeleteEmailChange(ctx, userID.String()); err != nil {
+				log.Println("Error deleting email change: ", err)
+				return nil, apierror.ErrInternalServer.Build()
+			}
+			return nil, ErrTooManyOTPAttempts.Build()
+		}
+		return nil, ErrInvalidOTP.Build()
+	}
+
+	// The code was delivered to the new address, so it is verified by confirming the change
+	err = uc.userRepo.Update(&schema.User{ID: userID, Email: newEmail, IsEmailVerified: true})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		ok := errors.As(err, &pgErr)
+		if ok && pgErr.Code == "23505" {
+			return nil, ErrEmailAlreadyRegistered.Build()
+		}
+		log.Println("Error updating user email: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	if err = uc.authRepo.DeleteEmailChange(ctx, userID.String()); err != nil {
+		log.Println("Error deleting email change: ", err)
+	}
+
+	usr, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		log.Println("Error getting user by ID: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	accessToken, err := jwtoken.CreateAccessJWT(
+		usr.ID.String(), usr.Email, usr.IsEmailVerified, usr.Name, string(usr.Role), sessionID,
+	)
+	if err != nil {
+		log.Println("Error creating access token: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	refreshToken, err := jwtoken.CreateRefreshJWT(usr.ID.String(), sessionID)
+	if err != nil {
+		log.Println("Error creating refresh token: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return &LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		User:         usr,
+	}, nil
+}
@@ -0,0 +1,452 @@
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/user"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"mime/multipart"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(certificate *schema.Certificate) error {
+	args := m.Called(certificate)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetBySerial(serial string) (*schema.Certificate, error) {
+	args := m.Called(serial)
+	certificate, ok := args.Get(0).(*schema.Certificate)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return certificate, args.Error(1)
+}
+
+func (m *MockRepository) GetByUserAndCourse(userID, courseID uuid.UUID) (*schema.Certificate, error) {
+	args := m.Called(userID, courseID)
+	certificate, ok := args.Get(0).(*schema.Certificate)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return certificate, args.Error(1)
+}
+
+func (m *MockRepository) GetByUserID(userID uuid.UUID) ([]*schema.Certificate, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*schema.Certificate), args.Error(1)
+}
+
+func (m *MockRepository) GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	args := m.Called(userID, courseID)
+	enroll, ok := args.Get(0).(*schema.CourseEnroll)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return enroll, args.Error(1)
+}
+
+func (m *MockRepository) GetUncertifiedCompletions(limit int) ([]*schema.CourseEnroll, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]*schema.CourseEnroll), args.Error(1)
+}
+
+type MockCourseRepository struct {
+	mock.Mock
+}
+
+func (m *MockCourseRepository) GetAll(ctx context.Context, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetByID(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetRating(ctx context.Context, courseID uuid.UUID) (float32, int64, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).(float32), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCourseRepository) Create(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) Update(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, publishedOnly bool, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, instructorID, publishedOnly, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) FindByPopularity(ctx context.Context, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetUserCourseProgress(ctx context.Context, courseID, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, courseID, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCourseRepository) Search(ctx context.Context, query string, page, pageSize int) ([]course.SearchHit, int, error) {
+	args := m.Called(ctx, query, page, pageSize)
+	return args.Get(0).([]course.SearchHit), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) Suggest(ctx context.Context, prefix string, limit int) ([]course.Suggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]course.Suggestion), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindCatalog(ctx context.Context, filter course.CatalogFilter, page, limit int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, filter, page, limit)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetCatalogFacets(ctx context.Context, filter course.CatalogFilter) (course.CatalogFacets, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(course.CatalogFacets), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindByStatus(ctx context.Context, status schema.CourseStatus, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, status, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) UpdateStatus(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetPrerequisites(ctx context.Context, courseID uuid.UUID) ([]course.PrerequisiteCourse, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]course.PrerequisiteCourse), args.Error(1)
+}
+
+func (m *MockCourseRepository) SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisites []schema.CoursePrerequisite) error {
+	args := m.Called(ctx, courseID, prerequisites)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetPrerequisiteClosure(ctx context.Context, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCompletedCourseIDs(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, userID, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCloneSource(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetSections(ctx context.Context, courseID uuid.UUID) ([]schema.CourseSection, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]schema.CourseSection), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateWithContent(ctx context.Context, course *schema.Course, sections []schema.CourseSection) error {
+	args := m.Called(ctx, course, sections)
+	return args.Error(0)
+}
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(user *schema.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetByID(id uuid.UUID) (*schema.User, error) {
+	args := m.Called(id)
+	user, ok := args.Get(0).(*schema.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmail(email string) (*schema.User, error) {
+	args := m.Called(email)
+	user, ok := args.Get(0).(*schema.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) Update(user *schema.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateByEmail(email string, user *schema.User) error {
+	args := m.Called(email, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePassword(userID uuid.UUID, passwordHash string, historySize int) error {
+	args := m.Called(userID, passwordHash, historySize)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetPasswordHistory(userID uuid.UUID, limit int) ([]string, error) {
+	args := m.Called(userID, limit)
+	hashes, ok := args.Get(0).([]string)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return hashes, args.Error(1)
+}
+
+func (m *MockUserRepository) GetPersonalData(userID uuid.UUID) (*user.PersonalData, error) {
+	args := m.Called(userID)
+	data, ok := args.Get(0).(*user.PersonalData)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return data, args.Error(1)
+}
+
+func (m *MockUserRepository) CountOwnedCourses(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) SetDeletionSchedule(userID uuid.UUID, at *time.Time) error {
+	args := m.Called(userID, at)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetDueForDeletion(before time.Time) ([]*schema.User, error) {
+	args := m.Called(before)
+	users, ok := args.Get(0).([]*schema.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return users, args.Error(1)
+}
+
+func (m *MockUserRepository) Anonymize(userID, anonymousID uuid.UUID) error {
+	args := m.Called(userID, anonymousID)
+	return args.Error(0)
+}
+
+type MockFileUploader struct {
+	mock.Mock
+}
+
+func (m *MockFileUploader) UploadFile(key string, fileHeader *multipart.FileHeader) (string, error) {
+	args := m.Called(key, fileHeader)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) UploadBytes(key string, data []byte, contentType string) (string, error) {
+	args := m.Called(key, data, contentType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) CopyFile(sourceURL, key string) (string, error) {
+	args := m.Called(sourceURL, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) DownloadFile(fileURL string) ([]byte, error) {
+	args := m.Called(fileURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockFileUploader) DeleteFile(fileURL string) error {
+	args := m.Called(fileURL)
+	return args.Error(0)
+}
+
+type CertificateUseCaseTestSuite struct {
+	suite.Suite
+	repo       *MockRepository
+	userRepo   *MockUserRepository
+	courseRepo *MockCourseRepository
+	uploader   *MockFileUploader
+	uc         *UseCase
+
+	studentID    uuid.UUID
+	instructorID uuid.UUID
+	courseID     uuid.UUID
+}
+
+func (s *CertificateUseCaseTestSuite) SetupTest() {
+	_ = os.Setenv("ENV", "test")
+	config.LoadEnv()
+
+	s.repo = new(MockRepository)
+	s.userRepo = new(MockUserRepository)
+	s.courseRepo = new(MockCourseRepository)
+	s.uploader = new(MockFileUploader)
+	s.uc = NewUseCase(s.repo, s.userRepo, s.courseRepo, s.uploader)
+
+	s.studentID = uuid.New()
+	s.instructorID = uuid.New()
+	s.courseID = uuid.New()
+}
+
+func TestCertificateUseCase(t *testing.T) {
+	suite.Run(t, new(CertificateUseCaseTestSuite))
+}
+
+func (s *CertificateUseCaseTestSuite) TestIssue_Success() {
+	completedAt := time.Date(2024, time.August, 17, 10, 0, 0, 0, time.UTC)
+	s.repo.On("GetByUserAndCourse", s.studentID, s.courseID).Return(nil, gorm.ErrRecordNotFound)
+	s.repo.On("GetEnrollment", s.studentID, s.courseID).
+		Return(&schema.CourseEnroll{UserID: s.studentID, CourseID: s.courseID, CompletedAt: &completedAt}, nil)
+	s.userRepo.On("GetByID", s.studentID).Return(&schema.User{ID: s.studentID, Name: "Budi Santoso"}, nil)
+	s.userRepo.On("GetByID", s.instructorID).Return(&schema.User{ID: s.instructorID, Name: "Ani Wijaya"}, nil)
+	s.courseRepo.On("GetByID", mock.Anything, s.courseID).
+		Return(schema.Course{ID: s.courseID, Title: "Intro to Go (2024)", InstructorID: s.instructorID}, nil)
+	s.uploader.On("UploadBytes", mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "certificates/SEA-") && strings.HasSuffix(key, ".pdf")
+	}), mock.MatchedBy(func(data []byte) bool {
+		return bytes.HasPrefix(data, []byte("%PDF-1.4")) && bytes.Contains(data, []byte(`(Intro to Go \(2024\)) Tj`))
+	}), "application/pdf").Return("https://bucket/certificates/file.pdf", nil)
+	s.repo.On("Create", mock.AnythingOfType("*schema.Certificate")).Return(nil)
+
+	certificate, err := s.uc.Issue(context.Background(), s.studentID, s.courseID)
+
+	s.NoError(err)
+	s.Equal("Budi Santoso", certificate.StudentName)
+	s.Equal("Ani Wijaya", certificate.InstructorName)
+	s.Equal(completedAt, certificate.IssuedAt)
+	s.Equal("https://bucket/certificates/file.pdf", certificate.FileURL)
+	s.Regexp(`^SEA-[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}$`, certificate.Serial)
+	s.uploader.AssertExpectations(s.T())
+}
+
+func (s *CertificateUseCaseTestSuite) TestIssue_AlreadyIssued() {
+	existing := &schema.Certificate{Serial: "SEA-AAAA-BBBB-CCCC-DDDD"}
+	s.repo.On("GetByUserAndCourse", s.studentID, s.courseID).Return(existing, nil)
+
+	certificate, err := s.uc.Issue(context.Background(), s.studentID, s.courseID)
+
+	s.NoError(err)
+	s.Equal(existing, certificate)
+	s.uploader.AssertNotCalled(s.T(), "UploadBytes", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CertificateUseCaseTestSuite) TestIssue_NotCompleted() {
+	s.repo.On("GetByUserAndCourse", s.studentID, s.courseID).Return(nil, gorm.ErrRecordNotFound)
+	s.repo.On("GetEnrollment", s.studentID, s.courseID).
+		Return(&schema.CourseEnroll{UserID: s.studentID, CourseID: s.courseID}, nil)
+
+	_, err := s.uc.Issue(context.Background(), s.studentID, s.courseID)
+
+	assert.Equal(s.T(), ErrCourseNotCompleted.Build(), err)
+}
+
+func (s *CertificateUseCaseTestSuite) TestIssuePending_ContinuesAfterFailure() {
+	otherCourseID := uuid.New()
+	s.repo.On("GetUncertifiedCompletions", issueBatchSize).Return([]*schema.CourseEnroll{
+		{UserID: s.studentID, CourseID: s.courseID},
+		{UserID: s.studentID, CourseID: otherCourseID},
+	}, nil)
+	s.repo.On("GetByUserAndCourse", s.studentID, s.courseID).Return(nil, assert.AnError)
+	s.repo.On("GetByUserAndCourse", s.studentID, otherCourseID).Return(&schema.Certificate{}, nil)
+
+	err := s.uc.IssuePending(context.Background())
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *CertificateUseCaseTestSuite) TestVerify_Success() {
+	issuedAt := time.Now()
+	s.repo.On("GetBySerial", "SEA-AAAA-BBBB-CCCC-DDDD").Return(&schema.Certificate{
+		Serial: "SEA-AAAA-BBBB-CCCC-DDDD", StudentName: "Budi Santoso", CourseTitle: "Intro to Go",
+		InstructorName: "Ani Wijaya", IssuedAt: issuedAt,
+	}, nil)
+
+	res, err := s.uc.Verify(&SerialRequest{Serial: " sea-aaaa-bbbb-cccc-dddd "})
+
+	s.NoError(err)
+	s.True(res.Valid)
+	s.Equal("Budi Santoso", res.StudentName)
+	s.Equal(issuedAt, res.IssuedAt)
+}
+
+func (s *CertificateUseCaseTestSuite) TestVerify_NotFound() {
+	s.repo.On("GetBySerial", "SEA-NOPE").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.uc.Verify(&SerialRequest{Serial: "SEA-NOPE"})
+
+	assert.Equal(s.T(), ErrCertificateNotFound.Build(), err)
+}
+
+func TestRenderPDF_CrossReferenceOffsets(t *testing.T) {
+	assert.Len(t, helveticaWidths, 126-32+1)
+
+	out := renderPDF([]textLine{{text: "Zoë (test) \\ 日本", size: 12, y: 100}})
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "(Zo\xeb \\(test\\) \\\\ ??) Tj")
+
+	// Every xref entry must point at the start of its object
+	xref := out[bytes.Index(out, []byte("\nxref\n"))+1:]
+	lines := strings.Split(string(xref), "\n")
+	for i := 1; i <= 6; i++ {
+		var offset int
+		_, err := fmt.Sscanf(lines[2+i], "%010d", &offset)
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj", i))))
+	}
+}
+
+func TestWriteCentered_ShrinksLongText(t *testing.T) {
+	var content bytes.Buffer
+	writeCentered(&content, textLine{text: strings.Repeat("W", 80), size: 30, bold: true, y: 100})
+
+	var font string
+	var size, x float64
+	_, err := fmt.Sscanf(content.String(), "BT /%s %f Tf %f", &font, &size, &x)
+	assert.NoError(t, err)
+	assert.Less(t, size, 30.0)
+	assert.GreaterOrEqual(t, x, 2*pageMargin)
+}
@@ -0,0 +1,24 @@
+package certificate
+
+import (
+	"time"
+)
+
+type SerialRequest struct {
+	Serial string `uri:"serial" binding:"required,max=32"`
+}
+
+type CourseIDRequest struct {
+	CourseID string `uri:"id" binding:"required,uuid"`
+}
+
+// VerificationResponse is returned to anyone holding a serial, so it only contains what is printed on the certificate
+type VerificationResponse struct {
+	Valid          bool      `json:"valid"`
+	Serial         string    `json:"serial"`
+	StudentName    string    `json:"student_name"`
+	CourseTitle    string    `json:"course_title"`
+	InstructorName string    `json:"instructor_name"`
+	IssuedAt       time.Time `json:"issued_at"`
+	FileURL        string    `json:"file_url"`
+}
@@ -0,0 +1,16 @@
+package certificate
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrCertificateNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("CERTIFICATE_NOT_FOUND")
+
+	ErrCourseNotCompleted = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusForbidden).
+				WithMessage("COURSE_NOT_COMPLETED")
+)
@@ -0,0 +1,127 @@
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// The certificate is drawn on a landscape A4 page using the standard Helvetica fonts,
+// which every PDF reader ships with, so no font files need to be embedded.
+const (
+	pageWidth    = 842.0
+	pageHeight   = 595.0
+	pageMargin   = 36.0
+	maxTextWidth = pageWidth - 4*pageMargin
+)
+
+// helveticaWidths holds the glyph widths of Helvetica for ASCII 32 to 126, in 1/1000 of the font size
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// boldWidthFactor approximates Helvetica-Bold from the regular widths, which is close enough for centering
+const boldWidthFactor = 1.06
+
+type textLine struct {
+	text string
+	size float64
+	bold bool
+	y    float64
+}
+
+// toWinAnsi maps text to single-byte WinAnsi, replacing characters the standard fonts cannot show
+func toWinAnsi(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= 32 && r <= 126 || r >= 160 && r <= 255 {
+			b.WriteByte(byte(r))
+		} else {
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func textWidth(s string, size float64, bold bool) float64 {
+	var units int
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 32 && c <= 126 {
+			units += helveticaWidths[c-32]
+		} else {
+			units += 556
+		}
+	}
+	width := float64(units) * size / 1000
+	if bold {
+		width *= boldWidthFactor
+	}
+	return width
+}
+
+func escapePDFString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// writeCentered shrinks the font until the line fits the page, then centers it horizontally
+func writeCentered(content *bytes.Buffer, line textLine) {
+	text := toWinAnsi(line.text)
+	size := line.size
+	for size > 8 && textWidth(text, size, line.bold) > maxTextWidth {
+		size--
+	}
+
+	font := "F1"
+	if line.bold {
+		font = "F2"
+	}
+	x := (pageWidth - textWidth(text, size, line.bold)) / 2
+	fmt.Fprintf(content, "BT /%s %.0f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, line.y, escapePDFString(text))
+}
+
+func renderPDF(lines []textLine) []byte {
+	var content bytes.Buffer
+	// Double border around the page
+	content.WriteString("0.16 0.32 0.55 RG\n")
+	fmt.Fprintf(&content, "4 w %.0f %.0f %.0f %.0f re S\n",
+		pageMargin, pageMargin, pageWidth-2*pageMargin, pageHeight-2*pageMargin)
+	fmt.Fprintf(&content, "1 w %.0f %.0f %.0f %.0f re S\n",
+		pageMargin+8, pageMargin+8, pageWidth-2*pageMargin-16, pageHeight-2*pageMargin-16)
+	content.WriteString("0 0 0 rg\n")
+	for _, line := range lines {
+		writeCentered(&content, line)
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> /Contents 4 0 R >>", pageWidth, pageHeight),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xrefOffset)
+
+	return out.Bytes()
+}
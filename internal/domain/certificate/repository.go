@@ -0,0 +1,75 @@
+package certificate
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+type IRepository interface {
+	Create(certificate *schema.Certificate) error
+	GetBySerial(serial string) (*schema.Certificate, error)
+	GetByUserAndCourse(userID, courseID uuid.UUID) (*schema.Certificate, error)
+	GetByUserID(userID uuid.UUID) ([]*schema.Certificate, error)
+	GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error)
+	GetUncertifiedCompletions(limit int) ([]*schema.CourseEnroll, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db}
+}
+
+func (r *repository) Create(certificate *schema.Certificate) error {
+	return r.db.Create(certificate).Error
+}
+
+func (r *repository) GetBySerial(serial string) (*schema.Certificate, error) {
+	var certificate schema.Certificate
+	if err := r.db.Where("serial = ?", serial).First(&certificate).Error; err != nil {
+		return nil, err
+	}
+	return &certificate, nil
+}
+
+func (r *repository) GetByUserAndCourse(userID, courseID uuid.UUID) (*schema.Certificate, error) {
+	var certificate schema.Certificate
+	if err := r.db.Where("user_id = ? AND course_id = ?", userID, courseID).First(&certificate).Error; err != nil {
+		return nil, err
+	}
+	return &certificate, nil
+}
+
+func (r *repository) GetByUserID(userID uuid.UUID) ([]*schema.Certificate, error) {
+	var certificates []*schema.Certificate
+	err := r.db.Where("user_id = ?", userID).Order("issued_at DESC").Find(&certificates).Error
+	return certificates, err
+}
+
+func (r *repository) GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	var enroll schema.CourseEnroll
+	if err := r.db.Where("user_id = ? AND course_id = ?", userID, courseID).First(&enroll).Error; err != nil {
+		return nil, err
+	}
+	return &enroll, nil
+}
+
+// GetUncertifiedCompletions finds completed enrollments that do not have a certificate yet. Deleted users and
+// courses are skipped, since Issue cannot load them and they would otherwise fill every batch.
+func (r *repository) GetUncertifiedCompletions(limit int) ([]*schema.CourseEnroll, error) {
+	var enrolls []*schema.CourseEnroll
+	err := r.db.Model(&schema.CourseEnroll{}).
+		Select("course_enrolls.*").
+		Joins("JOIN users ON users.id = course_enrolls.user_id AND users.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = course_enrolls.course_id AND courses.deleted_at IS NULL").
+		Joins("LEFT JOIN certificates ON certificates.user_id = course_enrolls.user_id " +
+			"AND certificates.course_id = course_enrolls.course_id").
+		Where("course_enrolls.completed_at IS NOT NULL AND certificates.id IS NULL").
+		Order("course_enrolls.completed_at").
+		Limit(limit).
+		Find(&enrolls).Error
+	return enrolls, err
+}
@@ -0,0 +1,78 @@
+package certificate
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	certificateGroup := engine.Group("/v1/certificates")
+	{
+		certificateGroup.GET("/me", middleware.Authenticate(), controller.GetMy())
+		certificateGroup.POST("/courses/:id",
+			middleware.Authenticate(),
+			middleware.RequireRole("student"),
+			controller.IssueForCourse(),
+		)
+		certificateGroup.GET("/:serial", controller.Verify())
+	}
+}
+
+func (c *RestController) GetMy() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res, err := c.uc.GetMy(ctx)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_CERTIFICATES_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) IssueForCourse() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CourseIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.IssueForCourse(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "ISSUE_CERTIFICATE_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Verify() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req SerialRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.Verify(&req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "CERTIFICATE_VALID", res).Send(ctx)
+	}
+}
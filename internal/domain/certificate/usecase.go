@@ -0,0 +1,208 @@
+package certificate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/courseenroll"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/user"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"log"
+	"strings"
+)
+
+const (
+	serialPrefix = "SEA"
+	// issueBatchSize bounds how many certificates one run of IssuePending generates
+	issueBatchSize = 50
+)
+
+type UseCase struct {
+	repo       IRepository
+	userRepo   user.IRepository
+	courseRepo course.Repository
+	uploader   config.FileUploader
+}
+
+func NewUseCase(repo IRepository, userRepo user.IRepository, courseRepo course.Repository, uploader config.FileUploader) *UseCase {
+	return &UseCase{repo: repo, userRepo: userRepo, courseRepo: courseRepo, uploader: uploader}
+}
+
+// generateSerial returns a serial such as SEA-7K2D-QX4M-PLW9-A3FZ
+func generateSerial() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	encoded := base32.StdEncoding.EncodeToString(b)
+
+	groups := []string{serialPrefix}
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+func verificationURL(serial string) string {
+	return config.Env.FrontendUrl + "/certificates/" + serial
+}
+
+func certificateLines(certificate *schema.Certificate) []textLine {
+	return []textLine{
+		{text: "SEATUDY", size: 16, bold: true, y: 490},
+		{text: "Certificate of Completion", size: 34, bold: true, y: 440},
+		{text: "This certifies that", size: 14, y: 390},
+		{text: certificate.StudentName, size: 30, bold: true, y: 345},
+		{text: "has successfully completed the course", size: 14, y: 305},
+		{text: certificate.CourseTitle, size: 22, bold: true, y: 265},
+		{text: "Instructor: " + certificate.InstructorName, size: 13, y: 215},
+		{text: "Issued on " + certificate.IssuedAt.Format("January 2, 2006"), size: 13, y: 195},
+		{text: "Serial: " + certificate.Serial, size: 11, y: 110},
+		{text: "Verify at " + verificationURL(certificate.Serial), size: 10, y: 92},
+	}
+}
+
+// Issue generates the certificate for a completed enrollment, returning the existing one if it was already issued
+func (uc *UseCase) Issue(ctx context.Context, userID, courseID uuid.UUID) (*schema.Certificate, error) {
+	existing, err := uc.repo.GetByUserAndCourse(userID, courseID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Println("Error getting certificate: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	enroll, err := uc.repo.GetEnrollment(userID, courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, courseenroll.ErrNotEnrolled.Build()
+		}
+		log.Println("Error getting enrollment: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if enroll.CompletedAt == nil {
+		return nil, ErrCourseNotCompleted.Build()
+	}
+
+	student, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		log.Println("Error getting student: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	courseObj, err := uc.courseRepo.GetByID(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, course.ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	instructor, err := uc.userRepo.GetByID(courseObj.InstructorID)
+	if err != nil {
+		log.Println("Error getting instructor: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	serial, err := generateSerial()
+	if err != nil {
+		log.Println("Error generating certificate serial: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	certificate := &schema.Certificate{
+		ID:             id,
+		Serial:         serial,
+		UserID:         userID,
+		CourseID:       courseID,
+		StudentName:    student.Name,
+		CourseTitle:    courseObj.Title,
+		InstructorName: instructor.Name,
+		IssuedAt:       *enroll.CompletedAt,
+	}
+
+	fileURL, err := uc.uploader.UploadBytes("certificates/"+serial+".pdf", renderPDF(certificateLines(certificate)), "application/pdf")
+	if err != nil {
+		log.Println("Error uploading certificate: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	certificate.FileURL = fileURL
+
+	if err := uc.repo.Create(certificate); err != nil {
+		log.Println("Error creating certificate: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return certificate, nil
+}
+
+// IssuePending generates certificates for completed enrollments that did not get one when they were completed
+func (uc *UseCase) IssuePending(ctx context.Context) error {
+	enrolls, err := uc.repo.GetUncertifiedCompletions(issueBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, enroll := range enrolls {
+		if _, err := uc.Issue(ctx, enroll.UserID, enroll.CourseID); err != nil {
+			log.Printf("Error issuing certificate for user %s in course %s: %v", enroll.UserID, enroll.CourseID, err)
+		}
+	}
+	return nil
+}
+
+// IssueForCourse lets a student get their certificate right away instead of waiting for the background job
+func (uc *UseCase) IssueForCourse(ctx context.Context, req *CourseIDRequest) (*schema.Certificate, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	return uc.Issue(ctx, userID, uuid.MustParse(req.CourseID))
+}
+
+func (uc *UseCase) GetMy(ctx context.Context) ([]*schema.Certificate, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	certificates, err := uc.repo.GetByUserID(userID)
+	if err != nil {
+		log.Println("Error getting certificates: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return certificates, nil
+}
+
+func (uc *UseCase) Verify(req *SerialRequest) (*VerificationResponse, error) {
+	certificate, err := uc.repo.GetBySerial(strings.ToUpper(strings.TrimSpace(req.Serial)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCertificateNotFound.Build()
+		}
+		log.Println("Error getting certificate: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return &VerificationResponse{
+		Valid:          true,
+		Serial:         certificate.Serial,
+		StudentName:    certificate.StudentName,
+		CourseTitle:    certificate.CourseTitle,
+		InstructorName: certificate.InstructorName,
+		IssuedAt:       certificate.IssuedAt,
+		FileURL:        certificate.FileURL,
+	}, nil
+}
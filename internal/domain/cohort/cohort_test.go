@@ -0,0 +1,278 @@
+package cohort
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) Create(cohort *schema.Cohort) error {
+	args := m.Called(cohort)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetByID(id uuid.UUID) (*schema.Cohort, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Cohort), args.Error(1)
+}
+
+func (m *MockRepository) GetByIDs(ids []uuid.UUID) ([]schema.Cohort, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]schema.Cohort), args.Error(1)
+}
+
+func (m *MockRepository) ListByCourse(courseID uuid.UUID) ([]schema.Cohort, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]schema.Cohort), args.Error(1)
+}
+
+func (m *MockRepository) CountMembers(cohortIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	args := m.Called(cohortIDs)
+	return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
+}
+
+func (m *MockRepository) Update(cohort *schema.Cohort) error {
+	args := m.Called(cohort)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	args := m.Called(userID, courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.CourseEnroll), args.Error(1)
+}
+
+func (m *MockRepository) GetEnrollments(userID uuid.UUID, courseIDs []uuid.UUID) ([]schema.CourseEnroll, error) {
+	args := m.Called(userID, courseIDs)
+	return args.Get(0).([]schema.CourseEnroll), args.Error(1)
+}
+
+// Join runs decide against the cohort and member count given to On, the way the repository does inside its lock
+func (m *MockRepository) Join(enrollID, cohortID uuid.UUID, decide func(cohort *schema.Cohort, members int64) error) error {
+	args := m.Called(enrollID, cohortID)
+	if err := decide(args.Get(0).(*schema.Cohort), args.Get(1).(int64)); err != nil {
+		return err
+	}
+	return args.Error(2)
+}
+
+func (m *MockRepository) Leave(enrollID uuid.UUID) error {
+	args := m.Called(enrollID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListMembers(cohortID uuid.UUID) ([]Member, error) {
+	args := m.Called(cohortID)
+	return args.Get(0).([]Member), args.Error(1)
+}
+
+type CohortUseCaseTestSuite struct {
+	suite.Suite
+	repo *MockRepository
+	uc   *UseCase
+
+	course     *schema.Course
+	cohort     *schema.Cohort
+	studentID  uuid.UUID
+	enrollment *schema.CourseEnroll
+}
+
+func (s *CohortUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.uc = NewUseCase(s.repo)
+
+	s.course = &schema.Course{ID: uuid.New(), Title: "Go", InstructorID: uuid.New()}
+	startsAt := time.Now().Add(7 * 24 * time.Hour)
+	s.cohort = &schema.Cohort{ID: uuid.New(), CourseID: s.course.ID, Name: "Spring", StartsAt: startsAt,
+		EndsAt: startsAt.Add(30 * 24 * time.Hour)}
+	s.studentID = uuid.New()
+	s.enrollment = &schema.CourseEnroll{ID: uuid.New(), UserID: s.studentID, CourseID: s.course.ID}
+}
+
+func TestCohortUseCase(t *testing.T) {
+	suite.Run(t, new(CohortUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID, role string) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", role)
+}
+
+func (s *CohortUseCaseTestSuite) cohortRequest() *IDRequest {
+	return &IDRequest{ID: s.cohort.ID.String()}
+}
+
+func (s *CohortUseCaseTestSuite) TestCreate() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("Create", mock.MatchedBy(func(cohort *schema.Cohort) bool {
+		return cohort.CourseID == s.course.ID && cohort.Name == "Autumn"
+	})).Return(nil)
+
+	startsAt := time.Now().Add(24 * time.Hour)
+	res, err := s.uc.Create(userContext(s.course.InstructorID, "instructor"), &IDRequest{ID: s.course.ID.String()},
+		&CreateRequest{Name: "Autumn", StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour)})
+
+	s.NoError(err)
+	s.Equal(StatusUpcoming, res.Status)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *CohortUseCaseTestSuite) TestCreate_NotOwner() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+
+	startsAt := time.Now().Add(24 * time.Hour)
+	_, err := s.uc.Create(userContext(uuid.New(), "instructor"), &IDRequest{ID: s.course.ID.String()},
+		&CreateRequest{Name: "Autumn", StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour)})
+
+	s.Equal(apierror.ErrNotYourResource.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *CohortUseCaseTestSuite) TestUpdate_EndBeforeStart() {
+	s.repo.On("GetByID", s.cohort.ID).Return(s.cohort, nil)
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+
+	endsAt := s.cohort.StartsAt.Add(-time.Hour)
+	_, err := s.uc.Update(userContext(s.course.InstructorID, "instructor"), s.cohortRequest(),
+		&UpdateRequest{EndsAt: &endsAt})
+
+	s.Equal(ErrInvalidSchedule.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "Update", mock.Anything)
+}
+
+func (s *CohortUseCaseTestSuite) TestDelete_StartedWithMembers() {
+	s.cohort.StartsAt = time.Now().Add(-time.Hour)
+	s.repo.On("GetByID", s.cohort.ID).Return(s.cohort, nil)
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("CountMembers", []uuid.UUID{s.cohort.ID}).Return(map[uuid.UUID]int64{s.cohort.ID: 4}, nil)
+
+	err := s.uc.Delete(userContext(s.course.InstructorID, "instructor"), s.cohortRequest())
+
+	s.Equal(ErrCohortStarted.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "Delete", mock.Anything)
+}
+
+func (s *CohortUseCaseTestSuite) TestJoin() {
+	s.repo.On("GetByID", s.cohort.ID).Return(s.cohort, nil)
+	s.repo.On("GetEnrollment", s.studentID, s.course.ID).Return(s.enrollment, nil)
+	s.repo.On("Join", s.enrollment.ID, s.cohort.ID).Return(s.cohort, int64(2), nil)
+
+	res, err := s.uc.Join(userContext(s.studentID, "student"), s.cohortRequest())
+
+	s.NoError(err)
+	s.Equal(int64(3), res.Members)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *CohortUseCaseTestSuite) TestJoin_NotEnrolled() {
+	s.repo.On("GetByID", s.cohort.ID).Return(s.cohort, nil)
+	s.repo.On("GetEnrollment", s.studentID, s.course.ID).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.uc.Join(userContext(s.studentID, "student"), s.cohortRequest())
+
+	s.Equal(ErrNotEnrolled.Build(), err)
+}
+
+func (s *CohortUseCaseTestSuite) TestJoin_Started() {
+	s.cohort.StartsAt = time.Now().Add(-time.Hour)
+	s.repo.On("GetByID", s.cohort.ID).Return(s.cohort, nil)
+	s.repo.On("GetEnrollment", s.studentID, s.course.ID).Return(s.enrollment, nil)
+	s.repo.On("Join", s.enrollment.ID, s.cohort.ID).Return(s.cohort, int64(0), nil)
+
+	_, err := s.uc.Join(userContext(s.studentID, "student"), s.cohortRequest())
+
+	s.Equal(ErrCohortStarted.Build(), err)
+}
+
+func (s *CohortUseCaseTestSuite) TestJoin_Full() {
+	maxMembers := 2
+	s.cohort.MaxMembers = &maxMembers
+	s.repo.On("GetByID", s.cohort.ID).Return(s.cohort, nil)
+	s.repo.On("GetEnrollment", s.studentID, s.course.ID).Return(s.enrollment, nil)
+	s.repo.On("Join", s.enrollment.ID, s.cohort.ID).Return(s.cohort, int64(2), nil)
+
+	_, err := s.uc.Join(userContext(s.studentID, "student"), s.cohortRequest())
+
+	s.Equal(ErrCohortFull.Build(), err)
+}
+
+func (s *CohortUseCaseTestSuite) TestJoin_SwitchOutOfRunningCohort() {
+	running := &schema.Cohort{ID: uuid.New(), CourseID: s.course.ID, StartsAt: time.Now().Add(-time.Hour),
+		EndsAt: time.Now().Add(time.Hour)}
+	s.enrollment.CohortID = &running.ID
+	s.repo.On("GetByID", s.cohort.ID).Return(s.cohort, nil)
+	s.repo.On("GetByID", running.ID).Return(running, nil)
+	s.repo.On("GetEnrollment", s.studentID, s.course.ID).Return(s.enrollment, nil)
+
+	_, err := s.uc.Join(userContext(s.studentID, "student"), s.cohortRequest())
+
+	s.Equal(ErrCohortStarted.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "Join", mock.Anything, mock.Anything)
+}
+
+func (s *CohortUseCaseTestSuite) TestLeave_NotMember() {
+	s.repo.On("GetByID", s.cohort.ID).Return(s.cohort, nil)
+	s.repo.On("GetEnrollment", s.studentID, s.course.ID).Return(s.enrollment, nil)
+
+	err := s.uc.Leave(userContext(s.studentID, "student"), s.cohortRequest())
+
+	s.Equal(ErrNotMember.Build(), err)
+}
+
+func (s *CohortUseCaseTestSuite) TestApplyDueDates() {
+	s.enrollment.CohortID = &s.cohort.ID
+	days := 10
+	fixed := time.Now().Add(time.Hour)
+	relative := &schema.Assignment{ID: uuid.New(), CourseID: s.course.ID, Due: &fixed, DueAfterDays: &days}
+	absolute := &schema.Assignment{ID: uuid.New(), CourseID: s.course.ID, Due: &fixed}
+	s.repo.On("GetEnrollments", s.studentID, []uuid.UUID{s.course.ID}).Return([]schema.CourseEnroll{*s.enrollment}, nil)
+	s.repo.On("GetByIDs", []uuid.UUID{s.cohort.ID}).Return([]schema.Cohort{*s.cohort}, nil)
+
+	err := s.uc.ApplyDueDates(userContext(s.studentID, "student"), []*schema.Assignment{relative, absolute})
+
+	s.NoError(err)
+	s.Equal(s.cohort.StartsAt.AddDate(0, 0, 10), *relative.Due)
+	s.Equal(fixed, *absolute.Due)
+}
+
+func (s *CohortUseCaseTestSuite) TestApplyDueDates_SelfPaced() {
+	days := 10
+	fixed := time.Now().Add(time.Hour)
+	relative := &schema.Assignment{ID: uuid.New(), CourseID: s.course.ID, Due: &fixed, DueAfterDays: &days}
+	s.repo.On("GetEnrollments", s.studentID, []uuid.UUID{s.course.ID}).Return([]schema.CourseEnroll{*s.enrollment}, nil)
+
+	err := s.uc.ApplyDueDates(userContext(s.studentID, "student"), []*schema.Assignment{relative})
+
+	s.NoError(err)
+	s.Equal(fixed, *relative.Due)
+	s.repo.AssertNotCalled(s.T(), "GetByIDs", mock.Anything)
+}
@@ -0,0 +1,56 @@
+package cohort
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IDRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type CreateRequest struct {
+	Name       string    `json:"name" binding:"required,max=100"`
+	StartsAt   time.Time `json:"starts_at" binding:"required"`
+	EndsAt     time.Time `json:"ends_at" binding:"required,gtfield=StartsAt"`
+	MaxMembers *int      `json:"max_members" binding:"omitempty,min=1,max=100000"`
+}
+
+// UpdateRequest changes only the fields that are sent. A MaxMembers of 0 removes the limit.
+type UpdateRequest struct {
+	Name       *string    `json:"name" binding:"omitempty,min=1,max=100"`
+	StartsAt   *time.Time `json:"starts_at"`
+	EndsAt     *time.Time `json:"ends_at"`
+	MaxMembers *int       `json:"max_members" binding:"omitempty,min=0,max=100000"`
+}
+
+type Status string
+
+const (
+	StatusUpcoming Status = "upcoming"
+	StatusRunning  Status = "running"
+	StatusFinished Status = "finished"
+)
+
+type CohortResponse struct {
+	ID         uuid.UUID `json:"id"`
+	CourseID   uuid.UUID `json:"course_id"`
+	Name       string    `json:"name"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	Status     Status    `json:"status"`
+	MaxMembers *int      `json:"max_members"`
+	Members    int64     `json:"members"`
+}
+
+type Member struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Name       string    `json:"name"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+}
+
+type MembersResponse struct {
+	Cohort  CohortResponse `json:"cohort"`
+	Members []Member       `json:"members"`
+}
@@ -0,0 +1,40 @@
+package cohort
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrCourseNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("COURSE_NOT_FOUND")
+
+	ErrCohortNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("COHORT_NOT_FOUND")
+
+	ErrInvalidSchedule = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("COHORT_MUST_END_AFTER_IT_STARTS")
+
+	ErrCohortStarted = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("COHORT_ALREADY_STARTED")
+
+	ErrCohortFull = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusConflict).
+			WithMessage("COHORT_FULL")
+
+	ErrNotEnrolled = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusForbidden).
+			WithMessage("NOT_ENROLLED_IN_COURSE")
+
+	ErrAlreadyMember = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("ALREADY_IN_COHORT")
+
+	ErrNotMember = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusNotFound).
+			WithMessage("NOT_IN_COHORT")
+)
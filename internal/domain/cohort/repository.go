@@ -0,0 +1,157 @@
+package cohort
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRepository interface {
+	GetCourse(courseID uuid.UUID) (*schema.Course, error)
+	Create(cohort *schema.Cohort) error
+	GetByID(id uuid.UUID) (*schema.Cohort, error)
+	GetByIDs(ids []uuid.UUID) ([]schema.Cohort, error)
+	ListByCourse(courseID uuid.UUID) ([]schema.Cohort, error)
+	CountMembers(cohortIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+	Update(cohort *schema.Cohort) error
+	Delete(id uuid.UUID) error
+	GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error)
+	GetEnrollments(userID uuid.UUID, courseIDs []uuid.UUID) ([]schema.CourseEnroll, error)
+	Join(enrollID, cohortID uuid.UUID, decide func(cohort *schema.Cohort, members int64) error) error
+	Leave(enrollID uuid.UUID) error
+	ListMembers(cohortID uuid.UUID) ([]Member, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	var course schema.Course
+	if err := r.db.First(&course, "id = ?", courseID).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *repository) Create(cohort *schema.Cohort) error {
+	return r.db.Create(cohort).Error
+}
+
+func (r *repository) GetByID(id uuid.UUID) (*schema.Cohort, error) {
+	var cohort schema.Cohort
+	if err := r.db.First(&cohort, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &cohort, nil
+}
+
+func (r *repository) GetByIDs(ids []uuid.UUID) ([]schema.Cohort, error) {
+	var cohorts []schema.Cohort
+	err := r.db.Where("id IN ?", ids).Find(&cohorts).Error
+	return cohorts, err
+}
+
+func (r *repository) ListByCourse(courseID uuid.UUID) ([]schema.Cohort, error) {
+	var cohorts []schema.Cohort
+	err := r.db.Where("course_id = ?", courseID).Order("starts_at, id").Find(&cohorts).Error
+	return cohorts, err
+}
+
+func (r *repository) CountMembers(cohortIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		CohortID uuid.UUID
+		Count    int64
+	}
+	if err := r.db.Model(&schema.CourseEnroll{}).Select("cohort_id, COUNT(*) AS count").
+		Where("cohort_id IN ?", cohortIDs).Group("cohort_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CohortID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *repository) Update(cohort *schema.Cohort) error {
+	return r.db.Save(cohort).Error
+}
+
+// Delete removes a cohort together with its forum, and moves its members back to self-paced study
+func (r *repository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		discussions := tx.Model(&schema.ForumDiscussion{}).Select("id").Where("cohort_id = ?", id)
+		if err := tx.Where("forum_discussion_id IN (?)", discussions).Delete(&schema.ForumReply{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("cohort_id = ?", id).Delete(&schema.ForumDiscussion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&schema.CourseEnroll{}).Where("cohort_id = ?", id).Update("cohort_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&schema.Cohort{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *repository) GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	var enrollment schema.CourseEnroll
+	if err := r.db.Where("user_id = ? AND course_id = ?", userID, courseID).First(&enrollment).Error; err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (r *repository) GetEnrollments(userID uuid.UUID, courseIDs []uuid.UUID) ([]schema.CourseEnroll, error) {
+	var enrollments []schema.CourseEnroll
+	err := r.db.Where("user_id = ? AND course_id IN ?", userID, courseIDs).Find(&enrollments).Error
+	return enrollments, err
+}
+
+// Join moves an enrollment into a cohort if decide accepts it. The cohort row stays locked from the member count
+// until the move, so two students cannot both take its last place.
+func (r *repository) Join(enrollID, cohortID uuid.UUID, decide func(cohort *schema.Cohort, members int64) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var cohort schema.Cohort
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&cohort, "id = ?", cohortID).Error; err != nil {
+			return err
+		}
+		var members int64
+		if err := tx.Model(&schema.CourseEnroll{}).Where("cohort_id = ?", cohortID).Count(&members).Error; err != nil {
+			return err
+		}
+		if err := decide(&cohort, members); err != nil {
+			return err
+		}
+		return tx.Model(&schema.CourseEnroll{}).Where("id = ?", enrollID).Update("cohort_id", cohortID).Error
+	})
+}
+
+func (r *repository) Leave(enrollID uuid.UUID) error {
+	return r.db.Model(&schema.CourseEnroll{}).Where("id = ?", enrollID).Update("cohort_id", nil).Error
+}
+
+func (r *repository) ListMembers(cohortID uuid.UUID) ([]Member, error) {
+	var members []Member
+	err := r.db.Model(&schema.CourseEnroll{}).
+		Select("course_enrolls.user_id, users.name, course_enrolls.created_at AS enrolled_at").
+		Joins("JOIN users ON users.id = course_enrolls.user_id").
+		Where("course_enrolls.cohort_id = ?", cohortID).
+		Order("users.name, course_enrolls.user_id").
+		Scan(&members).Error
+	return members, err
+}
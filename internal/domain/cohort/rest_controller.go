@@ -0,0 +1,241 @@
+package cohort
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	courseGroup := engine.Group("/v1/courses/:id/cohorts")
+	{
+		courseGroup.GET("", middleware.APIKeyScope("courses:read"), controller.List())
+		courseGroup.POST("",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.Create(),
+		)
+		courseGroup.GET("/mine",
+			middleware.Authenticate(),
+			middleware.RequireRole("student"),
+			controller.GetMine(),
+		)
+	}
+
+	cohortGroup := engine.Group("/v1/cohorts/:id")
+	{
+		cohortGroup.GET("", middleware.APIKeyScope("courses:read"), controller.Get())
+		cohortGroup.PATCH("",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.Update(),
+		)
+		cohortGroup.DELETE("",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.Delete(),
+		)
+		cohortGroup.GET("/members",
+			middleware.APIKeyScope("courses:read"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.Members(),
+		)
+		cohortGroup.POST("/members",
+			middleware.Authenticate(),
+			middleware.RequireRole("student"),
+			controller.Join(),
+		)
+		cohortGroup.DELETE("/members",
+			middleware.Authenticate(),
+			middleware.RequireRole("student"),
+			controller.Leave(),
+		)
+	}
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) List() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.List(&req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_COHORTS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var courseReq IDRequest
+		if err := ctx.ShouldBindUri(&courseReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req CreateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Create(ctx, &courseReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "CREATE_COHORT_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) GetMine() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.GetMine(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_MY_COHORT_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Get(&req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_COHORT_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var idReq IDRequest
+		if err := ctx.ShouldBindUri(&idReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req UpdateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Update(ctx, &idReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "UPDATE_COHORT_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.Delete(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "DELETE_COHORT_SUCCESS", nil).Send(ctx)
+	}
+}
+
+func (c *RestController) Members() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Members(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_COHORT_MEMBERS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Join() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Join(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "JOIN_COHORT_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Leave() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.Leave(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "LEAVE_COHORT_SUCCESS", nil).Send(ctx)
+	}
+}
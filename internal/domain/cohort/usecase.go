@@ -0,0 +1,446 @@
+package cohort
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+type UseCase struct {
+	repo IRepository
+}
+
+func NewUseCase(repo IRepository) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+func statusAt(cohort *schema.Cohort, now time.Time) Status {
+	switch {
+	case now.Before(cohort.StartsAt):
+		return StatusUpcoming
+	case now.Before(cohort.EndsAt):
+		return StatusRunning
+	default:
+		return StatusFinished
+	}
+}
+
+func toResponse(cohort *schema.Cohort, members int64, now time.Time) CohortResponse {
+	return CohortResponse{
+		ID:         cohort.ID,
+		CourseID:   cohort.CourseID,
+		Name:       cohort.Name,
+		StartsAt:   cohort.StartsAt,
+		EndsAt:     cohort.EndsAt,
+		Status:     statusAt(cohort, now),
+		MaxMembers: cohort.MaxMembers,
+		Members:    members,
+	}
+}
+
+func (uc *UseCase) getCohort(id uuid.UUID) (*schema.Cohort, error) {
+	cohort, err := uc.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCohortNotFound.Build()
+		}
+		log.Println("Error getting cohort: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return cohort, nil
+}
+
+func (uc *UseCase) countMembers(cohortIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts, err := uc.repo.CountMembers(cohortIDs)
+	if err != nil {
+		log.Println("Error counting cohort members: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return counts, nil
+}
+
+// checkOwner lets only the course's instructor, or an admin, manage its cohorts
+func (uc *UseCase) checkOwner(ctx context.Context, courseID uuid.UUID) error {
+	course, err := uc.repo.GetCourse(courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	if role, _ := ctx.Value("user.role").(string); role == string(schema.RoleAdmin) {
+		return nil
+	}
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+	if course.InstructorID != userID {
+		return apierror.ErrNotYourResource.Build()
+	}
+	return nil
+}
+
+func (uc *UseCase) Create(ctx context.Context, courseReq *IDRequest, req *CreateRequest) (*CohortResponse, error) {
+	courseID := uuid.MustParse(courseReq.ID)
+	if err := uc.checkOwner(ctx, courseID); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	cohort := &schema.Cohort{
+		ID:         id,
+		CourseID:   courseID,
+		Name:       req.Name,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		MaxMembers: req.MaxMembers,
+	}
+	if err := uc.repo.Create(cohort); err != nil {
+		log.Println("Error creating cohort: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	res := toResponse(cohort, 0, time.Now())
+	return &res, nil
+}
+
+// List shows every run of a course, soonest first
+func (uc *UseCase) List(req *IDRequest) ([]CohortResponse, error) {
+	courseID := uuid.MustParse(req.ID)
+	if _, err := uc.repo.GetCourse(courseID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	cohorts, err := uc.repo.ListByCourse(courseID)
+	if err != nil {
+		log.Println("Error listing cohorts: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	res := make([]CohortResponse, len(cohorts))
+	if len(cohorts) == 0 {
+		return res, nil
+	}
+
+	ids := make([]uuid.UUID, len(cohorts))
+	for i := range cohorts {
+		ids[i] = cohorts[i].ID
+	}
+	counts, err := uc.countMembers(ids)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range cohorts {
+		res[i] = toResponse(&cohorts[i], counts[cohorts[i].ID], now)
+	}
+	return res, nil
+}
+
+func (uc *UseCase) Get(req *IDRequest) (*CohortResponse, error) {
+	cohort, err := uc.getCohort(uuid.MustParse(req.ID))
+	if err != nil {
+		return nil, err
+	}
+	counts, err := uc.countMembers([]uuid.UUID{cohort.ID})
+	if err != nil {
+		return nil, err
+	}
+	res := toResponse(cohort, counts[cohort.ID], time.Now())
+	return &res, nil
+}
+
+func (uc *UseCase) Update(ctx context.Context, idReq *IDRequest, req *UpdateRequest) (*CohortResponse, error) {
+	cohort, err := uc.getCohort(uuid.MustParse(idReq.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkOwner(ctx, cohort.CourseID); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		cohort.Name = *req.Name
+	}
+	if req.StartsAt != nil {
+		cohort.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		cohort.EndsAt = *req.EndsAt
+	}
+	if req.MaxMembers != nil {
+		if *req.MaxMembers == 0 {
+			cohort.MaxMembers = nil
+		} else {
+			cohort.MaxMembers = req.MaxMembers
+		}
+	}
+	if !cohort.EndsAt.After(cohort.StartsAt) {
+		return nil, ErrInvalidSchedule.Build()
+	}
+
+	if err := uc.repo.Update(cohort); err != nil {
+		log.Println("Error updating cohort: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	counts, err := uc.countMembers([]uuid.UUID{cohort.ID})
+	if err != nil {
+		return nil, err
+	}
+	res := toResponse(cohort, counts[cohort.ID], time.Now())
+	return &res, nil
+}
+
+// Delete removes a cohort along with its forum. Once a cohort is under way it can only be deleted if nobody joined.
+func (uc *UseCase) Delete(ctx context.Context, req *IDRequest) error {
+	cohort, err := uc.getCohort(uuid.MustParse(req.ID))
+	if err != nil {
+		return err
+	}
+	if err := uc.checkOwner(ctx, cohort.CourseID); err != nil {
+		return err
+	}
+
+	if !time.Now().Before(cohort.StartsAt) {
+		counts, err := uc.countMembers([]uuid.UUID{cohort.ID})
+		if err != nil {
+			return err
+		}
+		if counts[cohort.ID] > 0 {
+			return ErrCohortStarted.Build()
+		}
+	}
+
+	if err := uc.repo.Delete(cohort.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCohortNotFound.Build()
+		}
+		log.Println("Error deleting cohort: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	return nil
+}
+
+func (uc *UseCase) getEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	enrollment, err := uc.repo.GetEnrollment(userID, courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotEnrolled.Build()
+		}
+		log.Println("Error getting enrollment: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return enrollment, nil
+}
+
+// ensureNotStarted stops a student from leaving the cohort they are in once it is under way
+func (uc *UseCase) ensureNotStarted(cohortID uuid.UUID, now time.Time) error {
+	current, err := uc.repo.GetByID(cohortID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		log.Println("Error getting cohort: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	if !now.Before(current.StartsAt) {
+		return ErrCohortStarted.Build()
+	}
+	return nil
+}
+
+// Join puts an enrolled student into a cohort that has not started yet, moving them out of any other upcoming one
+func (uc *UseCase) Join(ctx context.Context, req *IDRequest) (*CohortResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+	cohort, err := uc.getCohort(uuid.MustParse(req.ID))
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := uc.getEnrollment(userID, cohort.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if enrollment.CohortID != nil {
+		if *enrollment.CohortID == cohort.ID {
+			return nil, ErrAlreadyMember.Build()
+		}
+		if err := uc.ensureNotStarted(*enrollment.CohortID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	var members int64
+	err = uc.repo.Join(enrollment.ID, cohort.ID, func(locked *schema.Cohort, count int64) error {
+		if !now.Before(locked.StartsAt) {
+			return ErrCohortStarted.Build()
+		}
+		if locked.MaxMembers != nil && count >= int64(*locked.MaxMembers) {
+			return ErrCohortFull.Build()
+		}
+		cohort, members = locked, count+1
+		return nil
+	})
+	if err != nil {
+		var apiErr *apierror.ApiError
+		if errors.As(err, &apiErr) {
+			return nil, err
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCohortNotFound.Build()
+		}
+		log.Println("Error joining cohort: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	res := toResponse(cohort, members, now)
+	return &res, nil
+}
+
+// Leave returns a student to self-paced study, as long as their cohort has not started
+func (uc *UseCase) Leave(ctx context.Context, req *IDRequest) error {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+	cohort, err := uc.getCohort(uuid.MustParse(req.ID))
+	if err != nil {
+		return err
+	}
+	enrollment, err := uc.getEnrollment(userID, cohort.CourseID)
+	if err != nil {
+		return err
+	}
+	if enrollment.CohortID == nil || *enrollment.CohortID != cohort.ID {
+		return ErrNotMember.Build()
+	}
+	if !time.Now().Before(cohort.StartsAt) {
+		return ErrCohortStarted.Build()
+	}
+
+	if err := uc.repo.Leave(enrollment.ID); err != nil {
+		log.Println("Error leaving cohort: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	return nil
+}
+
+// GetMine returns the cohort the caller studies a course in
+func (uc *UseCase) GetMine(ctx context.Context, req *IDRequest) (*CohortResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+	enrollment, err := uc.getEnrollment(userID, uuid.MustParse(req.ID))
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.CohortID == nil {
+		return nil, ErrNotMember.Build()
+	}
+	return uc.Get(&IDRequest{ID: enrollment.CohortID.String()})
+}
+
+func (uc *UseCase) Members(ctx context.Context, req *IDRequest) (*MembersResponse, error) {
+	cohort, err := uc.getCohort(uuid.MustParse(req.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkOwner(ctx, cohort.CourseID); err != nil {
+		return nil, err
+	}
+
+	members, err := uc.repo.ListMembers(cohort.ID)
+	if err != nil {
+		log.Println("Error listing cohort members: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if members == nil {
+		members = []Member{}
+	}
+	return &MembersResponse{Cohort: toResponse(cohort, int64(len(members)), time.Now()), Members: members}, nil
+}
+
+// ApplyDueDates sets the deadline of relatively scheduled assignments to the one the viewer in ctx has in their
+// cohort. Everyone studying outside a cohort keeps the fixed Due date.
+func (uc *UseCase) ApplyDueDates(ctx context.Context, assignments []*schema.Assignment) error {
+	rawUserID, ok := ctx.Value("user.id").(string)
+	if !ok {
+		return nil
+	}
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return nil
+	}
+
+	courseIDs := make([]uuid.UUID, 0)
+	seenCourses := make(map[uuid.UUID]bool)
+	for _, assignment := range assignments {
+		if assignment.DueAfterDays != nil && !seenCourses[assignment.CourseID] {
+			seenCourses[assignment.CourseID] = true
+			courseIDs = append(courseIDs, assignment.CourseID)
+		}
+	}
+	if len(courseIDs) == 0 {
+		return nil
+	}
+
+	enrollments, err := uc.repo.GetEnrollments(userID, courseIDs)
+	if err != nil {
+		log.Println("Error getting enrollments: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	cohortIDs := make([]uuid.UUID, 0, len(enrollments))
+	cohortOf := make(map[uuid.UUID]uuid.UUID, len(enrollments))
+	for _, enrollment := range enrollments {
+		if enrollment.CohortID != nil {
+			cohortIDs = append(cohortIDs, *enrollment.CohortID)
+			cohortOf[enrollment.CourseID] = *enrollment.CohortID
+		}
+	}
+	if len(cohortIDs) == 0 {
+		return nil
+	}
+
+	cohorts, err := uc.repo.GetByIDs(cohortIDs)
+	if err != nil {
+		log.Println("Error getting cohorts: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	startsAt := make(map[uuid.UUID]time.Time, len(cohorts))
+	for _, cohort := range cohorts {
+		startsAt[cohort.ID] = cohort.StartsAt
+	}
+
+	for _, assignment := range assignments {
+		if assignment.DueAfterDays == nil {
+			continue
+		}
+		start, ok := startsAt[cohortOf[assignment.CourseID]]
+		if !ok {
+			continue
+		}
+		due := start.AddDate(0, 0, *assignment.DueAfterDays)
+		assignment.Due = &due
+	}
+	return nil
+}
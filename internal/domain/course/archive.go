@@ -0,0 +1,547 @@
+package course
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/fileutil"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+const (
+	archiveFormat       = "seatudy-course"
+	archiveVersion      = 1
+	archiveManifestName = "manifest.json"
+)
+
+var (
+	maxArchiveSize  = 200 * fileutil.MegaByte
+	maxManifestSize = 5 * fileutil.MegaByte
+	// maxArchiveContentSize caps how much the files of an archive may expand to, so a small zip cannot exhaust memory
+	maxArchiveContentSize = 500 * fileutil.MegaByte
+)
+
+func invalidArchive(reason string) error {
+	return ErrInvalidArchive.WithPayload(map[string]string{"reason": reason}).Build()
+}
+
+// archiveFiles assigns each file added to an export a unique path inside the archive
+type archiveFiles struct {
+	paths []string
+	urls  []string
+}
+
+func (f *archiveFiles) add(fileURL string) string {
+	if fileURL == "" {
+		return ""
+	}
+	filePath := fmt.Sprintf("files/%d/%s", len(f.paths)+1, uploadedFileName(fileURL))
+	f.paths = append(f.paths, filePath)
+	f.urls = append(f.urls, fileURL)
+	return filePath
+}
+
+func (f *archiveFiles) addAll(attachments []schema.Attachment) []ArchiveFile {
+	files := make([]ArchiveFile, len(attachments))
+	for i, attachment := range attachments {
+		files[i] = ArchiveFile{Path: f.add(attachment.URL), Description: attachment.Description}
+	}
+	return files
+}
+
+// Export bundles a course, its sections, materials, assignments and every uploaded file into a zip archive
+func (uc *UseCase) Export(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	source, err := uc.courseRepo.GetCloneSource(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course to export: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	sections, err := uc.courseRepo.GetSections(ctx, id)
+	if err != nil {
+		log.Println("Error getting course sections: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	files := &archiveFiles{}
+	manifest := ArchiveManifest{
+		Format:     archiveFormat,
+		Version:    archiveVersion,
+		ExportedAt: time.Now(),
+		Course: ArchiveCourse{
+			Title:       source.Title,
+			Description: source.Description,
+			Price:       source.Price,
+			Difficulty:  source.Difficulty,
+			Language:    source.Language,
+			MaxSeats:    source.MaxSeats,
+			Tags:        make([]string, len(source.Tags)),
+			Image:       files.add(source.ImageURL),
+			Syllabus:    files.add(source.SyllabusURL),
+		},
+		Sections:    make([]ArchiveSection, len(sections)),
+		Materials:   make([]ArchiveMaterial, 0, len(source.Materials)),
+		Assignments: make([]ArchiveAssignment, 0, len(source.Assignments)),
+	}
+	if source.Category != nil {
+		manifest.Course.Category = source.Category.Slug
+	}
+	for i, tag := range source.Tags {
+		manifest.Course.Tags[i] = tag.Name
+	}
+
+	sectionIndex := make(map[uuid.UUID]int, len(sections))
+	for i, section := range sections {
+		sectionIndex[section.ID] = i
+		manifest.Sections[i] = ArchiveSection{
+			Title:            section.Title,
+			Position:         section.Position,
+			ReleaseAt:        section.ReleaseAt,
+			ReleaseAfterDays: section.ReleaseAfterDays,
+		}
+	}
+	indexOf := func(sectionID *uuid.UUID) *int {
+		if sectionID == nil {
+			return nil
+		}
+		if i, ok := sectionIndex[*sectionID]; ok {
+			return &i
+		}
+		return nil
+	}
+
+	sort.SliceStable(source.Materials, func(i, j int) bool {
+		return source.Materials[i].CreatedAt.Before(source.Materials[j].CreatedAt)
+	})
+	for _, material := range source.Materials {
+		manifest.Materials = append(manifest.Materials, ArchiveMaterial{
+			Title:            material.Title,
+			Description:      material.Description,
+			Section:          indexOf(material.SectionID),
+			Position:         material.Position,
+			ReleaseAt:        material.ReleaseAt,
+			ReleaseAfterDays: material.ReleaseAfterDays,
+			Attachments:      files.addAll(material.Attachments),
+		})
+	}
+	sort.SliceStable(source.Assignments, func(i, j int) bool {
+		return source.Assignments[i].CreatedAt.Before(source.Assignments[j].CreatedAt)
+	})
+	for _, assignment := range source.Assignments {
+		manifest.Assignments = append(manifest.Assignments, ArchiveAssignment{
+			Title:        assignment.Title,
+			Description:  assignment.Description,
+			Due:          assignment.Due,
+			DueAfterDays: assignment.DueAfterDays,
+			Section:      indexOf(assignment.SectionID),
+			Position:     assignment.Position,
+			Attachments:  files.addAll(assignment.Attachments),
+		})
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(archiveManifestName)
+	if err != nil {
+		log.Println("Error creating course archive manifest: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		log.Println("Error encoding course archive manifest: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	for i, filePath := range files.paths {
+		data, err := uc.uploader.DownloadFile(files.urls[i])
+		if err != nil {
+			log.Println("Error downloading file for course archive: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		w, err := zw.Create(filePath)
+		if err != nil {
+			log.Println("Error creating course archive entry: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		if _, err := w.Write(data); err != nil {
+			log.Println("Error writing course archive entry: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+	}
+	if err := zw.Close(); err != nil {
+		log.Println("Error closing course archive: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return buf.Bytes(), nil
+}
+
+// readArchiveEntry reads a file out of an archive, refusing entries that expand past their declared size
+func readArchiveEntry(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, invalidArchive("unreadable entry " + file.Name)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, int64(file.UncompressedSize64)+1))
+	if err != nil || uint64(len(data)) > file.UncompressedSize64 {
+		return nil, invalidArchive("corrupt entry " + file.Name)
+	}
+	return data, nil
+}
+
+// readManifest reads and validates the manifest of an uploaded archive
+func readManifest(reader *zip.Reader) (*ArchiveManifest, map[string]*zip.File, error) {
+	entries := make(map[string]*zip.File, len(reader.File))
+	for _, file := range reader.File {
+		entries[file.Name] = file
+	}
+
+	manifestFile, ok := entries[archiveManifestName]
+	if !ok {
+		return nil, nil, invalidArchive("missing " + archiveManifestName)
+	}
+	if manifestFile.UncompressedSize64 > uint64(maxManifestSize) {
+		return nil, nil, invalidArchive(archiveManifestName + " is too large")
+	}
+	data, err := readArchiveEntry(manifestFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The manifest is not content, so files may not refer to it
+	delete(entries, archiveManifestName)
+
+	var manifest ArchiveManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, nil, invalidArchive("malformed " + archiveManifestName + ": " + err.Error())
+	}
+	if err := validateManifest(&manifest, entries); err != nil {
+		return nil, nil, err
+	}
+	return &manifest, entries, nil
+}
+
+// validateManifest checks that a manifest is complete and only refers to files the archive contains
+func validateManifest(manifest *ArchiveManifest, entries map[string]*zip.File) error {
+	if err := binding.Validator.ValidateStruct(manifest); err != nil {
+		return invalidArchive(err.Error())
+	}
+
+	var contentSize uint64
+	checkFile := func(filePath string) error {
+		if filePath == "" {
+			return nil
+		}
+		file, ok := entries[filePath]
+		if !ok {
+			return invalidArchive("missing file " + filePath)
+		}
+		contentSize += file.UncompressedSize64
+		if contentSize > uint64(maxArchiveContentSize) {
+			return invalidArchive("archive content is too large")
+		}
+		return nil
+	}
+	checkSection := func(section *int) error {
+		if section != nil && *section >= len(manifest.Sections) {
+			return invalidArchive(fmt.Sprintf("unknown section %d", *section))
+		}
+		return nil
+	}
+
+	if err := checkFile(manifest.Course.Image); err != nil {
+		return err
+	}
+	if err := checkFile(manifest.Course.Syllabus); err != nil {
+		return err
+	}
+	for _, material := range manifest.Materials {
+		if err := checkSection(material.Section); err != nil {
+			return err
+		}
+		for _, attachment := range material.Attachments {
+			if err := checkFile(attachment.Path); err != nil {
+				return err
+			}
+		}
+	}
+	for _, assignment := range manifest.Assignments {
+		if err := checkSection(assignment.Section); err != nil {
+			return err
+		}
+		for _, attachment := range assignment.Attachments {
+			if err := checkFile(attachment.Path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// archiveUploads uploads files out of an archive and remembers them so a failed import can remove them again
+type archiveUploads struct {
+	uc      *UseCase
+	entries map[string]*zip.File
+	urls    []string
+}
+
+func (u *archiveUploads) upload(filePath, folder string, id uuid.UUID, allowedTypes []string) (string, error) {
+	if filePath == "" {
+		return "", nil
+	}
+	data, err := readArchiveEntry(u.entries[filePath])
+	if err != nil {
+		return "", err
+	}
+	contentType := http.DetectContentType(data)
+	if allowedTypes != nil && !slices.Contains(allowedTypes, contentType) {
+		return "", invalidArchive(fmt.Sprintf("%s has unsupported type %s", filePath, contentType))
+	}
+
+	fileURL, err := u.uc.uploader.UploadBytes(folder+id.String()+"."+path.Base(filePath), data, contentType)
+	if err != nil {
+		log.Println("Error uploading archive file: ", err)
+		return "", apierror.ErrInternalServer.Build()
+	}
+	u.urls = append(u.urls, fileURL)
+	return fileURL, nil
+}
+
+func (u *archiveUploads) attachments(files []ArchiveFile, folder string, materialID, assignmentID *uuid.UUID) ([]schema.Attachment, error) {
+	attachments := make([]schema.Attachment, 0, len(files))
+	for _, file := range files {
+		id, err := uuid.NewV7()
+		if err != nil {
+			log.Println("Error generating UUID: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		fileURL, err := u.upload(file.Path, folder, id, nil)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, schema.Attachment{
+			ID:           id,
+			URL:          fileURL,
+			MaterialID:   materialID,
+			AssignmentID: assignmentID,
+			Description:  file.Description,
+		})
+	}
+	return attachments, nil
+}
+
+// discard removes every file uploaded so far; failures are only logged since the import has already failed
+func (u *archiveUploads) discard() {
+	for _, fileURL := range u.urls {
+		if err := u.uc.uploader.DeleteFile(fileURL); err != nil {
+			log.Println("Error removing file of failed import: ", err)
+		}
+	}
+}
+
+// openArchive opens an uploaded zip, enforcing the upload size limit. The caller closes the returned file.
+func openArchive(archive *multipart.FileHeader) (*zip.Reader, io.Closer, error) {
+	if archive.Size > maxArchiveSize {
+		return nil, nil, apierror.ErrFileTooLarge.WithPayload(map[string]string{
+			"max_size":      fileutil.ByteToAppropriateUnit(maxArchiveSize),
+			"received_size": fileutil.ByteToAppropriateUnit(archive.Size),
+		}).Build()
+	}
+	file, err := archive.Open()
+	if err != nil {
+		log.Println("Error opening course archive: ", err)
+		return nil, nil, apierror.ErrInternalServer.Build()
+	}
+
+	reader, err := zip.NewReader(file, archive.Size)
+	if err != nil {
+		file.Close()
+		return nil, nil, invalidArchive("not a zip file")
+	}
+	return reader, file, nil
+}
+
+// Import recreates an exported course as a new draft owned by the caller. Nothing is kept when any step fails.
+func (uc *UseCase) Import(ctx context.Context, archive *multipart.FileHeader) (*schema.Course, error) {
+	instructorID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	reader, file, err := openArchive(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	manifest, entries, err := readManifest(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	course := &schema.Course{
+		Title:        manifest.Course.Title,
+		Description:  manifest.Course.Description,
+		Price:        manifest.Course.Price,
+		InstructorID: instructorID,
+		Difficulty:   manifest.Course.Difficulty,
+		Language:     manifest.Course.Language,
+		MaxSeats:     manifest.Course.MaxSeats,
+	}
+	if manifest.Course.Category != "" {
+		category, err := uc.taxonomyUseCase.FindCategoryBySlug(manifest.Course.Category)
+		if err != nil {
+			return nil, err
+		}
+		if category != nil {
+			course.CategoryID = &category.ID
+		}
+	}
+	if len(manifest.Course.Tags) > 0 {
+		if course.Tags, err = uc.taxonomyUseCase.ResolveTags(manifest.Course.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.createFromManifest(ctx, course, manifest, entries); err != nil {
+		return nil, err
+	}
+	return course, nil
+}
+
+// createFromManifest stores course as a draft holding the content of manifest, removing the uploaded files again if
+// anything fails
+func (uc *UseCase) createFromManifest(ctx context.Context, course *schema.Course, manifest *ArchiveManifest,
+	entries map[string]*zip.File) error {
+	course.Status = schema.CourseStatusDraft
+	if course.Language == "" {
+		course.Language = defaultLanguage
+	}
+	course.Materials = make([]schema.Material, 0, len(manifest.Materials))
+	course.Assignments = make([]schema.Assignment, 0, len(manifest.Assignments))
+
+	uploads := &archiveUploads{uc: uc, entries: entries}
+	sections, err := uploads.build(course, manifest)
+	if err != nil {
+		uploads.discard()
+		return err
+	}
+
+	if err := uc.courseRepo.CreateWithContent(ctx, course, sections); err != nil {
+		uploads.discard()
+		log.Println("Error creating imported course: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	uc.recordPrice(ctx, course, nil)
+	return nil
+}
+
+// build assigns new IDs to everything in the manifest and uploads the files it refers to
+func (u *archiveUploads) build(course *schema.Course, manifest *ArchiveManifest) ([]schema.CourseSection, error) {
+	newID := func() (uuid.UUID, error) {
+		id, err := uuid.NewV7()
+		if err != nil {
+			log.Println("Error generating UUID: ", err)
+			return uuid.Nil, apierror.ErrInternalServer.Build()
+		}
+		return id, nil
+	}
+
+	var err error
+	if course.ID, err = newID(); err != nil {
+		return nil, err
+	}
+	if course.ImageURL, err = u.upload(manifest.Course.Image, "course/image/", course.ID, fileutil.ImageContentTypes); err != nil {
+		return nil, err
+	}
+	if course.SyllabusURL, err = u.upload(manifest.Course.Syllabus, "course/syllabus/", course.ID, fileutil.SyllabusContentTypes); err != nil {
+		return nil, err
+	}
+
+	sections := make([]schema.CourseSection, len(manifest.Sections))
+	for i, section := range manifest.Sections {
+		id, err := newID()
+		if err != nil {
+			return nil, err
+		}
+		sections[i] = schema.CourseSection{
+			ID:               id,
+			CourseID:         course.ID,
+			Title:            section.Title,
+			Position:         section.Position,
+			ReleaseAt:        section.ReleaseAt,
+			ReleaseAfterDays: section.ReleaseAfterDays,
+		}
+	}
+	sectionID := func(index *int) *uuid.UUID {
+		if index == nil {
+			return nil
+		}
+		return &sections[*index].ID
+	}
+
+	for _, material := range manifest.Materials {
+		id, err := newID()
+		if err != nil {
+			return nil, err
+		}
+		attachments, err := u.attachments(material.Attachments, "attachments/material/", &id, nil)
+		if err != nil {
+			return nil, err
+		}
+		course.Materials = append(course.Materials, schema.Material{
+			ID:               id,
+			CourseID:         course.ID,
+			Title:            material.Title,
+			Description:      material.Description,
+			SectionID:        sectionID(material.Section),
+			Position:         material.Position,
+			ReleaseAt:        material.ReleaseAt,
+			ReleaseAfterDays: material.ReleaseAfterDays,
+			Attachments:      attachments,
+		})
+	}
+	for _, assignment := range manifest.Assignments {
+		id, err := newID()
+		if err != nil {
+			return nil, err
+		}
+		attachments, err := u.attachments(assignment.Attachments, "attachments/assignment/", nil, &id)
+		if err != nil {
+			return nil, err
+		}
+		course.Assignments = append(course.Assignments, schema.Assignment{
+			ID:           id,
+			CourseID:     course.ID,
+			Title:        assignment.Title,
+			Description:  assignment.Description,
+			Due:          assignment.Due,
+			DueAfterDays: assignment.DueAfterDays,
+			SectionID:    sectionID(assignment.Section),
+			Position:     assignment.Position,
+			Attachments:  attachments,
+		})
+	}
+	return sections, nil
+}
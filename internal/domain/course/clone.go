@@ -0,0 +1,234 @@
+package course
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+const (
+	cloneTitleSuffix = " (Copy)"
+	// maxTitleLength matches the size of the courses.title column
+	maxTitleLength = 100
+)
+
+// cloneTitle appends the copy suffix, shortening the original title when the result would not fit
+func cloneTitle(title string) string {
+	runes := []rune(title)
+	if limit := maxTitleLength - len([]rune(cloneTitleSuffix)); len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return strings.TrimSpace(string(runes)) + cloneTitleSuffix
+}
+
+// uploadedFileName recovers the original file name from an uploaded object's URL, whose key is "<id>.<file name>"
+func uploadedFileName(fileURL string) string {
+	name := path.Base(fileURL)
+	if parsed, err := url.Parse(fileURL); err == nil {
+		name = path.Base(parsed.Path)
+	}
+	if prefix, rest, found := strings.Cut(name, "."); found {
+		if _, err := uuid.Parse(prefix); err == nil {
+			return rest
+		}
+	}
+	return name
+}
+
+// cloneCopies copies a course's files for its clone and remembers the copies so a failed clone can remove them
+type cloneCopies struct {
+	uc   *UseCase
+	urls []string
+}
+
+// copy gives the clone its own copy of an uploaded file so deleting one course's files never breaks the other
+func (c *cloneCopies) copy(sourceURL, folder string, id uuid.UUID) (string, error) {
+	if sourceURL == "" {
+		return "", nil
+	}
+	fileURL, err := c.uc.uploader.CopyFile(sourceURL, folder+id.String()+"."+uploadedFileName(sourceURL))
+	if err != nil {
+		return "", err
+	}
+	c.urls = append(c.urls, fileURL)
+	return fileURL, nil
+}
+
+func (c *cloneCopies) attachments(attachments []schema.Attachment, folder string, materialID, assignmentID *uuid.UUID) ([]schema.Attachment, error) {
+	copies := make([]schema.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, err
+		}
+		fileURL, err := c.copy(attachment.URL, folder, id)
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, schema.Attachment{
+			ID:           id,
+			URL:          fileURL,
+			MaterialID:   materialID,
+			AssignmentID: assignmentID,
+			Description:  attachment.Description,
+		})
+	}
+	return copies, nil
+}
+
+// discard removes every copy made so far; failures are only logged since the clone has already failed
+func (c *cloneCopies) discard() {
+	for _, fileURL := range c.urls {
+		if err := c.uc.uploader.DeleteFile(fileURL); err != nil {
+			log.Println("Error removing file of failed clone: ", err)
+		}
+	}
+}
+
+// Clone duplicates a course with its sections, materials, assignments and attachment files as a new draft
+func (uc *UseCase) Clone(ctx context.Context, id uuid.UUID) (*schema.Course, error) {
+	source, err := uc.courseRepo.GetCloneSource(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course to clone: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	sections, err := uc.courseRepo.GetSections(ctx, id)
+	if err != nil {
+		log.Println("Error getting course sections: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	copies := &cloneCopies{uc: uc}
+	clone, clonedSections, err := copies.build(source, sections)
+	if err != nil {
+		log.Println("Error copying course content: ", err)
+		copies.discard()
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	if err := uc.courseRepo.CreateWithContent(ctx, clone, clonedSections); err != nil {
+		log.Println("Error creating cloned course: ", err)
+		copies.discard()
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	uc.recordPrice(ctx, clone, nil)
+	return clone, nil
+}
+
+func (c *cloneCopies) build(source schema.Course, sections []schema.CourseSection) (*schema.Course, []schema.CourseSection, error) {
+	courseID, err := uuid.NewV7()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sectionIDs := make(map[uuid.UUID]uuid.UUID, len(sections))
+	clonedSections := make([]schema.CourseSection, 0, len(sections))
+	for _, section := range sections {
+		sectionID, err := uuid.NewV7()
+		if err != nil {
+			return nil, nil, err
+		}
+		sectionIDs[section.ID] = sectionID
+		clonedSections = append(clonedSections, schema.CourseSection{
+			ID:               sectionID,
+			CourseID:         courseID,
+			Title:            section.Title,
+			Position:         section.Position,
+			ReleaseAt:        section.ReleaseAt,
+			ReleaseAfterDays: section.ReleaseAfterDays,
+		})
+	}
+	mapSection := func(sectionID *uuid.UUID) *uuid.UUID {
+		if sectionID == nil {
+			return nil
+		}
+		if mapped, ok := sectionIDs[*sectionID]; ok {
+			return &mapped
+		}
+		return nil
+	}
+
+	imageURL, err := c.copy(source.ImageURL, "course/image/", courseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	syllabusURL, err := c.copy(source.SyllabusURL, "course/syllabus/", courseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clone := &schema.Course{
+		ID:           courseID,
+		Title:        cloneTitle(source.Title),
+		Description:  source.Description,
+		Price:        source.Price,
+		ImageURL:     imageURL,
+		SyllabusURL:  syllabusURL,
+		InstructorID: source.InstructorID,
+		Difficulty:   source.Difficulty,
+		CategoryID:   source.CategoryID,
+		Tags:         source.Tags,
+		Language:     source.Language,
+		MaxSeats:     source.MaxSeats,
+		Status:       schema.CourseStatusDraft,
+		Materials:    make([]schema.Material, 0, len(source.Materials)),
+		Assignments:  make([]schema.Assignment, 0, len(source.Assignments)),
+	}
+
+	for _, material := range source.Materials {
+		materialID, err := uuid.NewV7()
+		if err != nil {
+			return nil, nil, err
+		}
+		attachments, err := c.attachments(material.Attachments, "attachments/material/", &materialID, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		clone.Materials = append(clone.Materials, schema.Material{
+			ID:               materialID,
+			CourseID:         courseID,
+			Title:            material.Title,
+			Description:      material.Description,
+			SectionID:        mapSection(material.SectionID),
+			Position:         material.Position,
+			ReleaseAt:        material.ReleaseAt,
+			ReleaseAfterDays: material.ReleaseAfterDays,
+			Attachments:      attachments,
+		})
+	}
+
+	for _, assignment := range source.Assignments {
+		assignmentID, err := uuid.NewV7()
+		if err != nil {
+			return nil, nil, err
+		}
+		attachments, err := c.attachments(assignment.Attachments, "attachments/assignment/", nil, &assignmentID)
+		if err != nil {
+			return nil, nil, err
+		}
+		clone.Assignments = append(clone.Assignments, schema.Assignment{
+			ID:           assignmentID,
+			CourseID:     courseID,
+			Title:        assignment.Title,
+			Description:  assignment.Description,
+			Due:          assignment.Due,
+			DueAfterDays: assignment.DueAfterDays,
+			SectionID:    mapSection(assignment.SectionID),
+			Position:     assignment.Position,
+			Attachments:  attachments,
+		})
+	}
+
+	return clone, clonedSections, nil
+}
@@ -1,9 +1,15 @@
 package course
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"mime/multipart"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -11,10 +17,18 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/contentpackage"
 	"github.com/highfive-compfest/seatudy-backend/internal/config"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/courseenroll"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/pricing"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/revision"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/taxonomy"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/waitlist"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/wishlist"
 	"github.com/highfive-compfest/seatudy-backend/internal/fileutil"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/user"
+	"time"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -54,8 +68,8 @@ func (m *MockCourseRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, page, pageSize int) ([]schema.Course, int, error) {
-	args := m.Called(ctx, instructorID, page, pageSize)
+func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, publishedOnly bool, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, instructorID, publishedOnly, page, pageSize)
 	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
 }
 
@@ -69,16 +83,76 @@ func (m *MockCourseRepository) GetUserCourseProgress(ctx context.Context, course
 	return args.Get(0).(float64), args.Error(1)
 }
 
-func (m *MockCourseRepository) SearchByTitle(ctx context.Context, title string, page, pageSize int) ([]schema.Course, int, error) {
-	args := m.Called(ctx, title, page, pageSize)
+func (m *MockCourseRepository) Search(ctx context.Context, query string, page, pageSize int) ([]SearchHit, int, error) {
+	args := m.Called(ctx, query, page, pageSize)
+	return args.Get(0).([]SearchHit), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]Suggestion), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindCatalog(ctx context.Context, filter CatalogFilter, page, limit int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, filter, page, limit)
 	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
 }
 
-func (m *MockCourseRepository) DynamicFilterCourses(ctx context.Context, filterType, filterValue, sort string, page, limit int) ([]schema.Course, int, error) {
-	args := m.Called(ctx, filterType, filterValue, sort, page, limit)
+func (m *MockCourseRepository) GetCatalogFacets(ctx context.Context, filter CatalogFilter) (CatalogFacets, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(CatalogFacets), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindByStatus(ctx context.Context, status schema.CourseStatus, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, status, page, pageSize)
 	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
 }
 
+func (m *MockCourseRepository) UpdateStatus(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetPrerequisites(ctx context.Context, courseID uuid.UUID) ([]PrerequisiteCourse, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]PrerequisiteCourse), args.Error(1)
+}
+
+func (m *MockCourseRepository) SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisites []schema.CoursePrerequisite) error {
+	args := m.Called(ctx, courseID, prerequisites)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetPrerequisiteClosure(ctx context.Context, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCompletedCourseIDs(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, userID, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCloneSource(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetSections(ctx context.Context, courseID uuid.UUID) ([]schema.CourseSection, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]schema.CourseSection), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateWithContent(ctx context.Context, course *schema.Course, sections []schema.CourseSection) error {
+	args := m.Called(ctx, course, sections)
+	return args.Error(0)
+}
+
 type MockWalletRepository struct {
 	mock.Mock
 }
@@ -157,6 +231,11 @@ func (m *MockEnrollRepository) IsEnrolled(ctx context.Context, userID, courseID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockEnrollRepository) MarkCompleted(ctx context.Context, userID, courseID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, courseID, at)
+	return args.Error(0)
+}
+
 type MockNotificationRepository struct {
 	mock.Mock
 }
@@ -218,6 +297,53 @@ func (m *MockUserRepository) UpdateByEmail(email string, user *schema.User) erro
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) UpdatePassword(userID uuid.UUID, passwordHash string, historySize int) error {
+	args := m.Called(userID, passwordHash, historySize)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetPasswordHistory(userID uuid.UUID, limit int) ([]string, error) {
+	args := m.Called(userID, limit)
+	hashes, ok := args.Get(0).([]string)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return hashes, args.Error(1)
+}
+
+func (m *MockUserRepository) GetPersonalData(userID uuid.UUID) (*user.PersonalData, error) {
+	args := m.Called(userID)
+	data, ok := args.Get(0).(*user.PersonalData)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return data, args.Error(1)
+}
+
+func (m *MockUserRepository) CountOwnedCourses(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) SetDeletionSchedule(userID uuid.UUID, at *time.Time) error {
+	args := m.Called(userID, at)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetDueForDeletion(before time.Time) ([]*schema.User, error) {
+	args := m.Called(before)
+	users, ok := args.Get(0).([]*schema.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return users, args.Error(1)
+}
+
+func (m *MockUserRepository) Anonymize(userID, anonymousID uuid.UUID) error {
+	args := m.Called(userID, anonymousID)
+	return args.Error(0)
+}
+
 type MockMailer struct {
 	mock.Mock
 }
@@ -236,6 +362,323 @@ func (m *MockFileUploader) UploadFile(key string, fileHeader *multipart.FileHead
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockFileUploader) UploadBytes(key string, data []byte, contentType string) (string, error) {
+	args := m.Called(key, data, contentType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) CopyFile(sourceURL, key string) (string, error) {
+	args := m.Called(sourceURL, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) DownloadFile(fileURL string) ([]byte, error) {
+	args := m.Called(fileURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockFileUploader) DeleteFile(fileURL string) error {
+	args := m.Called(fileURL)
+	return args.Error(0)
+}
+
+type MockRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRevisionRepository) Create(rev *schema.Revision) error {
+	args := m.Called(rev)
+	return args.Error(0)
+}
+
+func (m *MockRevisionRepository) GetLatest(entityType schema.RevisionEntityType, entityID uuid.UUID) (*schema.Revision, error) {
+	args := m.Called(entityType, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Revision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) GetByVersion(entityType schema.RevisionEntityType, entityID uuid.UUID, version int) (*schema.Revision, error) {
+	args := m.Called(entityType, entityID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Revision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) List(entityType schema.RevisionEntityType, entityID uuid.UUID) ([]schema.Revision, error) {
+	args := m.Called(entityType, entityID)
+	return args.Get(0).([]schema.Revision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) GetCourse(id uuid.UUID) (*schema.Course, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockRevisionRepository) GetMaterial(id uuid.UUID) (*schema.Material, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Material), args.Error(1)
+}
+
+func (m *MockRevisionRepository) UpdateContent(entityType schema.RevisionEntityType, entityID uuid.UUID, snapshot revision.Snapshot) error {
+	args := m.Called(entityType, entityID, snapshot)
+	return args.Error(0)
+}
+
+type MockTaxonomyRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaxonomyRepository) CreateCategory(category *schema.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockTaxonomyRepository) GetCategoryByID(id uuid.UUID) (*schema.Category, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Category), args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) GetCategoryBySlug(slug string) (*schema.Category, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Category), args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) GetAllCategories() ([]*schema.Category, error) {
+	args := m.Called()
+	return args.Get(0).([]*schema.Category), args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) UpdateCategory(category *schema.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockTaxonomyRepository) DeleteCategory(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaxonomyRepository) CountCategoryUsage(id uuid.UUID) (int64, int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTaxonomyRepository) GetPublishedCourseCounts() (map[uuid.UUID]int64, error) {
+	args := m.Called()
+	return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) FindOrCreateTags(tags []schema.Tag) ([]schema.Tag, error) {
+	args := m.Called(tags)
+	return args.Get(0).([]schema.Tag), args.Error(1)
+}
+
+func (m *MockTaxonomyRepository) SetCourseTags(courseID uuid.UUID, tags []schema.Tag) error {
+	args := m.Called(courseID, tags)
+	return args.Error(0)
+}
+
+func (m *MockTaxonomyRepository) GetPopularTags(limit int) ([]taxonomy.TagCount, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]taxonomy.TagCount), args.Error(1)
+}
+
+type MockWaitlistRepository struct {
+	mock.Mock
+	// enroll is the enrollment of the last purchase
+	enroll *schema.CourseEnroll
+}
+
+func (m *MockWaitlistRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) IsEnrolled(userID, courseID uuid.UUID) (bool, error) {
+	args := m.Called(userID, courseID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) GetEntry(courseID, userID uuid.UUID) (*schema.WaitlistEntry, error) {
+	args := m.Called(courseID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) GetSeats(courseID, userID uuid.UUID, now time.Time) (waitlist.Seats, error) {
+	args := m.Called(courseID, userID)
+	return args.Get(0).(waitlist.Seats), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) Create(entry *schema.WaitlistEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) Delete(courseID, userID uuid.UUID) error {
+	args := m.Called(courseID, userID)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) List(courseID uuid.UUID) ([]schema.WaitlistEntry, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]schema.WaitlistEntry), args.Error(1)
+}
+
+// PurchaseSeat runs decide with the seats given to On, as the repository would inside its transaction
+func (m *MockWaitlistRepository) PurchaseSeat(courseID uuid.UUID, enroll *schema.CourseEnroll, now time.Time,
+	decide func(tx *gorm.DB, seats waitlist.Seats) error) error {
+	m.enroll = enroll
+	args := m.Called(courseID, enroll.UserID)
+	if err := decide(&gorm.DB{}, args.Get(0).(waitlist.Seats)); err != nil {
+		return err
+	}
+	return args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ExpireReservations(now time.Time) ([]schema.WaitlistEntry, error) {
+	args := m.Called()
+	return args.Get(0).([]schema.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) GetCoursesWithWaitingEntries() ([]uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) OfferSeats(courseID uuid.UUID, now, until time.Time,
+	count func(seats waitlist.Seats) int) (*schema.Course, []schema.WaitlistEntry, error) {
+	args := m.Called(courseID)
+	return args.Get(0).(*schema.Course), args.Get(1).([]schema.WaitlistEntry), args.Error(2)
+}
+
+type MockWishlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWishlistRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockWishlistRepository) IsEnrolled(userID, courseID uuid.UUID) (bool, error) {
+	args := m.Called(userID, courseID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWishlistRepository) Create(item *schema.WishlistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockWishlistRepository) Delete(userID, courseID uuid.UUID) error {
+	args := m.Called(userID, courseID)
+	return args.Error(0)
+}
+
+func (m *MockWishlistRepository) List(userID uuid.UUID, page, limit int) ([]schema.WishlistItem, int64, error) {
+	args := m.Called(userID, page, limit)
+	return args.Get(0).([]schema.WishlistItem), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWishlistRepository) GetWatchers(courseID uuid.UUID) ([]schema.User, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]schema.User), args.Error(1)
+}
+
+type MockPricingRepository struct {
+	mock.Mock
+}
+
+// expects reports whether the test set up a call to method. Sales and price history are incidental to most course
+// tests, so their calls are only checked by the tests that expect them.
+func (m *MockPricingRepository) expects(method string) bool {
+	for _, call := range m.ExpectedCalls {
+		if call.Method == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockPricingRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockPricingRepository) CreateSale(sale *schema.CourseSale, decide func(overlapping int64) error) error {
+	args := m.Called(sale)
+	return args.Error(0)
+}
+
+func (m *MockPricingRepository) GetSale(id uuid.UUID) (*schema.CourseSale, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.CourseSale), args.Error(1)
+}
+
+func (m *MockPricingRepository) DeleteSale(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPricingRepository) ListSales(courseID uuid.UUID) ([]schema.CourseSale, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]schema.CourseSale), args.Error(1)
+}
+
+func (m *MockPricingRepository) GetRunningSales(courseIDs []uuid.UUID, at time.Time) ([]schema.CourseSale, error) {
+	if !m.expects("GetRunningSales") {
+		return nil, nil
+	}
+	args := m.Called(courseIDs)
+	return args.Get(0).([]schema.CourseSale), args.Error(1)
+}
+
+func (m *MockPricingRepository) CreatePriceChange(change *schema.CoursePriceChange) error {
+	if !m.expects("CreatePriceChange") {
+		return nil
+	}
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockPricingRepository) ListPriceChanges(courseID uuid.UUID) ([]schema.CoursePriceChange, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]schema.CoursePriceChange), args.Error(1)
+}
+
 type CourseUseCaseTestSuite struct {
 	suite.Suite
 	walletRepo       *MockWalletRepository
@@ -247,6 +690,12 @@ type CourseUseCaseTestSuite struct {
 	notificationRepo *MockNotificationRepository
 	courseUseCase    *UseCase
 	uploader         *MockFileUploader
+	taxonomyRepo     *MockTaxonomyRepository
+	revisionRepo     *MockRevisionRepository
+	waitlistRepo     *MockWaitlistRepository
+	wishlistRepo     *MockWishlistRepository
+	pricingRepo      *MockPricingRepository
+	categoryID       uuid.UUID
 }
 
 func (suite *CourseUseCaseTestSuite) SetupTest() {
@@ -257,8 +706,18 @@ func (suite *CourseUseCaseTestSuite) SetupTest() {
 	suite.uploader = new(MockFileUploader)
 	suite.mailer = new(MockMailer)
 	suite.notificationRepo = new(MockNotificationRepository)
+	suite.taxonomyRepo = new(MockTaxonomyRepository)
+	suite.revisionRepo = new(MockRevisionRepository)
+	suite.waitlistRepo = new(MockWaitlistRepository)
+	suite.wishlistRepo = new(MockWishlistRepository)
+	suite.pricingRepo = new(MockPricingRepository)
+	suite.categoryID = uuid.New()
 	suite.enrollUseCase = courseenroll.NewUseCase(suite.enrollRepo)
-	suite.courseUseCase = NewUseCase(suite.courseRepo, suite.walletRepo, *suite.enrollUseCase, suite.userRepo, suite.notificationRepo, suite.mailer, suite.uploader)
+	suite.courseUseCase = NewUseCase(suite.courseRepo, suite.walletRepo, *suite.enrollUseCase, suite.userRepo, suite.notificationRepo, suite.mailer, suite.uploader,
+		taxonomy.NewUseCase(suite.taxonomyRepo), revision.NewUseCase(suite.revisionRepo),
+		waitlist.NewUseCase(suite.waitlistRepo, suite.notificationRepo),
+		wishlist.NewUseCase(suite.wishlistRepo, suite.notificationRepo, suite.mailer),
+		pricing.NewUseCase(suite.pricingRepo))
 
 }
 
@@ -270,10 +729,11 @@ func (suite *CourseUseCaseTestSuite) TestCreateCourse_Success() {
 		Description: "Learn the basics of Go.",
 		Price:       10000,
 		Difficulty:  "beginner",
-		Category:    "Programming Languages",
+		CategoryID:  suite.categoryID.String(),
 	}
 
 	instructorID, _ := uuid.NewV7()
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
 
 	suite.courseRepo.On("Create", ctx, mock.Anything).Return(nil)
 
@@ -292,10 +752,11 @@ func (suite *CourseUseCaseTestSuite) TestCreateCourse_InvalidImageFileType() {
 		Description: "Learn the basics of Go.",
 		Price:       10000,
 		Difficulty:  "beginner",
-		Category:    "Programming Languages",
+		CategoryID:  suite.categoryID.String(),
 	}
 	imageFile := &multipart.FileHeader{Filename: "image.pdf", Size: 1 * fileutil.MegaByte} // Unsupported file type for this test
 	instructorID, _ := uuid.NewV7()
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
 
 	// This should return an error due to invalid image file type
 
@@ -314,11 +775,12 @@ func (suite *CourseUseCaseTestSuite) TestCreateCourse_InvalidSyllabusFileType()
 		Description: "Deep dive into Node.js.",
 		Price:       12000,
 		Difficulty:  "advanced",
-		Category:    "Programming Languages",
+		CategoryID:  suite.categoryID.String(),
 	}
 
 	syllabusFile := &multipart.FileHeader{Filename: "syllabus.jpg", Size: 1 * fileutil.MegaByte} // Unsupported file type
 	instructorID, _ := uuid.NewV7()
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
 
 	err := suite.courseUseCase.Create(ctx, req, nil, syllabusFile, instructorID.String())
 
@@ -335,7 +797,7 @@ func (suite *CourseUseCaseTestSuite) TestCreateCourse_InvalidInstructorID() {
 		Description: "Explore Python in Data Science.",
 		Price:       15000,
 		Difficulty:  "intermediate",
-		Category:    "Data Science & Analytics",
+		CategoryID:  suite.categoryID.String(),
 	}
 
 	invalidId := "invalid"
@@ -356,11 +818,12 @@ func (suite *CourseUseCaseTestSuite) TestCreateCourse_FileTooLarge() {
 		Description: "Explore Python in Data Science.",
 		Price:       15000,
 		Difficulty:  "intermediate",
-		Category:    "Data Science & Analytics",
+		CategoryID:  suite.categoryID.String(),
 	}
 	imageFile := &multipart.FileHeader{Filename: "image.jpg", Size: 3 * fileutil.MegaByte}
 	syllabusFile := &multipart.FileHeader{Filename: "syllabus.pdf", Size: 1 * fileutil.MegaByte}
 	instructorID, _ := uuid.NewV7()
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
 
 	// This should return an error due to invalid instructor ID
 	err := suite.courseUseCase.Create(ctx, req, imageFile, syllabusFile, instructorID.String())
@@ -374,28 +837,113 @@ func (suite *CourseUseCaseTestSuite) TestCreateCourse_FileTooLarge() {
 	suite.courseRepo.AssertExpectations(suite.T())
 }
 
-func (suite *CourseUseCaseTestSuite) TestGetAll_Success() {
+func (suite *CourseUseCaseTestSuite) TestCreateCourse_UnknownCategory() {
 	ctx := context.Background()
-	page, pageSize := 1, 10
-	courseID, _ := uuid.NewV7()
-	courseID1, _ := uuid.NewV7()
-	mockCourses := []schema.Course{{ID: courseID, Title: "Go Programming"}, {ID: courseID1, Title: "Python Programming"}}
-	mockTotal := 2
+	req := CreateCourseRequest{
+		Title:      "Introduction to Go",
+		Price:      10000,
+		Difficulty: "beginner",
+		CategoryID: suite.categoryID.String(),
+	}
+	instructorID, _ := uuid.NewV7()
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(nil, gorm.ErrRecordNotFound)
 
-	suite.courseRepo.On("GetAll", ctx, page, pageSize).Return(mockCourses, mockTotal, nil)
+	err := suite.courseUseCase.Create(ctx, req, nil, nil, instructorID.String())
 
-	response, err := suite.courseUseCase.GetAll(ctx, page, pageSize)
+	assert.Equal(suite.T(), taxonomy.ErrCategoryNotFound.Build(), err)
+	suite.courseRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestCreateCourse_WithTags() {
+	ctx := context.Background()
+	req := CreateCourseRequest{
+		Title:      "Introduction to Go",
+		Price:      10000,
+		Difficulty: "beginner",
+		CategoryID: suite.categoryID.String(),
+		Tags:       []string{"Golang", "  concurrency ", "golang"},
+	}
+	instructorID, _ := uuid.NewV7()
+	stored := []schema.Tag{{ID: uuid.New(), Name: "Golang", Slug: "golang"}, {ID: uuid.New(), Name: "concurrency", Slug: "concurrency"}}
+
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
+	suite.taxonomyRepo.On("FindOrCreateTags", mock.MatchedBy(func(tags []schema.Tag) bool {
+		return len(tags) == 2 && tags[0].Slug == "golang" && tags[1].Slug == "concurrency"
+	})).Return(stored, nil)
+	suite.courseRepo.On("Create", ctx, mock.MatchedBy(func(c *schema.Course) bool {
+		return c.CategoryID != nil && *c.CategoryID == suite.categoryID && assert.ObjectsAreEqual(stored, c.Tags)
+	})).Return(nil)
+
+	err := suite.courseUseCase.Create(ctx, req, nil, nil, instructorID.String())
 
 	assert.NoError(suite.T(), err)
-	assert.Len(suite.T(), response.Courses, 2)
-	assert.Equal(suite.T(), mockTotal, response.Pagination.TotalData)
 	suite.courseRepo.AssertExpectations(suite.T())
+	suite.taxonomyRepo.AssertExpectations(suite.T())
 }
 
-func (suite *CourseUseCaseTestSuite) TestGetCourseByPopularity_Success() {
+func (suite *CourseUseCaseTestSuite) TestCreateCourse_TagsFailNotSaved() {
 	ctx := context.Background()
-	page, pageSize := 1, 10
-	courseID, _ := uuid.NewV7()
+	req := CreateCourseRequest{
+		Title:      "Introduction to Go",
+		Price:      10000,
+		Difficulty: "beginner",
+		CategoryID: suite.categoryID.String(),
+		Tags:       []string{"Golang"},
+	}
+	instructorID, _ := uuid.NewV7()
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
+	suite.taxonomyRepo.On("FindOrCreateTags", mock.Anything).Return([]schema.Tag(nil), errors.New("db down"))
+
+	err := suite.courseUseCase.Create(ctx, req, nil, nil, instructorID.String())
+
+	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
+	suite.courseRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestCreateCourse_TooManyTags() {
+	ctx := context.Background()
+	tags := make([]string, taxonomy.MaxCourseTags+1)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("tag %d", i)
+	}
+	req := CreateCourseRequest{
+		Title:      "Introduction to Go",
+		Price:      10000,
+		Difficulty: "beginner",
+		CategoryID: suite.categoryID.String(),
+		Tags:       tags,
+	}
+	instructorID, _ := uuid.NewV7()
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
+
+	err := suite.courseUseCase.Create(ctx, req, nil, nil, instructorID.String())
+
+	assert.Equal(suite.T(), taxonomy.ErrInvalidTag.Build().Error(), err.Error())
+	suite.courseRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestGetAll_Success() {
+	ctx := context.Background()
+	page, pageSize := 1, 10
+	courseID, _ := uuid.NewV7()
+	courseID1, _ := uuid.NewV7()
+	mockCourses := []schema.Course{{ID: courseID, Title: "Go Programming"}, {ID: courseID1, Title: "Python Programming"}}
+	mockTotal := 2
+
+	suite.courseRepo.On("GetAll", ctx, page, pageSize).Return(mockCourses, mockTotal, nil)
+
+	response, err := suite.courseUseCase.GetAll(ctx, page, pageSize)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response.Courses, 2)
+	assert.Equal(suite.T(), mockTotal, response.Pagination.TotalData)
+	suite.courseRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CourseUseCaseTestSuite) TestGetCourseByPopularity_Success() {
+	ctx := context.Background()
+	page, pageSize := 1, 10
+	courseID, _ := uuid.NewV7()
 	courseID1, _ := uuid.NewV7()
 	mockCourses := []schema.Course{{ID: courseID, Title: "Node.js Best Practices"}, {ID: courseID1, Title: "Advanced React"}}
 	mockTotal := 2
@@ -418,7 +966,7 @@ func (suite *CourseUseCaseTestSuite) TestGetByInstructorID_Success() {
 	mockCourses := []schema.Course{{ID: courseID, Title: "Intro to Docker"}}
 	mockTotal := 1
 
-	suite.courseRepo.On("FindByInstructorID", ctx, instructorID, page, pageSize).Return(mockCourses, mockTotal, nil)
+	suite.courseRepo.On("FindByInstructorID", ctx, instructorID, true, page, pageSize).Return(mockCourses, mockTotal, nil)
 
 	response, err := suite.courseUseCase.GetByInstructorID(ctx, instructorID, page, pageSize)
 
@@ -450,12 +998,95 @@ func (suite *CourseUseCaseTestSuite) TestUpdate_Success() {
 
 	suite.courseRepo.On("GetByID", ctx, id).Return(mockCourse, nil)
 	suite.courseRepo.On("Update", ctx, mock.Anything).Return(nil)
+	suite.revisionRepo.On("GetLatest", schema.RevisionEntityCourse, id).Return(nil, gorm.ErrRecordNotFound)
+	suite.revisionRepo.On("Create", mock.MatchedBy(func(r *schema.Revision) bool {
+		return r.Version == 1 && r.Title == "Original Title"
+	})).Return(nil).Once()
+	suite.revisionRepo.On("Create", mock.MatchedBy(func(r *schema.Revision) bool {
+		return r.Version == 2 && r.Title == "Updated Title"
+	})).Return(nil).Once()
 
 	updatedCourse, err := suite.courseUseCase.Update(ctx, req, id, nil, nil)
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), "Updated Title", updatedCourse.Title)
 	suite.courseRepo.AssertExpectations(suite.T())
+	suite.revisionRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CourseUseCaseTestSuite) TestUpdate_ChangesCategory() {
+	ctx := context.Background()
+	id := uuid.New()
+	oldCategoryID := uuid.New()
+	mockCourse := schema.Course{ID: id, CategoryID: &oldCategoryID, Category: &schema.Category{ID: oldCategoryID}}
+	newCategoryID := suite.categoryID.String()
+	req := UpdateCourseRequest{CategoryID: &newCategoryID}
+
+	suite.courseRepo.On("GetByID", ctx, id).Return(mockCourse, nil)
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
+	suite.courseRepo.On("Update", ctx, mock.Anything).Return(nil)
+
+	updatedCourse, err := suite.courseUseCase.Update(ctx, req, id, nil, nil)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.categoryID, *updatedCourse.CategoryID)
+	assert.Nil(suite.T(), updatedCourse.Category)
+	suite.courseRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CourseUseCaseTestSuite) TestUpdate_RecordsPriceChange() {
+	instructorID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", instructorID.String())
+	id := uuid.New()
+	mockCourse := schema.Course{ID: id, Title: "Go", Price: 100000, Status: schema.CourseStatusDraft}
+	price := int64(120000)
+	req := UpdateCourseRequest{Price: &price}
+
+	suite.courseRepo.On("GetByID", ctx, id).Return(mockCourse, nil)
+	suite.courseRepo.On("Update", ctx, mock.Anything).Return(nil)
+	suite.revisionRepo.On("GetLatest", schema.RevisionEntityCourse, id).Return(nil, gorm.ErrRecordNotFound)
+	suite.pricingRepo.On("CreatePriceChange", mock.MatchedBy(func(change *schema.CoursePriceChange) bool {
+		return change.CourseID == id && *change.OldPrice == 100000 && change.NewPrice == 120000 &&
+			*change.ChangedByID == instructorID
+	})).Return(nil).Once()
+
+	updatedCourse, err := suite.courseUseCase.Update(ctx, req, id, nil, nil)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(120000), updatedCourse.EffectivePrice)
+	suite.pricingRepo.AssertExpectations(suite.T())
+	suite.wishlistRepo.AssertNotCalled(suite.T(), "GetWatchers", mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestUpdate_PriceDropNotifiesWishlist() {
+	ctx := context.Background()
+	id := uuid.New()
+	mockCourse := schema.Course{ID: id, Title: "Go", Price: 200000, Status: schema.CourseStatusPublished}
+	price := int64(150000)
+	req := UpdateCourseRequest{Price: &price}
+	studentID := uuid.New()
+
+	suite.courseRepo.On("GetByID", ctx, id).Return(mockCourse, nil)
+	suite.courseRepo.On("Update", ctx, mock.Anything).Return(nil)
+	suite.revisionRepo.On("GetLatest", schema.RevisionEntityCourse, id).Return(nil, gorm.ErrRecordNotFound)
+	suite.wishlistRepo.On("GetWatchers", id).Return([]schema.User{{ID: studentID, Name: "Student"}}, nil)
+	notified := make(chan *schema.Notification, 1)
+	suite.notificationRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		notified <- args.Get(0).(*schema.Notification)
+	}).Return(nil)
+
+	_, err := suite.courseUseCase.Update(ctx, req, id, nil, nil)
+	assert.NoError(suite.T(), err)
+
+	select {
+	case notif := <-notified:
+		assert.Equal(suite.T(), studentID, notif.UserID)
+		assert.Contains(suite.T(), notif.Detail, "150000")
+	case <-time.After(time.Second):
+		suite.T().Fatal("wishlist was not notified of the price drop")
+	}
+	// The student's email is unverified, so no mail goes out
+	suite.mailer.AssertNotCalled(suite.T(), "DialAndSend", mock.Anything)
 }
 
 func (suite *CourseUseCaseTestSuite) TestUpdate_InvalidFileType() {
@@ -487,26 +1118,57 @@ func (suite *CourseUseCaseTestSuite) TestUpdate_InvalidCourseID() {
 	assert.IsType(suite.T(), ErrCourseNotFound.Build(), err)
 }
 
-func (suite *CourseUseCaseTestSuite) TestSearchCoursesByTitle_Success() {
+func (suite *CourseUseCaseTestSuite) TestSearchCourses_Success() {
 	ctx := context.Background()
-	title := "Go Programming"
+	query := "Go Programming"
 	page, pageSize := 1, 10
-	mockCourses := []schema.Course{
-		{Title: "Go Programming 101", ID: uuid.New()},
-		{Title: "Advanced Go Programming", ID: uuid.New()},
+	mockHits := []SearchHit{
+		{
+			Course:         schema.Course{Title: "Go Programming 101", ID: uuid.New()},
+			TitleHighlight: "\x02Go\x03 \x02Programming\x03 101",
+			Snippet:        "Learn <b>\x02Go\x03</b> & more",
+		},
+		{Course: schema.Course{Title: "Advanced Go Programming", ID: uuid.New()}},
 	}
-	total := len(mockCourses)
+	total := len(mockHits)
 
-	suite.courseRepo.On("SearchByTitle", ctx, title, page, pageSize).Return(mockCourses, total, nil)
+	suite.courseRepo.On("Search", ctx, query, page, pageSize).Return(mockHits, total, nil)
 
-	response, err := suite.courseUseCase.SearchCoursesByTitle(ctx, title, page, pageSize)
+	response, err := suite.courseUseCase.SearchCourses(ctx, " "+query+" ", page, pageSize)
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), total, response.Pagination.TotalData)
 	assert.Len(suite.T(), response.Courses, total)
+	assert.Equal(suite.T(), "<mark>Go</mark> <mark>Programming</mark> 101", response.Courses[0].TitleHighlight)
+	assert.Equal(suite.T(), "Learn &lt;b&gt;<mark>Go</mark>&lt;/b&gt; &amp; more", response.Courses[0].Snippet)
 	suite.courseRepo.AssertExpectations(suite.T())
 }
 
+func (suite *CourseUseCaseTestSuite) TestSearchCourses_OnlyPunctuation() {
+	response, err := suite.courseUseCase.SearchCourses(context.Background(), "&|!:*", 1, 10)
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), response.Courses)
+	suite.courseRepo.AssertNotCalled(suite.T(), "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestSuggestCourses_DefaultLimit() {
+	ctx := context.Background()
+	suggestions := []Suggestion{{Type: "course", ID: uuid.New(), Text: "Golang Basics"}}
+	suite.courseRepo.On("Suggest", ctx, "gola", defaultSuggestionLimit).Return(suggestions, nil)
+
+	result, err := suite.courseUseCase.SuggestCourses(ctx, SuggestRequest{Query: "gola"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suggestions, result)
+}
+
+func TestPrefixTSQuery(t *testing.T) {
+	assert.Equal(t, "web:* & dev:*", prefixTSQuery("Web dev"))
+	assert.Equal(t, "c:* & go:*", prefixTSQuery("c++ & go:*!"))
+	assert.Equal(t, "", prefixTSQuery("  !! "))
+}
+
 func (suite *CourseUseCaseTestSuite) TestBuyCourse_Success() {
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, "user.name", "John Doe")
@@ -518,18 +1180,19 @@ func (suite *CourseUseCaseTestSuite) TestBuyCourse_Success() {
 	studentId, _ := uuid.NewV7()
 	instructorId, _ := uuid.NewV7()
 
-	mockCourse := schema.Course{ID: courseId, InstructorID: instructorId, Price: 10000}
+	mockCourse := schema.Course{ID: courseId, InstructorID: instructorId, Price: 10000, Status: schema.CourseStatusPublished}
 	mockInstructor := schema.User{ID: instructorId, Name: "Instructor Name", Email: "instructor@example.com"}
 
 	suite.courseRepo.On("GetByID", ctx, courseId).Return(mockCourse, nil)
 	suite.userRepo.On("GetByID", instructorId).Return(&mockInstructor, nil)
 
 	suite.enrollRepo.On("IsEnrolled", ctx, studentId, courseId).Return(false, nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{}, nil)
 	suite.mailer.On("DialAndSend", mock.Anything).Return(nil)
 
 	suite.walletRepo.On("TransferByUserID", mock.AnythingOfType("*gorm.DB"), studentId, instructorId, int64(10000)).Return(nil)
 
-	suite.enrollRepo.On("Create", ctx, mock.AnythingOfType("*schema.CourseEnroll")).Return(nil)
+	suite.waitlistRepo.On("PurchaseSeat", courseId, studentId).Return(waitlist.Seats{}, nil)
 	suite.notificationRepo.On("Create", mock.AnythingOfType("*schema.Notification")).Return(nil)
 
 	// Executing the method under test
@@ -538,9 +1201,87 @@ func (suite *CourseUseCaseTestSuite) TestBuyCourse_Success() {
 	// Assertions to check that no error occurred and all expectations were met
 	assert.NoError(suite.T(), err)
 	suite.courseRepo.AssertExpectations(suite.T())
-	suite.enrollRepo.AssertExpectations(suite.T())
+	suite.waitlistRepo.AssertExpectations(suite.T())
+	suite.walletRepo.AssertExpectations(suite.T())
+
+}
+
+func (suite *CourseUseCaseTestSuite) TestBuyCourse_DuringSale() {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, "user.name", "John Doe")
+	ctx = context.WithValue(ctx, "user.email", "john.doe@example.com")
+	_ = os.Setenv("ENV", "test")
+	_ = os.Setenv("SMTP_EMAIL", "test")
+	config.LoadEnv()
+	courseId, _ := uuid.NewV7()
+	studentId, _ := uuid.NewV7()
+	instructorId, _ := uuid.NewV7()
+
+	mockCourse := schema.Course{ID: courseId, InstructorID: instructorId, Price: 10000, Status: schema.CourseStatusPublished}
+	sale := schema.CourseSale{ID: uuid.New(), CourseID: courseId, SalePrice: 7500,
+		StartsAt: time.Now().Add(-time.Hour), EndsAt: time.Now().Add(time.Hour)}
+
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(mockCourse, nil)
+	suite.userRepo.On("GetByID", instructorId).Return(&schema.User{ID: instructorId}, nil)
+	suite.enrollRepo.On("IsEnrolled", ctx, studentId, courseId).Return(false, nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{}, nil)
+	suite.pricingRepo.On("GetRunningSales", []uuid.UUID{courseId}).Return([]schema.CourseSale{sale}, nil)
+	suite.mailer.On("DialAndSend", mock.Anything).Return(nil)
+	suite.walletRepo.On("TransferByUserID", mock.AnythingOfType("*gorm.DB"), studentId, instructorId, int64(7500)).Return(nil)
+	suite.waitlistRepo.On("PurchaseSeat", courseId, studentId).Return(waitlist.Seats{}, nil)
+	suite.notificationRepo.On("Create", mock.AnythingOfType("*schema.Notification")).Return(nil)
+
+	err := suite.courseUseCase.BuyCourse(ctx, courseId, studentId.String())
+
+	assert.NoError(suite.T(), err)
+	suite.walletRepo.AssertExpectations(suite.T())
+	assert.Equal(suite.T(), int64(7500), *suite.waitlistRepo.enroll.AmountPaid)
+}
+
+func (suite *CourseUseCaseTestSuite) TestBuyCourse_LimitedSeats() {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, "user.name", "John Doe")
+	ctx = context.WithValue(ctx, "user.email", "john.doe@example.com")
+	courseId, studentId, instructorId := uuid.New(), uuid.New(), uuid.New()
+	maxSeats := 10
+	mockCourse := schema.Course{ID: courseId, InstructorID: instructorId, Price: 10000, MaxSeats: &maxSeats,
+		Status: schema.CourseStatusPublished}
+
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(mockCourse, nil)
+	suite.enrollRepo.On("IsEnrolled", ctx, studentId, courseId).Return(false, nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{}, nil)
+	suite.waitlistRepo.On("PurchaseSeat", courseId, studentId).Return(waitlist.Seats{Capacity: &maxSeats, Enrolled: 9}, nil)
+	suite.walletRepo.On("TransferByUserID", mock.AnythingOfType("*gorm.DB"), studentId, instructorId, int64(10000)).Return(nil)
+	suite.userRepo.On("GetByID", instructorId).Return(&schema.User{ID: instructorId}, nil)
+	suite.mailer.On("DialAndSend", mock.Anything).Return(nil)
+	suite.notificationRepo.On("Create", mock.AnythingOfType("*schema.Notification")).Return(nil)
+
+	err := suite.courseUseCase.BuyCourse(ctx, courseId, studentId.String())
+
+	assert.NoError(suite.T(), err)
 	suite.walletRepo.AssertExpectations(suite.T())
+	// The enrollment is created inside the seat transaction, not through the enrollment repository
+	suite.enrollRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestBuyCourse_Full() {
+	ctx := context.Background()
+	courseId, studentId := uuid.New(), uuid.New()
+	maxSeats := 10
+	mockCourse := schema.Course{ID: courseId, InstructorID: uuid.New(), Price: 10000, MaxSeats: &maxSeats,
+		Status: schema.CourseStatusPublished}
+
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(mockCourse, nil)
+	suite.enrollRepo.On("IsEnrolled", ctx, studentId, courseId).Return(false, nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{}, nil)
+	// One seat is open, but a student who joined the waitlist earlier is entitled to it
+	suite.waitlistRepo.On("PurchaseSeat", courseId, studentId).
+		Return(waitlist.Seats{Capacity: &maxSeats, Enrolled: 9, WaitingAhead: 1}, nil)
+
+	err := suite.courseUseCase.BuyCourse(ctx, courseId, studentId.String())
 
+	assert.Equal(suite.T(), waitlist.ErrCourseFull.Build(), err)
+	suite.walletRepo.AssertNotCalled(suite.T(), "TransferByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (suite *CourseUseCaseTestSuite) TestBuyCourse_CourseNotFound() {
@@ -556,12 +1297,30 @@ func (suite *CourseUseCaseTestSuite) TestBuyCourse_CourseNotFound() {
 	assert.Equal(suite.T(), ErrCourseNotFound.Build(), err)
 }
 
+// TestBuyCourse_EnrolledMeanwhile covers a second purchase by the same student that passed the enrollment check
+// before the first one committed
+func (suite *CourseUseCaseTestSuite) TestBuyCourse_EnrolledMeanwhile() {
+	ctx := context.Background()
+	courseId, studentId := uuid.New(), uuid.New()
+	mockCourse := schema.Course{ID: courseId, InstructorID: uuid.New(), Price: 10000, Status: schema.CourseStatusPublished}
+
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(mockCourse, nil)
+	suite.enrollRepo.On("IsEnrolled", ctx, studentId, courseId).Return(false, nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{}, nil)
+	suite.waitlistRepo.On("PurchaseSeat", courseId, studentId).Return(waitlist.Seats{AlreadyEnrolled: true}, nil)
+
+	err := suite.courseUseCase.BuyCourse(ctx, courseId, studentId.String())
+
+	assert.Equal(suite.T(), waitlist.ErrAlreadyEnrolled.Build(), err)
+	suite.walletRepo.AssertNotCalled(suite.T(), "TransferByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (suite *CourseUseCaseTestSuite) TestBuyCourse_AlreadyEnrolled() {
 	ctx := context.Background()
 	courseId, _ := uuid.NewV7()
 	studentId, _ := uuid.NewV7()
 
-	mockCourse := schema.Course{ID: courseId}
+	mockCourse := schema.Course{ID: courseId, Status: schema.CourseStatusPublished}
 	suite.courseRepo.On("GetByID", ctx, courseId).Return(mockCourse, nil)
 	suite.enrollRepo.On("IsEnrolled", ctx, studentId, courseId).Return(true, nil)
 
@@ -577,9 +1336,11 @@ func (suite *CourseUseCaseTestSuite) TestBuyCourse_TransactionError() {
 	studentId, _ := uuid.NewV7()
 	instructorId := uuid.New()
 
-	mockCourse := schema.Course{ID: courseId, InstructorID: instructorId, Price: 10000}
+	mockCourse := schema.Course{ID: courseId, InstructorID: instructorId, Price: 10000, Status: schema.CourseStatusPublished}
 	suite.courseRepo.On("GetByID", ctx, courseId).Return(mockCourse, nil)
 	suite.enrollRepo.On("IsEnrolled", ctx, studentId, courseId).Return(false, nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{}, nil)
+	suite.waitlistRepo.On("PurchaseSeat", courseId, studentId).Return(waitlist.Seats{}, nil)
 	suite.walletRepo.On("TransferByUserID", mock.Anything, studentId, instructorId, int64(10000)).Return(apierror.ErrInternalServer.Build())
 
 	err := suite.courseUseCase.BuyCourse(ctx, courseId, studentId.String())
@@ -588,6 +1349,112 @@ func (suite *CourseUseCaseTestSuite) TestBuyCourse_TransactionError() {
 	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
 }
 
+func (suite *CourseUseCaseTestSuite) TestBuyCourse_PrerequisitesNotMet() {
+	ctx := context.Background()
+	courseId, _ := uuid.NewV7()
+	studentId, _ := uuid.NewV7()
+	required, recommended := uuid.New(), uuid.New()
+
+	mockCourse := schema.Course{ID: courseId, Price: 10000, Status: schema.CourseStatusPublished}
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(mockCourse, nil)
+	suite.enrollRepo.On("IsEnrolled", ctx, studentId, courseId).Return(false, nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{
+		{ID: required, Required: true},
+		{ID: recommended},
+	}, nil)
+	suite.courseRepo.On("GetCompletedCourseIDs", ctx, studentId, []uuid.UUID{required, recommended}).Return([]uuid.UUID{}, nil)
+
+	err := suite.courseUseCase.BuyCourse(ctx, courseId, studentId.String())
+
+	assert.Equal(suite.T(), ErrPrerequisitesNotMet.Build().Error(), err.Error())
+	suite.walletRepo.AssertNotCalled(suite.T(), "TransferByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestGetPrerequisites_MarksCompleted() {
+	studentId := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", studentId.String())
+	courseId, done, pending := uuid.New(), uuid.New(), uuid.New()
+
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(schema.Course{ID: courseId}, nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{
+		{ID: done, Required: true},
+		{ID: pending, Required: true},
+	}, nil)
+	suite.courseRepo.On("GetCompletedCourseIDs", ctx, studentId, []uuid.UUID{done, pending}).Return([]uuid.UUID{done}, nil)
+
+	res, err := suite.courseUseCase.GetPrerequisites(ctx, courseId)
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), res.Prerequisites[0].Completed)
+	assert.False(suite.T(), res.Prerequisites[1].Completed)
+	assert.Equal(suite.T(), []uuid.UUID{pending}, res.Missing)
+	assert.False(suite.T(), res.CanPurchase)
+}
+
+func (suite *CourseUseCaseTestSuite) TestGetPrerequisites_Anonymous() {
+	ctx := context.Background()
+	courseId, recommended := uuid.New(), uuid.New()
+
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(schema.Course{ID: courseId}, nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{{ID: recommended}}, nil)
+
+	res, err := suite.courseUseCase.GetPrerequisites(ctx, courseId)
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), res.Missing)
+	assert.True(suite.T(), res.CanPurchase)
+	suite.courseRepo.AssertNotCalled(suite.T(), "GetCompletedCourseIDs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestSetPrerequisites_Self() {
+	ctx := context.Background()
+	courseId := uuid.New()
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(schema.Course{ID: courseId}, nil)
+
+	_, err := suite.courseUseCase.SetPrerequisites(ctx, courseId, SetPrerequisitesRequest{
+		Prerequisites: []PrerequisiteInput{{CourseID: courseId.String(), Required: true}},
+	})
+
+	assert.Equal(suite.T(), ErrInvalidPrerequisite.Build().Error(), err.Error())
+}
+
+func (suite *CourseUseCaseTestSuite) TestSetPrerequisites_Cycle() {
+	ctx := context.Background()
+	courseId, prerequisiteId := uuid.New(), uuid.New()
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(schema.Course{ID: courseId}, nil)
+	suite.courseRepo.On("GetByIDs", ctx, []uuid.UUID{prerequisiteId}).Return([]schema.Course{{ID: prerequisiteId}}, nil)
+	suite.courseRepo.On("GetPrerequisiteClosure", ctx, []uuid.UUID{prerequisiteId}).Return([]uuid.UUID{uuid.New(), courseId}, nil)
+
+	_, err := suite.courseUseCase.SetPrerequisites(ctx, courseId, SetPrerequisitesRequest{
+		Prerequisites: []PrerequisiteInput{{CourseID: prerequisiteId.String()}},
+	})
+
+	assert.Equal(suite.T(), ErrPrerequisiteCycle.Build(), err)
+	suite.courseRepo.AssertNotCalled(suite.T(), "SetPrerequisites", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestSetPrerequisites_Success() {
+	ctx := context.Background()
+	courseId, prerequisiteId := uuid.New(), uuid.New()
+	expected := []schema.CoursePrerequisite{{CourseID: courseId, PrerequisiteID: prerequisiteId, Required: true}}
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(schema.Course{ID: courseId}, nil)
+	suite.courseRepo.On("GetByIDs", ctx, []uuid.UUID{prerequisiteId}).Return([]schema.Course{{ID: prerequisiteId}}, nil)
+	suite.courseRepo.On("GetPrerequisiteClosure", ctx, []uuid.UUID{prerequisiteId}).Return([]uuid.UUID{}, nil)
+	suite.courseRepo.On("SetPrerequisites", ctx, courseId, expected).Return(nil)
+	suite.courseRepo.On("GetPrerequisites", ctx, courseId).Return([]PrerequisiteCourse{{ID: prerequisiteId, Required: true}}, nil)
+
+	res, err := suite.courseUseCase.SetPrerequisites(ctx, courseId, SetPrerequisitesRequest{
+		Prerequisites: []PrerequisiteInput{
+			{CourseID: prerequisiteId.String(), Required: true},
+			{CourseID: prerequisiteId.String()},
+		},
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), res.Prerequisites, 1)
+	suite.courseRepo.AssertExpectations(suite.T())
+}
+
 func (suite *CourseUseCaseTestSuite) TestGetEnrollmentsByCourse_Success() {
 	ctx := context.Background()
 	courseId := uuid.New()
@@ -732,77 +1599,86 @@ func (suite *CourseUseCaseTestSuite) TestGetUserCourseProgress_FetchProgressErro
 
 func (suite *CourseUseCaseTestSuite) TestFilterCourses_ByCategory() {
 	ctx := context.Background()
-	category := "Programming Languages"
 	req := FilterCoursesRequest{
-		Category: &category,
-		Page:     1,
-		Limit:    10,
+		Categories: []string{"Programming Languages"},
+		Page:       1,
+		Limit:      10,
 	}
 	mockCourses := []schema.Course{{ID: uuid.New(), Title: "Intro to Go"}, {ID: uuid.New(), Title: "Advanced Python"}}
 	total := 2
+	filter := CatalogFilter{Categories: []string{"programming-languages"}}
+	facets := CatalogFacets{Categories: []FacetCount{{Value: "programming-languages", Count: 2}, {Value: "networking", Count: 5}}}
 
-	suite.courseRepo.On("DynamicFilterCourses", ctx, "category", "Programming Languages", "", 1, 10).Return(mockCourses, total, nil)
+	suite.courseRepo.On("FindCatalog", ctx, filter, 1, 10).Return(mockCourses, total, nil)
+	suite.courseRepo.On("GetCatalogFacets", ctx, filter).Return(facets, nil)
 
 	response, err := suite.courseUseCase.FilterCourses(ctx, req)
 
 	assert.NoError(suite.T(), err)
 	assert.Len(suite.T(), response.Courses, 2)
 	assert.Equal(suite.T(), total, response.Pagination.TotalData)
+	assert.Equal(suite.T(), facets, response.Facets)
 	suite.courseRepo.AssertExpectations(suite.T())
 }
 
-func (suite *CourseUseCaseTestSuite) TestFilterCourses_ByDifficulty() {
+func (suite *CourseUseCaseTestSuite) TestFilterCourses_CombinedFacets() {
 	ctx := context.Background()
-	difficulty := "beginner"
+	instructorID := uuid.New()
+	minRating := float32(4.0)
+	maxPrice := int64(50000)
 	req := FilterCoursesRequest{
-		Difficulty: &difficulty,
-		Page:       1,
-		Limit:      10,
+		Categories:   []string{"Web Development", "networking"},
+		Tags:         []string{"Go Lang", "grpc"},
+		Difficulties: []string{"beginner", "intermediate"},
+		Languages:    []string{"id"},
+		MaxPrice:     &maxPrice,
+		MinRating:    &minRating,
+		InstructorID: instructorID.String(),
+		Sort:         "price_asc",
+		Page:         2,
+		Limit:        5,
+	}
+	filter := CatalogFilter{
+		Categories:   []string{"web-development", "networking"},
+		Tags:         []string{"go-lang", "grpc"},
+		Difficulties: req.Difficulties,
+		Languages:    req.Languages,
+		MaxPrice:     &maxPrice,
+		MinRating:    &minRating,
+		InstructorID: &instructorID,
+		Sort:         "price_asc",
 	}
 
-	mockCourses := []schema.Course{{ID: uuid.New(), Title: "Basic HTML"}, {ID: uuid.New(), Title: "CSS Fundamentals"}}
-	total := 2
-
-	suite.courseRepo.On("DynamicFilterCourses", ctx, "difficulty", "beginner", "", 1, 10).Return(mockCourses, total, nil)
+	suite.courseRepo.On("FindCatalog", ctx, filter, 2, 5).Return([]schema.Course{}, 6, nil)
+	suite.courseRepo.On("GetCatalogFacets", ctx, filter).Return(CatalogFacets{}, nil)
 
 	response, err := suite.courseUseCase.FilterCourses(ctx, req)
 
 	assert.NoError(suite.T(), err)
-	assert.Len(suite.T(), response.Courses, 2)
+	assert.Equal(suite.T(), 2, response.Pagination.TotalPage)
 	suite.courseRepo.AssertExpectations(suite.T())
 }
 
-func (suite *CourseUseCaseTestSuite) TestFilterCourses_ByRating() {
-	ctx := context.Background()
-	rating := float32(4.0)
-	req := FilterCoursesRequest{
-		Rating: &rating,
-		Page:   1,
-		Limit:  10,
-	}
-
-	mockCourses := []schema.Course{{ID: uuid.New(), Title: "React for Beginners"}, {ID: uuid.New(), Title: "Vue Advanced Techniques"}}
-	total := 2
-
-	suite.courseRepo.On("DynamicFilterCourses", ctx, "rating", "4.0", "", 1, 10).Return(mockCourses, total, nil)
+func (suite *CourseUseCaseTestSuite) TestFilterCourses_InvalidPriceRange() {
+	minPrice, maxPrice := int64(100), int64(10)
+	req := FilterCoursesRequest{MinPrice: &minPrice, MaxPrice: &maxPrice, Page: 1, Limit: 10}
 
-	response, err := suite.courseUseCase.FilterCourses(ctx, req)
+	response, err := suite.courseUseCase.FilterCourses(context.Background(), req)
 
-	assert.NoError(suite.T(), err)
-	assert.Len(suite.T(), response.Courses, 2)
-	suite.courseRepo.AssertExpectations(suite.T())
+	assert.Nil(suite.T(), response)
+	assert.Equal(suite.T(), ErrInvalidCourseData.Build().Error(), err.Error())
+	suite.courseRepo.AssertNotCalled(suite.T(), "FindCatalog", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (suite *CourseUseCaseTestSuite) TestFilterCourses_Failure() {
 	ctx := context.Background()
-	category := "Programming Languages"
 	req := FilterCoursesRequest{
-		Category: &category,
-		Page:     1,
-		Limit:    10,
+		Categories: []string{"Programming Languages"},
+		Page:       1,
+		Limit:      10,
 	}
 
-	suite.courseRepo.On("DynamicFilterCourses", ctx, "category", "Programming Languages", "", 1, 10).
+	suite.courseRepo.On("FindCatalog", ctx, CatalogFilter{Categories: []string{"programming-languages"}}, 1, 10).
 		Return([]schema.Course{}, 0, apierror.ErrInternalServer.Build())
 
 	response, err := suite.courseUseCase.FilterCourses(ctx, req)
@@ -812,6 +1688,647 @@ func (suite *CourseUseCaseTestSuite) TestFilterCourses_Failure() {
 	suite.courseRepo.AssertExpectations(suite.T())
 }
 
+func (suite *CourseUseCaseTestSuite) TestGetByInstructorID_OwnerSeesAllStatuses() {
+	instructorID, _ := uuid.NewV7()
+	ctx := context.WithValue(context.Background(), "user.id", instructorID.String())
+	mockCourses := []schema.Course{{ID: uuid.New(), Status: schema.CourseStatusDraft}}
+
+	suite.courseRepo.On("FindByInstructorID", ctx, instructorID, false, 1, 10).Return(mockCourses, 1, nil)
+
+	response, err := suite.courseUseCase.GetByInstructorID(ctx, instructorID, 1, 10)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), response.Courses, 1)
+	suite.courseRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CourseUseCaseTestSuite) TestGetVisibleByID_DraftHidden() {
+	ctx := context.Background()
+	courseID := uuid.New()
+
+	suite.courseRepo.On("GetByID", ctx, courseID).Return(schema.Course{ID: courseID, Status: schema.CourseStatusDraft}, nil)
+
+	_, err := suite.courseUseCase.GetVisibleByID(ctx, courseID)
+	assert.Equal(suite.T(), ErrCourseNotFound.Build(), err)
+}
+
+func (suite *CourseUseCaseTestSuite) TestGetVisibleByID_UnlistedVisible() {
+	ctx := context.Background()
+	courseID := uuid.New()
+
+	suite.courseRepo.On("GetByID", ctx, courseID).Return(schema.Course{ID: courseID, Status: schema.CourseStatusUnlisted}, nil)
+
+	course, err := suite.courseUseCase.GetVisibleByID(ctx, courseID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), courseID, course.ID)
+}
+
+func (suite *CourseUseCaseTestSuite) TestBuyCourse_NotPurchasable() {
+	ctx := context.Background()
+	courseId := uuid.New()
+
+	suite.courseRepo.On("GetByID", ctx, courseId).Return(schema.Course{ID: courseId, Status: schema.CourseStatusArchived}, nil)
+
+	err := suite.courseUseCase.BuyCourse(ctx, courseId, uuid.NewString())
+	assert.Equal(suite.T(), ErrCourseNotPurchasable.Build(), err)
+	suite.walletRepo.AssertNotCalled(suite.T(), "TransferByUserID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func readyCourse(status schema.CourseStatus) schema.Course {
+	return schema.Course{
+		ID:          uuid.New(),
+		Description: "A course",
+		ImageURL:    "https://example.com/image.png",
+		Materials:   []schema.Material{{ID: uuid.New()}},
+		Status:      status,
+	}
+}
+
+func (suite *CourseUseCaseTestSuite) TestChangeStatus_PublishWithoutReview() {
+	_ = os.Setenv("ENV", "test")
+	_ = os.Setenv("COURSE_REVIEW_REQUIRED", "false")
+	config.LoadEnv()
+	ctx := context.Background()
+	course := readyCourse(schema.CourseStatusDraft)
+
+	suite.courseRepo.On("GetByID", ctx, course.ID).Return(course, nil)
+	suite.courseRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*schema.Course")).Return(nil)
+
+	updated, err := suite.courseUseCase.ChangeStatus(ctx, course.ID, ChangeCourseStatusRequest{Status: schema.CourseStatusPublished})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), schema.CourseStatusPublished, updated.Status)
+	assert.NotNil(suite.T(), updated.PublishedAt)
+}
+
+func (suite *CourseUseCaseTestSuite) TestChangeStatus_ReviewRequired() {
+	_ = os.Setenv("ENV", "test")
+	_ = os.Setenv("COURSE_REVIEW_REQUIRED", "true")
+	defer os.Unsetenv("COURSE_REVIEW_REQUIRED")
+	config.LoadEnv()
+	ctx := context.Background()
+	course := readyCourse(schema.CourseStatusDraft)
+
+	suite.courseRepo.On("GetByID", ctx, course.ID).Return(course, nil)
+
+	_, err := suite.courseUseCase.ChangeStatus(ctx, course.ID, ChangeCourseStatusRequest{Status: schema.CourseStatusPublished})
+
+	assert.Equal(suite.T(), "INVALID_STATUS_TRANSITION", err.Error())
+	suite.courseRepo.AssertNotCalled(suite.T(), "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestChangeStatus_NotReady() {
+	_ = os.Setenv("ENV", "test")
+	config.LoadEnv()
+	ctx := context.Background()
+	course := schema.Course{ID: uuid.New(), Status: schema.CourseStatusDraft, Description: "A course"}
+
+	suite.courseRepo.On("GetByID", ctx, course.ID).Return(course, nil)
+
+	_, err := suite.courseUseCase.ChangeStatus(ctx, course.ID, ChangeCourseStatusRequest{Status: schema.CourseStatusPublished})
+
+	assert.Equal(suite.T(), "COURSE_NOT_READY", err.Error())
+	assert.Equal(suite.T(), map[string]any{"missing": []string{"MATERIAL", "IMAGE"}}, apierror.GetPayload(err))
+}
+
+func (suite *CourseUseCaseTestSuite) TestChangeStatus_ArchiveSkipsReadiness() {
+	_ = os.Setenv("ENV", "test")
+	config.LoadEnv()
+	ctx := context.Background()
+	course := schema.Course{ID: uuid.New(), Status: schema.CourseStatusPublished}
+
+	suite.courseRepo.On("GetByID", ctx, course.ID).Return(course, nil)
+	suite.courseRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*schema.Course")).Return(nil)
+
+	updated, err := suite.courseUseCase.ChangeStatus(ctx, course.ID, ChangeCourseStatusRequest{Status: schema.CourseStatusArchived})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), schema.CourseStatusArchived, updated.Status)
+}
+
+func (suite *CourseUseCaseTestSuite) TestReviewCourse_Approve() {
+	ctx := context.Background()
+	course := readyCourse(schema.CourseStatusInReview)
+	approve := true
+
+	suite.courseRepo.On("GetByID", ctx, course.ID).Return(course, nil)
+	suite.courseRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*schema.Course")).Return(nil)
+	suite.notificationRepo.On("Create", mock.AnythingOfType("*schema.Notification")).Return(nil)
+
+	updated, err := suite.courseUseCase.ReviewCourse(ctx, course.ID, ReviewCourseRequest{Approve: &approve})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), schema.CourseStatusPublished, updated.Status)
+}
+
+func (suite *CourseUseCaseTestSuite) TestReviewCourse_Reject() {
+	ctx := context.Background()
+	course := readyCourse(schema.CourseStatusInReview)
+	approve := false
+
+	suite.courseRepo.On("GetByID", ctx, course.ID).Return(course, nil)
+	suite.courseRepo.On("UpdateStatus", ctx, mock.AnythingOfType("*schema.Course")).Return(nil)
+	suite.notificationRepo.On("Create", mock.AnythingOfType("*schema.Notification")).Return(nil)
+
+	updated, err := suite.courseUseCase.ReviewCourse(ctx, course.ID, ReviewCourseRequest{Approve: &approve, Note: "Add captions"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), schema.CourseStatusDraft, updated.Status)
+	assert.Equal(suite.T(), "Add captions", updated.ReviewNote)
+}
+
+func (suite *CourseUseCaseTestSuite) TestReviewCourse_NotInReview() {
+	ctx := context.Background()
+	course := readyCourse(schema.CourseStatusPublished)
+	approve := true
+
+	suite.courseRepo.On("GetByID", ctx, course.ID).Return(course, nil)
+
+	_, err := suite.courseUseCase.ReviewCourse(ctx, course.ID, ReviewCourseRequest{Approve: &approve})
+	assert.Equal(suite.T(), ErrCourseNotInReview.Build(), err)
+}
+
 func TestCourseUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(CourseUseCaseTestSuite))
 }
+
+func (suite *CourseUseCaseTestSuite) TestClone_CopiesContentAsDraft() {
+	ctx := context.Background()
+	id := uuid.New()
+	sectionID := uuid.New()
+	materialID := uuid.New()
+	source := schema.Course{
+		ID:           id,
+		Title:        "Go Basics",
+		InstructorID: uuid.New(),
+		Status:       schema.CourseStatusPublished,
+		ImageURL:     "https://bucket.s3.region.amazonaws.com/course%2Fimage%2F" + id.String() + ".cover.png",
+		Materials: []schema.Material{{
+			ID:        materialID,
+			CourseID:  id,
+			Title:     "Week 1",
+			SectionID: &sectionID,
+			Attachments: []schema.Attachment{{
+				ID:  uuid.New(),
+				URL: "https://bucket.s3.region.amazonaws.com/attachments%2Fmaterial%2F" + uuid.NewString() + ".slides.pdf",
+			}},
+		}},
+		Assignments: []schema.Assignment{{ID: uuid.New(), CourseID: id, Title: "Homework"}},
+	}
+	sections := []schema.CourseSection{{ID: sectionID, CourseID: id, Title: "Basics"}}
+
+	suite.courseRepo.On("GetCloneSource", ctx, id).Return(source, nil)
+	suite.courseRepo.On("GetSections", ctx, id).Return(sections, nil)
+	suite.uploader.On("CopyFile", source.ImageURL, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "course/image/") && strings.HasSuffix(key, ".cover.png")
+	})).Return("https://copied/cover.png", nil)
+	suite.uploader.On("CopyFile", source.Materials[0].Attachments[0].URL, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "attachments/material/") && strings.HasSuffix(key, ".slides.pdf")
+	})).Return("https://copied/slides.pdf", nil)
+	suite.courseRepo.On("CreateWithContent", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	clone, err := suite.courseUseCase.Clone(ctx, id)
+
+	assert.NoError(suite.T(), err)
+	assert.NotEqual(suite.T(), id, clone.ID)
+	assert.Equal(suite.T(), "Go Basics (Copy)", clone.Title)
+	assert.Equal(suite.T(), schema.CourseStatusDraft, clone.Status)
+	assert.Equal(suite.T(), "https://copied/cover.png", clone.ImageURL)
+	assert.Len(suite.T(), clone.Materials, 1)
+	assert.NotEqual(suite.T(), materialID, clone.Materials[0].ID)
+	assert.Equal(suite.T(), clone.ID, clone.Materials[0].CourseID)
+	assert.Equal(suite.T(), "https://copied/slides.pdf", clone.Materials[0].Attachments[0].URL)
+	assert.Equal(suite.T(), clone.Materials[0].ID, *clone.Materials[0].Attachments[0].MaterialID)
+	assert.Len(suite.T(), clone.Assignments, 1)
+	assert.Equal(suite.T(), clone.ID, clone.Assignments[0].CourseID)
+
+	clonedSections := suite.courseRepo.Calls[len(suite.courseRepo.Calls)-1].Arguments.Get(2).([]schema.CourseSection)
+	assert.Len(suite.T(), clonedSections, 1)
+	assert.NotEqual(suite.T(), sectionID, clonedSections[0].ID)
+	assert.Equal(suite.T(), clonedSections[0].ID, *clone.Materials[0].SectionID)
+	suite.uploader.AssertExpectations(suite.T())
+}
+
+func (suite *CourseUseCaseTestSuite) TestClone_RemovesCopiesWhenCopyFails() {
+	ctx := context.Background()
+	id := uuid.New()
+	source := schema.Course{
+		ID:          id,
+		Title:       "Go Basics",
+		ImageURL:    "https://bucket.s3.region.amazonaws.com/course%2Fimage%2F" + id.String() + ".cover.png",
+		SyllabusURL: "https://bucket.s3.region.amazonaws.com/course%2Fsyllabus%2F" + id.String() + ".syllabus.pdf",
+	}
+	suite.courseRepo.On("GetCloneSource", ctx, id).Return(source, nil)
+	suite.courseRepo.On("GetSections", ctx, id).Return([]schema.CourseSection{}, nil)
+	suite.uploader.On("CopyFile", source.ImageURL, mock.Anything).Return("https://copied/cover.png", nil)
+	suite.uploader.On("CopyFile", source.SyllabusURL, mock.Anything).Return("", errors.New("s3 unavailable"))
+	suite.uploader.On("DeleteFile", "https://copied/cover.png").Return(nil)
+
+	_, err := suite.courseUseCase.Clone(ctx, id)
+
+	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
+	suite.uploader.AssertExpectations(suite.T())
+	suite.courseRepo.AssertNotCalled(suite.T(), "CreateWithContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestClone_RemovesCopiesWhenSaveFails() {
+	ctx := context.Background()
+	id := uuid.New()
+	source := schema.Course{
+		ID:       id,
+		Title:    "Go Basics",
+		ImageURL: "https://bucket.s3.region.amazonaws.com/course%2Fimage%2F" + id.String() + ".cover.png",
+		Materials: []schema.Material{{ID: uuid.New(), CourseID: id, Title: "Week 1", Attachments: []schema.Attachment{{
+			ID:  uuid.New(),
+			URL: "https://bucket.s3.region.amazonaws.com/attachments%2Fmaterial%2F" + uuid.NewString() + ".slides.pdf",
+		}}}},
+	}
+	suite.courseRepo.On("GetCloneSource", ctx, id).Return(source, nil)
+	suite.courseRepo.On("GetSections", ctx, id).Return([]schema.CourseSection{}, nil)
+	suite.uploader.On("CopyFile", source.ImageURL, mock.Anything).Return("https://copied/cover.png", nil)
+	suite.uploader.On("CopyFile", source.Materials[0].Attachments[0].URL, mock.Anything).Return("https://copied/slides.pdf", nil)
+	suite.courseRepo.On("CreateWithContent", ctx, mock.Anything, mock.Anything).Return(errors.New("db down"))
+	suite.uploader.On("DeleteFile", "https://copied/cover.png").Return(nil)
+	suite.uploader.On("DeleteFile", "https://copied/slides.pdf").Return(nil)
+
+	_, err := suite.courseUseCase.Clone(ctx, id)
+
+	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
+	suite.uploader.AssertExpectations(suite.T())
+}
+
+func (suite *CourseUseCaseTestSuite) TestClone_NotFound() {
+	ctx := context.Background()
+	id := uuid.New()
+	suite.courseRepo.On("GetCloneSource", ctx, id).Return(schema.Course{}, gorm.ErrRecordNotFound)
+
+	_, err := suite.courseUseCase.Clone(ctx, id)
+
+	assert.Equal(suite.T(), ErrCourseNotFound.Build(), err)
+}
+
+func TestCloneTitle_Truncates(t *testing.T) {
+	title := cloneTitle(strings.Repeat("a", maxTitleLength))
+
+	assert.Len(t, []rune(title), maxTitleLength)
+	assert.True(t, strings.HasSuffix(title, cloneTitleSuffix))
+}
+
+// archiveFileHeader wraps archive bytes in a multipart file header as if they had been uploaded
+func archiveFileHeader(t *testing.T, data []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("archive", "course.zip")
+	assert.NoError(t, err)
+	_, err = part.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(int64(len(data)) + 1024)
+	assert.NoError(t, err)
+	return form.File["archive"][0]
+}
+
+func buildArchive(t *testing.T, manifest string, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("manifest.json")
+	assert.NoError(t, err)
+	_, err = w.Write([]byte(manifest))
+	assert.NoError(t, err)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func (suite *CourseUseCaseTestSuite) TestExportImport_RoundTrip() {
+	ctx := context.Background()
+	id := uuid.New()
+	sectionID := uuid.New()
+	png := []byte("\x89PNG\r\n\x1a\nimage-bytes")
+	imageURL := "https://bucket.s3.region.amazonaws.com/course%2Fimage%2F" + id.String() + ".cover.png"
+	slidesURL := "https://bucket.s3.region.amazonaws.com/attachments%2Fmaterial%2F" + uuid.NewString() + ".slides.txt"
+	source := schema.Course{
+		ID:         id,
+		Title:      "Go Basics",
+		Price:      1000,
+		Difficulty: schema.Beginner,
+		Language:   "en",
+		ImageURL:   imageURL,
+		Category:   &schema.Category{ID: suite.categoryID, Slug: "programming"},
+		Tags:       []schema.Tag{{ID: uuid.New(), Name: "Go", Slug: "go"}},
+		Materials: []schema.Material{{
+			ID:          uuid.New(),
+			CourseID:    id,
+			Title:       "Week 1",
+			SectionID:   &sectionID,
+			Attachments: []schema.Attachment{{ID: uuid.New(), URL: slidesURL, Description: "Slides"}},
+		}},
+		Assignments: []schema.Assignment{{ID: uuid.New(), CourseID: id, Title: "Homework"}},
+	}
+	suite.courseRepo.On("GetCloneSource", ctx, id).Return(source, nil)
+	suite.courseRepo.On("GetSections", ctx, id).Return([]schema.CourseSection{{ID: sectionID, CourseID: id, Title: "Basics"}}, nil)
+	suite.uploader.On("DownloadFile", imageURL).Return(png, nil)
+	suite.uploader.On("DownloadFile", slidesURL).Return([]byte("slide text"), nil)
+
+	archive, err := suite.courseUseCase.Export(ctx, id)
+	assert.NoError(suite.T(), err)
+
+	instructorID := uuid.New()
+	importCtx := context.WithValue(ctx, "user.id", instructorID.String())
+	tag := schema.Tag{ID: uuid.New(), Name: "Go", Slug: "go"}
+	suite.taxonomyRepo.On("GetCategoryBySlug", "programming").Return(&schema.Category{ID: suite.categoryID}, nil)
+	suite.taxonomyRepo.On("FindOrCreateTags", mock.Anything).Return([]schema.Tag{tag}, nil)
+	suite.uploader.On("UploadBytes", mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "course/image/") && strings.HasSuffix(key, ".cover.png")
+	}), png, "image/png").Return("https://new/cover.png", nil)
+	suite.uploader.On("UploadBytes", mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "attachments/material/") && strings.HasSuffix(key, ".slides.txt")
+	}), []byte("slide text"), mock.Anything).Return("https://new/slides.txt", nil)
+	suite.courseRepo.On("CreateWithContent", importCtx, mock.Anything, mock.Anything).Return(nil)
+
+	course, err := suite.courseUseCase.Import(importCtx, archiveFileHeader(suite.T(), archive))
+
+	assert.NoError(suite.T(), err)
+	assert.NotEqual(suite.T(), id, course.ID)
+	assert.Equal(suite.T(), instructorID, course.InstructorID)
+	assert.Equal(suite.T(), "Go Basics", course.Title)
+	assert.Equal(suite.T(), schema.CourseStatusDraft, course.Status)
+	assert.Equal(suite.T(), suite.categoryID, *course.CategoryID)
+	assert.Equal(suite.T(), []schema.Tag{tag}, course.Tags)
+	assert.Equal(suite.T(), "https://new/cover.png", course.ImageURL)
+	assert.Len(suite.T(), course.Materials, 1)
+	assert.Equal(suite.T(), "https://new/slides.txt", course.Materials[0].Attachments[0].URL)
+	assert.Equal(suite.T(), "Slides", course.Materials[0].Attachments[0].Description)
+	assert.Len(suite.T(), course.Assignments, 1)
+
+	sections := suite.courseRepo.Calls[len(suite.courseRepo.Calls)-1].Arguments.Get(2).([]schema.CourseSection)
+	assert.Len(suite.T(), sections, 1)
+	assert.Equal(suite.T(), sections[0].ID, *course.Materials[0].SectionID)
+	assert.Equal(suite.T(), course.ID, sections[0].CourseID)
+}
+
+func (suite *CourseUseCaseTestSuite) TestImport_UnknownSection() {
+	ctx := context.WithValue(context.Background(), "user.id", uuid.NewString())
+	manifest := `{"format":"seatudy-course","version":1,
+		"course":{"title":"Go","difficulty":"beginner"},
+		"materials":[{"title":"Week 1","section":2}]}`
+
+	_, err := suite.courseUseCase.Import(ctx, archiveFileHeader(suite.T(), buildArchive(suite.T(), manifest, nil)))
+
+	assert.Equal(suite.T(), ErrInvalidArchive.Build().Error(), err.Error())
+	suite.courseRepo.AssertNotCalled(suite.T(), "CreateWithContent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestImport_MissingFile() {
+	ctx := context.WithValue(context.Background(), "user.id", uuid.NewString())
+	manifest := `{"format":"seatudy-course","version":1,
+		"course":{"title":"Go","difficulty":"beginner"},
+		"materials":[{"title":"Week 1","attachments":[{"path":"files/1/missing.pdf"}]}]}`
+
+	_, err := suite.courseUseCase.Import(ctx, archiveFileHeader(suite.T(), buildArchive(suite.T(), manifest, nil)))
+
+	assert.Equal(suite.T(), http.StatusBadRequest, apierror.GetHttpStatus(err))
+	suite.uploader.AssertNotCalled(suite.T(), "UploadBytes", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CourseUseCaseTestSuite) TestImport_WrongFormat() {
+	ctx := context.WithValue(context.Background(), "user.id", uuid.NewString())
+	manifest := `{"format":"other","version":1,"course":{"title":"Go","difficulty":"beginner"}}`
+
+	_, err := suite.courseUseCase.Import(ctx, archiveFileHeader(suite.T(), buildArchive(suite.T(), manifest, nil)))
+
+	assert.Equal(suite.T(), ErrInvalidArchive.Build().Error(), err.Error())
+}
+
+func (suite *CourseUseCaseTestSuite) TestImport_RemovesUploadsWhenCreateFails() {
+	ctx := context.WithValue(context.Background(), "user.id", uuid.NewString())
+	manifest := `{"format":"seatudy-course","version":1,
+		"course":{"title":"Go","difficulty":"beginner"},
+		"materials":[{"title":"Week 1","attachments":[{"path":"files/1/notes.txt"}]}]}`
+	archive := buildArchive(suite.T(), manifest, map[string][]byte{"files/1/notes.txt": []byte("notes")})
+
+	suite.uploader.On("UploadBytes", mock.Anything, []byte("notes"), mock.Anything).Return("https://new/notes.txt", nil)
+	suite.courseRepo.On("CreateWithContent", ctx, mock.Anything, mock.Anything).Return(errors.New("db down"))
+	suite.uploader.On("DeleteFile", "https://new/notes.txt").Return(nil)
+
+	_, err := suite.courseUseCase.Import(ctx, archiveFileHeader(suite.T(), archive))
+
+	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
+	suite.uploader.AssertExpectations(suite.T())
+}
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+const scorm12Manifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest identifier="course" xmlns="http://www.imsproject.org/xsd/imscp_rootv1p1p2"
+	xmlns:adlcp="http://www.adlnet.org/xsd/adlcp_rootv1p2">
+	<metadata>
+		<schema>ADL SCORM</schema>
+		<schemaversion>1.2</schemaversion>
+	</metadata>
+	<organizations default="org">
+		<organization identifier="org">
+			<title>Safety Basics</title>
+			<item identifier="welcome" identifierref="res-welcome"><title>Welcome</title></item>
+			<item identifier="module-1">
+				<title>Module 1</title>
+				<item identifier="lesson-1" identifierref="res-lesson"><title>Lesson 1</title></item>
+				<item identifier="group">
+					<title>Group</title>
+					<item identifier="lesson-2" identifierref="res-partial"><title>Lesson 2</title></item>
+				</item>
+			</item>
+		</organization>
+	</organizations>
+	<resources>
+		<resource identifier="res-welcome" type="webcontent" adlcp:scormtype="asset" href="welcome.html">
+			<file href="welcome.html"/>
+		</resource>
+		<resource identifier="res-lesson" type="webcontent" adlcp:scormtype="sco" xml:base="lesson/" href="index.html?page=1">
+			<file href="index.html"/>
+			<file href="style.css"/>
+		</resource>
+		<resource identifier="res-partial" type="webcontent" adlcp:scormtype="asset" href="gone.html">
+			<file href="gone.html"/>
+			<file href="notes.txt"/>
+		</resource>
+	</resources>
+</manifest>`
+
+func (suite *CourseUseCaseTestSuite) importPackageRequest(files map[string]string) *ImportPackageRequest {
+	header := archiveFileHeader(suite.T(), buildZip(suite.T(), files))
+	header.Filename = "safety.zip"
+	return &ImportPackageRequest{
+		Package:    header,
+		Price:      500,
+		Difficulty: schema.Beginner,
+		CategoryID: suite.categoryID.String(),
+	}
+}
+
+func (suite *CourseUseCaseTestSuite) TestImportPackage_SCORM12() {
+	instructorID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", instructorID.String())
+	req := suite.importPackageRequest(map[string]string{
+		"imsmanifest.xml":   scorm12Manifest,
+		"welcome.html":      "<p>hi</p>",
+		"lesson/index.html": "<p>lesson</p>",
+		"lesson/style.css":  "p {}",
+		"notes.txt":         "notes",
+	})
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
+	suite.uploader.On("UploadBytes", mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "attachments/material/")
+	}), mock.Anything, mock.Anything).Return("https://new/file", nil)
+	suite.courseRepo.On("CreateWithContent", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	res, err := suite.courseUseCase.ImportPackage(ctx, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), contentpackage.StandardSCORM12, res.Standard)
+	course := res.Course
+	assert.Equal(suite.T(), "Safety Basics", course.Title)
+	assert.Equal(suite.T(), instructorID, course.InstructorID)
+	assert.Equal(suite.T(), int64(500), course.Price)
+	assert.Equal(suite.T(), schema.CourseStatusDraft, course.Status)
+	assert.Equal(suite.T(), suite.categoryID, *course.CategoryID)
+
+	sections := suite.courseRepo.Calls[len(suite.courseRepo.Calls)-1].Arguments.Get(2).([]schema.CourseSection)
+	assert.Len(suite.T(), sections, 1)
+	assert.Equal(suite.T(), "Module 1", sections[0].Title)
+
+	assert.Len(suite.T(), course.Materials, 3)
+	assert.Equal(suite.T(), "Welcome", course.Materials[0].Title)
+	assert.Nil(suite.T(), course.Materials[0].SectionID)
+	assert.Len(suite.T(), course.Materials[0].Attachments, 1)
+	assert.Equal(suite.T(), "Lesson 1", course.Materials[1].Title)
+	assert.Equal(suite.T(), sections[0].ID, *course.Materials[1].SectionID)
+	assert.Len(suite.T(), course.Materials[1].Attachments, 2)
+	// The nested item is flattened into its top-level module
+	assert.Equal(suite.T(), "Lesson 2", course.Materials[2].Title)
+	assert.Equal(suite.T(), 1, course.Materials[2].Position)
+	assert.Len(suite.T(), course.Materials[2].Attachments, 1)
+
+	assert.Len(suite.T(), res.Unsupported, 2)
+	assert.Equal(suite.T(), "lesson-1", res.Unsupported[0].Identifier)
+	assert.Contains(suite.T(), res.Unsupported[0].Reason, "SCORM runtime")
+	assert.Equal(suite.T(), "lesson-2", res.Unsupported[1].Identifier)
+	assert.Contains(suite.T(), res.Unsupported[1].Reason, "gone.html")
+}
+
+const cartridgeManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest identifier="cc" xmlns="http://www.imsglobal.org/xsd/imsccv1p1/imscp_v1p1"
+	xmlns:lomimscc="http://ltsc.ieee.org/xsd/imsccv1p1/LOM/manifest">
+	<metadata>
+		<schema>IMS Common Cartridge</schema>
+		<schemaversion>1.1.0</schemaversion>
+		<lomimscc:lom>
+			<lomimscc:general>
+				<lomimscc:title><lomimscc:string language="en">Intro to Go</lomimscc:string></lomimscc:title>
+				<lomimscc:description><lomimscc:string>Learn Go.</lomimscc:string></lomimscc:description>
+			</lomimscc:general>
+		</lomimscc:lom>
+	</metadata>
+	<organizations>
+		<organization identifier="org" structure="rooted-hierarchy">
+			<item identifier="root">
+				<item identifier="week-1">
+					<title>Week 1</title>
+					<item identifier="reading" identifierref="res-reading"><title>Reading</title></item>
+					<item identifier="link" identifierref="res-link"><title>Go Tour</title></item>
+					<item identifier="quiz" identifierref="res-quiz"><title>Quiz 1</title></item>
+					<item identifier="forum" identifierref="res-forum"><title>Say hello</title></item>
+				</item>
+			</item>
+		</organization>
+	</organizations>
+	<resources>
+		<resource identifier="res-reading" type="webcontent" href="week1/reading.html">
+			<file href="week1/reading.html"/>
+		</resource>
+		<resource identifier="res-link" type="imswl_xmlv1p1">
+			<file href="links/tour.xml"/>
+		</resource>
+		<resource identifier="res-quiz" type="imsqti_xmlv1p2/imscc_xmlv1p1/assessment">
+			<file href="quiz/assessment.xml"/>
+		</resource>
+		<resource identifier="res-forum" type="imsdt_xmlv1p1">
+			<file href="forum.xml"/>
+		</resource>
+	</resources>
+</manifest>`
+
+func (suite *CourseUseCaseTestSuite) TestImportPackage_CommonCartridgeInFolder() {
+	ctx := context.WithValue(context.Background(), "user.id", uuid.NewString())
+	req := suite.importPackageRequest(map[string]string{
+		"export/imsmanifest.xml":    cartridgeManifest,
+		"export/week1/reading.html": "<p>read</p>",
+		"export/links/tour.xml": `<webLink xmlns="http://www.imsglobal.org/xsd/imsccv1p1/imswl_v1p1">
+			<title>Go Tour</title><url href="https://go.dev/tour"/></webLink>`,
+		"export/quiz/assessment.xml": "<questestinterop/>",
+		"export/forum.xml":           "<topic/>",
+	})
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
+	suite.uploader.On("UploadBytes", mock.MatchedBy(func(key string) bool {
+		return strings.HasSuffix(key, ".reading.html")
+	}), []byte("<p>read</p>"), mock.Anything).Return("https://new/reading.html", nil)
+	suite.courseRepo.On("CreateWithContent", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	res, err := suite.courseUseCase.ImportPackage(ctx, req)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), contentpackage.StandardCommonCartridge, res.Standard)
+	assert.Equal(suite.T(), "Intro to Go", res.Course.Title)
+	assert.Equal(suite.T(), "Learn Go.", res.Course.Description)
+	assert.Len(suite.T(), res.Course.Materials, 2)
+	assert.Equal(suite.T(), "https://new/reading.html", res.Course.Materials[0].Attachments[0].URL)
+	assert.Equal(suite.T(), "Go Tour", res.Course.Materials[1].Title)
+	assert.Equal(suite.T(), "https://go.dev/tour", res.Course.Materials[1].Description)
+	assert.Empty(suite.T(), res.Course.Materials[1].Attachments)
+
+	assert.Len(suite.T(), res.Unsupported, 2)
+	assert.Equal(suite.T(), "quiz", res.Unsupported[0].Identifier)
+	assert.Equal(suite.T(), "forum", res.Unsupported[1].Identifier)
+}
+
+func (suite *CourseUseCaseTestSuite) TestImportPackage_NoManifest() {
+	ctx := context.WithValue(context.Background(), "user.id", uuid.NewString())
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
+
+	_, err := suite.courseUseCase.ImportPackage(ctx, suite.importPackageRequest(map[string]string{"index.html": "<p></p>"}))
+
+	assert.Equal(suite.T(), ErrInvalidArchive.Build().Error(), err.Error())
+}
+
+func (suite *CourseUseCaseTestSuite) TestImportPackage_NothingImportable() {
+	ctx := context.WithValue(context.Background(), "user.id", uuid.NewString())
+	suite.taxonomyRepo.On("GetCategoryByID", suite.categoryID).Return(&schema.Category{ID: suite.categoryID}, nil)
+	manifest := `<manifest><metadata><schema>IMS Common Cartridge</schema></metadata>
+		<organizations><organization identifier="o">
+			<item identifier="quiz" identifierref="res-quiz"><title>Quiz</title></item>
+		</organization></organizations>
+		<resources><resource identifier="res-quiz" type="imsqti_xmlv1p2/imscc_xmlv1p1/assessment"/></resources>
+	</manifest>`
+
+	_, err := suite.courseUseCase.ImportPackage(ctx, suite.importPackageRequest(map[string]string{"imsmanifest.xml": manifest}))
+
+	assert.Equal(suite.T(), ErrInvalidArchive.Build().Error(), err.Error())
+	suite.courseRepo.AssertNotCalled(suite.T(), "CreateWithContent", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -2,7 +2,10 @@ package course
 
 import (
 	"mime/multipart"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/contentpackage"
 	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 )
@@ -14,7 +17,10 @@ type CreateCourseRequest struct {
 	Image       *multipart.FileHeader   `form:"image"`
 	Syllabus    *multipart.FileHeader   `form:"syllabus"`
 	Difficulty  schema.CourseDifficulty `form:"difficulty" binding:"required,oneof=beginner intermediate advanced expert"`
-	Category    schema.CourseCategory   `form:"category" binding:"required,oneof='Web Development' 'Game Development' 'Cloud Computing' 'Data Science & Analytics' 'Programming Languages' 'Cybersecurity' 'Mobile App Development' 'Database Management' 'Software Development' 'DevOps & Automation' 'Networking' 'AI & Machine Learning' 'Internet of Things (IoT)' 'Blockchain & Cryptocurrency' 'Augmented Reality (AR) & Virtual Reality (VR)'"`
+	CategoryID  string                  `form:"category_id" binding:"required,uuid"`
+	Tags        []string                `form:"tags" binding:"omitempty,max=10,dive,min=1,max=50"`
+	Language    string                  `form:"language" binding:"omitempty,max=10,bcp47_language_tag"`
+	MaxSeats    *int                    `form:"max_seats" binding:"omitempty,min=1,max=100000"`
 }
 
 type UpdateCourseRequest struct {
@@ -24,7 +30,10 @@ type UpdateCourseRequest struct {
 	Image       *multipart.FileHeader    `form:"image,omitempty"`    // Handled separately, not through direct JSON binding
 	Syllabus    *multipart.FileHeader    `form:"syllabus,omitempty"` // Handled separately
 	Difficulty  *schema.CourseDifficulty `form:"difficulty,omitempty" binding:"omitempty,oneof=beginner intermediate advanced expert"`
-	Category    *schema.CourseCategory   `form:"category" binding:"required,oneof='Web Development' 'Game Development' 'Cloud Computing' 'Data Science & Analytics' 'Programming Languages' 'Cybersecurity' 'Mobile App Development' 'Database Management' 'Software Development' 'DevOps & Automation' 'Networking' 'AI & Machine Learning' 'Internet of Things (IoT)' 'Blockchain & Cryptocurrency' 'Augmented Reality (AR) & Virtual Reality (VR)'"`
+	CategoryID  *string                  `form:"category_id,omitempty" binding:"omitempty,uuid"`
+	Tags        []string                 `form:"tags,omitempty" binding:"omitempty,max=10,dive,min=1,max=50"`
+	Language    *string                  `form:"language,omitempty" binding:"omitempty,max=10,bcp47_language_tag"`
+	MaxSeats    *int                     `form:"max_seats,omitempty" binding:"omitempty,min=0,max=100000"`
 }
 
 type CoursesPaginatedResponse struct {
@@ -47,16 +56,216 @@ type CourseProgressResponse struct {
 }
 
 type SearchPaginationRequest struct {
-	Title  string  `form:"title" binding:"required"` 
-    Page   int     `form:"page" binding:"required,min=1"`
-	Limit  int     `form:"limit" binding:"required,min=1,max=30"`
+	// Title is matched against the title, description, material titles and instructor name
+	Title string `form:"title" binding:"required,max=100"`
+	Page  int    `form:"page" binding:"required,min=1"`
+	Limit int    `form:"limit" binding:"required,min=1,max=30"`
 }
 
+// SearchHit is a course matched by a search, with its matched terms wrapped in <mark> tags
+type SearchHit struct {
+	schema.Course
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+type SearchCoursesResponse struct {
+	Courses    []SearchHit           `json:"courses"`
+	Pagination pagination.Pagination `json:"pagination"`
+}
+
+const defaultSuggestionLimit = 8
+
+type SuggestRequest struct {
+	Query string `form:"q" binding:"required,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+type Suggestion struct {
+	Type string    `json:"type"`
+	ID   uuid.UUID `json:"id"`
+	Text string    `json:"text"`
+}
+
+// FilterCoursesRequest combines any number of catalog facets; list parameters may be repeated, e.g. ?category=a&category=b
 type FilterCoursesRequest struct {
-    Rating    *float32              `form:"rating" binding:"omitempty,min=0,max=5"`
-    Category  *string `form:"category" binding:"omitempty,oneof='Web Development' 'Game Development' 'Cloud Computing' 'Data Science & Analytics' 'Programming Languages' 'Cybersecurity' 'Mobile App Development' 'Database Management' 'Software Development' 'DevOps & Automation' 'Networking' 'AI & Machine Learning' 'Internet of Things (IoT)' 'Blockchain & Cryptocurrency' 'Augmented Reality (AR) & Virtual Reality (VR)'"`
-    Difficulty *string `form:"difficulty" binding:"omitempty,oneof=beginner intermediate advanced expert"`
-    Sort      *string               `form:"sort" binding:"omitempty,oneof=highest lowest"` 
-    Page      int                  `form:"page" binding:"required,min=1"`
-    Limit     int                  `form:"limit" binding:"required,min=1,max=50"`
-}
\ No newline at end of file
+	// Categories are category slugs; a category also matches the courses of its subcategories
+	Categories   []string `form:"category" binding:"omitempty,dive,max=120"`
+	Tags         []string `form:"tag" binding:"omitempty,dive,max=60"`
+	Difficulties []string `form:"difficulty" binding:"omitempty,dive,oneof=beginner intermediate advanced expert"`
+	Languages    []string `form:"language" binding:"omitempty,dive,max=10"`
+	// Price filters and sorts apply to the effective price, so a course on sale matches its sale price
+	MinPrice     *int64   `form:"min_price" binding:"omitempty,gte=0"`
+	MaxPrice     *int64   `form:"max_price" binding:"omitempty,gte=0"`
+	FreeOnly     bool     `form:"free_only"`
+	MinRating    *float32 `form:"min_rating" binding:"omitempty,min=0,max=5"`
+	InstructorID string   `form:"instructor_id" binding:"omitempty,uuid"`
+	// Sort defaults to newest; highest and lowest sort by rating
+	Sort  string `form:"sort" binding:"omitempty,oneof=highest lowest price_asc price_desc newest popularity"`
+	Page  int    `form:"page" binding:"required,min=1"`
+	Limit int    `form:"limit" binding:"required,min=1,max=50"`
+}
+
+// CatalogFilter is the parsed form of FilterCoursesRequest handed to the repository
+type CatalogFilter struct {
+	Categories   []string
+	Tags         []string
+	Difficulties []string
+	Languages    []string
+	MinPrice     *int64
+	MaxPrice     *int64
+	FreeOnly     bool
+	MinRating    *float32
+	InstructorID *uuid.UUID
+	Sort         string
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// CatalogFacets counts matching courses per facet value. Each facet ignores its own filter
+// so the client can show how many results picking another value would give.
+type CatalogFacets struct {
+	Categories   []FacetCount `json:"categories"`
+	Tags         []FacetCount `json:"tags"`
+	Difficulties []FacetCount `json:"difficulties"`
+	Languages    []FacetCount `json:"languages"`
+	Prices       []FacetCount `json:"prices"`
+	Ratings      []FacetCount `json:"ratings"`
+}
+
+type CatalogResponse struct {
+	Courses    []schema.Course       `json:"courses"`
+	Pagination pagination.Pagination `json:"pagination"`
+	Facets     CatalogFacets         `json:"facets"`
+}
+// SetCourseTagsRequest replaces every tag on a course; an empty list removes them all
+type SetCourseTagsRequest struct {
+	Tags []string `json:"tags" binding:"max=10,dive,min=1,max=50"`
+}
+
+// maxPrerequisites caps how many courses a single course can depend on
+const maxPrerequisites = 10
+
+type PrerequisiteInput struct {
+	CourseID string `json:"course_id" binding:"required,uuid"`
+	Required bool   `json:"required"`
+}
+
+// SetPrerequisitesRequest replaces every prerequisite of a course; an empty list removes them all
+type SetPrerequisitesRequest struct {
+	Prerequisites []PrerequisiteInput `json:"prerequisites" binding:"max=10,dive"`
+}
+
+type PrerequisiteCourse struct {
+	ID       uuid.UUID           `json:"id"`
+	Title    string              `json:"title"`
+	ImageURL string              `json:"image_url"`
+	Status   schema.CourseStatus `json:"status"`
+	Required bool                `json:"required"`
+}
+
+type PrerequisiteStatus struct {
+	PrerequisiteCourse
+	Completed bool `json:"completed"`
+}
+
+type PrerequisitesResponse struct {
+	CourseID      uuid.UUID            `json:"course_id"`
+	Prerequisites []PrerequisiteStatus `json:"prerequisites"`
+	// Missing lists the required prerequisites the caller has not completed; anonymous callers miss all of them
+	Missing     []uuid.UUID `json:"missing"`
+	CanPurchase bool        `json:"can_purchase"`
+}
+
+type ChangeCourseStatusRequest struct {
+	Status schema.CourseStatus `json:"status" binding:"required,oneof=draft in_review published unlisted archived"`
+}
+
+type ReviewCourseRequest struct {
+	Approve *bool  `json:"approve" binding:"required"`
+	Note    string `json:"note" binding:"max=500"`
+}
+
+type ImportCourseRequest struct {
+	Archive *multipart.FileHeader `form:"archive" binding:"required"`
+}
+
+// ImportPackageRequest carries a SCORM or Common Cartridge package. Packages say nothing about pricing or how a course
+// is classified here, so those come with the upload.
+type ImportPackageRequest struct {
+	Package    *multipart.FileHeader   `form:"package" binding:"required"`
+	Price      int64                   `form:"price" binding:"gte=0"`
+	Difficulty schema.CourseDifficulty `form:"difficulty" binding:"required,oneof=beginner intermediate advanced expert"`
+	CategoryID string                  `form:"category_id" binding:"required,uuid"`
+	Tags       []string                `form:"tags" binding:"omitempty,max=10,dive,min=1,max=50"`
+	Language   string                  `form:"language" binding:"omitempty,max=10,bcp47_language_tag"`
+}
+
+type ImportPackageResponse struct {
+	Course   *schema.Course          `json:"course"`
+	Standard contentpackage.Standard `json:"standard"`
+	// Unsupported lists package items that were skipped or only partly converted
+	Unsupported []contentpackage.Unsupported `json:"unsupported"`
+}
+
+// ArchiveManifest is the manifest.json of a course archive. Sections are referred to by their index in Sections and
+// files by their path inside the archive, so an archive carries no IDs from the server that produced it.
+type ArchiveManifest struct {
+	Format      string              `json:"format" binding:"required,eq=seatudy-course"`
+	Version     int                 `json:"version" binding:"required,eq=1"`
+	ExportedAt  time.Time           `json:"exported_at"`
+	Course      ArchiveCourse       `json:"course"`
+	Sections    []ArchiveSection    `json:"sections" binding:"max=200,dive"`
+	Materials   []ArchiveMaterial   `json:"materials" binding:"max=1000,dive"`
+	Assignments []ArchiveAssignment `json:"assignments" binding:"max=1000,dive"`
+}
+
+type ArchiveCourse struct {
+	Title       string                  `json:"title" binding:"required,max=100"`
+	Description string                  `json:"description" binding:"max=1000"`
+	Price       int64                   `json:"price" binding:"gte=0"`
+	Difficulty  schema.CourseDifficulty `json:"difficulty" binding:"required,oneof=beginner intermediate advanced expert"`
+	Language    string                  `json:"language" binding:"omitempty,max=10,bcp47_language_tag"`
+	MaxSeats    *int                    `json:"max_seats,omitempty" binding:"omitempty,min=1,max=100000"`
+	// Category is a slug; an archive from another server may name a category that does not exist here
+	Category string   `json:"category,omitempty" binding:"max=120"`
+	Tags     []string `json:"tags" binding:"omitempty,max=10,dive,min=1,max=50"`
+	Image    string   `json:"image,omitempty"`
+	Syllabus string   `json:"syllabus,omitempty"`
+}
+
+type ArchiveSection struct {
+	Title            string     `json:"title" binding:"required,max=150"`
+	Position         int        `json:"position"`
+	ReleaseAt        *time.Time `json:"release_at,omitempty"`
+	ReleaseAfterDays *int       `json:"release_after_days,omitempty" binding:"omitempty,min=0,max=3650"`
+}
+
+type ArchiveFile struct {
+	Path        string `json:"path" binding:"required"`
+	Description string `json:"description" binding:"max=1000"`
+}
+
+type ArchiveMaterial struct {
+	Title            string        `json:"title" binding:"required,max=150"`
+	Description      string        `json:"description" binding:"max=2000"`
+	Section          *int          `json:"section,omitempty" binding:"omitempty,min=0"`
+	Position         int           `json:"position"`
+	ReleaseAt        *time.Time    `json:"release_at,omitempty"`
+	ReleaseAfterDays *int          `json:"release_after_days,omitempty" binding:"omitempty,min=0,max=3650"`
+	Attachments      []ArchiveFile `json:"attachments" binding:"max=50,dive"`
+}
+
+type ArchiveAssignment struct {
+	Title        string        `json:"title" binding:"required,max=150"`
+	Description  string        `json:"description" binding:"max=2000"`
+	Due          *time.Time    `json:"due,omitempty"`
+	DueAfterDays *int          `json:"due_after_days,omitempty" binding:"omitempty,min=0,max=3650"`
+	Section      *int          `json:"section,omitempty" binding:"omitempty,min=0"`
+	Position     int           `json:"position"`
+	Attachments  []ArchiveFile `json:"attachments" binding:"max=50,dive"`
+}
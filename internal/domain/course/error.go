@@ -46,4 +46,36 @@ var (
 	ErrAlreadyEnrolled = apierror.NewApiErrorBuilder().
 				WithHttpStatus(http.StatusBadRequest).
 				WithMessage("COURSE_ALREADY_ENROLLED")
+
+	ErrCourseNotPurchasable = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("COURSE_NOT_PURCHASABLE")
+
+	ErrInvalidStatusTransition = apierror.NewApiErrorBuilder().
+					WithHttpStatus(http.StatusConflict).
+					WithMessage("INVALID_STATUS_TRANSITION")
+
+	ErrCourseNotReady = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusUnprocessableEntity).
+				WithMessage("COURSE_NOT_READY")
+
+	ErrCourseNotInReview = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("COURSE_NOT_IN_REVIEW")
+
+	ErrInvalidPrerequisite = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("INVALID_PREREQUISITE")
+
+	ErrPrerequisiteCycle = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("PREREQUISITE_CYCLE")
+
+	ErrPrerequisitesNotMet = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusForbidden).
+				WithMessage("PREREQUISITES_NOT_MET")
+
+	ErrInvalidArchive = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("INVALID_COURSE_ARCHIVE")
 )
@@ -0,0 +1,176 @@
+package course
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+// allowedStatusTransitions lists the statuses an instructor may move a course to from its current status.
+// Leaving in_review is done by an admin through ReviewCourse, except for withdrawing back to draft.
+func allowedStatusTransitions(from schema.CourseStatus, reviewRequired bool) []schema.CourseStatus {
+	switch from {
+	case schema.CourseStatusDraft:
+		if reviewRequired {
+			return []schema.CourseStatus{schema.CourseStatusInReview, schema.CourseStatusArchived}
+		}
+		return []schema.CourseStatus{schema.CourseStatusPublished, schema.CourseStatusArchived}
+	case schema.CourseStatusInReview:
+		return []schema.CourseStatus{schema.CourseStatusDraft}
+	case schema.CourseStatusPublished:
+		return []schema.CourseStatus{schema.CourseStatusUnlisted, schema.CourseStatusArchived}
+	case schema.CourseStatusUnlisted:
+		return []schema.CourseStatus{schema.CourseStatusPublished, schema.CourseStatusArchived}
+	case schema.CourseStatusArchived:
+		return []schema.CourseStatus{schema.CourseStatusDraft}
+	}
+	return nil
+}
+
+// checkPublishReadiness reports what a course is missing before it can be shown in the catalog
+func checkPublishReadiness(course *schema.Course) error {
+	var missing []string
+	if len(course.Materials) == 0 {
+		missing = append(missing, "MATERIAL")
+	}
+	if course.ImageURL == "" {
+		missing = append(missing, "IMAGE")
+	}
+	if course.Description == "" {
+		missing = append(missing, "DESCRIPTION")
+	}
+
+	if len(missing) > 0 {
+		return ErrCourseNotReady.WithPayload(map[string]any{"missing": missing}).Build()
+	}
+	return nil
+}
+
+func (uc *UseCase) getCourse(ctx context.Context, id uuid.UUID) (*schema.Course, error) {
+	course, err := uc.courseRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course by id: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return &course, nil
+}
+
+func (uc *UseCase) ChangeStatus(ctx context.Context, id uuid.UUID, req ChangeCourseStatusRequest) (schema.Course, error) {
+	course, err := uc.getCourse(ctx, id)
+	if err != nil {
+		return schema.Course{}, err
+	}
+
+	allowed := allowedStatusTransitions(course.Status, config.Env.CourseReviewRequired)
+	if !slices.Contains(allowed, req.Status) {
+		return schema.Course{}, ErrInvalidStatusTransition.WithPayload(map[string]any{
+			"from":    course.Status,
+			"to":      req.Status,
+			"allowed": allowed,
+		}).Build()
+	}
+
+	if req.Status == schema.CourseStatusInReview || req.Status == schema.CourseStatusPublished {
+		if err := checkPublishReadiness(course); err != nil {
+			return schema.Course{}, err
+		}
+	}
+
+	course.Status = req.Status
+	if req.Status == schema.CourseStatusInReview {
+		course.ReviewNote = ""
+	}
+	if req.Status == schema.CourseStatusPublished && course.PublishedAt == nil {
+		now := time.Now()
+		course.PublishedAt = &now
+	}
+
+	if err := uc.courseRepo.UpdateStatus(ctx, course); err != nil {
+		log.Println("Error updating course status: ", err)
+		return schema.Course{}, apierror.ErrInternalServer.Build()
+	}
+
+	return *course, nil
+}
+
+func (uc *UseCase) GetReviewQueue(ctx context.Context, page, pageSize int) (CoursesPaginatedResponse, error) {
+	courses, total, err := uc.courseRepo.FindByStatus(ctx, schema.CourseStatusInReview, page, pageSize)
+	if err != nil {
+		log.Println("Error getting courses in review: ", err)
+		return CoursesPaginatedResponse{}, apierror.ErrInternalServer.Build()
+	}
+	return CoursesPaginatedResponse{
+		Courses:    courses,
+		Pagination: pagination.NewPagination(total, page, pageSize),
+	}, nil
+}
+
+func (uc *UseCase) ReviewCourse(ctx context.Context, id uuid.UUID, req ReviewCourseRequest) (schema.Course, error) {
+	course, err := uc.getCourse(ctx, id)
+	if err != nil {
+		return schema.Course{}, err
+	}
+
+	if !*req.Approve && req.Note == "" {
+		return schema.Course{}, apierror.ErrValidation.WithPayload("a note is required when rejecting a course").Build()
+	}
+
+	if course.Status != schema.CourseStatusInReview {
+		return schema.Course{}, ErrCourseNotInReview.Build()
+	}
+
+	var title, detail string
+	if *req.Approve {
+		if err := checkPublishReadiness(course); err != nil {
+			return schema.Course{}, err
+		}
+		now := time.Now()
+		course.Status = schema.CourseStatusPublished
+		course.ReviewNote = ""
+		if course.PublishedAt == nil {
+			course.PublishedAt = &now
+		}
+		title = "Your course has been published"
+		detail = fmt.Sprintf("%s was approved and is now listed in the catalog", course.Title)
+	} else {
+		course.Status = schema.CourseStatusDraft
+		course.ReviewNote = req.Note
+		title = "Your course needs changes"
+		detail = fmt.Sprintf("%s was returned to draft: %s", course.Title, req.Note)
+	}
+
+	if err := uc.courseRepo.UpdateStatus(ctx, course); err != nil {
+		log.Println("Error updating course status: ", err)
+		return schema.Course{}, apierror.ErrInternalServer.Build()
+	}
+
+	go func() {
+		notificationID, err := uuid.NewV7()
+		if err != nil {
+			return
+		}
+		if err := uc.notificationRepo.Create(&schema.Notification{
+			ID:     notificationID,
+			UserID: course.InstructorID,
+			Title:  title,
+			Detail: detail,
+		}); err != nil {
+			log.Println("Error creating notification: ", err)
+		}
+	}()
+
+	return *course, nil
+}
@@ -0,0 +1,151 @@
+package course
+
+import (
+	"archive/zip"
+	"context"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/contentpackage"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+)
+
+// Column sizes the text taken from a package is shortened to
+const (
+	maxDescriptionLength         = 1000
+	maxSectionTitleLength        = 150
+	maxMaterialDescriptionLength = 2000
+	maxAttachments               = 50
+)
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n]))
+}
+
+// packageManifest lays a parsed package out as an archive manifest, so it goes through the same checks and upload
+// steps as an exported course. Limits a package exceeds are added to its unsupported report.
+func packageManifest(pkg *contentpackage.Package, fallbackTitle string) *ArchiveManifest {
+	title := truncate(pkg.Title, maxTitleLength)
+	if title == "" {
+		title = truncate(fallbackTitle, maxTitleLength)
+	}
+
+	manifest := &ArchiveManifest{
+		Format:  archiveFormat,
+		Version: archiveVersion,
+		Course: ArchiveCourse{
+			Title:       title,
+			Description: truncate(pkg.Description, maxDescriptionLength),
+		},
+		Sections:  make([]ArchiveSection, len(pkg.Modules)),
+		Materials: make([]ArchiveMaterial, 0, len(pkg.Lessons)),
+	}
+
+	addLesson := func(lesson contentpackage.Lesson, section *int, position int) {
+		files := lesson.Files
+		if len(files) > maxAttachments {
+			pkg.Unsupported = append(pkg.Unsupported, contentpackage.Unsupported{
+				Title:  lesson.Title,
+				Reason: "only the first 50 files were imported",
+			})
+			files = files[:maxAttachments]
+		}
+		attachments := make([]ArchiveFile, len(files))
+		for i, file := range files {
+			attachments[i] = ArchiveFile{Path: file}
+		}
+		manifest.Materials = append(manifest.Materials, ArchiveMaterial{
+			Title:       truncate(lesson.Title, maxSectionTitleLength),
+			Description: truncate(lesson.Description, maxMaterialDescriptionLength),
+			Section:     section,
+			Position:    position,
+			Attachments: attachments,
+		})
+	}
+
+	for i, lesson := range pkg.Lessons {
+		addLesson(lesson, nil, i)
+	}
+	for i, module := range pkg.Modules {
+		manifest.Sections[i] = ArchiveSection{Title: truncate(module.Title, maxSectionTitleLength), Position: i}
+		for j, lesson := range module.Lessons {
+			addLesson(lesson, &i, j)
+		}
+	}
+	return manifest
+}
+
+// ImportPackage converts a SCORM or Common Cartridge package into a new draft owned by the caller, reporting what
+// could not be carried over
+func (uc *UseCase) ImportPackage(ctx context.Context, req *ImportPackageRequest) (*ImportPackageResponse, error) {
+	instructorID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	categoryID := uuid.MustParse(req.CategoryID)
+	if err := uc.taxonomyUseCase.ValidateCategory(categoryID); err != nil {
+		return nil, err
+	}
+
+	reader, file, err := openArchive(req.Package)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	pkg, err := contentpackage.Parse(reader)
+	if err != nil {
+		return nil, invalidArchive(err.Error())
+	}
+	fileName := path.Base(req.Package.Filename)
+	manifest := packageManifest(pkg, strings.TrimSuffix(fileName, path.Ext(fileName)))
+	if len(manifest.Materials) == 0 {
+		return nil, invalidArchive("package has no content that can be imported")
+	}
+	manifest.Course.Price = req.Price
+	manifest.Course.Difficulty = req.Difficulty
+	manifest.Course.Language = req.Language
+
+	entries := make(map[string]*zip.File, len(reader.File))
+	for _, entry := range reader.File {
+		entries[entry.Name] = entry
+	}
+	if err := validateManifest(manifest, entries); err != nil {
+		return nil, err
+	}
+
+	var tags []schema.Tag
+	if len(req.Tags) > 0 {
+		if tags, err = uc.taxonomyUseCase.ResolveTags(req.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	course := &schema.Course{
+		Title:        manifest.Course.Title,
+		Description:  manifest.Course.Description,
+		Price:        req.Price,
+		InstructorID: instructorID,
+		Difficulty:   req.Difficulty,
+		CategoryID:   &categoryID,
+		Tags:         tags,
+		Language:     req.Language,
+	}
+	if err := uc.createFromManifest(ctx, course, manifest, entries); err != nil {
+		return nil, err
+	}
+
+	unsupported := pkg.Unsupported
+	if unsupported == nil {
+		unsupported = []contentpackage.Unsupported{}
+	}
+	return &ImportPackageResponse{Course: course, Standard: pkg.Standard, Unsupported: unsupported}, nil
+}
@@ -0,0 +1,136 @@
+package course
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+func (uc *UseCase) getCourseOrNotFound(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	course, err := uc.courseRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return schema.Course{}, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course by id: ", err)
+		return schema.Course{}, apierror.ErrInternalServer.Build()
+	}
+	return course, nil
+}
+
+// prerequisiteStatus marks which prerequisites userID has completed. A nil userID stands for an anonymous caller.
+func (uc *UseCase) prerequisiteStatus(ctx context.Context, courseID uuid.UUID, userID *uuid.UUID) (*PrerequisitesResponse, error) {
+	prerequisites, err := uc.courseRepo.GetPrerequisites(ctx, courseID)
+	if err != nil {
+		log.Println("Error getting prerequisites: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	var completed []uuid.UUID
+	if userID != nil && len(prerequisites) > 0 {
+		ids := make([]uuid.UUID, len(prerequisites))
+		for i, prerequisite := range prerequisites {
+			ids[i] = prerequisite.ID
+		}
+		completed, err = uc.courseRepo.GetCompletedCourseIDs(ctx, *userID, ids)
+		if err != nil {
+			log.Println("Error getting completed courses: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+	}
+
+	res := &PrerequisitesResponse{
+		CourseID:      courseID,
+		Prerequisites: make([]PrerequisiteStatus, 0, len(prerequisites)),
+		Missing:       make([]uuid.UUID, 0),
+	}
+	for _, prerequisite := range prerequisites {
+		done := slices.Contains(completed, prerequisite.ID)
+		res.Prerequisites = append(res.Prerequisites, PrerequisiteStatus{PrerequisiteCourse: prerequisite, Completed: done})
+		if prerequisite.Required && !done {
+			res.Missing = append(res.Missing, prerequisite.ID)
+		}
+	}
+	res.CanPurchase = len(res.Missing) == 0
+	return res, nil
+}
+
+// GetPrerequisites lists the prerequisites of a course along with the caller's completion of each
+func (uc *UseCase) GetPrerequisites(ctx context.Context, courseID uuid.UUID) (*PrerequisitesResponse, error) {
+	if _, err := uc.getCourseOrNotFound(ctx, courseID); err != nil {
+		return nil, err
+	}
+
+	var userID *uuid.UUID
+	if rawUserID, ok := ctx.Value("user.id").(string); ok {
+		if parsed, err := uuid.Parse(rawUserID); err == nil {
+			userID = &parsed
+		}
+	}
+	return uc.prerequisiteStatus(ctx, courseID, userID)
+}
+
+func (uc *UseCase) SetPrerequisites(ctx context.Context, courseID uuid.UUID, req SetPrerequisitesRequest) (*PrerequisitesResponse, error) {
+	if _, err := uc.getCourseOrNotFound(ctx, courseID); err != nil {
+		return nil, err
+	}
+
+	prerequisites := make([]schema.CoursePrerequisite, 0, len(req.Prerequisites))
+	ids := make([]uuid.UUID, 0, len(req.Prerequisites))
+	for _, input := range req.Prerequisites {
+		id := uuid.MustParse(input.CourseID)
+		if id == courseID {
+			return nil, ErrInvalidPrerequisite.WithPayload("a course cannot be its own prerequisite").Build()
+		}
+		if slices.Contains(ids, id) {
+			continue
+		}
+		ids = append(ids, id)
+		prerequisites = append(prerequisites, schema.CoursePrerequisite{CourseID: courseID, PrerequisiteID: id, Required: input.Required})
+	}
+	if len(prerequisites) > maxPrerequisites {
+		return nil, ErrInvalidPrerequisite.WithPayload(map[string]int{"max_prerequisites": maxPrerequisites}).Build()
+	}
+
+	courses, err := uc.courseRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		log.Println("Error getting prerequisite courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if len(courses) != len(ids) {
+		return nil, ErrInvalidPrerequisite.WithPayload("prerequisite course not found").Build()
+	}
+
+	closure, err := uc.courseRepo.GetPrerequisiteClosure(ctx, ids)
+	if err != nil {
+		log.Println("Error getting prerequisite closure: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if slices.Contains(closure, courseID) {
+		return nil, ErrPrerequisiteCycle.Build()
+	}
+
+	if err := uc.courseRepo.SetPrerequisites(ctx, courseID, prerequisites); err != nil {
+		log.Println("Error setting prerequisites: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return uc.prerequisiteStatus(ctx, courseID, nil)
+}
+
+// checkPrerequisites blocks a purchase while any required prerequisite is unfinished
+func (uc *UseCase) checkPrerequisites(ctx context.Context, courseID, userID uuid.UUID) error {
+	status, err := uc.prerequisiteStatus(ctx, courseID, &userID)
+	if err != nil {
+		return err
+	}
+	if !status.CanPurchase {
+		return ErrPrerequisitesNotMet.WithPayload(map[string]any{"missing": status.Missing}).Build()
+	}
+	return nil
+}
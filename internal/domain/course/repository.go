@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/config"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -17,11 +20,23 @@ type Repository interface {
 	Create(ctx context.Context, course *schema.Course) error
 	Update(ctx context.Context, course *schema.Course) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	FindByInstructorID(ctx context.Context, instructorID uuid.UUID, page, pageSize int) ([]schema.Course, int, error)
+	FindByInstructorID(ctx context.Context, instructorID uuid.UUID, publishedOnly bool, page, pageSize int) ([]schema.Course, int, error)
+	FindByStatus(ctx context.Context, status schema.CourseStatus, page, pageSize int) ([]schema.Course, int, error)
+	UpdateStatus(ctx context.Context, course *schema.Course) error
 	FindByPopularity(ctx context.Context, page, pageSize int) ([]schema.Course, int, error)
 	GetUserCourseProgress(ctx context.Context, courseID, userID uuid.UUID) (float64, error)
-	SearchByTitle(ctx context.Context, title string, page, pageSize int) ([]schema.Course, int, error)
-	DynamicFilterCourses(ctx context.Context, filterType, filterValue, sort string, page, limit int) ([]schema.Course, int, error)
+	Search(ctx context.Context, query string, page, pageSize int) ([]SearchHit, int, error)
+	Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+	FindCatalog(ctx context.Context, filter CatalogFilter, page, limit int) ([]schema.Course, int, error)
+	GetCatalogFacets(ctx context.Context, filter CatalogFilter) (CatalogFacets, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]schema.Course, error)
+	GetPrerequisites(ctx context.Context, courseID uuid.UUID) ([]PrerequisiteCourse, error)
+	SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisites []schema.CoursePrerequisite) error
+	GetPrerequisiteClosure(ctx context.Context, courseIDs []uuid.UUID) ([]uuid.UUID, error)
+	GetCompletedCourseIDs(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error)
+	GetCloneSource(ctx context.Context, id uuid.UUID) (schema.Course, error)
+	GetSections(ctx context.Context, courseID uuid.UUID) ([]schema.CourseSection, error)
+	CreateWithContent(ctx context.Context, course *schema.Course, sections []schema.CourseSection) error
 }
 
 type repository struct {
@@ -34,12 +49,12 @@ func NewRepository(db *gorm.DB) Repository {
 
 func (r *repository) GetAll(ctx context.Context, page, pageSize int) ([]schema.Course, int, error) {
     var courses []schema.Course
-    result := r.db.Preload("Materials.Attachments").Preload("Assignments.Attachments").Offset((page - 1) * pageSize).Limit(pageSize).Find(&courses)
+    result := r.db.Where("status = ?", schema.CourseStatusPublished).Preload("Materials.Attachments").Preload("Assignments.Attachments").Offset((page - 1) * pageSize).Limit(pageSize).Find(&courses)
     if result.Error != nil {
         return nil, 0, result.Error
     }
     var totalRecords int64
-    r.db.Model(&schema.Course{}).Count(&totalRecords)
+    r.db.Model(&schema.Course{}).Where("status = ?", schema.CourseStatusPublished).Count(&totalRecords)
     return courses, int(totalRecords), nil
 }
 
@@ -52,6 +67,7 @@ func (r *repository) FindByPopularity(ctx context.Context, page, pageSize int) (
 
     result := r.db.Model(&schema.Course{}).
         Select("courses.*, " + enrollmentCountSQL + " as enrollment_count").
+        Where("status = ?", schema.CourseStatusPublished).
         Order("enrollment_count DESC").
         Order("rating DESC").
         Preload("Materials.Attachments").
@@ -64,12 +80,13 @@ func (r *repository) FindByPopularity(ctx context.Context, page, pageSize int) (
     }
 
     var totalRecords int64
-    r.db.Model(&schema.Course{}).Count(&totalRecords)
+    r.db.Model(&schema.Course{}).Where("status = ?", schema.CourseStatusPublished).Count(&totalRecords)
     return courses, int(totalRecords), nil
 }
 
+// GetUserCourseProgress weighs completed materials and submitted assignments into a percentage
 func (r *repository) GetUserCourseProgress(ctx context.Context, courseID, userID uuid.UUID) (float64, error) {
-	var totalAssignments, completedAssignments int64
+	var totalAssignments, completedAssignments, totalMaterials, completedMaterials int64
 
 
 	if err := r.db.Model(&schema.Assignment{}).Where("course_id = ?", courseID).Count(&totalAssignments).Error; err != nil {
@@ -80,33 +97,75 @@ func (r *repository) GetUserCourseProgress(ctx context.Context, courseID, userID
 	if err := r.db.Model(&schema.Assignment{}).
 		Joins("inner join submissions on submissions.assignment_id = assignments.id").
 		Where("assignments.course_id = ? AND submissions.user_id = ? AND submissions.deleted_at IS NULL", courseID, userID).
+		Distinct("assignments.id").
 		Count(&completedAssignments).Error; err != nil {
 		return 0, err
 		}
 
+	if err := r.db.Model(&schema.Material{}).Where("course_id = ?", courseID).Count(&totalMaterials).Error; err != nil {
+		return 0, err
+	}
+
+	if err := r.db.Model(&schema.Material{}).
+		Joins("inner join lesson_progresses on lesson_progresses.material_id = materials.id").
+		Where("materials.course_id = ? AND lesson_progresses.user_id = ? AND lesson_progresses.completed_at IS NOT NULL", courseID, userID).
+		Count(&completedMaterials).Error; err != nil {
+		return 0, err
+	}
+
+	materialWeight := float64(config.Env.ProgressMaterialWeight)
+	assignmentWeight := float64(config.Env.ProgressAssignmentWeight)
+	total := materialWeight*float64(totalMaterials) + assignmentWeight*float64(totalAssignments)
 
 	var progress float64
-	if totalAssignments > 0 {
-		progress = (float64(completedAssignments) / float64(totalAssignments)) * 100
+	if total > 0 {
+		progress = (materialWeight*float64(completedMaterials) + assignmentWeight*float64(completedAssignments)) / total * 100
 	}
 
 	return progress, nil
 }
 
-func (r *repository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, page, pageSize int) ([]schema.Course, int, error) {
-    var courses []schema.Course
-    result := r.db.Where("instructor_id = ?", instructorID).Offset((page - 1) * pageSize).Limit(pageSize).Find(&courses)
-    if result.Error != nil {
-        return nil, 0, result.Error
-    }
-    var totalRecords int64
-    r.db.Model(&schema.Course{}).Where("instructor_id = ?", instructorID).Count(&totalRecords)
-    return courses, int(totalRecords), nil
+func (r *repository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, publishedOnly bool, page, pageSize int) ([]schema.Course, int, error) {
+	var courses []schema.Course
+	query := r.db.Model(&schema.Course{}).Where("instructor_id = ?", instructorID)
+	if publishedOnly {
+		query = query.Where("status = ?", schema.CourseStatusPublished)
+	}
+
+	var totalRecords int64
+	if err := query.Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Offset((page - 1) * pageSize).Limit(pageSize).Find(&courses).Error; err != nil {
+		return nil, 0, err
+	}
+	return courses, int(totalRecords), nil
+}
+
+func (r *repository) FindByStatus(ctx context.Context, status schema.CourseStatus, page, pageSize int) ([]schema.Course, int, error) {
+	var courses []schema.Course
+	query := r.db.Model(&schema.Course{}).Where("status = ?", status)
+
+	var totalRecords int64
+	if err := query.Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("updated_at").Offset((page - 1) * pageSize).Limit(pageSize).Find(&courses).Error; err != nil {
+		return nil, 0, err
+	}
+	return courses, int(totalRecords), nil
+}
+
+// UpdateStatus only writes the lifecycle columns, leaving content and associations untouched
+func (r *repository) UpdateStatus(ctx context.Context, course *schema.Course) error {
+	return r.db.WithContext(ctx).Model(course).
+		Select("status", "review_note", "published_at").
+		Updates(course).Error
 }
 
 func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (schema.Course, error) {
 	var course schema.Course
-	if err := r.db.Preload("Materials.Attachments").First(&course, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("Materials.Attachments").Preload("Category").Preload("Tags").First(&course, "id = ?", id).Error; err != nil {
 		return schema.Course{}, err
 	}
 	return course, nil
@@ -125,57 +184,354 @@ func (r *repository) Create(ctx context.Context, course *schema.Course) error {
 }
 
 func (r *repository) Update(ctx context.Context, course *schema.Course) error {
-	return r.db.WithContext(ctx).Save(course).Error
+	// Preloaded materials, category and tags are managed elsewhere, so only the course row is written
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(course).Error
 }
 
 func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&schema.Course{}, id).Error
 }
 
-func (r *repository) SearchByTitle(ctx context.Context, title string, page, pageSize int) ([]schema.Course, int, error) {
-    var courses []schema.Course
-    result := r.db.Where("title ILIKE ?", "%"+title+"%").Offset((page - 1) * pageSize).Limit(pageSize).Find(&courses)
-    if result.Error != nil {
-        return nil, 0, result.Error
-    }
-    var totalRecords int64
-    r.db.Model(&schema.Course{}).Where("title ILIKE ?", "%"+title+"%").Count(&totalRecords)
-    return courses, int(totalRecords), nil
+// searchMatchesCTE ranks published courses against a prefix tsquery, falling back to trigram similarity on the
+// title and instructor name so small typos still find the course. Material titles are folded into the document
+// at query time because a stored tsvector could not follow edits to materials or instructor names.
+const searchMatchesCTE = `
+WITH documents AS (
+	SELECT c.id,
+		setweight(to_tsvector('simple', c.title), 'A') ||
+		setweight(to_tsvector('simple', coalesce(u.name, '')), 'B') ||
+		setweight(to_tsvector('simple', coalesce(string_agg(m.title, ' '), '')), 'C') ||
+		setweight(to_tsvector('simple', coalesce(c.description, '')), 'D') AS document,
+		c.title || ' ' || coalesce(u.name, '') AS label
+	FROM courses c
+	LEFT JOIN users u ON u.id = c.instructor_id
+	LEFT JOIN materials m ON m.course_id = c.id AND m.deleted_at IS NULL
+	WHERE c.status = 'published' AND c.deleted_at IS NULL
+	GROUP BY c.id, u.name
+), matches AS (
+	SELECT d.id,
+		ts_rank_cd(d.document, to_tsquery('simple', @tsquery)) + word_similarity(@query, d.label) AS rank
+	FROM documents d
+	WHERE d.document @@ to_tsquery('simple', @tsquery) OR word_similarity(@query, d.label) >= @threshold
+)
+`
+
+func (r *repository) Search(ctx context.Context, query string, page, pageSize int) ([]SearchHit, int, error) {
+	args := map[string]any{
+		"query":      query,
+		"tsquery":    prefixTSQuery(query),
+		"threshold":  similarityThreshold,
+		"title_opts": titleHeadlineOptions,
+		"body_opts":  snippetHeadlineOptions,
+		"limit":      pageSize,
+		"offset":     (page - 1) * pageSize,
+	}
+
+	var hits []SearchHit
+	err := r.db.WithContext(ctx).Raw(searchMatchesCTE+`
+		SELECT c.*, matches.rank,
+			ts_headline('simple', c.title, to_tsquery('simple', @tsquery), @title_opts) AS title_highlight,
+			ts_headline('simple', coalesce(c.description, ''), to_tsquery('simple', @tsquery), @body_opts) AS snippet
+		FROM matches
+		JOIN courses c ON c.id = matches.id
+		ORDER BY matches.rank DESC, c.id
+		LIMIT @limit OFFSET @offset
+	`, args).Scan(&hits).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	if err := r.db.WithContext(ctx).Raw(searchMatchesCTE+`SELECT count(*) FROM matches`, args).
+		Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return hits, int(total), nil
 }
 
-func (r *repository) DynamicFilterCourses(ctx context.Context, filterType, filterValue, sort string, page, limit int) ([]schema.Course, int, error) {
-    var courses []schema.Course
-    var total int64
-
-    query := r.db.Model(&schema.Course{})
-
-    switch filterType {
-    case "category":
-        query = query.Where("category = ?", filterValue)
-    case "difficulty":
-        query = query.Where("difficulty = ?", filterValue)
-    case "rating":
-		rating, _ := strconv.ParseFloat(filterValue, 32) 
-        upperBound := rating + 1.0
-        query = query.Where("rating >= ? AND rating < ?", rating, upperBound)
-    }
+// Suggest completes what the user has typed so far with course titles and instructor names
+func (r *repository) Suggest(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	var suggestions []Suggestion
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT type, id, text FROM (
+			SELECT 'course' AS type, c.id, c.title AS text,
+				(c.title ILIKE @starts)::int + word_similarity(@prefix, c.title) AS score
+			FROM courses c
+			WHERE c.status = 'published' AND c.deleted_at IS NULL
+				AND (c.title ILIKE @contains OR word_similarity(@prefix, c.title) >= @threshold)
+			UNION ALL
+			SELECT DISTINCT ON (u.id) 'instructor' AS type, u.id, u.name AS text,
+				(u.name ILIKE @starts)::int + word_similarity(@prefix, u.name) AS score
+			FROM users u
+			JOIN courses c ON c.instructor_id = u.id AND c.status = 'published' AND c.deleted_at IS NULL
+			WHERE u.deleted_at IS NULL
+				AND (u.name ILIKE @contains OR word_similarity(@prefix, u.name) >= @threshold)
+		) candidates
+		ORDER BY score DESC, text
+		LIMIT @limit
+	`, map[string]any{
+		"prefix":    prefix,
+		"starts":    escapeLike(prefix) + "%",
+		"contains":  "%" + escapeLike(prefix) + "%",
+		"threshold": similarityThreshold,
+		"limit":     limit,
+	}).Scan(&suggestions).Error
+	return suggestions, err
+}
 
-    if sort == "highest" {
-        query = query.Order("rating DESC")
-    } else if sort == "lowest" {
-        query = query.Order("rating ASC")
-    }
+const (
+	facetCategory   = "category"
+	facetTag        = "tag"
+	facetDifficulty = "difficulty"
+	facetLanguage   = "language"
+	facetPrice      = "price"
+	facetRating     = "rating"
+)
+
+// tagFacetLimit keeps the tag facet to the most used tags
+const tagFacetLimit = 20
+
+// ratingFacetFloors are the "N stars and up" buckets counted for the rating facet
+var ratingFacetFloors = []float32{4.5, 4.0, 3.5, 3.0}
+
+// effectivePrice is what a buyer pays now, the running sale price or else the list price.
+// It needs the running_sale join added by catalogQuery.
+const effectivePrice = "COALESCE(running_sale.sale_price, courses.price)"
+
+// catalogQuery applies every filter except the one named by skipFacet
+func (r *repository) catalogQuery(ctx context.Context, filter CatalogFilter, skipFacet string) *gorm.DB {
+	now := time.Now()
+	// Sales never overlap, so a course joins at most one. Like the pricing use case, a sale above a lowered
+	// list price is ignored.
+	query := r.db.WithContext(ctx).Model(&schema.Course{}).
+		Joins(`LEFT JOIN (
+			SELECT course_id AS sale_course_id, sale_price FROM course_sales WHERE starts_at <= ? AND ends_at > ?
+		) running_sale ON running_sale.sale_course_id = courses.id AND running_sale.sale_price < courses.price`,
+			now, now).
+		Where("status = ?", schema.CourseStatusPublished)
+
+	if len(filter.Categories) > 0 && skipFacet != facetCategory {
+		query = query.Where(`category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE slug IN ?
+				UNION
+				SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT id FROM subtree
+		)`, filter.Categories)
+	}
+	if len(filter.Tags) > 0 && skipFacet != facetTag {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM course_tags ct JOIN tags t ON t.id = ct.tag_id
+			WHERE ct.course_id = courses.id AND t.slug IN ?
+		)`, filter.Tags)
+	}
+	if len(filter.Difficulties) > 0 && skipFacet != facetDifficulty {
+		query = query.Where("difficulty IN ?", filter.Difficulties)
+	}
+	if len(filter.Languages) > 0 && skipFacet != facetLanguage {
+		query = query.Where("language IN ?", filter.Languages)
+	}
+	if skipFacet != facetPrice {
+		if filter.FreeOnly {
+			query = query.Where(effectivePrice + " = 0")
+		}
+		if filter.MinPrice != nil {
+			query = query.Where(effectivePrice+" >= ?", *filter.MinPrice)
+		}
+		if filter.MaxPrice != nil {
+			query = query.Where(effectivePrice+" <= ?", *filter.MaxPrice)
+		}
+	}
+	if filter.MinRating != nil && skipFacet != facetRating {
+		query = query.Where("rating >= ?", *filter.MinRating)
+	}
+	if filter.InstructorID != nil {
+		query = query.Where("instructor_id = ?", *filter.InstructorID)
+	}
 
+	return query
+}
 
-    if err := query.Count(&total).Error; err != nil {
-        return nil, 0, err
-    }
+func (r *repository) FindCatalog(ctx context.Context, filter CatalogFilter, page, limit int) ([]schema.Course, int, error) {
+	var courses []schema.Course
+	var total int64
 
-    query = query.Offset((page - 1) * limit).Limit(limit)
+	if err := r.catalogQuery(ctx, filter, "").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
 
-    if err := query.Find(&courses).Error; err != nil {
-        return nil, 0, err
-    }
+	query := r.catalogQuery(ctx, filter, "")
+	switch filter.Sort {
+	case "highest":
+		query = query.Order("rating DESC")
+	case "lowest":
+		query = query.Order("rating ASC")
+	case "price_asc":
+		query = query.Order(effectivePrice + " ASC")
+	case "price_desc":
+		query = query.Order(effectivePrice + " DESC")
+	case "popularity":
+		query = query.Order("(SELECT COUNT(*) FROM course_enrolls WHERE course_enrolls.course_id = courses.id) DESC")
+	default:
+		query = query.Order("published_at DESC NULLS LAST").Order("created_at DESC")
+	}
+
+	if err := query.Order("id").Offset((page - 1) * limit).Limit(limit).Find(&courses).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return courses, int(total), nil
+}
+
+func (r *repository) GetCatalogFacets(ctx context.Context, filter CatalogFilter) (CatalogFacets, error) {
+	var facets CatalogFacets
+
+	if err := r.catalogQuery(ctx, filter, facetCategory).
+		Select("(SELECT slug FROM categories WHERE categories.id = courses.category_id) AS value, COUNT(*) AS count").
+		Where("category_id IS NOT NULL").
+		Group("value").
+		Order("count DESC, value").
+		Scan(&facets.Categories).Error; err != nil {
+		return CatalogFacets{}, err
+	}
+
+	if err := r.catalogQuery(ctx, filter, facetTag).
+		Select("t.slug AS value, COUNT(*) AS count").
+		Joins("JOIN course_tags ct ON ct.course_id = courses.id").
+		Joins("JOIN tags t ON t.id = ct.tag_id").
+		Group("t.slug").
+		Order("count DESC, value").
+		Limit(tagFacetLimit).
+		Scan(&facets.Tags).Error; err != nil {
+		return CatalogFacets{}, err
+	}
+
+	for column, dest := range map[string]*[]FacetCount{
+		facetDifficulty: &facets.Difficulties,
+		facetLanguage:   &facets.Languages,
+	} {
+		if err := r.catalogQuery(ctx, filter, column).
+			Select(column + "::text AS value, COUNT(*) AS count").
+			Where(column + " IS NOT NULL").
+			Group(column).
+			Order("count DESC, value").
+			Scan(dest).Error; err != nil {
+			return CatalogFacets{}, err
+		}
+	}
+
+	if err := r.catalogQuery(ctx, filter, facetPrice).
+		Select("CASE WHEN " + effectivePrice + " = 0 THEN 'free' ELSE 'paid' END AS value, COUNT(*) AS count").
+		Group("value").
+		Order("value").
+		Scan(&facets.Prices).Error; err != nil {
+		return CatalogFacets{}, err
+	}
+
+	facets.Ratings = make([]FacetCount, 0, len(ratingFacetFloors))
+	for _, floor := range ratingFacetFloors {
+		var count int64
+		if err := r.catalogQuery(ctx, filter, facetRating).Where("rating >= ?", floor).Count(&count).Error; err != nil {
+			return CatalogFacets{}, err
+		}
+		facets.Ratings = append(facets.Ratings, FacetCount{Value: strconv.FormatFloat(float64(floor), 'f', 1, 32), Count: count})
+	}
 
-    return courses, int(total) , nil
+	return facets, nil
+}
+
+func (r *repository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]schema.Course, error) {
+	var courses []schema.Course
+	if len(ids) == 0 {
+		return courses, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&courses).Error
+	return courses, err
+}
+
+func (r *repository) GetPrerequisites(ctx context.Context, courseID uuid.UUID) ([]PrerequisiteCourse, error) {
+	var prerequisites []PrerequisiteCourse
+	err := r.db.WithContext(ctx).Table("course_prerequisites cp").
+		Select("c.id, c.title, c.image_url, c.status, cp.required").
+		Joins("JOIN courses c ON c.id = cp.prerequisite_id AND c.deleted_at IS NULL").
+		Where("cp.course_id = ?", courseID).
+		Order("cp.required DESC, cp.created_at, c.title").
+		Scan(&prerequisites).Error
+	return prerequisites, err
+}
+
+func (r *repository) SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisites []schema.CoursePrerequisite) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("course_id = ?", courseID).Delete(&schema.CoursePrerequisite{}).Error; err != nil {
+			return err
+		}
+		if len(prerequisites) == 0 {
+			return nil
+		}
+		return tx.Create(&prerequisites).Error
+	})
+}
+
+// GetPrerequisiteClosure returns every course the given courses depend on, directly or transitively
+func (r *repository) GetPrerequisiteClosure(ctx context.Context, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if len(courseIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		WITH RECURSIVE closure AS (
+			SELECT prerequisite_id AS id FROM course_prerequisites WHERE course_id IN ?
+			UNION
+			SELECT cp.prerequisite_id FROM course_prerequisites cp JOIN closure ON cp.course_id = closure.id
+		)
+		SELECT id FROM closure
+	`, courseIDs).Scan(&ids).Error
+	return ids, err
+}
+
+// GetCompletedCourseIDs filters courseIDs down to the ones the user has finished
+func (r *repository) GetCompletedCourseIDs(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if len(courseIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.WithContext(ctx).Model(&schema.CourseEnroll{}).
+		Where("user_id = ? AND course_id IN ? AND completed_at IS NOT NULL", userID, courseIDs).
+		Distinct().
+		Pluck("course_id", &ids).Error
+	return ids, err
+}
+
+// GetCloneSource loads a course with everything that is copied when it is cloned
+func (r *repository) GetCloneSource(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	var course schema.Course
+	err := r.db.WithContext(ctx).
+		Preload("Materials.Attachments").
+		Preload("Assignments.Attachments").
+		Preload("Tags").
+		Preload("Category").
+		First(&course, "id = ?", id).Error
+	return course, err
+}
+
+func (r *repository) GetSections(ctx context.Context, courseID uuid.UUID) ([]schema.CourseSection, error) {
+	var sections []schema.CourseSection
+	err := r.db.WithContext(ctx).Where("course_id = ?", courseID).Order("position").Find(&sections).Error
+	return sections, err
+}
+
+// CreateWithContent inserts a new course together with its materials, assignments, attachments and sections
+func (r *repository) CreateWithContent(ctx context.Context, course *schema.Course, sections []schema.CourseSection) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Tags already exist, only the join rows are new
+		if err := tx.Omit("Tags.*").Create(course).Error; err != nil {
+			return err
+		}
+		if len(sections) == 0 {
+			return nil
+		}
+		return tx.Create(&sections).Error
+	})
 }
@@ -6,33 +6,35 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/drip"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/wallet"
 	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
 	"github.com/highfive-compfest/seatudy-backend/internal/response"
 )
 
 type RestController struct {
-	uc  *UseCase
-	wuc *wallet.UseCase
+	uc   *UseCase
+	wuc  *wallet.UseCase
+	drip *drip.UseCase
 }
 
-func NewRestController(router *gin.Engine, uc *UseCase, wuc *wallet.UseCase) {
+func NewRestController(router *gin.Engine, uc *UseCase, wuc *wallet.UseCase, dripUseCase *drip.UseCase) {
 
-	controller := &RestController{uc: uc, wuc: wuc}
+	controller := &RestController{uc: uc, wuc: wuc, drip: dripUseCase}
 
 	courseGroup := router.Group("/v1/courses")
 	{
 		courseGroup.GET("", controller.GetAll())
-		courseGroup.GET("/:id", controller.GetByID())
+		courseGroup.GET("/:id", middleware.OptionalAuthenticate(), controller.GetByID())
 		courseGroup.POST("",
 			middleware.Authenticate(),
 			middleware.RequireEmailVerified(),
 			middleware.RequireRole("instructor"),
 			controller.Create(),
 		)
-		courseGroup.PUT("/:id", middleware.Authenticate(), middleware.RequireRole("instructor"), controller.Update())
+		courseGroup.PUT("/:id", middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"), controller.Update())
 		courseGroup.POST("/buy/:id", middleware.Authenticate(), middleware.RequireEmailVerified(), middleware.RequireRole("student"), controller.BuyCourse())
-		courseGroup.GET("/instructor/:id", middleware.Authenticate(), controller.GetInstructorCourse())
+		courseGroup.GET("/instructor/:id", middleware.APIKeyScope("courses:read"), middleware.Authenticate(), controller.GetInstructorCourse())
 		courseGroup.DELETE("/:id",
 			middleware.Authenticate(),
 			middleware.RequireRole("instructor"),
@@ -41,10 +43,67 @@ func NewRestController(router *gin.Engine, uc *UseCase, wuc *wallet.UseCase) {
 
 		courseGroup.GET("/popularity", controller.GetByPopularity())
 		courseGroup.GET("/mycourse", middleware.Authenticate(), controller.GetUserEnrollments())
-		courseGroup.GET("/usersEnroll/:courseId", middleware.Authenticate(), controller.GetCourseEnrollments())
+		courseGroup.GET("/usersEnroll/:courseId", middleware.APIKeyScope("courses:read"), middleware.Authenticate(), controller.GetCourseEnrollments())
 		courseGroup.GET("/progress/:courseId", middleware.Authenticate(), middleware.RequireEmailVerified(), controller.GetStudentProgress())
 		courseGroup.GET("/search", controller.SearchCourses())
+		courseGroup.GET("/search/suggest", controller.SuggestCourses())
 		courseGroup.GET("/filter", controller.FilterCourse())
+
+		courseGroup.PATCH("/:id/status",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor"),
+			controller.ChangeStatus(),
+		)
+		courseGroup.GET("/:id/prerequisites", middleware.OptionalAuthenticate(), controller.GetPrerequisites())
+		courseGroup.PUT("/:id/prerequisites",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor"),
+			controller.SetPrerequisites(),
+		)
+		courseGroup.PUT("/:id/tags",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor"),
+			controller.SetTags(),
+		)
+		courseGroup.POST("/:id/clone",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor"),
+			controller.Clone(),
+		)
+		courseGroup.GET("/:id/export",
+			middleware.APIKeyScope("courses:read"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor"),
+			controller.Export(),
+		)
+		courseGroup.POST("/import",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireEmailVerified(),
+			middleware.RequireRole("instructor"),
+			controller.Import(),
+		)
+		courseGroup.POST("/import/package",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireEmailVerified(),
+			middleware.RequireRole("instructor"),
+			controller.ImportPackage(),
+		)
+		courseGroup.GET("/review-queue",
+			middleware.Authenticate(),
+			middleware.RequireRole("admin"),
+			controller.GetReviewQueue(),
+		)
+		courseGroup.POST("/:id/review",
+			middleware.Authenticate(),
+			middleware.RequireRole("admin"),
+			controller.ReviewCourse(),
+		)
 	}
 
 }
@@ -61,6 +120,10 @@ func (c *RestController) GetAll() gin.HandlerFunc {
 			response.NewRestResponse(http.StatusInternalServerError, "Failed to retrieve courses", nil).Send(ctx)
 			return
 		}
+		if err := c.drip.ApplyToCourses(ctx, result.Courses); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
 		response.NewRestResponse(http.StatusOK, "Courses retrieved successfully", result).Send(ctx)
 	}
 }
@@ -77,6 +140,10 @@ func (c *RestController) GetByPopularity() gin.HandlerFunc {
 			response.NewRestResponse(http.StatusInternalServerError, "Failed to retrieve courses", nil).Send(ctx)
 			return
 		}
+		if err := c.drip.ApplyToCourses(ctx, result.Courses); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
 		response.NewRestResponse(http.StatusOK, "Courses retrieved successfully", result).Send(ctx)
 	}
 }
@@ -89,7 +156,7 @@ func (c *RestController) SearchCourses() gin.HandlerFunc {
 			return
 		}
 
-		result, err := c.uc.SearchCoursesByTitle(ctx, req.Title, req.Page, req.Limit)
+		result, err := c.uc.SearchCourses(ctx, req.Title, req.Page, req.Limit)
 		if err != nil {
 			response.NewRestResponse(http.StatusInternalServerError, "Failed to search courses", err.Error()).Send(ctx)
 			return
@@ -99,6 +166,24 @@ func (c *RestController) SearchCourses() gin.HandlerFunc {
 	}
 }
 
+func (c *RestController) SuggestCourses() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req SuggestRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid request parameters: "+err.Error(), nil).Send(ctx)
+			return
+		}
+
+		result, err := c.uc.SuggestCourses(ctx, req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "Suggestions retrieved successfully", result).Send(ctx)
+	}
+}
+
 func (c *RestController) GetInstructorCourse() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
@@ -207,9 +292,13 @@ func (c *RestController) GetByID() gin.HandlerFunc {
 			return
 		}
 
-		course, err := c.uc.GetByID(ctx, id)
+		course, err := c.uc.GetVisibleByID(ctx, id)
 		if err != nil {
-			response.NewRestResponse(http.StatusInternalServerError, err.Error(), nil).Send(ctx)
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+		if err := c.drip.ApplyToCourse(ctx, &course); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
 			return
 		}
 		response.NewRestResponse(http.StatusOK, "Course retrieved successfully", course).Send(ctx)
@@ -368,3 +457,234 @@ func (c *RestController) FilterCourse() gin.HandlerFunc {
 		response.NewRestResponse(http.StatusOK, "Course retrieve successfully", result).Send(ctx)
 	}
 }
+
+func (c *RestController) ChangeStatus() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid ID", nil).Send(ctx)
+			return
+		}
+
+		var req ChangeCourseStatusRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid status: "+err.Error(), nil).Send(ctx)
+			return
+		}
+
+		if err := c.checkCourseOwnership(ctx, id); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		course, err := c.uc.ChangeStatus(ctx, id, req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "Course status updated successfully", course).Send(ctx)
+	}
+}
+
+func (c *RestController) SetTags() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid ID", nil).Send(ctx)
+			return
+		}
+
+		var req SetCourseTagsRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid tags: "+err.Error(), nil).Send(ctx)
+			return
+		}
+
+		if err := c.checkCourseOwnership(ctx, id); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		tags, err := c.uc.SetTags(ctx, id, req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "Course tags updated successfully", tags).Send(ctx)
+	}
+}
+
+func (c *RestController) Clone() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid ID", nil).Send(ctx)
+			return
+		}
+
+		if err := c.checkCourseOwnership(ctx, id); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		course, err := c.uc.Clone(ctx, id)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "Course cloned successfully", course).Send(ctx)
+	}
+}
+
+func (c *RestController) Export() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid ID", nil).Send(ctx)
+			return
+		}
+
+		if err := c.checkCourseOwnership(ctx, id); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		archive, err := c.uc.Export(ctx, id)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		filename := "seatudy-course-" + id.String() + ".zip"
+		ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		ctx.Data(http.StatusOK, "application/zip", archive)
+	}
+}
+
+func (c *RestController) Import() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req ImportCourseRequest
+		if err := ctx.ShouldBind(&req); err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid archive: "+err.Error(), nil).Send(ctx)
+			return
+		}
+
+		course, err := c.uc.Import(ctx, req.Archive)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "Course imported successfully", course).Send(ctx)
+	}
+}
+
+func (c *RestController) ImportPackage() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req ImportPackageRequest
+		if err := ctx.ShouldBind(&req); err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid request: "+err.Error(), nil).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.ImportPackage(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "Package imported successfully", res).Send(ctx)
+	}
+}
+
+func (c *RestController) GetPrerequisites() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid ID", nil).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.GetPrerequisites(ctx, id)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "Prerequisites retrieved successfully", res).Send(ctx)
+	}
+}
+
+func (c *RestController) SetPrerequisites() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid ID", nil).Send(ctx)
+			return
+		}
+
+		var req SetPrerequisitesRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid prerequisites: "+err.Error(), nil).Send(ctx)
+			return
+		}
+
+		if err := c.checkCourseOwnership(ctx, id); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.SetPrerequisites(ctx, id, req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "Prerequisites updated successfully", res).Send(ctx)
+	}
+}
+
+func (c *RestController) GetReviewQueue() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req PaginationRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid pagination parameters", nil).Send(ctx)
+			return
+		}
+
+		result, err := c.uc.GetReviewQueue(ctx, req.Page, req.Limit)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "Courses retrieved successfully", result).Send(ctx)
+	}
+}
+
+func (c *RestController) ReviewCourse() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := uuid.Parse(ctx.Param("id"))
+		if err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid ID", nil).Send(ctx)
+			return
+		}
+
+		var req ReviewCourseRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.NewRestResponse(http.StatusBadRequest, "Invalid review data: "+err.Error(), nil).Send(ctx)
+			return
+		}
+
+		course, err := c.uc.ReviewCourse(ctx, id, req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "Course reviewed successfully", course).Send(ctx)
+	}
+}
@@ -0,0 +1,99 @@
+package course
+
+import (
+	"context"
+	"html"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+)
+
+const (
+	// similarityThreshold is the minimum trigram word similarity for a fuzzy match
+	similarityThreshold = 0.4
+
+	// ts_headline wraps matches in these control characters; they cannot come from user input,
+	// so the text can be HTML-escaped before turning them into <mark> tags
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+
+	titleHeadlineOptions   = "HighlightAll=true, StartSel=" + highlightStart + ", StopSel=" + highlightStop
+	snippetHeadlineOptions = "MaxFragments=2, MaxWords=25, MinWords=8, FragmentDelimiter=\" ... \", " +
+		"StartSel=" + highlightStart + ", StopSel=" + highlightStop
+)
+
+// searchTerms splits a query into words, dropping punctuation that has a meaning in tsquery syntax
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// prefixTSQuery turns "web dev" into "web:* & dev:*" so partially typed words still match
+func prefixTSQuery(query string) string {
+	terms := searchTerms(query)
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// markHighlights escapes a ts_headline result and converts its markers into <mark> tags
+func markHighlights(s string) string {
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(html.EscapeString(s))
+}
+
+func (uc *UseCase) SearchCourses(ctx context.Context, query string, page, pageSize int) (SearchCoursesResponse, error) {
+	query = strings.TrimSpace(query)
+	if len(searchTerms(query)) == 0 {
+		return SearchCoursesResponse{Courses: []SearchHit{}, Pagination: pagination.NewPagination(0, page, pageSize)}, nil
+	}
+
+	hits, total, err := uc.courseRepo.Search(ctx, query, page, pageSize)
+	if err != nil {
+		log.Println("Error searching courses: ", err)
+		return SearchCoursesResponse{}, apierror.ErrInternalServer.Build()
+	}
+
+	courses := make([]*schema.Course, len(hits))
+	for i := range hits {
+		hits[i].TitleHighlight = markHighlights(hits[i].TitleHighlight)
+		hits[i].Snippet = markHighlights(hits[i].Snippet)
+		courses[i] = &hits[i].Course
+	}
+	if err := uc.pricingUseCase.ApplySales(courses); err != nil {
+		return SearchCoursesResponse{}, err
+	}
+
+	return SearchCoursesResponse{
+		Courses:    hits,
+		Pagination: pagination.NewPagination(total, page, pageSize),
+	}, nil
+}
+
+func (uc *UseCase) SuggestCourses(ctx context.Context, req SuggestRequest) ([]Suggestion, error) {
+	prefix := strings.TrimSpace(req.Query)
+	if len(searchTerms(prefix)) == 0 {
+		return []Suggestion{}, nil
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSuggestionLimit
+	}
+
+	suggestions, err := uc.courseRepo.Suggest(ctx, prefix, limit)
+	if err != nil {
+		log.Println("Error getting search suggestions: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return suggestions, nil
+}
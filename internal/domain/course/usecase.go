@@ -3,6 +3,7 @@ package course
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"log"
 	"mime/multipart"
@@ -13,14 +14,23 @@ import (
 	"github.com/highfive-compfest/seatudy-backend/internal/config"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/courseenroll"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/notification"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/pricing"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/revision"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/taxonomy"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/user"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/wallet"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/waitlist"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/wishlist"
 	"github.com/highfive-compfest/seatudy-backend/internal/fileutil"
 	"github.com/highfive-compfest/seatudy-backend/internal/mailer"
 	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
 )
 
+// defaultLanguage is assigned to courses created without a language
+const defaultLanguage = "en"
+
 type UseCase struct {
 	courseRepo          Repository
 	walletRepo          wallet.IRepository
@@ -29,12 +39,21 @@ type UseCase struct {
 	notificationRepo    notification.IRepository
 	mailDialer          config.IMailer
 	uploader            config.FileUploader
+	taxonomyUseCase     *taxonomy.UseCase
+	revisionUseCase     *revision.UseCase
+	waitlistUseCase     *waitlist.UseCase
+	wishlistUseCase     *wishlist.UseCase
+	pricingUseCase      *pricing.UseCase
 }
 
 func NewUseCase(courseRepo Repository, walletRepo wallet.IRepository, ceUseCase courseenroll.UseCase,
-	userRepo user.IRepository, notificationRepo notification.IRepository, mailDialer config.IMailer, uploader config.FileUploader) *UseCase {
+	userRepo user.IRepository, notificationRepo notification.IRepository, mailDialer config.IMailer, uploader config.FileUploader,
+	taxonomyUseCase *taxonomy.UseCase, revisionUseCase *revision.UseCase, waitlistUseCase *waitlist.UseCase,
+	wishlistUseCase *wishlist.UseCase, pricingUseCase *pricing.UseCase) *UseCase {
 	return &UseCase{courseRepo: courseRepo, walletRepo: walletRepo, courseEnrollUseCase: ceUseCase,
-		userRepo: userRepo, notificationRepo: notificationRepo, mailDialer: mailDialer, uploader: uploader}
+		userRepo: userRepo, notificationRepo: notificationRepo, mailDialer: mailDialer, uploader: uploader,
+		taxonomyUseCase: taxonomyUseCase, revisionUseCase: revisionUseCase, waitlistUseCase: waitlistUseCase,
+		wishlistUseCase: wishlistUseCase, pricingUseCase: pricingUseCase}
 }
 
 func (uc *UseCase) GetAll(ctx context.Context, page, pageSize int) (CoursesPaginatedResponse, error) {
@@ -42,6 +61,9 @@ func (uc *UseCase) GetAll(ctx context.Context, page, pageSize int) (CoursesPagin
 	if err != nil {
 		return CoursesPaginatedResponse{}, err
 	}
+	if err := uc.applySales(courses); err != nil {
+		return CoursesPaginatedResponse{}, err
+	}
 	pag := pagination.NewPagination(total, page, pageSize)
 	return CoursesPaginatedResponse{
 		Courses:    courses,
@@ -54,6 +76,9 @@ func (uc *UseCase) GetCourseByPopularity(ctx context.Context, page, pageSize int
 	if err != nil {
 		return CoursesPaginatedResponse{}, err
 	}
+	if err := uc.applySales(courses); err != nil {
+		return CoursesPaginatedResponse{}, err
+	}
 	pag := pagination.NewPagination(total, page, pageSize)
 	return CoursesPaginatedResponse{
 		Courses:    courses,
@@ -61,11 +86,19 @@ func (uc *UseCase) GetCourseByPopularity(ctx context.Context, page, pageSize int
 	}, nil
 }
 
+// GetByInstructorID lists every course to the instructor themselves and to admins, and only published ones to others
 func (uc *UseCase) GetByInstructorID(ctx context.Context, instructorID uuid.UUID, page, pageSize int) (CoursesPaginatedResponse, error) {
-	courses, total, err := uc.courseRepo.FindByInstructorID(ctx, instructorID, page, pageSize)
+	viewerID, _ := ctx.Value("user.id").(string)
+	viewerRole, _ := ctx.Value("user.role").(string)
+	publishedOnly := viewerID != instructorID.String() && viewerRole != string(schema.RoleAdmin)
+
+	courses, total, err := uc.courseRepo.FindByInstructorID(ctx, instructorID, publishedOnly, page, pageSize)
 	if err != nil {
 		return CoursesPaginatedResponse{}, err
 	}
+	if err := uc.applySales(courses); err != nil {
+		return CoursesPaginatedResponse{}, err
+	}
 	pag := pagination.NewPagination(total, page, pageSize)
 	return CoursesPaginatedResponse{
 		Courses:    courses,
@@ -77,6 +110,43 @@ func (uc *UseCase) GetByID(ctx context.Context, id uuid.UUID) (schema.Course, er
 	return uc.courseRepo.GetByID(ctx, id)
 }
 
+// GetVisibleByID is used by the public course page, which must not expose courses that were never released
+func (uc *UseCase) GetVisibleByID(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	course, err := uc.courseRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return schema.Course{}, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course by id: ", err)
+		return schema.Course{}, apierror.ErrInternalServer.Build()
+	}
+
+	if course.Status == schema.CourseStatusDraft || course.Status == schema.CourseStatusInReview {
+		return schema.Course{}, ErrCourseNotFound.Build()
+	}
+
+	if err := uc.pricingUseCase.ApplySales([]*schema.Course{&course}); err != nil {
+		return schema.Course{}, err
+	}
+	return course, nil
+}
+
+// applySales shows the prices of the sales running now on a page of courses
+func (uc *UseCase) applySales(courses []schema.Course) error {
+	pointers := make([]*schema.Course, len(courses))
+	for i := range courses {
+		pointers[i] = &courses[i]
+	}
+	return uc.pricingUseCase.ApplySales(pointers)
+}
+
+// recordPrice adds the course's current price to its price history. Failing to do so does not undo the change.
+func (uc *UseCase) recordPrice(ctx context.Context, course *schema.Course, oldPrice *int64) {
+	if err := uc.pricingUseCase.RecordPriceChange(ctx, course.ID, oldPrice, course.Price); err != nil {
+		log.Println("Error recording course price change: ", err)
+	}
+}
+
 func (uc *UseCase) Create(ctx context.Context, req CreateCourseRequest, imageFile, syllabusFile *multipart.FileHeader, instructorID string) error {
 	var imageUrl, syllabusUrl string
 	var err error
@@ -86,6 +156,18 @@ func (uc *UseCase) Create(ctx context.Context, req CreateCourseRequest, imageFil
 		return ErrUnauthorizedAccess.Build() // Or any other appropriate error
 	}
 
+	categoryID := uuid.MustParse(req.CategoryID)
+	if err := uc.taxonomyUseCase.ValidateCategory(categoryID); err != nil {
+		return err
+	}
+	// The tags are attached by the course insert itself, so a course is never saved without them
+	var tags []schema.Tag
+	if len(req.Tags) > 0 {
+		if tags, err = uc.taxonomyUseCase.ResolveTags(req.Tags); err != nil {
+			return err
+		}
+	}
+
 	id, err := uuid.NewV7()
 	if err != nil {
 		log.Println("Error generating UUID: ", err)
@@ -158,10 +240,21 @@ func (uc *UseCase) Create(ctx context.Context, req CreateCourseRequest, imageFil
 		InstructorID: uuidInstructorID,
 		Difficulty:   req.Difficulty,
 		ID:           id,
-		Category:     req.Category,
+		CategoryID:   &categoryID,
+		Language:     req.Language,
+		MaxSeats:     req.MaxSeats,
+		Status:       schema.CourseStatusDraft,
+		Tags:         tags,
+	}
+	if course.Language == "" {
+		course.Language = defaultLanguage
 	}
 
-	return uc.courseRepo.Create(ctx, &course)
+	if err := uc.courseRepo.Create(ctx, &course); err != nil {
+		return err
+	}
+	uc.recordPrice(ctx, &course, nil)
+	return nil
 }
 
 func (uc *UseCase) Update(ctx context.Context, req UpdateCourseRequest, id uuid.UUID, imageFile, syllabusFile *multipart.FileHeader) (schema.Course, error) {
@@ -170,7 +263,8 @@ func (uc *UseCase) Update(ctx context.Context, req UpdateCourseRequest, id uuid.
 	if err != nil {
 		return schema.Course{}, ErrCourseNotFound.Build()
 	}
-
+	before := revision.Snapshot{Title: course.Title, Description: course.Description}
+	oldPrice := course.Price
 
 	if req.Title != nil {
 		course.Title = *req.Title
@@ -184,8 +278,23 @@ func (uc *UseCase) Update(ctx context.Context, req UpdateCourseRequest, id uuid.
 	if req.Difficulty != nil {
 		course.Difficulty = *req.Difficulty
 	}
-	if req.Category != nil {
-		course.Category = *req.Category
+	if req.CategoryID != nil {
+		categoryID := uuid.MustParse(*req.CategoryID)
+		if err := uc.taxonomyUseCase.ValidateCategory(categoryID); err != nil {
+			return schema.Course{}, err
+		}
+		course.CategoryID = &categoryID
+		course.Category = nil
+	}
+	if req.Language != nil {
+		course.Language = *req.Language
+	}
+	if req.MaxSeats != nil {
+		// Zero lifts the limit
+		course.MaxSeats = req.MaxSeats
+		if *req.MaxSeats == 0 {
+			course.MaxSeats = nil
+		}
 	}
 
 	// Handle image update if file is provided
@@ -248,20 +357,35 @@ func (uc *UseCase) Update(ctx context.Context, req UpdateCourseRequest, id uuid.
 	if err != nil {
 		return schema.Course{}, fmt.Errorf("failed to update course: %v", err)
 	}
+	after := revision.Snapshot{Title: course.Title, Description: course.Description}
+	if err := uc.revisionUseCase.Record(ctx, schema.RevisionEntityCourse, course.ID, course.ID, before, after); err != nil {
+		log.Println("Error recording course revision: ", err)
+	}
 
-	return course, nil
-}
+	if course.Price != oldPrice {
+		uc.recordPrice(ctx, &course, &oldPrice)
+	}
+	if course.Price < oldPrice {
+		updated := course
+		go func() {
+			if err := uc.wishlistUseCase.NotifyPriceDrop(&updated, oldPrice); err != nil {
+				log.Println("Error notifying wishlists of price drop: ", err)
+			}
+		}()
+	}
 
-func (uc *UseCase) SearchCoursesByTitle(ctx context.Context, title string, page, pageSize int) (CoursesPaginatedResponse, error) {
-	courses, total, err := uc.courseRepo.SearchByTitle(ctx, title, page, pageSize)
-	if err != nil {
-		return CoursesPaginatedResponse{}, err
+	if len(req.Tags) > 0 {
+		tags, err := uc.taxonomyUseCase.SetCourseTags(course.ID, req.Tags)
+		if err != nil {
+			return schema.Course{}, err
+		}
+		course.Tags = tags
 	}
-	pag := pagination.NewPagination(total, page, pageSize)
-	return CoursesPaginatedResponse{
-		Courses:    courses,
-		Pagination: pag,
-	}, nil
+
+	if err := uc.pricingUseCase.ApplySales([]*schema.Course{&course}); err != nil {
+		return schema.Course{}, err
+	}
+	return course, nil
 }
 
 //go:embed buy_course_instructor_email_template.html
@@ -274,6 +398,10 @@ func (uc *UseCase) BuyCourse(ctx context.Context, courseId uuid.UUID, studentId
 		return ErrCourseNotFound.Build()
 	}
 
+	if course.Status != schema.CourseStatusPublished && course.Status != schema.CourseStatusUnlisted {
+		return ErrCourseNotPurchasable.Build()
+	}
+
 	studentUUID, err := uuid.Parse(studentId)
 	if err != nil {
 
@@ -289,14 +417,20 @@ func (uc *UseCase) BuyCourse(ctx context.Context, courseId uuid.UUID, studentId
 		return ErrAlreadyEnrolled.Build() 
 	}
 
-	err = uc.walletRepo.TransferByUserID(nil, studentUUID, course.InstructorID, course.Price)
-
-	if err != nil {
+	if err := uc.checkPrerequisites(ctx, course.ID, studentUUID); err != nil {
 		return err
 	}
 
-	err = uc.courseEnrollUseCase.EnrollStudent(ctx, studentUUID, course.ID)
+	// A running sale decides the price at the moment of purchase
+	if err := uc.pricingUseCase.ApplySales([]*schema.Course{&course}); err != nil {
+		return err
+	}
 
+	// Seats are counted, paid for and taken in one transaction under a lock on the course, so concurrent buyers
+	// cannot overfill it and the same student cannot pay twice
+	err = uc.waitlistUseCase.Purchase(course.ID, studentUUID, course.EffectivePrice, func(tx *gorm.DB) error {
+		return uc.walletRepo.TransferByUserID(tx, studentUUID, course.InstructorID, course.EffectivePrice)
+	})
 	if err != nil {
 		return err
 	}
@@ -408,33 +542,71 @@ func (uc *UseCase) GetUserCourseProgress(ctx context.Context, courseId uuid.UUID
 	return uc.courseRepo.GetUserCourseProgress(ctx, course.ID, studentUUID)
 }
 
-func (uc *UseCase) FilterCourses(ctx context.Context, req FilterCoursesRequest) (*CoursesPaginatedResponse, error) {
-	var filterType, filterValue, sort string
-
+func (uc *UseCase) FilterCourses(ctx context.Context, req FilterCoursesRequest) (*CatalogResponse, error) {
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		return nil, ErrInvalidCourseData.WithPayload("min_price must not exceed max_price").Build()
+	}
 
-	if req.Category != nil {
-		filterType = "category"
-		filterValue = string(*req.Category)
-	} else if req.Difficulty != nil {
-		filterType = "difficulty"
-		filterValue = string(*req.Difficulty)
-	} else if req.Rating != nil {
-		filterType = "rating"
-		filterValue = fmt.Sprintf("%.1f", *req.Rating)
-	} else if req.Sort != nil {
-		sort = string(*req.Sort)
+	filter := CatalogFilter{
+		Categories:   slugs(req.Categories),
+		Tags:         slugs(req.Tags),
+		Difficulties: req.Difficulties,
+		Languages:    req.Languages,
+		MinPrice:     req.MinPrice,
+		MaxPrice:     req.MaxPrice,
+		FreeOnly:     req.FreeOnly,
+		MinRating:    req.MinRating,
+		Sort:         req.Sort,
+	}
+	if req.InstructorID != "" {
+		instructorID := uuid.MustParse(req.InstructorID)
+		filter.InstructorID = &instructorID
 	}
 
-	// Query the repository with the constructed filters and sorting
-	courses, total, err := uc.courseRepo.DynamicFilterCourses(ctx, filterType, filterValue, sort, req.Page, req.Limit)
+	courses, total, err := uc.courseRepo.FindCatalog(ctx, filter, req.Page, req.Limit)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.applySales(courses); err != nil {
+		return nil, err
+	}
 
-	pagination := pagination.NewPagination(int(total), req.Page, req.Limit)
+	facets, err := uc.courseRepo.GetCatalogFacets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
 
-	return &CoursesPaginatedResponse{
+	return &CatalogResponse{
 		Courses:    courses,
-		Pagination: pagination,
+		Pagination: pagination.NewPagination(total, req.Page, req.Limit),
+		Facets:     facets,
 	}, nil
 }
+
+// slugs normalizes user-typed filter values so "Web Development" and "web-development" match the same category
+func slugs(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if slug := taxonomy.Slugify(value); slug != "" {
+			out = append(out, slug)
+		}
+	}
+	return out
+}
+
+// SetTags replaces the tags of a course
+func (uc *UseCase) SetTags(ctx context.Context, courseID uuid.UUID, req SetCourseTagsRequest) ([]schema.Tag, error) {
+	course, err := uc.courseRepo.GetByID(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course by id: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return uc.taxonomyUseCase.SetCourseTags(course.ID, req.Tags)
+}
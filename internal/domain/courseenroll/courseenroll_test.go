@@ -3,6 +3,7 @@ package courseenroll
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
@@ -35,6 +36,11 @@ func (m *MockEnrollRepository) IsEnrolled(ctx context.Context, userID, courseID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockEnrollRepository) MarkCompleted(ctx context.Context, userID, courseID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, courseID, at)
+	return args.Error(0)
+}
+
 type CourseEnrollUseCaseTestSuite struct {
 	suite.Suite
 	enrollRepo *MockEnrollRepository
@@ -56,7 +62,7 @@ func (suite *CourseEnrollUseCaseTestSuite) TestEnrollStudent() {
 
     suite.enrollRepo.On("Create", ctx, mock.AnythingOfType("*schema.CourseEnroll")).Return(nil)
 
-    err := suite.enrollUseCase.EnrollStudent(ctx, userID, courseID)
+    err := suite.enrollUseCase.EnrollStudent(ctx, userID, courseID, 10000)
 
     assert.NoError(suite.T(), err)
     suite.enrollRepo.AssertExpectations(suite.T())
@@ -2,6 +2,7 @@ package courseenroll
 import (
     "context"
     "github.com/google/uuid"
+    "time"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
     "gorm.io/gorm"
 )
@@ -11,6 +12,7 @@ type Repository interface {
     GetUsersByCourseID(ctx context.Context, courseID uuid.UUID) ([]schema.User, error)
     GetCoursesByUserID(ctx context.Context, userID uuid.UUID) ([]schema.Course, error)
 	IsEnrolled(ctx context.Context, userID, courseID uuid.UUID) (bool, error)
+	MarkCompleted(ctx context.Context, userID, courseID uuid.UUID, at time.Time) error
 }
 
 type repository struct {
@@ -50,3 +52,10 @@ func (r *repository) IsEnrolled(ctx context.Context, userID, courseID uuid.UUID)
     }
     return count > 0, nil
 }
+
+// MarkCompleted only stamps the first completion; later calls leave the original timestamp in place
+func (r *repository) MarkCompleted(ctx context.Context, userID, courseID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&schema.CourseEnroll{}).
+		Where("user_id = ? AND course_id = ? AND completed_at IS NULL", userID, courseID).
+		Update("completed_at", at).Error
+}
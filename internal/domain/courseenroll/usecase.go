@@ -17,16 +17,17 @@ func NewUseCase(repo Repository) *UseCase {
 	return &UseCase{repo: repo}
 }
 
-func (uc *UseCase) EnrollStudent(ctx context.Context, userID, courseID uuid.UUID) error {
+func (uc *UseCase) EnrollStudent(ctx context.Context, userID, courseID uuid.UUID, amountPaid int64) error {
 	id, err := uuid.NewV7()
 	if err != nil {
 		return apierror.ErrInternalServer.Build()
 	}
 	enroll := schema.CourseEnroll{
-		ID:        id,
-		UserID:    userID,
-		CourseID:  courseID,
-		CreatedAt: time.Now(),
+		ID:         id,
+		UserID:     userID,
+		CourseID:   courseID,
+		CreatedAt:  time.Now(),
+		AmountPaid: &amountPaid,
 	}
 	return uc.repo.Create(ctx, &enroll)
 }
@@ -0,0 +1,366 @@
+package curriculum
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CreateSection(section *schema.CourseSection) error {
+	args := m.Called(section)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetSectionByID(id uuid.UUID) (*schema.CourseSection, error) {
+	args := m.Called(id)
+	section, ok := args.Get(0).(*schema.CourseSection)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return section, args.Error(1)
+}
+
+func (m *MockRepository) GetSectionsByCourseID(courseID uuid.UUID) ([]*schema.CourseSection, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]*schema.CourseSection), args.Error(1)
+}
+
+func (m *MockRepository) UpdateSection(section *schema.CourseSection) error {
+	args := m.Called(section)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteSection(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetItemsByCourseID(courseID uuid.UUID) ([]*Item, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]*Item), args.Error(1)
+}
+
+func (m *MockRepository) ReorderSections(courseID uuid.UUID, sectionIDs []uuid.UUID) error {
+	args := m.Called(courseID, sectionIDs)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SetSectionItems(sectionID uuid.UUID, items []ItemRef) error {
+	args := m.Called(sectionID, items)
+	return args.Error(0)
+}
+
+type MockCourseRepository struct {
+	mock.Mock
+}
+
+func (m *MockCourseRepository) GetAll(ctx context.Context, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetByID(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetRating(ctx context.Context, courseID uuid.UUID) (float32, int64, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).(float32), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCourseRepository) Create(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) Update(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, publishedOnly bool, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, instructorID, publishedOnly, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) FindByPopularity(ctx context.Context, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetUserCourseProgress(ctx context.Context, courseID, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, courseID, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCourseRepository) Search(ctx context.Context, query string, page, pageSize int) ([]course.SearchHit, int, error) {
+	args := m.Called(ctx, query, page, pageSize)
+	return args.Get(0).([]course.SearchHit), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) Suggest(ctx context.Context, prefix string, limit int) ([]course.Suggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]course.Suggestion), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindCatalog(ctx context.Context, filter course.CatalogFilter, page, limit int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, filter, page, limit)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetCatalogFacets(ctx context.Context, filter course.CatalogFilter) (course.CatalogFacets, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(course.CatalogFacets), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindByStatus(ctx context.Context, status schema.CourseStatus, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, status, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) UpdateStatus(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetPrerequisites(ctx context.Context, courseID uuid.UUID) ([]course.PrerequisiteCourse, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]course.PrerequisiteCourse), args.Error(1)
+}
+
+func (m *MockCourseRepository) SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisites []schema.CoursePrerequisite) error {
+	args := m.Called(ctx, courseID, prerequisites)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetPrerequisiteClosure(ctx context.Context, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCompletedCourseIDs(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, userID, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCloneSource(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetSections(ctx context.Context, courseID uuid.UUID) ([]schema.CourseSection, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]schema.CourseSection), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateWithContent(ctx context.Context, course *schema.Course, sections []schema.CourseSection) error {
+	args := m.Called(ctx, course, sections)
+	return args.Error(0)
+}
+
+type CurriculumUseCaseTestSuite struct {
+	suite.Suite
+	repo       *MockRepository
+	courseRepo *MockCourseRepository
+	uc         *UseCase
+}
+
+func (s *CurriculumUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.courseRepo = new(MockCourseRepository)
+	s.uc = NewUseCase(s.repo, s.courseRepo)
+}
+
+func TestCurriculumUseCase(t *testing.T) {
+	suite.Run(t, new(CurriculumUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID, role string) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", role)
+}
+
+func (s *CurriculumUseCaseTestSuite) TestGetCurriculum_BuildsTree() {
+	courseID := uuid.New()
+	sectionA, sectionB := uuid.New(), uuid.New()
+	now := time.Now()
+	material1, material2, assignment1, loose := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	s.courseRepo.On("GetByID", mock.Anything, courseID).
+		Return(schema.Course{ID: courseID, Status: schema.CourseStatusPublished}, nil)
+	s.repo.On("GetSectionsByCourseID", courseID).Return([]*schema.CourseSection{
+		{ID: sectionA, CourseID: courseID, Title: "Intro", Position: 0},
+		{ID: sectionB, CourseID: courseID, Title: "Deep dive", Position: 1},
+	}, nil)
+	s.repo.On("GetItemsByCourseID", courseID).Return([]*Item{
+		{Type: schema.CurriculumItemMaterial, ID: material1, SectionID: &sectionA, Position: 1, CreatedAt: now},
+		{Type: schema.CurriculumItemMaterial, ID: material2, SectionID: &sectionB, Position: 0, CreatedAt: now},
+		{Type: schema.CurriculumItemAssignment, ID: assignment1, SectionID: &sectionA, Position: 0, CreatedAt: now},
+		{Type: schema.CurriculumItemMaterial, ID: loose, CreatedAt: now},
+	}, nil)
+
+	res, err := s.uc.GetCurriculum(context.Background(), &CourseIDRequest{CourseID: courseID.String()})
+
+	s.NoError(err)
+	s.Len(res.Sections, 2)
+	s.Equal(assignment1, res.Sections[0].Items[0].ID)
+	s.Equal(material1, res.Sections[0].Items[1].ID)
+	s.Equal(material2, res.Sections[1].Items[0].ID)
+	s.Len(res.Unsectioned, 1)
+	s.Equal(loose, res.Unsectioned[0].ID)
+}
+
+func (s *CurriculumUseCaseTestSuite) TestGetCurriculum_DraftHiddenFromAnonymous() {
+	courseID := uuid.New()
+	s.courseRepo.On("GetByID", mock.Anything, courseID).
+		Return(schema.Course{ID: courseID, InstructorID: uuid.New(), Status: schema.CourseStatusDraft}, nil)
+
+	_, err := s.uc.GetCurriculum(context.Background(), &CourseIDRequest{CourseID: courseID.String()})
+
+	assert.Equal(s.T(), course.ErrCourseNotFound.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "GetSectionsByCourseID", mock.Anything)
+}
+
+func (s *CurriculumUseCaseTestSuite) TestGetCurriculum_DraftVisibleToOwner() {
+	courseID, instructorID := uuid.New(), uuid.New()
+	s.courseRepo.On("GetByID", mock.Anything, courseID).
+		Return(schema.Course{ID: courseID, InstructorID: instructorID, Status: schema.CourseStatusDraft}, nil)
+	s.repo.On("GetSectionsByCourseID", courseID).Return([]*schema.CourseSection{}, nil)
+	s.repo.On("GetItemsByCourseID", courseID).Return([]*Item{}, nil)
+
+	res, err := s.uc.GetCurriculum(userContext(instructorID, "instructor"), &CourseIDRequest{CourseID: courseID.String()})
+
+	s.NoError(err)
+	s.Empty(res.Sections)
+}
+
+func (s *CurriculumUseCaseTestSuite) TestCreateSection_AppendsAtEnd() {
+	courseID, instructorID := uuid.New(), uuid.New()
+	s.courseRepo.On("GetByID", mock.Anything, courseID).
+		Return(schema.Course{ID: courseID, InstructorID: instructorID}, nil)
+	s.repo.On("GetSectionsByCourseID", courseID).Return([]*schema.CourseSection{
+		{ID: uuid.New(), Position: 0},
+		{ID: uuid.New(), Position: 3},
+	}, nil)
+	s.repo.On("CreateSection", mock.MatchedBy(func(section *schema.CourseSection) bool {
+		return section.CourseID == courseID && section.Position == 4 && section.Title == "Wrap up"
+	})).Return(nil)
+
+	_, err := s.uc.CreateSection(userContext(instructorID, "instructor"),
+		&CourseIDRequest{CourseID: courseID.String()}, &CreateSectionRequest{Title: "Wrap up"})
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *CurriculumUseCaseTestSuite) TestCreateSection_NotOwner() {
+	courseID := uuid.New()
+	s.courseRepo.On("GetByID", mock.Anything, courseID).
+		Return(schema.Course{ID: courseID, InstructorID: uuid.New()}, nil)
+
+	_, err := s.uc.CreateSection(userContext(uuid.New(), "instructor"),
+		&CourseIDRequest{CourseID: courseID.String()}, &CreateSectionRequest{Title: "Wrap up"})
+
+	assert.Equal(s.T(), apierror.ErrNotYourResource.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "CreateSection", mock.Anything)
+}
+
+func (s *CurriculumUseCaseTestSuite) TestReorderSections_Success() {
+	courseID, instructorID := uuid.New(), uuid.New()
+	first, second := uuid.New(), uuid.New()
+	s.courseRepo.On("GetByID", mock.Anything, courseID).
+		Return(schema.Course{ID: courseID, InstructorID: instructorID}, nil)
+	s.repo.On("GetSectionsByCourseID", courseID).Return([]*schema.CourseSection{{ID: first}, {ID: second}}, nil)
+	s.repo.On("ReorderSections", courseID, []uuid.UUID{second, first}).Return(nil)
+
+	err := s.uc.ReorderSections(userContext(instructorID, "instructor"), &CourseIDRequest{CourseID: courseID.String()},
+		&ReorderSectionsRequest{SectionIDs: []string{second.String(), first.String()}})
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *CurriculumUseCaseTestSuite) TestReorderSections_NotAPermutation() {
+	courseID, instructorID := uuid.New(), uuid.New()
+	first, second := uuid.New(), uuid.New()
+	s.courseRepo.On("GetByID", mock.Anything, courseID).
+		Return(schema.Course{ID: courseID, InstructorID: instructorID}, nil)
+	s.repo.On("GetSectionsByCourseID", courseID).Return([]*schema.CourseSection{{ID: first}, {ID: second}}, nil)
+
+	err := s.uc.ReorderSections(userContext(instructorID, "instructor"), &CourseIDRequest{CourseID: courseID.String()},
+		&ReorderSectionsRequest{SectionIDs: []string{first.String(), first.String()}})
+
+	assert.Equal(s.T(), ErrInvalidOrder.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "ReorderSections", mock.Anything, mock.Anything)
+}
+
+func (s *CurriculumUseCaseTestSuite) TestSetSectionItems_ItemFromAnotherCourse() {
+	courseID, instructorID, sectionID := uuid.New(), uuid.New(), uuid.New()
+	s.repo.On("GetSectionByID", sectionID).Return(&schema.CourseSection{ID: sectionID, CourseID: courseID}, nil)
+	s.courseRepo.On("GetByID", mock.Anything, courseID).
+		Return(schema.Course{ID: courseID, InstructorID: instructorID}, nil)
+	s.repo.On("GetItemsByCourseID", courseID).Return([]*Item{
+		{Type: schema.CurriculumItemMaterial, ID: uuid.New()},
+	}, nil)
+
+	err := s.uc.SetSectionItems(userContext(instructorID, "instructor"), &SectionIDRequest{SectionID: sectionID.String()},
+		&SetSectionItemsRequest{Items: []ItemRef{{Type: schema.CurriculumItemMaterial, ID: uuid.NewString()}}})
+
+	s.Equal(ErrItemNotInCourse.Build().Error(), err.Error())
+	s.repo.AssertNotCalled(s.T(), "SetSectionItems", mock.Anything, mock.Anything)
+}
+
+func (s *CurriculumUseCaseTestSuite) TestSetSectionItems_Success() {
+	courseID, instructorID, sectionID := uuid.New(), uuid.New(), uuid.New()
+	materialID, assignmentID := uuid.New(), uuid.New()
+	s.repo.On("GetSectionByID", sectionID).Return(&schema.CourseSection{ID: sectionID, CourseID: courseID}, nil)
+	s.courseRepo.On("GetByID", mock.Anything, courseID).
+		Return(schema.Course{ID: courseID, InstructorID: instructorID}, nil)
+	s.repo.On("GetItemsByCourseID", courseID).Return([]*Item{
+		{Type: schema.CurriculumItemMaterial, ID: materialID},
+		{Type: schema.CurriculumItemAssignment, ID: assignmentID},
+	}, nil)
+	items := []ItemRef{
+		{Type: schema.CurriculumItemAssignment, ID: assignmentID.String()},
+		{Type: schema.CurriculumItemMaterial, ID: materialID.String()},
+	}
+	s.repo.On("SetSectionItems", sectionID, items).Return(nil)
+
+	err := s.uc.SetSectionItems(userContext(instructorID, "instructor"), &SectionIDRequest{SectionID: sectionID.String()},
+		&SetSectionItemsRequest{Items: items})
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *CurriculumUseCaseTestSuite) TestDeleteSection_NotFound() {
+	sectionID := uuid.New()
+	s.repo.On("GetSectionByID", sectionID).Return(nil, gorm.ErrRecordNotFound)
+
+	err := s.uc.DeleteSection(userContext(uuid.New(), "instructor"), &SectionIDRequest{SectionID: sectionID.String()})
+
+	assert.Equal(s.T(), ErrSectionNotFound.Build(), err)
+}
@@ -0,0 +1,62 @@
+package curriculum
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"time"
+)
+
+type CourseIDRequest struct {
+	CourseID string `uri:"id" binding:"required,uuid"`
+}
+
+type SectionIDRequest struct {
+	SectionID string `uri:"id" binding:"required,uuid"`
+}
+
+type CreateSectionRequest struct {
+	Title string `json:"title" binding:"required,max=150"`
+}
+
+type UpdateSectionRequest struct {
+	Title string `json:"title" binding:"required,max=150"`
+}
+
+type ReorderSectionsRequest struct {
+	SectionIDs []string `json:"section_ids" binding:"required,dive,uuid"`
+}
+
+type ItemRef struct {
+	Type schema.CurriculumItemType `json:"type" binding:"required,oneof=material assignment"`
+	ID   string                    `json:"id" binding:"required,uuid"`
+}
+
+// SetSectionItemsRequest replaces the contents of a section; items left out are moved back to unsectioned
+type SetSectionItemsRequest struct {
+	Items []ItemRef `json:"items" binding:"required,dive"`
+}
+
+type Item struct {
+	Type         schema.CurriculumItemType `json:"type"`
+	ID           uuid.UUID                 `json:"id"`
+	Title        string                    `json:"title"`
+	Position     int                       `json:"position"`
+	Due          *time.Time                `json:"due,omitempty"`
+	DueAfterDays *int                      `json:"due_after_days,omitempty"`
+	SectionID    *uuid.UUID                `json:"-"`
+	CreatedAt    time.Time                 `json:"-"`
+}
+
+type SectionResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Title    string    `json:"title"`
+	Position int       `json:"position"`
+	Items    []*Item   `json:"items"`
+}
+
+type CurriculumResponse struct {
+	CourseID uuid.UUID          `json:"course_id"`
+	Sections []*SectionResponse `json:"sections"`
+	// Unsectioned holds items that have not been placed in a section yet
+	Unsectioned []*Item `json:"unsectioned"`
+}
@@ -0,0 +1,20 @@
+package curriculum
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrSectionNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("SECTION_NOT_FOUND")
+
+	ErrInvalidOrder = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusBadRequest).
+			WithMessage("INVALID_ORDER")
+
+	ErrItemNotInCourse = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("ITEM_NOT_IN_COURSE")
+)
@@ -0,0 +1,166 @@
+package curriculum
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+type IRepository interface {
+	CreateSection(section *schema.CourseSection) error
+	GetSectionByID(id uuid.UUID) (*schema.CourseSection, error)
+	GetSectionsByCourseID(courseID uuid.UUID) ([]*schema.CourseSection, error)
+	UpdateSection(section *schema.CourseSection) error
+	DeleteSection(id uuid.UUID) error
+	GetItemsByCourseID(courseID uuid.UUID) ([]*Item, error)
+	ReorderSections(courseID uuid.UUID, sectionIDs []uuid.UUID) error
+	SetSectionItems(sectionID uuid.UUID, items []ItemRef) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db}
+}
+
+func (r *repository) CreateSection(section *schema.CourseSection) error {
+	return r.db.Create(section).Error
+}
+
+func (r *repository) GetSectionByID(id uuid.UUID) (*schema.CourseSection, error) {
+	var section schema.CourseSection
+	if err := r.db.First(&section, id).Error; err != nil {
+		return nil, err
+	}
+	return &section, nil
+}
+
+func (r *repository) GetSectionsByCourseID(courseID uuid.UUID) ([]*schema.CourseSection, error) {
+	var sections []*schema.CourseSection
+	err := r.db.Where("course_id = ?", courseID).Order("position, created_at").Find(&sections).Error
+	return sections, err
+}
+
+func (r *repository) UpdateSection(section *schema.CourseSection) error {
+	tx := r.db.Updates(section)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteSection keeps the section's materials and assignments, moving them back to unsectioned
+func (r *repository) DeleteSection(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{&schema.Material{}, &schema.Assignment{}} {
+			if err := tx.Model(model).Where("section_id = ?", id).
+				Updates(map[string]any{"section_id": nil, "position": 0}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&schema.CourseSection{}, id).Error
+	})
+}
+
+type itemRow struct {
+	ID           uuid.UUID
+	Title        string
+	SectionID    *uuid.UUID
+	Position     int
+	Due          *time.Time
+	DueAfterDays *int
+	CreatedAt    time.Time
+}
+
+func (r *repository) GetItemsByCourseID(courseID uuid.UUID) ([]*Item, error) {
+	var materials, assignments []itemRow
+	if err := r.db.Model(&schema.Material{}).
+		Select("id, title, section_id, position, created_at").
+		Where("course_id = ?", courseID).
+		Scan(&materials).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&schema.Assignment{}).
+		Select("id, title, section_id, position, due, due_after_days, created_at").
+		Where("course_id = ?", courseID).
+		Scan(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*Item, 0, len(materials)+len(assignments))
+	for _, rows := range []struct {
+		itemType schema.CurriculumItemType
+		rows     []itemRow
+	}{
+		{schema.CurriculumItemMaterial, materials},
+		{schema.CurriculumItemAssignment, assignments},
+	} {
+		for _, row := range rows.rows {
+			items = append(items, &Item{
+				Type:         rows.itemType,
+				ID:           row.ID,
+				Title:        row.Title,
+				Position:     row.Position,
+				Due:          row.Due,
+				DueAfterDays: row.DueAfterDays,
+				SectionID:    row.SectionID,
+				CreatedAt:    row.CreatedAt,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+func (r *repository) ReorderSections(courseID uuid.UUID, sectionIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range sectionIDs {
+			if err := tx.Model(&schema.CourseSection{}).
+				Where("id = ? AND course_id = ?", id, courseID).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func itemModel(itemType schema.CurriculumItemType) any {
+	if itemType == schema.CurriculumItemAssignment {
+		return &schema.Assignment{}
+	}
+	return &schema.Material{}
+}
+
+func (r *repository) SetSectionItems(sectionID uuid.UUID, items []ItemRef) error {
+	keep := map[schema.CurriculumItemType][]string{}
+	for _, item := range items {
+		keep[item.Type] = append(keep[item.Type], item.ID)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, itemType := range []schema.CurriculumItemType{schema.CurriculumItemMaterial, schema.CurriculumItemAssignment} {
+			query := tx.Model(itemModel(itemType)).Where("section_id = ?", sectionID)
+			if ids := keep[itemType]; len(ids) > 0 {
+				query = query.Where("id NOT IN ?", ids)
+			}
+			if err := query.Updates(map[string]any{"section_id": nil, "position": 0}).Error; err != nil {
+				return err
+			}
+		}
+
+		for position, item := range items {
+			if err := tx.Model(itemModel(item.Type)).Where("id = ?", item.ID).
+				Updates(map[string]any{"section_id": sectionID, "position": position}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
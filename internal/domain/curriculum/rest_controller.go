@@ -0,0 +1,172 @@
+package curriculum
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	courseGroup := engine.Group("/v1/courses/:id")
+	{
+		courseGroup.GET("/curriculum", middleware.OptionalAuthenticate(), controller.GetCurriculum())
+		courseGroup.POST("/sections",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor"),
+			controller.CreateSection(),
+		)
+		courseGroup.PUT("/sections/order",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor"),
+			controller.ReorderSections(),
+		)
+	}
+
+	sectionGroup := engine.Group("/v1/sections")
+	sectionGroup.Use(middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"))
+	{
+		sectionGroup.PATCH("/:id", controller.UpdateSection())
+		sectionGroup.DELETE("/:id", controller.DeleteSection())
+		sectionGroup.PUT("/:id/items", controller.SetSectionItems())
+	}
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) GetCurriculum() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CourseIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.GetCurriculum(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_CURRICULUM_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) CreateSection() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var courseReq CourseIDRequest
+		if err := ctx.ShouldBindUri(&courseReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req CreateSectionRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.CreateSection(ctx, &courseReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "CREATE_SECTION_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) ReorderSections() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var courseReq CourseIDRequest
+		if err := ctx.ShouldBindUri(&courseReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req ReorderSectionsRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.ReorderSections(ctx, &courseReq, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "REORDER_SECTIONS_SUCCESS", nil).Send(ctx)
+	}
+}
+
+func (c *RestController) UpdateSection() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var sectionReq SectionIDRequest
+		if err := ctx.ShouldBindUri(&sectionReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req UpdateSectionRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.UpdateSection(ctx, &sectionReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "UPDATE_SECTION_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) DeleteSection() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req SectionIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.DeleteSection(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "DELETE_SECTION_SUCCESS", nil).Send(ctx)
+	}
+}
+
+func (c *RestController) SetSectionItems() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var sectionReq SectionIDRequest
+		if err := ctx.ShouldBindUri(&sectionReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req SetSectionItemsRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.SetSectionItems(ctx, &sectionReq, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "SET_SECTION_ITEMS_SUCCESS", nil).Send(ctx)
+	}
+}
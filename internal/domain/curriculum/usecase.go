@@ -0,0 +1,294 @@
+package curriculum
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"log"
+	"sort"
+)
+
+type UseCase struct {
+	repo       IRepository
+	courseRepo course.Repository
+}
+
+func NewUseCase(repo IRepository, courseRepo course.Repository) *UseCase {
+	return &UseCase{repo: repo, courseRepo: courseRepo}
+}
+
+func (uc *UseCase) getCourse(ctx context.Context, courseID uuid.UUID) (*schema.Course, error) {
+	courseObj, err := uc.courseRepo.GetByID(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, course.ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return &courseObj, nil
+}
+
+func (uc *UseCase) getOwnedCourse(ctx context.Context, courseID uuid.UUID) (*schema.Course, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	courseObj, err := uc.getCourse(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if courseObj.InstructorID != userID {
+		return nil, apierror.ErrNotYourResource.Build()
+	}
+	return courseObj, nil
+}
+
+func (uc *UseCase) getOwnedSection(ctx context.Context, sectionID uuid.UUID) (*schema.CourseSection, error) {
+	section, err := uc.repo.GetSectionByID(sectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSectionNotFound.Build()
+		}
+		log.Println("Error getting section: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	if _, err := uc.getOwnedCourse(ctx, section.CourseID); err != nil {
+		return nil, err
+	}
+	return section, nil
+}
+
+// canViewUnpublished reports whether the caller may see a course that is not in the catalog yet.
+// Anonymous callers have no user.id in the context.
+func canViewUnpublished(ctx context.Context, courseObj *schema.Course) bool {
+	if role, ok := ctx.Value("user.role").(string); ok && role == string(schema.RoleAdmin) {
+		return true
+	}
+	rawUserID, ok := ctx.Value("user.id").(string)
+	if !ok {
+		return false
+	}
+	userID, err := uuid.Parse(rawUserID)
+	return err == nil && userID == courseObj.InstructorID
+}
+
+func sortItems(items []*Item) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+}
+
+func (uc *UseCase) GetCurriculum(ctx context.Context, req *CourseIDRequest) (*CurriculumResponse, error) {
+	courseObj, err := uc.getCourse(ctx, uuid.MustParse(req.CourseID))
+	if err != nil {
+		return nil, err
+	}
+	if (courseObj.Status == schema.CourseStatusDraft || courseObj.Status == schema.CourseStatusInReview) &&
+		!canViewUnpublished(ctx, courseObj) {
+		return nil, course.ErrCourseNotFound.Build()
+	}
+
+	sections, err := uc.repo.GetSectionsByCourseID(courseObj.ID)
+	if err != nil {
+		log.Println("Error getting sections: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	items, err := uc.repo.GetItemsByCourseID(courseObj.ID)
+	if err != nil {
+		log.Println("Error getting curriculum items: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	res := &CurriculumResponse{
+		CourseID:    courseObj.ID,
+		Sections:    make([]*SectionResponse, 0, len(sections)),
+		Unsectioned: make([]*Item, 0),
+	}
+	bySection := make(map[uuid.UUID]*SectionResponse, len(sections))
+	for _, section := range sections {
+		sectionRes := &SectionResponse{
+			ID:       section.ID,
+			Title:    section.Title,
+			Position: section.Position,
+			Items:    make([]*Item, 0),
+		}
+		bySection[section.ID] = sectionRes
+		res.Sections = append(res.Sections, sectionRes)
+	}
+
+	for _, item := range items {
+		if item.SectionID != nil {
+			if sectionRes, ok := bySection[*item.SectionID]; ok {
+				sectionRes.Items = append(sectionRes.Items, item)
+				continue
+			}
+		}
+		res.Unsectioned = append(res.Unsectioned, item)
+	}
+
+	for _, sectionRes := range res.Sections {
+		sortItems(sectionRes.Items)
+	}
+	sort.SliceStable(res.Unsectioned, func(i, j int) bool {
+		return res.Unsectioned[i].CreatedAt.Before(res.Unsectioned[j].CreatedAt)
+	})
+
+	return res, nil
+}
+
+func (uc *UseCase) CreateSection(ctx context.Context, courseReq *CourseIDRequest, req *CreateSectionRequest) (*schema.CourseSection, error) {
+	courseObj, err := uc.getOwnedCourse(ctx, uuid.MustParse(courseReq.CourseID))
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := uc.repo.GetSectionsByCourseID(courseObj.ID)
+	if err != nil {
+		log.Println("Error getting sections: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	position := 0
+	for _, section := range sections {
+		if section.Position >= position {
+			position = section.Position + 1
+		}
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	section := &schema.CourseSection{
+		ID:       id,
+		CourseID: courseObj.ID,
+		Title:    req.Title,
+		Position: position,
+	}
+	if err := uc.repo.CreateSection(section); err != nil {
+		log.Println("Error creating section: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return section, nil
+}
+
+func (uc *UseCase) UpdateSection(ctx context.Context, sectionReq *SectionIDRequest, req *UpdateSectionRequest) (*schema.CourseSection, error) {
+	section, err := uc.getOwnedSection(ctx, uuid.MustParse(sectionReq.SectionID))
+	if err != nil {
+		return nil, err
+	}
+
+	section.Title = req.Title
+	if err := uc.repo.UpdateSection(section); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSectionNotFound.Build()
+		}
+		log.Println("Error updating section: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return section, nil
+}
+
+func (uc *UseCase) DeleteSection(ctx context.Context, req *SectionIDRequest) error {
+	section, err := uc.getOwnedSection(ctx, uuid.MustParse(req.SectionID))
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.DeleteSection(section.ID); err != nil {
+		log.Println("Error deleting section: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	return nil
+}
+
+// ReorderSections requires the full list of the course's sections so positions never end up ambiguous
+func (uc *UseCase) ReorderSections(ctx context.Context, courseReq *CourseIDRequest, req *ReorderSectionsRequest) error {
+	courseObj, err := uc.getOwnedCourse(ctx, uuid.MustParse(courseReq.CourseID))
+	if err != nil {
+		return err
+	}
+
+	sections, err := uc.repo.GetSectionsByCourseID(courseObj.ID)
+	if err != nil {
+		log.Println("Error getting sections: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	remaining := make(map[uuid.UUID]bool, len(sections))
+	for _, section := range sections {
+		remaining[section.ID] = true
+	}
+	if len(req.SectionIDs) != len(sections) {
+		return ErrInvalidOrder.Build()
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.SectionIDs))
+	for _, rawID := range req.SectionIDs {
+		id := uuid.MustParse(rawID)
+		if !remaining[id] {
+			return ErrInvalidOrder.Build()
+		}
+		delete(remaining, id)
+		ids = append(ids, id)
+	}
+
+	if err := uc.repo.ReorderSections(courseObj.ID, ids); err != nil {
+		log.Println("Error reordering sections: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	return nil
+}
+
+func (uc *UseCase) SetSectionItems(ctx context.Context, sectionReq *SectionIDRequest, req *SetSectionItemsRequest) error {
+	section, err := uc.getOwnedSection(ctx, uuid.MustParse(sectionReq.SectionID))
+	if err != nil {
+		return err
+	}
+
+	items, err := uc.repo.GetItemsByCourseID(section.CourseID)
+	if err != nil {
+		log.Println("Error getting curriculum items: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	inCourse := make(map[ItemRef]bool, len(items))
+	for _, item := range items {
+		inCourse[ItemRef{Type: item.Type, ID: item.ID.String()}] = true
+	}
+
+	seen := make(map[ItemRef]bool, len(req.Items))
+	for i, ref := range req.Items {
+		// Normalize so the same id in a different case is not treated as a separate item
+		ref.ID = uuid.MustParse(ref.ID).String()
+		req.Items[i] = ref
+		if !inCourse[ref] {
+			return ErrItemNotInCourse.WithPayload(ref).Build()
+		}
+		if seen[ref] {
+			return ErrInvalidOrder.Build()
+		}
+		seen[ref] = true
+	}
+
+	if err := uc.repo.SetSectionItems(section.ID, req.Items); err != nil {
+		log.Println("Error setting section items: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	return nil
+}
@@ -0,0 +1,333 @@
+package drip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetMaterialByID(id uuid.UUID) (*schema.Material, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Material), args.Error(1)
+}
+
+func (m *MockRepository) GetSectionByID(id uuid.UUID) (*schema.CourseSection, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.CourseSection), args.Error(1)
+}
+
+func (m *MockRepository) GetSectionsByIDs(ids []uuid.UUID) ([]schema.CourseSection, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]schema.CourseSection), args.Error(1)
+}
+
+func (m *MockRepository) GetCourseInstructors(courseIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	args := m.Called(courseIDs)
+	return args.Get(0).(map[uuid.UUID]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) GetEnrollments(userID uuid.UUID, courseIDs []uuid.UUID) ([]schema.CourseEnroll, error) {
+	args := m.Called(userID, courseIDs)
+	return args.Get(0).([]schema.CourseEnroll), args.Error(1)
+}
+
+func (m *MockRepository) UpdateMaterialRelease(id uuid.UUID, releaseAt *time.Time, releaseAfterDays *int) error {
+	args := m.Called(id, releaseAt, releaseAfterDays)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateSectionRelease(id uuid.UUID, releaseAt *time.Time, releaseAfterDays *int) error {
+	args := m.Called(id, releaseAt, releaseAfterDays)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetUnlockCandidates(from, to time.Time) ([]UnlockCandidate, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]UnlockCandidate), args.Error(1)
+}
+
+func (m *MockRepository) SaveNotices(notices []schema.ContentUnlockNotice, notification *schema.Notification) error {
+	args := m.Called(notices, notification)
+	return args.Error(0)
+}
+
+type DripUseCaseTestSuite struct {
+	suite.Suite
+	repo *MockRepository
+	uc   *UseCase
+
+	courseID     uuid.UUID
+	instructorID uuid.UUID
+	studentID    uuid.UUID
+}
+
+func (s *DripUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.uc = NewUseCase(s.repo)
+
+	s.courseID = uuid.New()
+	s.instructorID = uuid.New()
+	s.studentID = uuid.New()
+}
+
+func TestDripUseCase(t *testing.T) {
+	suite.Run(t, new(DripUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID, role string) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", role)
+}
+
+func (s *DripUseCaseTestSuite) material(releaseAt *time.Time, releaseAfterDays *int) *schema.Material {
+	return &schema.Material{
+		ID:               uuid.New(),
+		CourseID:         s.courseID,
+		Title:            "Week 2",
+		Description:      "Body",
+		ReleaseAt:        releaseAt,
+		ReleaseAfterDays: releaseAfterDays,
+		Attachments:      []schema.Attachment{{ID: uuid.New()}},
+	}
+}
+
+func (s *DripUseCaseTestSuite) expectViewer(enrolledAt *time.Time) {
+	s.repo.On("GetCourseInstructors", []uuid.UUID{s.courseID}).
+		Return(map[uuid.UUID]uuid.UUID{s.courseID: s.instructorID}, nil)
+	enrollments := []schema.CourseEnroll{}
+	if enrolledAt != nil {
+		enrollments = append(enrollments, schema.CourseEnroll{UserID: s.studentID, CourseID: s.courseID, CreatedAt: *enrolledAt})
+	}
+	s.repo.On("GetEnrollments", s.studentID, []uuid.UUID{s.courseID}).Return(enrollments, nil)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_UnscheduledSkipsLookups() {
+	material := s.material(nil, nil)
+
+	err := s.uc.Apply(context.Background(), []*schema.Material{material})
+
+	s.NoError(err)
+	s.False(material.Locked)
+	s.repo.AssertNotCalled(s.T(), "GetCourseInstructors", mock.Anything)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_AbsoluteReleaseInFuture() {
+	releaseAt := time.Now().Add(48 * time.Hour)
+	material := s.material(&releaseAt, nil)
+	s.expectViewer(nil)
+
+	err := s.uc.Apply(userContext(s.studentID, "student"), []*schema.Material{material})
+
+	s.NoError(err)
+	s.True(material.Locked)
+	s.Equal(&releaseAt, material.UnlocksAt)
+	s.Empty(material.Description)
+	s.Empty(material.Attachments)
+	s.Equal("Week 2", material.Title)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_AbsoluteReleaseInPast() {
+	releaseAt := time.Now().Add(-time.Hour)
+	material := s.material(&releaseAt, nil)
+	s.expectViewer(nil)
+
+	err := s.uc.Apply(userContext(s.studentID, "student"), []*schema.Material{material})
+
+	s.NoError(err)
+	s.False(material.Locked)
+	s.Equal("Body", material.Description)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_RelativeRelease() {
+	days := 7
+	enrolledAt := time.Now().AddDate(0, 0, -3)
+	material := s.material(nil, &days)
+	s.expectViewer(&enrolledAt)
+
+	err := s.uc.Apply(userContext(s.studentID, "student"), []*schema.Material{material})
+
+	s.NoError(err)
+	s.True(material.Locked)
+	s.WithinDuration(enrolledAt.AddDate(0, 0, days), *material.UnlocksAt, time.Second)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_RelativeReleaseElapsed() {
+	days := 7
+	enrolledAt := time.Now().AddDate(0, 0, -10)
+	material := s.material(nil, &days)
+	s.expectViewer(&enrolledAt)
+
+	err := s.uc.Apply(userContext(s.studentID, "student"), []*schema.Material{material})
+
+	s.NoError(err)
+	s.False(material.Locked)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_RelativeReleaseLockedForGuests() {
+	days := 0
+	material := s.material(nil, &days)
+
+	err := s.uc.Apply(context.Background(), []*schema.Material{material})
+
+	s.NoError(err)
+	s.True(material.Locked)
+	s.Nil(material.UnlocksAt)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_InheritsSectionSchedule() {
+	releaseAt := time.Now().Add(24 * time.Hour)
+	sectionID := uuid.New()
+	material := s.material(nil, nil)
+	material.SectionID = &sectionID
+	s.repo.On("GetSectionsByIDs", []uuid.UUID{sectionID}).
+		Return([]schema.CourseSection{{ID: sectionID, CourseID: s.courseID, ReleaseAt: &releaseAt}}, nil)
+	s.expectViewer(nil)
+
+	err := s.uc.Apply(userContext(s.studentID, "student"), []*schema.Material{material})
+
+	s.NoError(err)
+	s.True(material.Locked)
+	s.Equal(&releaseAt, material.UnlocksAt)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_MaterialScheduleOverridesSection() {
+	releaseAt := time.Now().Add(-time.Hour)
+	sectionID := uuid.New()
+	material := s.material(&releaseAt, nil)
+	material.SectionID = &sectionID
+	s.expectViewer(nil)
+
+	err := s.uc.Apply(userContext(s.studentID, "student"), []*schema.Material{material})
+
+	s.NoError(err)
+	s.False(material.Locked)
+	s.repo.AssertNotCalled(s.T(), "GetSectionsByIDs", mock.Anything)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_InstructorSeesEverything() {
+	releaseAt := time.Now().Add(48 * time.Hour)
+	material := s.material(&releaseAt, nil)
+	s.repo.On("GetCourseInstructors", []uuid.UUID{s.courseID}).
+		Return(map[uuid.UUID]uuid.UUID{s.courseID: s.instructorID}, nil)
+	s.repo.On("GetEnrollments", s.instructorID, []uuid.UUID{s.courseID}).Return([]schema.CourseEnroll{}, nil)
+
+	err := s.uc.Apply(userContext(s.instructorID, "instructor"), []*schema.Material{material})
+
+	s.NoError(err)
+	s.False(material.Locked)
+}
+
+func (s *DripUseCaseTestSuite) TestApply_AdminSeesEverything() {
+	releaseAt := time.Now().Add(48 * time.Hour)
+	material := s.material(&releaseAt, nil)
+
+	err := s.uc.Apply(userContext(uuid.New(), "admin"), []*schema.Material{material})
+
+	s.NoError(err)
+	s.False(material.Locked)
+	s.repo.AssertNotCalled(s.T(), "GetCourseInstructors", mock.Anything)
+}
+
+func (s *DripUseCaseTestSuite) TestEnsureUnlocked_Locked() {
+	releaseAt := time.Now().Add(48 * time.Hour)
+	material := s.material(&releaseAt, nil)
+	s.expectViewer(nil)
+
+	err := s.uc.EnsureUnlocked(userContext(s.studentID, "student"), material)
+
+	s.Equal(apierror.GetHttpStatus(ErrContentLocked.Build()), apierror.GetHttpStatus(err))
+	s.Equal(ErrContentLocked.Build().Error(), err.Error())
+	s.False(material.Locked)
+	s.Equal("Body", material.Description)
+}
+
+func (s *DripUseCaseTestSuite) TestSetMaterialRelease_NotOwner() {
+	material := s.material(nil, nil)
+	s.repo.On("GetMaterialByID", material.ID).Return(material, nil)
+	s.repo.On("GetCourseInstructors", []uuid.UUID{s.courseID}).
+		Return(map[uuid.UUID]uuid.UUID{s.courseID: s.instructorID}, nil)
+	days := 7
+
+	_, err := s.uc.SetMaterialRelease(userContext(uuid.New(), "instructor"),
+		&IDRequest{ID: material.ID.String()}, &SetReleaseRequest{ReleaseAfterDays: &days})
+
+	s.Equal(apierror.ErrNotYourResource.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "UpdateMaterialRelease", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *DripUseCaseTestSuite) TestSetSectionRelease_Success() {
+	sectionID := uuid.New()
+	s.repo.On("GetSectionByID", sectionID).Return(&schema.CourseSection{ID: sectionID, CourseID: s.courseID}, nil)
+	s.repo.On("GetCourseInstructors", []uuid.UUID{s.courseID}).
+		Return(map[uuid.UUID]uuid.UUID{s.courseID: s.instructorID}, nil)
+	releaseAt := time.Now().Add(24 * time.Hour)
+	s.repo.On("UpdateSectionRelease", sectionID, &releaseAt, (*int)(nil)).Return(nil)
+
+	res, err := s.uc.SetSectionRelease(userContext(s.instructorID, "instructor"),
+		&IDRequest{ID: sectionID.String()}, &SetReleaseRequest{ReleaseAt: &releaseAt})
+
+	s.NoError(err)
+	s.Equal(sectionID, res.ID)
+	s.Equal(&releaseAt, res.ReleaseAt)
+}
+
+func (s *DripUseCaseTestSuite) TestNotifyUnlocked_GroupsPerCourse() {
+	otherStudent := uuid.New()
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	s.repo.On("GetUnlockCandidates", mock.Anything, mock.Anything).Return([]UnlockCandidate{
+		{UserID: s.studentID, MaterialID: first, MaterialTitle: "Week 2", CourseID: s.courseID, CourseTitle: "Go"},
+		{UserID: s.studentID, MaterialID: second, MaterialTitle: "Week 3", CourseID: s.courseID, CourseTitle: "Go"},
+		{UserID: otherStudent, MaterialID: third, MaterialTitle: "Week 2", CourseID: s.courseID, CourseTitle: "Go"},
+	}, nil)
+	s.repo.On("SaveNotices", []schema.ContentUnlockNotice{
+		{UserID: s.studentID, MaterialID: first},
+		{UserID: s.studentID, MaterialID: second},
+	}, mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == s.studentID && n.Detail == "2 new materials are now available in Go"
+	})).Return(nil).Once()
+	s.repo.On("SaveNotices", []schema.ContentUnlockNotice{
+		{UserID: otherStudent, MaterialID: third},
+	}, mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == otherStudent && n.Detail == "\"Week 2\" is now available in Go"
+	})).Return(nil).Once()
+
+	err := s.uc.NotifyUnlocked(context.Background())
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *DripUseCaseTestSuite) TestNotifyUnlocked_ContinuesAfterFailure() {
+	otherStudent := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	s.repo.On("GetUnlockCandidates", mock.Anything, mock.Anything).Return([]UnlockCandidate{
+		{UserID: s.studentID, MaterialID: first, MaterialTitle: "Week 2", CourseID: s.courseID, CourseTitle: "Go"},
+		{UserID: otherStudent, MaterialID: second, MaterialTitle: "Week 2", CourseID: s.courseID, CourseTitle: "Go"},
+	}, nil)
+	s.repo.On("SaveNotices", []schema.ContentUnlockNotice{{UserID: s.studentID, MaterialID: first}}, mock.Anything).
+		Return(errors.New("db down")).Once()
+	s.repo.On("SaveNotices", []schema.ContentUnlockNotice{{UserID: otherStudent, MaterialID: second}}, mock.Anything).
+		Return(nil).Once()
+
+	err := s.uc.NotifyUnlocked(context.Background())
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
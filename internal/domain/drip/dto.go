@@ -0,0 +1,31 @@
+package drip
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type IDRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// SetReleaseRequest replaces the schedule of a material or section; sending neither field releases it immediately
+type SetReleaseRequest struct {
+	ReleaseAt        *time.Time `json:"release_at" binding:"omitempty,excluded_with=ReleaseAfterDays"`
+	ReleaseAfterDays *int       `json:"release_after_days" binding:"omitempty,min=0,max=3650"`
+}
+
+type ReleaseResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	ReleaseAt        *time.Time `json:"release_at"`
+	ReleaseAfterDays *int       `json:"release_after_days"`
+}
+
+// UnlockCandidate is a material that unlocked for an enrolled student who has not been notified yet
+type UnlockCandidate struct {
+	UserID        uuid.UUID
+	MaterialID    uuid.UUID
+	MaterialTitle string
+	CourseID      uuid.UUID
+	CourseTitle   string
+}
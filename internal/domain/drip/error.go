@@ -0,0 +1,20 @@
+package drip
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrContentLocked = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusForbidden).
+				WithMessage("CONTENT_LOCKED")
+
+	ErrMaterialNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("MATERIAL_NOT_FOUND")
+
+	ErrSectionNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("SECTION_NOT_FOUND")
+)
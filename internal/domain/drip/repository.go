@@ -0,0 +1,125 @@
+package drip
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type IRepository interface {
+	GetMaterialByID(id uuid.UUID) (*schema.Material, error)
+	GetSectionByID(id uuid.UUID) (*schema.CourseSection, error)
+	GetSectionsByIDs(ids []uuid.UUID) ([]schema.CourseSection, error)
+	GetCourseInstructors(courseIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error)
+	GetEnrollments(userID uuid.UUID, courseIDs []uuid.UUID) ([]schema.CourseEnroll, error)
+	UpdateMaterialRelease(id uuid.UUID, releaseAt *time.Time, releaseAfterDays *int) error
+	UpdateSectionRelease(id uuid.UUID, releaseAt *time.Time, releaseAfterDays *int) error
+	GetUnlockCandidates(from, to time.Time) ([]UnlockCandidate, error)
+	SaveNotices(notices []schema.ContentUnlockNotice, notification *schema.Notification) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db}
+}
+
+func (r *repository) GetMaterialByID(id uuid.UUID) (*schema.Material, error) {
+	var material schema.Material
+	if err := r.db.First(&material, id).Error; err != nil {
+		return nil, err
+	}
+	return &material, nil
+}
+
+func (r *repository) GetSectionByID(id uuid.UUID) (*schema.CourseSection, error) {
+	var section schema.CourseSection
+	if err := r.db.First(&section, id).Error; err != nil {
+		return nil, err
+	}
+	return &section, nil
+}
+
+func (r *repository) GetSectionsByIDs(ids []uuid.UUID) ([]schema.CourseSection, error) {
+	var sections []schema.CourseSection
+	err := r.db.Where("id IN ?", ids).Find(&sections).Error
+	return sections, err
+}
+
+func (r *repository) GetCourseInstructors(courseIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	var rows []struct {
+		ID           uuid.UUID
+		InstructorID uuid.UUID
+	}
+	if err := r.db.Model(&schema.Course{}).Select("id, instructor_id").Where("id IN ?", courseIDs).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	instructors := make(map[uuid.UUID]uuid.UUID, len(rows))
+	for _, row := range rows {
+		instructors[row.ID] = row.InstructorID
+	}
+	return instructors, nil
+}
+
+func (r *repository) GetEnrollments(userID uuid.UUID, courseIDs []uuid.UUID) ([]schema.CourseEnroll, error) {
+	var enrollments []schema.CourseEnroll
+	err := r.db.Where("user_id = ? AND course_id IN ?", userID, courseIDs).Find(&enrollments).Error
+	return enrollments, err
+}
+
+func (r *repository) UpdateMaterialRelease(id uuid.UUID, releaseAt *time.Time, releaseAfterDays *int) error {
+	return r.db.Model(&schema.Material{}).Where("id = ?", id).
+		Updates(map[string]any{"release_at": releaseAt, "release_after_days": releaseAfterDays}).Error
+}
+
+func (r *repository) UpdateSectionRelease(id uuid.UUID, releaseAt *time.Time, releaseAfterDays *int) error {
+	return r.db.Model(&schema.CourseSection{}).Where("id = ?", id).
+		Updates(map[string]any{"release_at": releaseAt, "release_after_days": releaseAfterDays}).Error
+}
+
+// GetUnlockCandidates resolves each material's schedule the same way UseCase.Apply does, per enrolled student
+func (r *repository) GetUnlockCandidates(from, to time.Time) ([]UnlockCandidate, error) {
+	var candidates []UnlockCandidate
+	err := r.db.Raw(`
+		SELECT ce.user_id, m.id AS material_id, m.title AS material_title, c.id AS course_id, c.title AS course_title
+		FROM materials m
+		JOIN courses c ON c.id = m.course_id AND c.deleted_at IS NULL
+		JOIN course_enrolls ce ON ce.course_id = m.course_id
+		LEFT JOIN course_sections s ON s.id = m.section_id
+		CROSS JOIN LATERAL (
+			SELECT CASE
+				WHEN m.release_at IS NOT NULL THEN m.release_at
+				WHEN m.release_after_days IS NOT NULL THEN ce.created_at + make_interval(days => m.release_after_days)
+				WHEN s.release_at IS NOT NULL THEN s.release_at
+				WHEN s.release_after_days IS NOT NULL THEN ce.created_at + make_interval(days => s.release_after_days)
+			END AS unlocks_at
+		) rel
+		WHERE m.deleted_at IS NULL
+			AND rel.unlocks_at > @from AND rel.unlocks_at <= @to
+			AND NOT EXISTS (
+				SELECT 1 FROM content_unlock_notices n WHERE n.user_id = ce.user_id AND n.material_id = m.id
+			)
+		ORDER BY ce.user_id, c.id, m.position
+	`, map[string]any{"from": from, "to": to}).Scan(&candidates).Error
+	return candidates, err
+}
+
+// SaveNotices records the notices together with the notification announcing them, so a failure leaves neither behind.
+// The notification is skipped when every notice was already recorded by an overlapping run.
+func (r *repository) SaveNotices(notices []schema.ContentUnlockNotice, notification *schema.Notification) error {
+	if len(notices) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&notices)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Create(notification).Error
+	})
+}
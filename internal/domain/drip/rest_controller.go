@@ -0,0 +1,81 @@
+package drip
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	engine.PUT("/v1/materials/:id/release",
+		middleware.APIKeyScope("courses:write"),
+		middleware.Authenticate(),
+		middleware.RequireRole("instructor"),
+		controller.SetMaterialRelease(),
+	)
+	engine.PUT("/v1/sections/:id/release",
+		middleware.APIKeyScope("courses:write"),
+		middleware.Authenticate(),
+		middleware.RequireRole("instructor"),
+		controller.SetSectionRelease(),
+	)
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) SetMaterialRelease() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var idReq IDRequest
+		if err := ctx.ShouldBindUri(&idReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req SetReleaseRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.SetMaterialRelease(ctx, &idReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "SET_MATERIAL_RELEASE_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) SetSectionRelease() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var idReq IDRequest
+		if err := ctx.ShouldBindUri(&idReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req SetReleaseRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.SetSectionRelease(ctx, &idReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "SET_SECTION_RELEASE_SUCCESS", res).Send(ctx)
+	}
+}
@@ -0,0 +1,294 @@
+package drip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+// unlockNoticeLookback bounds how far back the notifier looks, so content scheduled long ago does not
+// trigger a burst of notifications the first time the job runs
+const unlockNoticeLookback = 24 * time.Hour
+
+type UseCase struct {
+	repo IRepository
+}
+
+func NewUseCase(repo IRepository) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+// releaseTime resolves a schedule into the moment it unlocks. scheduled is false when there is no schedule.
+// A relative schedule has no unlock time for a viewer who is not enrolled.
+func releaseTime(releaseAt *time.Time, releaseAfterDays *int, enrolledAt *time.Time) (unlocksAt *time.Time, scheduled bool) {
+	if releaseAt != nil {
+		return releaseAt, true
+	}
+	if releaseAfterDays != nil {
+		if enrolledAt == nil {
+			return nil, true
+		}
+		t := enrolledAt.AddDate(0, 0, *releaseAfterDays)
+		return &t, true
+	}
+	return nil, false
+}
+
+func hasSchedule(releaseAt *time.Time, releaseAfterDays *int) bool {
+	return releaseAt != nil || releaseAfterDays != nil
+}
+
+func viewer(ctx context.Context) (userID *uuid.UUID, isAdmin bool) {
+	if role, ok := ctx.Value("user.role").(string); ok && role == string(schema.RoleAdmin) {
+		isAdmin = true
+	}
+	if rawUserID, ok := ctx.Value("user.id").(string); ok {
+		if parsed, err := uuid.Parse(rawUserID); err == nil {
+			userID = &parsed
+		}
+	}
+	return userID, isAdmin
+}
+
+// Apply locks the materials the viewer in ctx cannot open yet, stripping their description and attachments.
+// The course's instructor and admins always see everything.
+func (uc *UseCase) Apply(ctx context.Context, materials []*schema.Material) error {
+	sectionIDs := make([]uuid.UUID, 0)
+	for _, material := range materials {
+		if !hasSchedule(material.ReleaseAt, material.ReleaseAfterDays) && material.SectionID != nil {
+			sectionIDs = append(sectionIDs, *material.SectionID)
+		}
+	}
+
+	sections := make(map[uuid.UUID]schema.CourseSection)
+	if len(sectionIDs) > 0 {
+		found, err := uc.repo.GetSectionsByIDs(sectionIDs)
+		if err != nil {
+			log.Println("Error getting sections: ", err)
+			return apierror.ErrInternalServer.Build()
+		}
+		for _, section := range found {
+			sections[section.ID] = section
+		}
+	}
+
+	// The schedule each material follows, either its own or its section's
+	type rule struct {
+		releaseAt        *time.Time
+		releaseAfterDays *int
+	}
+	scheduled := make(map[*schema.Material]rule)
+	courseIDs := make([]uuid.UUID, 0)
+	seenCourses := make(map[uuid.UUID]bool)
+	for _, material := range materials {
+		r := rule{material.ReleaseAt, material.ReleaseAfterDays}
+		if !hasSchedule(r.releaseAt, r.releaseAfterDays) && material.SectionID != nil {
+			if section, ok := sections[*material.SectionID]; ok {
+				r = rule{section.ReleaseAt, section.ReleaseAfterDays}
+			}
+		}
+		if !hasSchedule(r.releaseAt, r.releaseAfterDays) {
+			continue
+		}
+		scheduled[material] = r
+		if !seenCourses[material.CourseID] {
+			seenCourses[material.CourseID] = true
+			courseIDs = append(courseIDs, material.CourseID)
+		}
+	}
+	if len(scheduled) == 0 {
+		return nil
+	}
+
+	userID, isAdmin := viewer(ctx)
+	if isAdmin {
+		return nil
+	}
+
+	instructors := make(map[uuid.UUID]uuid.UUID)
+	enrolledAt := make(map[uuid.UUID]time.Time)
+	if userID != nil {
+		var err error
+		instructors, err = uc.repo.GetCourseInstructors(courseIDs)
+		if err != nil {
+			log.Println("Error getting course instructors: ", err)
+			return apierror.ErrInternalServer.Build()
+		}
+		enrollments, err := uc.repo.GetEnrollments(*userID, courseIDs)
+		if err != nil {
+			log.Println("Error getting enrollments: ", err)
+			return apierror.ErrInternalServer.Build()
+		}
+		for _, enrollment := range enrollments {
+			enrolledAt[enrollment.CourseID] = enrollment.CreatedAt
+		}
+	}
+
+	now := time.Now()
+	for material, r := range scheduled {
+		if userID != nil && instructors[material.CourseID] == *userID {
+			continue
+		}
+
+		var enrolled *time.Time
+		if t, ok := enrolledAt[material.CourseID]; ok {
+			enrolled = &t
+		}
+		unlocksAt, _ := releaseTime(r.releaseAt, r.releaseAfterDays, enrolled)
+		if unlocksAt != nil && !now.Before(*unlocksAt) {
+			continue
+		}
+
+		material.Locked = true
+		material.UnlocksAt = unlocksAt
+		material.Description = ""
+		material.Attachments = []schema.Attachment{}
+	}
+	return nil
+}
+
+// ApplyToCourse locks the course's preloaded materials for the viewer in ctx
+func (uc *UseCase) ApplyToCourse(ctx context.Context, course *schema.Course) error {
+	materials := make([]*schema.Material, len(course.Materials))
+	for i := range course.Materials {
+		materials[i] = &course.Materials[i]
+	}
+	return uc.Apply(ctx, materials)
+}
+
+// ApplyToCourses locks the preloaded materials of every course in a listing with a single pass
+func (uc *UseCase) ApplyToCourses(ctx context.Context, courses []schema.Course) error {
+	materials := make([]*schema.Material, 0)
+	for i := range courses {
+		for j := range courses[i].Materials {
+			materials = append(materials, &courses[i].Materials[j])
+		}
+	}
+	return uc.Apply(ctx, materials)
+}
+
+// EnsureUnlocked is used before recording progress so locked content cannot be completed ahead of schedule
+func (uc *UseCase) EnsureUnlocked(ctx context.Context, material *schema.Material) error {
+	check := *material
+	if err := uc.Apply(ctx, []*schema.Material{&check}); err != nil {
+		return err
+	}
+	if check.Locked {
+		return ErrContentLocked.WithPayload(map[string]any{"unlocks_at": check.UnlocksAt}).Build()
+	}
+	return nil
+}
+
+func (uc *UseCase) checkOwner(ctx context.Context, courseID uuid.UUID) error {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+
+	instructors, err := uc.repo.GetCourseInstructors([]uuid.UUID{courseID})
+	if err != nil {
+		log.Println("Error getting course instructors: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	if instructors[courseID] != userID {
+		return apierror.ErrNotYourResource.Build()
+	}
+	return nil
+}
+
+func (uc *UseCase) SetMaterialRelease(ctx context.Context, idReq *IDRequest, req *SetReleaseRequest) (*ReleaseResponse, error) {
+	material, err := uc.repo.GetMaterialByID(uuid.MustParse(idReq.ID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMaterialNotFound.Build()
+		}
+		log.Println("Error getting material: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if err := uc.checkOwner(ctx, material.CourseID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateMaterialRelease(material.ID, req.ReleaseAt, req.ReleaseAfterDays); err != nil {
+		log.Println("Error updating material release: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return &ReleaseResponse{ID: material.ID, ReleaseAt: req.ReleaseAt, ReleaseAfterDays: req.ReleaseAfterDays}, nil
+}
+
+func (uc *UseCase) SetSectionRelease(ctx context.Context, idReq *IDRequest, req *SetReleaseRequest) (*ReleaseResponse, error) {
+	section, err := uc.repo.GetSectionByID(uuid.MustParse(idReq.ID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSectionNotFound.Build()
+		}
+		log.Println("Error getting section: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if err := uc.checkOwner(ctx, section.CourseID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateSectionRelease(section.ID, req.ReleaseAt, req.ReleaseAfterDays); err != nil {
+		log.Println("Error updating section release: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return &ReleaseResponse{ID: section.ID, ReleaseAt: req.ReleaseAt, ReleaseAfterDays: req.ReleaseAfterDays}, nil
+}
+
+// NotifyUnlocked sends each student one notification per course listing the materials that unlocked for them
+func (uc *UseCase) NotifyUnlocked(ctx context.Context) error {
+	now := time.Now()
+	candidates, err := uc.repo.GetUnlockCandidates(now.Add(-unlockNoticeLookback), now)
+	if err != nil {
+		return err
+	}
+
+	type recipient struct {
+		userID   uuid.UUID
+		courseID uuid.UUID
+	}
+	var order []recipient
+	grouped := make(map[recipient][]UnlockCandidate)
+	for _, candidate := range candidates {
+		key := recipient{candidate.UserID, candidate.CourseID}
+		if _, ok := grouped[key]; !ok {
+			order = append(order, key)
+		}
+		grouped[key] = append(grouped[key], candidate)
+	}
+
+	for _, key := range order {
+		items := grouped[key]
+		detail := fmt.Sprintf("\"%s\" is now available in %s", items[0].MaterialTitle, items[0].CourseTitle)
+		if len(items) > 1 {
+			detail = fmt.Sprintf("%d new materials are now available in %s", len(items), items[0].CourseTitle)
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			log.Println("Error generating UUID: ", err)
+			continue
+		}
+		notices := make([]schema.ContentUnlockNotice, len(items))
+		for i, item := range items {
+			notices[i] = schema.ContentUnlockNotice{UserID: item.UserID, MaterialID: item.MaterialID}
+		}
+		// One student's failure is retried on the next run and must not hold back everyone else's notification
+		if err := uc.repo.SaveNotices(notices, &schema.Notification{
+			ID:     id,
+			UserID: key.userID,
+			Title:  "New content unlocked",
+			Detail: detail,
+		}); err != nil {
+			log.Println("Error saving content unlock notices: ", err)
+		}
+	}
+	return nil
+}
@@ -2,18 +2,30 @@ package forum
 
 import "github.com/google/uuid"
 
+// CreateForumDiscussionRequest opens a discussion to the whole course, or only to one of its cohorts when CohortID
+// is set
 type CreateForumDiscussionRequest struct {
-	CourseID uuid.UUID `json:"course_id" binding:"required,uuid"`
-	Title    string    `json:"title" binding:"required,max=150"`
-	Content  string    `json:"content" binding:"required,max=30000"`
+	CourseID uuid.UUID  `json:"course_id" binding:"required,uuid"`
+	CohortID *uuid.UUID `json:"cohort_id"`
+	Title    string     `json:"title" binding:"required,max=150"`
+	Content  string     `json:"content" binding:"required,max=30000"`
 }
 
 type GetForumDiscussionsRequest struct {
 	CourseID string `form:"course_id" binding:"required,uuid"`
+	CohortID string `form:"cohort_id" binding:"omitempty,uuid"`
 	Page     int    `form:"page" binding:"required"`
 	Limit    int    `form:"limit" binding:"required,max=30"`
 }
 
+// DiscussionFilter picks which of a course's discussions a listing includes. The zero value lists only the
+// discussions open to the whole course.
+type DiscussionFilter struct {
+	AllCohorts bool       // add the discussions of every cohort
+	CohortID   *uuid.UUID // add the discussions of this cohort
+	OnlyCohort bool       // leave out the discussions open to the whole course
+}
+
 type UpdateForumDiscussionRequest struct {
 	ID      string `uri:"id" binding:"required,uuid"`
 	Title   string `json:"title" binding:"max=150"`
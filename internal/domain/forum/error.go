@@ -13,4 +13,12 @@ var (
 	ErrReplyNotFound = apierror.NewApiErrorBuilder().
 				WithHttpStatus(http.StatusNotFound).
 				WithMessage("REPLY_NOT_FOUND")
+
+	ErrCohortNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("COHORT_NOT_FOUND")
+
+	ErrCohortMembersOnly = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusForbidden).
+				WithMessage("COHORT_MEMBERS_ONLY")
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/courseenroll"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 	"testing"
+	"time"
 )
 
 type MockEnrollRepository struct {
@@ -37,6 +39,11 @@ func (m *MockEnrollRepository) IsEnrolled(ctx context.Context, userID, courseID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockEnrollRepository) MarkCompleted(ctx context.Context, userID, courseID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, courseID, at)
+	return args.Error(0)
+}
+
 type MockCourseRepository struct {
 	mock.Mock
 }
@@ -71,8 +78,8 @@ func (m *MockCourseRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, page, pageSize int) ([]schema.Course, int, error) {
-	args := m.Called(ctx, instructorID, page, pageSize)
+func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, publishedOnly bool, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, instructorID, publishedOnly, page, pageSize)
 	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
 }
 
@@ -86,16 +93,76 @@ func (m *MockCourseRepository) GetUserCourseProgress(ctx context.Context, course
 	return args.Get(0).(float64), args.Error(1)
 }
 
-func (m *MockCourseRepository) SearchByTitle(ctx context.Context, title string, page, pageSize int) ([]schema.Course, int, error) {
-	args := m.Called(ctx, title, page, pageSize)
+func (m *MockCourseRepository) Search(ctx context.Context, query string, page, pageSize int) ([]course.SearchHit, int, error) {
+	args := m.Called(ctx, query, page, pageSize)
+	return args.Get(0).([]course.SearchHit), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) Suggest(ctx context.Context, prefix string, limit int) ([]course.Suggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]course.Suggestion), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindCatalog(ctx context.Context, filter course.CatalogFilter, page, limit int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, filter, page, limit)
 	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
 }
 
-func (m *MockCourseRepository) DynamicFilterCourses(ctx context.Context, filterType, filterValue, sort string, page, limit int) ([]schema.Course, int, error) {
-	args := m.Called(ctx, filterType, filterValue, sort, page, limit)
+func (m *MockCourseRepository) GetCatalogFacets(ctx context.Context, filter course.CatalogFilter) (course.CatalogFacets, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(course.CatalogFacets), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindByStatus(ctx context.Context, status schema.CourseStatus, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, status, page, pageSize)
 	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
 }
 
+func (m *MockCourseRepository) UpdateStatus(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetPrerequisites(ctx context.Context, courseID uuid.UUID) ([]course.PrerequisiteCourse, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]course.PrerequisiteCourse), args.Error(1)
+}
+
+func (m *MockCourseRepository) SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisites []schema.CoursePrerequisite) error {
+	args := m.Called(ctx, courseID, prerequisites)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetPrerequisiteClosure(ctx context.Context, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCompletedCourseIDs(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, userID, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCloneSource(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetSections(ctx context.Context, courseID uuid.UUID) ([]schema.CourseSection, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]schema.CourseSection), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateWithContent(ctx context.Context, course *schema.Course, sections []schema.CourseSection) error {
+	args := m.Called(ctx, course, sections)
+	return args.Error(0)
+}
+
 type MockForumRepository struct {
 	mock.Mock
 }
@@ -113,8 +180,8 @@ func (m *MockForumRepository) GetDiscussionByID(id uuid.UUID) (*schema.ForumDisc
 	return args.Get(0).(*schema.ForumDiscussion), args.Error(1)
 }
 
-func (m *MockForumRepository) GetDiscussionsByCourseID(courseID uuid.UUID, page int, limit int) ([]*schema.ForumDiscussion, int64, error) {
-	args := m.Called(courseID, page, limit)
+func (m *MockForumRepository) GetDiscussionsByCourseID(courseID uuid.UUID, filter DiscussionFilter, page int, limit int) ([]*schema.ForumDiscussion, int64, error) {
+	args := m.Called(courseID, filter, page, limit)
 	return args.Get(0).([]*schema.ForumDiscussion), args.Get(1).(int64), args.Error(2)
 }
 
@@ -156,6 +223,22 @@ func (m *MockForumRepository) DeleteReply(id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockForumRepository) GetCohortByID(id uuid.UUID) (*schema.Cohort, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Cohort), args.Error(1)
+}
+
+func (m *MockForumRepository) GetMemberCohortID(userID, courseID uuid.UUID) (*uuid.UUID, error) {
+	args := m.Called(userID, courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*uuid.UUID), args.Error(1)
+}
+
 type ForumUseCaseTestSuite struct {
 	suite.Suite
 	forumRepo     *MockForumRepository
@@ -227,6 +310,111 @@ func (suite *ForumUseCaseTestSuite) TestGetDiscussionByID_Success() {
 	suite.forumRepo.AssertExpectations(suite.T())
 }
 
+func (suite *ForumUseCaseTestSuite) TestCreateDiscussion_CohortMember() {
+	userID, _ := uuid.NewV7()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.role", "student")
+	courseID, _ := uuid.NewV7()
+	cohortID, _ := uuid.NewV7()
+	req := &CreateForumDiscussionRequest{
+		CourseID: courseID,
+		CohortID: &cohortID,
+		Title:    "Week 1 study group",
+		Content:  "Who wants to meet on Friday?",
+	}
+
+	suite.enrollRepo.On("IsEnrolled", ctx, userID, courseID).Return(true, nil)
+	suite.forumRepo.On("GetCohortByID", cohortID).Return(&schema.Cohort{ID: cohortID, CourseID: courseID}, nil)
+	suite.forumRepo.On("GetMemberCohortID", userID, courseID).Return(&cohortID, nil)
+	suite.forumRepo.On("CreateDiscussion", mock.MatchedBy(func(d *schema.ForumDiscussion) bool {
+		return d.CohortID != nil && *d.CohortID == cohortID
+	})).Return(nil)
+
+	err := suite.forumUseCase.CreateDiscussion(ctx, req)
+
+	assert.NoError(suite.T(), err)
+	suite.forumRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ForumUseCaseTestSuite) TestCreateDiscussion_OtherCohort() {
+	userID, _ := uuid.NewV7()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.role", "student")
+	courseID, _ := uuid.NewV7()
+	cohortID, _ := uuid.NewV7()
+	ownCohortID, _ := uuid.NewV7()
+	req := &CreateForumDiscussionRequest{
+		CourseID: courseID,
+		CohortID: &cohortID,
+		Title:    "Week 1 study group",
+		Content:  "Who wants to meet on Friday?",
+	}
+
+	suite.enrollRepo.On("IsEnrolled", ctx, userID, courseID).Return(true, nil)
+	suite.forumRepo.On("GetCohortByID", cohortID).Return(&schema.Cohort{ID: cohortID, CourseID: courseID}, nil)
+	suite.forumRepo.On("GetMemberCohortID", userID, courseID).Return(&ownCohortID, nil)
+
+	err := suite.forumUseCase.CreateDiscussion(ctx, req)
+
+	assert.Equal(suite.T(), ErrCohortMembersOnly.Build(), err)
+	suite.forumRepo.AssertNotCalled(suite.T(), "CreateDiscussion", mock.Anything)
+}
+
+func (suite *ForumUseCaseTestSuite) TestCreateDiscussion_CohortOfAnotherCourse() {
+	userID, _ := uuid.NewV7()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.role", "student")
+	courseID, _ := uuid.NewV7()
+	cohortID, _ := uuid.NewV7()
+	req := &CreateForumDiscussionRequest{CourseID: courseID, CohortID: &cohortID, Title: "Hi", Content: "Hello"}
+
+	suite.enrollRepo.On("IsEnrolled", ctx, userID, courseID).Return(true, nil)
+	suite.forumRepo.On("GetCohortByID", cohortID).Return(&schema.Cohort{ID: cohortID, CourseID: uuid.New()}, nil)
+
+	err := suite.forumUseCase.CreateDiscussion(ctx, req)
+
+	assert.Equal(suite.T(), ErrCohortNotFound.Build(), err)
+}
+
+func (suite *ForumUseCaseTestSuite) TestGetDiscussionByID_OtherCohort() {
+	userID, _ := uuid.NewV7()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.role", "student")
+	courseID, _ := uuid.NewV7()
+	cohortID, _ := uuid.NewV7()
+	discussionID, _ := uuid.NewV7()
+
+	suite.forumRepo.On("GetDiscussionByID", discussionID).
+		Return(&schema.ForumDiscussion{ID: discussionID, CourseID: courseID, CohortID: &cohortID}, nil)
+	suite.enrollRepo.On("IsEnrolled", ctx, userID, courseID).Return(true, nil)
+	suite.forumRepo.On("GetMemberCohortID", userID, courseID).Return(nil, nil)
+
+	res, err := suite.forumUseCase.GetDiscussionByID(ctx, discussionID.String())
+
+	assert.Nil(suite.T(), res)
+	assert.Equal(suite.T(), ErrCohortMembersOnly.Build(), err)
+}
+
+func (suite *ForumUseCaseTestSuite) TestGetDiscussionsByCourseID_StudentSeesOwnCohort() {
+	userID, _ := uuid.NewV7()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.role", "student")
+	courseID, _ := uuid.NewV7()
+	cohortID, _ := uuid.NewV7()
+	req := &GetForumDiscussionsRequest{CourseID: courseID.String(), Page: 1, Limit: 10}
+
+	suite.enrollRepo.On("IsEnrolled", ctx, userID, courseID).Return(true, nil)
+	suite.forumRepo.On("GetMemberCohortID", userID, courseID).Return(&cohortID, nil)
+	suite.forumRepo.On("GetDiscussionsByCourseID", courseID, DiscussionFilter{CohortID: &cohortID}, 1, 10).
+		Return([]*schema.ForumDiscussion{}, int64(0), nil)
+
+	res, err := suite.forumUseCase.GetDiscussionsByCourseID(ctx, req)
+
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), res)
+	suite.forumRepo.AssertExpectations(suite.T())
+}
+
 func (suite *ForumUseCaseTestSuite) TestGetDiscussionByID_NotFound() {
 	userID, _ := uuid.NewV7()
 	ctx := context.WithValue(context.Background(), "user.id", userID.String())
@@ -9,7 +9,7 @@ import (
 type IRepository interface {
 	CreateDiscussion(discussion *schema.ForumDiscussion) error
 	GetDiscussionByID(id uuid.UUID) (*schema.ForumDiscussion, error)
-	GetDiscussionsByCourseID(courseID uuid.UUID, page int, limit int) ([]*schema.ForumDiscussion, int64, error)
+	GetDiscussionsByCourseID(courseID uuid.UUID, filter DiscussionFilter, page int, limit int) ([]*schema.ForumDiscussion, int64, error)
 	UpdateDiscussion(discussion *schema.ForumDiscussion) error
 	DeleteDiscussion(id uuid.UUID) error
 
@@ -18,6 +18,9 @@ type IRepository interface {
 	GetRepliesByDiscussionID(discussionID uuid.UUID, page int, limit int) ([]*schema.ForumReply, int64, error)
 	UpdateReply(reply *schema.ForumReply) error
 	DeleteReply(id uuid.UUID) error
+
+	GetCohortByID(id uuid.UUID) (*schema.Cohort, error)
+	GetMemberCohortID(userID, courseID uuid.UUID) (*uuid.UUID, error)
 }
 
 type repository struct {
@@ -38,11 +41,20 @@ func (r *repository) GetDiscussionByID(id uuid.UUID) (*schema.ForumDiscussion, e
 	return &discussion, err
 }
 
-func (r *repository) GetDiscussionsByCourseID(courseID uuid.UUID, page int, limit int) ([]*schema.ForumDiscussion, int64, error) {
+func (r *repository) GetDiscussionsByCourseID(courseID uuid.UUID, filter DiscussionFilter, page int, limit int) ([]*schema.ForumDiscussion, int64, error) {
 	var discussions []*schema.ForumDiscussion
 	var total int64
 
 	tx := r.db.Model(&schema.ForumDiscussion{}).Where("course_id = ?", courseID)
+	switch {
+	case filter.AllCohorts:
+	case filter.CohortID == nil:
+		tx = tx.Where("cohort_id IS NULL")
+	case filter.OnlyCohort:
+		tx = tx.Where("cohort_id = ?", *filter.CohortID)
+	default:
+		tx = tx.Where("(cohort_id IS NULL OR cohort_id = ?)", *filter.CohortID)
+	}
 
 	tx.Count(&total)
 
@@ -123,3 +135,19 @@ func (r *repository) DeleteReply(id uuid.UUID) error {
 	}
 	return nil
 }
+
+func (r *repository) GetCohortByID(id uuid.UUID) (*schema.Cohort, error) {
+	var cohort schema.Cohort
+	if err := r.db.First(&cohort, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &cohort, nil
+}
+
+// GetMemberCohortID returns the cohort a user studies a course in, or nil when they study it on their own
+func (r *repository) GetMemberCohortID(userID, courseID uuid.UUID) (*uuid.UUID, error) {
+	var enrollment schema.CourseEnroll
+	err := r.db.Select("cohort_id").Where("user_id = ? AND course_id = ?", userID, courseID).
+		Limit(1).Find(&enrollment).Error
+	return enrollment.CohortID, err
+}
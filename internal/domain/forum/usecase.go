@@ -51,6 +51,23 @@ func (uc *UseCase) isPermitted(ctx context.Context, userRole string, userID, cou
 	return true, nil
 }
 
+// checkCohortAccess keeps a cohort's discussions to its members. The instructor has already been checked by
+// isPermitted and sees every cohort.
+func (uc *UseCase) checkCohortAccess(userRole string, userID, courseID uuid.UUID, cohortID *uuid.UUID) error {
+	if cohortID == nil || userRole == "instructor" {
+		return nil
+	}
+	memberOf, err := uc.repo.GetMemberCohortID(userID, courseID)
+	if err != nil {
+		log.Println("Error getting cohort membership: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	if memberOf == nil || *memberOf != *cohortID {
+		return ErrCohortMembersOnly.Build()
+	}
+	return nil
+}
+
 func (uc *UseCase) CreateDiscussion(ctx context.Context, req *CreateForumDiscussionRequest) error {
 	userID, err := uuid.Parse(ctx.Value("user.id").(string))
 	if err != nil {
@@ -67,6 +84,20 @@ func (uc *UseCase) CreateDiscussion(ctx context.Context, req *CreateForumDiscuss
 		return courseenroll.ErrNotEnrolled.Build()
 	}
 
+	if req.CohortID != nil {
+		cohort, err := uc.repo.GetCohortByID(*req.CohortID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("Error getting cohort: ", err)
+			return apierror.ErrInternalServer.Build()
+		}
+		if err != nil || cohort.CourseID != req.CourseID {
+			return ErrCohortNotFound.Build()
+		}
+		if err := uc.checkCohortAccess(userRole, userID, req.CourseID, req.CohortID); err != nil {
+			return err
+		}
+	}
+
 	discussionID, err := uuid.NewV7()
 	if err != nil {
 		return apierror.ErrInternalServer.Build()
@@ -76,6 +107,7 @@ func (uc *UseCase) CreateDiscussion(ctx context.Context, req *CreateForumDiscuss
 		ID:       discussionID,
 		UserID:   userID,
 		CourseID: req.CourseID,
+		CohortID: req.CohortID,
 		Title:    req.Title,
 		Content:  req.Content,
 	}
@@ -108,13 +140,17 @@ func (uc *UseCase) GetDiscussionByID(ctx context.Context, idStr string) (*schema
 		return nil, apierror.ErrInternalServer.Build()
 	}
 
-	ok, err := uc.isPermitted(ctx, ctx.Value("user.role").(string), userID, discussion.CourseID)
+	userRole := ctx.Value("user.role").(string)
+	ok, err := uc.isPermitted(ctx, userRole, userID, discussion.CourseID)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
 		return nil, courseenroll.ErrNotEnrolled.Build()
 	}
+	if err := uc.checkCohortAccess(userRole, userID, discussion.CourseID, discussion.CohortID); err != nil {
+		return nil, err
+	}
 
 	return discussion, nil
 }
@@ -130,7 +166,8 @@ func (uc *UseCase) GetDiscussionsByCourseID(ctx context.Context, req *GetForumDi
 		return nil, apierror.ErrValidation.Build()
 	}
 
-	ok, err := uc.isPermitted(ctx, ctx.Value("user.role").(string), userID, courseID)
+	userRole := ctx.Value("user.role").(string)
+	ok, err := uc.isPermitted(ctx, userRole, userID, courseID)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +175,26 @@ func (uc *UseCase) GetDiscussionsByCourseID(ctx context.Context, req *GetForumDi
 		return nil, courseenroll.ErrNotEnrolled.Build()
 	}
 
-	discussions, total, err := uc.repo.GetDiscussionsByCourseID(courseID, req.Page, req.Limit)
+	// Students see the course-wide discussions and those of their own cohort; the instructor sees them all
+	var filter DiscussionFilter
+	if userRole == "instructor" {
+		filter.AllCohorts = true
+	} else if filter.CohortID, err = uc.repo.GetMemberCohortID(userID, courseID); err != nil {
+		log.Println("Error getting cohort membership: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if req.CohortID != "" {
+		cohortID, err := uuid.Parse(req.CohortID)
+		if err != nil {
+			return nil, apierror.ErrValidation.Build()
+		}
+		if !filter.AllCohorts && (filter.CohortID == nil || *filter.CohortID != cohortID) {
+			return nil, ErrCohortMembersOnly.Build()
+		}
+		filter = DiscussionFilter{CohortID: &cohortID, OnlyCohort: true}
+	}
+
+	discussions, total, err := uc.repo.GetDiscussionsByCourseID(courseID, filter, req.Page, req.Limit)
 	if err != nil {
 		log.Println("Error getting discussions: ", err)
 		return nil, apierror.ErrInternalServer.Build()
@@ -237,13 +293,17 @@ func (uc *UseCase) CreateReply(ctx context.Context, req *CreateForumReplyRequest
 		return apierror.ErrInternalServer.Build()
 	}
 
-	ok, err := uc.isPermitted(ctx, ctx.Value("user.role").(string), userID, discussion.CourseID)
+	userRole := ctx.Value("user.role").(string)
+	ok, err := uc.isPermitted(ctx, userRole, userID, discussion.CourseID)
 	if err != nil {
 		return err
 	}
 	if !ok {
 		return courseenroll.ErrNotEnrolled.Build()
 	}
+	if err := uc.checkCohortAccess(userRole, userID, discussion.CourseID, discussion.CohortID); err != nil {
+		return err
+	}
 
 	replyID, err := uuid.NewV7()
 	if err != nil {
@@ -286,7 +346,8 @@ func (uc *UseCase) GetReplyByID(ctx context.Context, idStr string) (*schema.Foru
 		return nil, apierror.ErrInternalServer.Build()
 	}
 
-	ok, err := uc.isPermitted(ctx, ctx.Value("user.role").(string), userID, reply.CourseID)
+	userRole := ctx.Value("user.role").(string)
+	ok, err := uc.isPermitted(ctx, userRole, userID, reply.CourseID)
 	if err != nil {
 		return nil, err
 	}
@@ -294,6 +355,18 @@ func (uc *UseCase) GetReplyByID(ctx context.Context, idStr string) (*schema.Foru
 		return nil, courseenroll.ErrNotEnrolled.Build()
 	}
 
+	discussion, err := uc.repo.GetDiscussionByID(reply.ForumDiscussionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDiscussionNotFound.Build()
+		}
+		log.Println("Error getting discussion: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if err := uc.checkCohortAccess(userRole, userID, reply.CourseID, discussion.CohortID); err != nil {
+		return nil, err
+	}
+
 	return reply, nil
 }
 
@@ -317,13 +390,17 @@ func (uc *UseCase) GetRepliesByDiscussionID(ctx context.Context, req *GetForumRe
 		return nil, apierror.ErrInternalServer.Build()
 	}
 
-	ok, err := uc.isPermitted(ctx, ctx.Value("user.role").(string), userID, discussion.CourseID)
+	userRole := ctx.Value("user.role").(string)
+	ok, err := uc.isPermitted(ctx, userRole, userID, discussion.CourseID)
 	if err != nil {
 		return nil, err
 	}
 	if !ok {
 		return nil, courseenroll.ErrNotEnrolled.Build()
 	}
+	if err := uc.checkCohortAccess(userRole, userID, discussion.CourseID, discussion.CohortID); err != nil {
+		return nil, err
+	}
 
 	replies, total, err := uc.repo.GetRepliesByDiscussionID(discussionID, req.Page, req.Limit)
 	if err != nil {
@@ -0,0 +1,62 @@
+package learningpath
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+)
+
+type PathIDRequest struct {
+	PathID string `uri:"id" binding:"required,uuid"`
+}
+
+type CreatePathRequest struct {
+	Title       string `json:"title" binding:"required,max=150"`
+	Description string `json:"description" binding:"max=2000"`
+}
+
+type UpdatePathRequest struct {
+	Title       *string `json:"title" binding:"omitempty,min=1,max=150"`
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+	Published   *bool   `json:"published"`
+}
+
+// SetPathCoursesRequest replaces the courses of a path, in the order they should be taken
+type SetPathCoursesRequest struct {
+	CourseIDs []string `json:"course_ids" binding:"required,max=30,unique,dive,uuid"`
+}
+
+type ListPathsRequest struct {
+	Page  int `form:"page" binding:"required,min=1"`
+	Limit int `form:"limit" binding:"required,min=1,max=30"`
+}
+
+// ListFilter narrows the paths returned by IRepository.List; a nil CreatorID matches every creator
+type ListFilter struct {
+	PublishedOnly bool
+	CreatorID     *uuid.UUID
+}
+
+type PathsPaginatedResponse struct {
+	Paths      []*schema.LearningPath `json:"paths"`
+	Pagination pagination.Pagination  `json:"pagination"`
+}
+
+type PathCourseProgress struct {
+	CourseID  uuid.UUID `json:"course_id"`
+	Title     string    `json:"title"`
+	Position  int       `json:"position"`
+	Progress  float64   `json:"progress"`
+	Completed bool      `json:"completed"`
+}
+
+type PathProgressResponse struct {
+	PathID  uuid.UUID            `json:"path_id"`
+	Courses []PathCourseProgress `json:"courses"`
+	// Progress is the mean progress over the path's courses, each course counting equally
+	Progress         float64    `json:"progress"`
+	CompletedCourses int        `json:"completed_courses"`
+	TotalCourses     int        `json:"total_courses"`
+	NextCourseID     *uuid.UUID `json:"next_course_id"`
+	Completed        bool       `json:"completed"`
+}
@@ -0,0 +1,20 @@
+package learningpath
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrPathNotFound = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusNotFound).
+			WithMessage("LEARNING_PATH_NOT_FOUND")
+
+	ErrPathEmpty = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusUnprocessableEntity).
+			WithMessage("LEARNING_PATH_EMPTY")
+
+	ErrCourseNotAvailable = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("COURSE_NOT_AVAILABLE")
+)
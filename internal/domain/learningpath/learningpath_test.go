@@ -0,0 +1,314 @@
+package learningpath
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(path *schema.LearningPath) error {
+	args := m.Called(path)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetByID(id uuid.UUID) (*schema.LearningPath, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.LearningPath), args.Error(1)
+}
+
+func (m *MockRepository) List(filter ListFilter, page, limit int) ([]*schema.LearningPath, int64, error) {
+	args := m.Called(filter, page, limit)
+	return args.Get(0).([]*schema.LearningPath), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) Update(path *schema.LearningPath) error {
+	args := m.Called(path)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SetCourses(pathID uuid.UUID, courseIDs []uuid.UUID) error {
+	args := m.Called(pathID, courseIDs)
+	return args.Error(0)
+}
+
+type MockCourseRepository struct {
+	mock.Mock
+}
+
+func (m *MockCourseRepository) GetAll(ctx context.Context, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetByID(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetRating(ctx context.Context, courseID uuid.UUID) (float32, int64, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).(float32), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCourseRepository) Create(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) Update(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, publishedOnly bool, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, instructorID, publishedOnly, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) FindByPopularity(ctx context.Context, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetUserCourseProgress(ctx context.Context, courseID, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, courseID, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCourseRepository) Search(ctx context.Context, query string, page, pageSize int) ([]course.SearchHit, int, error) {
+	args := m.Called(ctx, query, page, pageSize)
+	return args.Get(0).([]course.SearchHit), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) Suggest(ctx context.Context, prefix string, limit int) ([]course.Suggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]course.Suggestion), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindCatalog(ctx context.Context, filter course.CatalogFilter, page, limit int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, filter, page, limit)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetCatalogFacets(ctx context.Context, filter course.CatalogFilter) (course.CatalogFacets, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(course.CatalogFacets), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindByStatus(ctx context.Context, status schema.CourseStatus, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, status, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) UpdateStatus(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetPrerequisites(ctx context.Context, courseID uuid.UUID) ([]course.PrerequisiteCourse, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]course.PrerequisiteCourse), args.Error(1)
+}
+
+func (m *MockCourseRepository) SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisites []schema.CoursePrerequisite) error {
+	args := m.Called(ctx, courseID, prerequisites)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetPrerequisiteClosure(ctx context.Context, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCompletedCourseIDs(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, userID, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCloneSource(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetSections(ctx context.Context, courseID uuid.UUID) ([]schema.CourseSection, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]schema.CourseSection), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateWithContent(ctx context.Context, course *schema.Course, sections []schema.CourseSection) error {
+	args := m.Called(ctx, course, sections)
+	return args.Error(0)
+}
+
+type LearningPathUseCaseTestSuite struct {
+	suite.Suite
+	repo       *MockRepository
+	courseRepo *MockCourseRepository
+	uc         *UseCase
+}
+
+func (s *LearningPathUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.courseRepo = new(MockCourseRepository)
+	s.uc = NewUseCase(s.repo, s.courseRepo)
+}
+
+func TestLearningPathUseCase(t *testing.T) {
+	suite.Run(t, new(LearningPathUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID, role string) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", role)
+}
+
+func (s *LearningPathUseCaseTestSuite) TestCreatePath() {
+	creatorID := uuid.New()
+	s.repo.On("Create", mock.Anything).Return(nil)
+
+	path, err := s.uc.CreatePath(userContext(creatorID, "instructor"), &CreatePathRequest{Title: "Backend Engineer"})
+
+	s.NoError(err)
+	s.Equal(creatorID, path.CreatorID)
+	s.False(path.Published)
+}
+
+func (s *LearningPathUseCaseTestSuite) TestGetPath_UnpublishedHiddenFromOthers() {
+	pathID := uuid.New()
+	s.repo.On("GetByID", pathID).Return(&schema.LearningPath{ID: pathID, CreatorID: uuid.New()}, nil)
+
+	_, err := s.uc.GetPath(userContext(uuid.New(), "student"), &PathIDRequest{PathID: pathID.String()})
+
+	s.Equal(ErrPathNotFound.Build(), err)
+}
+
+func (s *LearningPathUseCaseTestSuite) TestGetPath_UnpublishedVisibleToAdmin() {
+	pathID := uuid.New()
+	s.repo.On("GetByID", pathID).Return(&schema.LearningPath{ID: pathID, CreatorID: uuid.New()}, nil)
+
+	path, err := s.uc.GetPath(userContext(uuid.New(), "admin"), &PathIDRequest{PathID: pathID.String()})
+
+	s.NoError(err)
+	s.Equal(pathID, path.ID)
+}
+
+func (s *LearningPathUseCaseTestSuite) TestUpdatePath_NotCreator() {
+	pathID := uuid.New()
+	s.repo.On("GetByID", pathID).Return(&schema.LearningPath{ID: pathID, CreatorID: uuid.New()}, nil)
+	title := "Renamed"
+
+	_, err := s.uc.UpdatePath(userContext(uuid.New(), "instructor"), &PathIDRequest{PathID: pathID.String()}, &UpdatePathRequest{Title: &title})
+
+	s.Equal(apierror.ErrNotYourResource.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "Update", mock.Anything)
+}
+
+func (s *LearningPathUseCaseTestSuite) TestUpdatePath_PublishEmpty() {
+	pathID, creatorID := uuid.New(), uuid.New()
+	s.repo.On("GetByID", pathID).Return(&schema.LearningPath{ID: pathID, CreatorID: creatorID}, nil)
+	published := true
+
+	_, err := s.uc.UpdatePath(userContext(creatorID, "instructor"), &PathIDRequest{PathID: pathID.String()}, &UpdatePathRequest{Published: &published})
+
+	s.Equal(ErrPathEmpty.Build(), err)
+}
+
+func (s *LearningPathUseCaseTestSuite) TestSetPathCourses_RejectsDraftCourse() {
+	pathID, creatorID := uuid.New(), uuid.New()
+	published, draft := uuid.New(), uuid.New()
+	ctx := userContext(creatorID, "instructor")
+	s.repo.On("GetByID", pathID).Return(&schema.LearningPath{ID: pathID, CreatorID: creatorID}, nil)
+	s.courseRepo.On("GetByIDs", ctx, []uuid.UUID{published, draft}).Return([]schema.Course{
+		{ID: published, Status: schema.CourseStatusPublished},
+		{ID: draft, Status: schema.CourseStatusDraft},
+	}, nil)
+
+	_, err := s.uc.SetPathCourses(ctx, &PathIDRequest{PathID: pathID.String()}, &SetPathCoursesRequest{
+		CourseIDs: []string{published.String(), draft.String()},
+	})
+
+	s.Equal(ErrCourseNotAvailable.Build().Error(), err.Error())
+	s.repo.AssertNotCalled(s.T(), "SetCourses", mock.Anything, mock.Anything)
+}
+
+func (s *LearningPathUseCaseTestSuite) TestSetPathCourses_Success() {
+	pathID, creatorID := uuid.New(), uuid.New()
+	first, second := uuid.New(), uuid.New()
+	ctx := userContext(creatorID, "instructor")
+	s.repo.On("GetByID", pathID).Return(&schema.LearningPath{ID: pathID, CreatorID: creatorID}, nil)
+	s.courseRepo.On("GetByIDs", ctx, []uuid.UUID{second, first}).Return([]schema.Course{
+		{ID: first, Status: schema.CourseStatusPublished},
+		{ID: second, Status: schema.CourseStatusUnlisted},
+	}, nil)
+	s.repo.On("SetCourses", pathID, []uuid.UUID{second, first}).Return(nil)
+
+	_, err := s.uc.SetPathCourses(ctx, &PathIDRequest{PathID: pathID.String()}, &SetPathCoursesRequest{
+		CourseIDs: []string{second.String(), first.String()},
+	})
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *LearningPathUseCaseTestSuite) TestGetPathProgress_Aggregates() {
+	pathID, studentID := uuid.New(), uuid.New()
+	done, inProgress, untouched := uuid.New(), uuid.New(), uuid.New()
+	ctx := userContext(studentID, "student")
+	s.repo.On("GetByID", pathID).Return(&schema.LearningPath{ID: pathID, Published: true, Courses: []schema.LearningPathCourse{
+		{CourseID: done, Position: 0, Course: &schema.Course{Title: "Basics"}},
+		{CourseID: inProgress, Position: 1},
+		{CourseID: untouched, Position: 2},
+	}}, nil)
+	s.courseRepo.On("GetCompletedCourseIDs", ctx, studentID, []uuid.UUID{done, inProgress, untouched}).Return([]uuid.UUID{done}, nil)
+	s.courseRepo.On("GetUserCourseProgress", ctx, done, studentID).Return(float64(90), nil)
+	s.courseRepo.On("GetUserCourseProgress", ctx, inProgress, studentID).Return(float64(50), nil)
+	s.courseRepo.On("GetUserCourseProgress", ctx, untouched, studentID).Return(float64(0), nil)
+
+	res, err := s.uc.GetPathProgress(ctx, &PathIDRequest{PathID: pathID.String()})
+
+	s.NoError(err)
+	s.Equal(float64(50), res.Progress)
+	s.Equal(1, res.CompletedCourses)
+	s.Equal(3, res.TotalCourses)
+	s.Equal(inProgress, *res.NextCourseID)
+	s.Equal("Basics", res.Courses[0].Title)
+	s.False(res.Completed)
+}
+
+func (s *LearningPathUseCaseTestSuite) TestDeletePath_NotFound() {
+	pathID := uuid.New()
+	s.repo.On("GetByID", pathID).Return(nil, gorm.ErrRecordNotFound)
+
+	err := s.uc.DeletePath(userContext(uuid.New(), "admin"), &PathIDRequest{PathID: pathID.String()})
+
+	s.Equal(ErrPathNotFound.Build(), err)
+}
@@ -0,0 +1,96 @@
+package learningpath
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+type IRepository interface {
+	Create(path *schema.LearningPath) error
+	GetByID(id uuid.UUID) (*schema.LearningPath, error)
+	List(filter ListFilter, page, limit int) ([]*schema.LearningPath, int64, error)
+	Update(path *schema.LearningPath) error
+	Delete(id uuid.UUID) error
+	SetCourses(pathID uuid.UUID, courseIDs []uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db}
+}
+
+func preloadCourses(db *gorm.DB) *gorm.DB {
+	return db.Preload("Courses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Courses.Course")
+}
+
+func (r *repository) Create(path *schema.LearningPath) error {
+	return r.db.Create(path).Error
+}
+
+func (r *repository) GetByID(id uuid.UUID) (*schema.LearningPath, error) {
+	var path schema.LearningPath
+	if err := preloadCourses(r.db).First(&path, id).Error; err != nil {
+		return nil, err
+	}
+	return &path, nil
+}
+
+func (r *repository) List(filter ListFilter, page, limit int) ([]*schema.LearningPath, int64, error) {
+	query := r.db.Model(&schema.LearningPath{})
+	if filter.PublishedOnly {
+		query = query.Where("published = ?", true)
+	}
+	if filter.CreatorID != nil {
+		query = query.Where("creator_id = ?", *filter.CreatorID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var paths []*schema.LearningPath
+	err := preloadCourses(query).
+		Order("updated_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&paths).Error
+	return paths, total, err
+}
+
+func (r *repository) Update(path *schema.LearningPath) error {
+	return r.db.Model(path).Select("title", "description", "published").Updates(path).Error
+}
+
+func (r *repository) Delete(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("path_id = ?", id).Delete(&schema.LearningPathCourse{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&schema.LearningPath{}, id).Error
+	})
+}
+
+func (r *repository) SetCourses(pathID uuid.UUID, courseIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("path_id = ?", pathID).Delete(&schema.LearningPathCourse{}).Error; err != nil {
+			return err
+		}
+		if len(courseIDs) > 0 {
+			items := make([]schema.LearningPathCourse, len(courseIDs))
+			for i, courseID := range courseIDs {
+				items[i] = schema.LearningPathCourse{PathID: pathID, CourseID: courseID, Position: i}
+			}
+			if err := tx.Create(&items).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&schema.LearningPath{ID: pathID}).Update("updated_at", gorm.Expr("now()")).Error
+	})
+}
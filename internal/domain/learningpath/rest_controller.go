@@ -0,0 +1,192 @@
+package learningpath
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	pathGroup := engine.Group("/v1/learning-paths")
+	{
+		pathGroup.GET("", controller.ListPaths())
+		pathGroup.GET("/:id", middleware.OptionalAuthenticate(), controller.GetPath())
+		pathGroup.GET("/:id/progress", middleware.Authenticate(), middleware.RequireRole("student"), controller.GetPathProgress())
+
+		manageGroup := pathGroup.Group("")
+		manageGroup.Use(middleware.Authenticate(), middleware.RequireRole("instructor", "admin"))
+		{
+			manageGroup.GET("/mine", controller.ListMyPaths())
+			manageGroup.POST("", controller.CreatePath())
+			manageGroup.PATCH("/:id", controller.UpdatePath())
+			manageGroup.DELETE("/:id", controller.DeletePath())
+			manageGroup.PUT("/:id/courses", controller.SetPathCourses())
+		}
+	}
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) ListPaths() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req ListPathsRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.ListPaths(&req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_LEARNING_PATHS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) ListMyPaths() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req ListPathsRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.ListMyPaths(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_LEARNING_PATHS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) GetPath() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req PathIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.GetPath(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_LEARNING_PATH_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) GetPathProgress() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req PathIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.GetPathProgress(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_LEARNING_PATH_PROGRESS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) CreatePath() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CreatePathRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.CreatePath(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "CREATE_LEARNING_PATH_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) UpdatePath() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var idReq PathIDRequest
+		if err := ctx.ShouldBindUri(&idReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req UpdatePathRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.UpdatePath(ctx, &idReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "UPDATE_LEARNING_PATH_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) DeletePath() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req PathIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.DeletePath(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "DELETE_LEARNING_PATH_SUCCESS", nil).Send(ctx)
+	}
+}
+
+func (c *RestController) SetPathCourses() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var idReq PathIDRequest
+		if err := ctx.ShouldBindUri(&idReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req SetPathCoursesRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.SetPathCourses(ctx, &idReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "SET_LEARNING_PATH_COURSES_SUCCESS", res).Send(ctx)
+	}
+}
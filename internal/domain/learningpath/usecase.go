@@ -0,0 +1,262 @@
+package learningpath
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"log"
+	"slices"
+)
+
+type UseCase struct {
+	repo       IRepository
+	courseRepo course.Repository
+}
+
+func NewUseCase(repo IRepository, courseRepo course.Repository) *UseCase {
+	return &UseCase{repo: repo, courseRepo: courseRepo}
+}
+
+func (uc *UseCase) getPath(id uuid.UUID) (*schema.LearningPath, error) {
+	path, err := uc.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPathNotFound.Build()
+		}
+		log.Println("Error getting learning path: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return path, nil
+}
+
+// canManage reports whether the caller created the path or is an admin
+func canManage(ctx context.Context, path *schema.LearningPath) bool {
+	if role, ok := ctx.Value("user.role").(string); ok && role == string(schema.RoleAdmin) {
+		return true
+	}
+	rawUserID, ok := ctx.Value("user.id").(string)
+	if !ok {
+		return false
+	}
+	userID, err := uuid.Parse(rawUserID)
+	return err == nil && userID == path.CreatorID
+}
+
+func (uc *UseCase) getManagedPath(ctx context.Context, id uuid.UUID) (*schema.LearningPath, error) {
+	path, err := uc.getPath(id)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage(ctx, path) {
+		return nil, apierror.ErrNotYourResource.Build()
+	}
+	return path, nil
+}
+
+func (uc *UseCase) CreatePath(ctx context.Context, req *CreatePathRequest) (*schema.LearningPath, error) {
+	creatorID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating learning path id: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	path := &schema.LearningPath{
+		ID:          id,
+		Title:       req.Title,
+		Description: req.Description,
+		CreatorID:   creatorID,
+		Courses:     make([]schema.LearningPathCourse, 0),
+	}
+	if err := uc.repo.Create(path); err != nil {
+		log.Println("Error creating learning path: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return path, nil
+}
+
+func (uc *UseCase) UpdatePath(ctx context.Context, idReq *PathIDRequest, req *UpdatePathRequest) (*schema.LearningPath, error) {
+	path, err := uc.getManagedPath(ctx, uuid.MustParse(idReq.PathID))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		path.Title = *req.Title
+	}
+	if req.Description != nil {
+		path.Description = *req.Description
+	}
+	if req.Published != nil {
+		if *req.Published && len(path.Courses) == 0 {
+			return nil, ErrPathEmpty.Build()
+		}
+		path.Published = *req.Published
+	}
+
+	if err := uc.repo.Update(path); err != nil {
+		log.Println("Error updating learning path: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return path, nil
+}
+
+func (uc *UseCase) DeletePath(ctx context.Context, req *PathIDRequest) error {
+	path, err := uc.getManagedPath(ctx, uuid.MustParse(req.PathID))
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(path.ID); err != nil {
+		log.Println("Error deleting learning path: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	return nil
+}
+
+// SetPathCourses only accepts courses students can actually buy, so a path never leads to a dead end
+func (uc *UseCase) SetPathCourses(ctx context.Context, idReq *PathIDRequest, req *SetPathCoursesRequest) (*schema.LearningPath, error) {
+	path, err := uc.getManagedPath(ctx, uuid.MustParse(idReq.PathID))
+	if err != nil {
+		return nil, err
+	}
+	if path.Published && len(req.CourseIDs) == 0 {
+		return nil, ErrPathEmpty.Build()
+	}
+
+	courseIDs := make([]uuid.UUID, len(req.CourseIDs))
+	for i, rawID := range req.CourseIDs {
+		courseIDs[i] = uuid.MustParse(rawID)
+	}
+
+	courses, err := uc.courseRepo.GetByIDs(ctx, courseIDs)
+	if err != nil {
+		log.Println("Error getting path courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	available := make([]uuid.UUID, 0, len(courses))
+	for _, courseObj := range courses {
+		if courseObj.Status == schema.CourseStatusPublished || courseObj.Status == schema.CourseStatusUnlisted {
+			available = append(available, courseObj.ID)
+		}
+	}
+	for _, courseID := range courseIDs {
+		if !slices.Contains(available, courseID) {
+			return nil, ErrCourseNotAvailable.WithPayload(map[string]uuid.UUID{"course_id": courseID}).Build()
+		}
+	}
+
+	if err := uc.repo.SetCourses(path.ID, courseIDs); err != nil {
+		log.Println("Error setting learning path courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return uc.getPath(path.ID)
+}
+
+// GetPath hides unpublished paths from everyone but their creator and admins
+func (uc *UseCase) GetPath(ctx context.Context, req *PathIDRequest) (*schema.LearningPath, error) {
+	path, err := uc.getPath(uuid.MustParse(req.PathID))
+	if err != nil {
+		return nil, err
+	}
+	if !path.Published && !canManage(ctx, path) {
+		return nil, ErrPathNotFound.Build()
+	}
+	return path, nil
+}
+
+func (uc *UseCase) listPaths(filter ListFilter, req *ListPathsRequest) (*PathsPaginatedResponse, error) {
+	paths, total, err := uc.repo.List(filter, req.Page, req.Limit)
+	if err != nil {
+		log.Println("Error listing learning paths: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return &PathsPaginatedResponse{
+		Paths:      paths,
+		Pagination: pagination.NewPagination(int(total), req.Page, req.Limit),
+	}, nil
+}
+
+func (uc *UseCase) ListPaths(req *ListPathsRequest) (*PathsPaginatedResponse, error) {
+	return uc.listPaths(ListFilter{PublishedOnly: true}, req)
+}
+
+// ListMyPaths includes the caller's unpublished paths
+func (uc *UseCase) ListMyPaths(ctx context.Context, req *ListPathsRequest) (*PathsPaginatedResponse, error) {
+	creatorID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+	return uc.listPaths(ListFilter{CreatorID: &creatorID}, req)
+}
+
+func (uc *UseCase) GetPathProgress(ctx context.Context, req *PathIDRequest) (*PathProgressResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	path, err := uc.GetPath(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	courseIDs := make([]uuid.UUID, len(path.Courses))
+	for i, item := range path.Courses {
+		courseIDs[i] = item.CourseID
+	}
+	completed, err := uc.courseRepo.GetCompletedCourseIDs(ctx, userID, courseIDs)
+	if err != nil {
+		log.Println("Error getting completed courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	res := &PathProgressResponse{
+		PathID:       path.ID,
+		Courses:      make([]PathCourseProgress, 0, len(path.Courses)),
+		TotalCourses: len(path.Courses),
+	}
+	var sum float64
+	for _, item := range path.Courses {
+		progress, err := uc.courseRepo.GetUserCourseProgress(ctx, item.CourseID, userID)
+		if err != nil {
+			log.Println("Error getting course progress: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+
+		courseProgress := PathCourseProgress{
+			CourseID:  item.CourseID,
+			Position:  item.Position,
+			Progress:  progress,
+			Completed: slices.Contains(completed, item.CourseID),
+		}
+		if item.Course != nil {
+			courseProgress.Title = item.Course.Title
+		}
+		if courseProgress.Completed {
+			courseProgress.Progress = 100
+			res.CompletedCourses++
+		} else if res.NextCourseID == nil {
+			nextCourseID := item.CourseID
+			res.NextCourseID = &nextCourseID
+		}
+
+		sum += courseProgress.Progress
+		res.Courses = append(res.Courses, courseProgress)
+	}
+
+	if res.TotalCourses > 0 {
+		res.Progress = sum / float64(res.TotalCourses)
+		res.Completed = res.CompletedCourses == res.TotalCourses
+	}
+	return res, nil
+}
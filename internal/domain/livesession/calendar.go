@@ -0,0 +1,124 @@
+package livesession
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/ical"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+const (
+	feedPath   = "/v1/calendar/feed/"
+	feedSuffix = ".ics"
+	// feedLookback keeps recent events in a feed without replaying a course's whole history
+	feedLookback = 30 * 24 * time.Hour
+)
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateFeed issues the caller a new calendar feed URL. Any URL issued before stops working.
+func (uc *UseCase) CreateFeed(ctx context.Context) (*FeedResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		log.Println("Error generating calendar feed token: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	feed := &schema.CalendarFeed{UserID: userID, TokenHash: hashToken(token), CreatedAt: time.Now()}
+	if err := uc.repo.SaveFeed(feed); err != nil {
+		log.Println("Error saving calendar feed: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return &FeedResponse{Path: feedPath + token + feedSuffix, CreatedAt: feed.CreatedAt}, nil
+}
+
+func (uc *UseCase) DeleteFeed(ctx context.Context) error {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+
+	if err := uc.repo.DeleteFeed(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrFeedNotFound.Build()
+		}
+		log.Println("Error deleting calendar feed: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	return nil
+}
+
+// Calendar renders the feed a token belongs to: the live sessions and assignment deadlines of every course its
+// owner studies or teaches
+func (uc *UseCase) Calendar(req *FeedTokenRequest) ([]byte, error) {
+	userID, err := uc.repo.GetFeedOwner(hashToken(strings.TrimSuffix(req.Token, feedSuffix)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFeedNotFound.Build()
+		}
+		log.Println("Error getting calendar feed: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	now := time.Now()
+	since := now.Add(-feedLookback)
+	sessions, err := uc.repo.GetCalendarSessions(userID, since)
+	if err != nil {
+		log.Println("Error getting calendar sessions: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	deadlines, err := uc.repo.GetCalendarDeadlines(userID, since)
+	if err != nil {
+		log.Println("Error getting calendar deadlines: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	events := make([]ical.Event, 0, len(sessions)+len(deadlines))
+	for _, session := range sessions {
+		description := session.CourseTitle
+		if session.Description != "" {
+			description += "\n\n" + session.Description
+		}
+		events = append(events, ical.Event{
+			UID:         fmt.Sprintf("live-session-%s@seatudy", session.ID),
+			Summary:     session.Title,
+			Description: description,
+			Location:    session.MeetingURL,
+			URL:         session.MeetingURL,
+			Start:       session.StartsAt,
+			End:         session.StartsAt.Add(time.Duration(session.DurationMinutes) * time.Minute),
+		})
+	}
+	for _, deadline := range deadlines {
+		events = append(events, ical.Event{
+			UID:         fmt.Sprintf("assignment-%s@seatudy", deadline.ID),
+			Summary:     "Due: " + deadline.Title,
+			Description: deadline.CourseTitle,
+			Start:       deadline.Due,
+			End:         deadline.Due,
+		})
+	}
+
+	return ical.Encode("Seatudy", events, now), nil
+}
@@ -0,0 +1,58 @@
+package livesession
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IDRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// CreateRequest schedules a session for the whole course, or only for one of its cohorts when CohortID is set
+type CreateRequest struct {
+	CohortID        *uuid.UUID `json:"cohort_id"`
+	Title           string     `json:"title" binding:"required,max=150"`
+	Description     string     `json:"description" binding:"max=2000"`
+	StartsAt        time.Time  `json:"starts_at" binding:"required"`
+	DurationMinutes int        `json:"duration_minutes" binding:"required,min=1,max=720"`
+	MeetingURL      string     `json:"meeting_url" binding:"required,url,max=500"`
+}
+
+type UpdateRequest struct {
+	Title           *string    `json:"title" binding:"omitempty,min=1,max=150"`
+	Description     *string    `json:"description" binding:"omitempty,max=2000"`
+	StartsAt        *time.Time `json:"starts_at"`
+	DurationMinutes *int       `json:"duration_minutes" binding:"omitempty,min=1,max=720"`
+	MeetingURL      *string    `json:"meeting_url" binding:"omitempty,url,max=500"`
+}
+
+type FeedTokenRequest struct {
+	Token string `uri:"token" binding:"required"`
+}
+
+type FeedResponse struct {
+	// Path is where calendar apps subscribe to the feed. It is only shown once, when the feed is created.
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CalendarSession is a live session in someone's calendar
+type CalendarSession struct {
+	ID              uuid.UUID
+	Title           string
+	Description     string
+	StartsAt        time.Time
+	DurationMinutes int
+	MeetingURL      string
+	CourseTitle     string
+}
+
+// CalendarDeadline is an assignment due date in someone's calendar, already resolved for their cohort
+type CalendarDeadline struct {
+	ID          uuid.UUID
+	Title       string
+	Due         time.Time
+	CourseTitle string
+}
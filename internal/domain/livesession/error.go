@@ -0,0 +1,32 @@
+package livesession
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrCourseNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("COURSE_NOT_FOUND")
+
+	ErrCohortNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("COHORT_NOT_FOUND")
+
+	ErrSessionNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("LIVE_SESSION_NOT_FOUND")
+
+	ErrNotEnrolled = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusForbidden).
+			WithMessage("NOT_ENROLLED_IN_COURSE")
+
+	ErrCohortMembersOnly = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusForbidden).
+				WithMessage("COHORT_MEMBERS_ONLY")
+
+	ErrFeedNotFound = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusNotFound).
+			WithMessage("CALENDAR_FEED_NOT_FOUND")
+)
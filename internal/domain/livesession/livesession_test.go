@@ -0,0 +1,359 @@
+package livesession
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) GetCohort(cohortID uuid.UUID) (*schema.Cohort, error) {
+	args := m.Called(cohortID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Cohort), args.Error(1)
+}
+
+func (m *MockRepository) GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	args := m.Called(userID, courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.CourseEnroll), args.Error(1)
+}
+
+func (m *MockRepository) Create(session *schema.LiveSession) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetByID(id uuid.UUID) (*schema.LiveSession, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.LiveSession), args.Error(1)
+}
+
+func (m *MockRepository) Update(session *schema.LiveSession) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListByCourse(courseID uuid.UUID, everyCohort bool, cohortID *uuid.UUID) ([]schema.LiveSession, error) {
+	args := m.Called(courseID, everyCohort, cohortID)
+	return args.Get(0).([]schema.LiveSession), args.Error(1)
+}
+
+func (m *MockRepository) GetAudience(courseID uuid.UUID, cohortID *uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(courseID, cohortID)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) GetDueForReminder(now, until time.Time) ([]schema.LiveSession, error) {
+	args := m.Called(now, until)
+	return args.Get(0).([]schema.LiveSession), args.Error(1)
+}
+
+func (m *MockRepository) MarkReminded(id uuid.UUID, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveFeed(feed *schema.CalendarFeed) error {
+	args := m.Called(feed)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteFeed(userID uuid.UUID) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetFeedOwner(tokenHash string) (uuid.UUID, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) GetCalendarSessions(userID uuid.UUID, since time.Time) ([]CalendarSession, error) {
+	args := m.Called(userID, since)
+	return args.Get(0).([]CalendarSession), args.Error(1)
+}
+
+func (m *MockRepository) GetCalendarDeadlines(userID uuid.UUID, since time.Time) ([]CalendarDeadline, error) {
+	args := m.Called(userID, since)
+	return args.Get(0).([]CalendarDeadline), args.Error(1)
+}
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(notification *schema.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetByUserID(userID uuid.UUID, limit, offset int) ([]*schema.Notification, int64, error) {
+	args := m.Called(userID, limit, offset)
+	return args.Get(0).([]*schema.Notification), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificationRepository) GetUnreadCount(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) UpdateRead(notificationID uuid.UUID) error {
+	args := m.Called(notificationID)
+	return args.Error(0)
+}
+
+type LiveSessionUseCaseTestSuite struct {
+	suite.Suite
+	repo             *MockRepository
+	notificationRepo *MockNotificationRepository
+	uc               *UseCase
+
+	course    *schema.Course
+	session   *schema.LiveSession
+	studentID uuid.UUID
+}
+
+func (s *LiveSessionUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.notificationRepo = new(MockNotificationRepository)
+	s.uc = NewUseCase(s.repo, s.notificationRepo)
+
+	s.course = &schema.Course{ID: uuid.New(), Title: "Go", InstructorID: uuid.New()}
+	s.session = &schema.LiveSession{ID: uuid.New(), CourseID: s.course.ID, Course: s.course, Title: "Office hours",
+		StartsAt: time.Now().Add(48 * time.Hour), DurationMinutes: 60, MeetingURL: "https://meet.example.com/abc"}
+	s.studentID = uuid.New()
+}
+
+func TestLiveSessionUseCase(t *testing.T) {
+	suite.Run(t, new(LiveSessionUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID, role string) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", role)
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestCreate_NotifiesStudents() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("Create", mock.AnythingOfType("*schema.LiveSession")).Return(nil)
+	s.repo.On("GetAudience", s.course.ID, (*uuid.UUID)(nil)).Return([]uuid.UUID{s.studentID, uuid.New()}, nil)
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.Title == "New live session"
+	})).Return(nil).Twice()
+
+	res, err := s.uc.Create(userContext(s.course.InstructorID, "instructor"), &IDRequest{ID: s.course.ID.String()},
+		&CreateRequest{Title: "Kickoff", StartsAt: time.Now().Add(time.Hour), DurationMinutes: 45,
+			MeetingURL: "https://meet.example.com/kickoff"})
+
+	s.NoError(err)
+	s.Equal("Kickoff", res.Title)
+	s.notificationRepo.AssertExpectations(s.T())
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestCreate_CohortOfAnotherCourse() {
+	cohortID := uuid.New()
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("GetCohort", cohortID).Return(&schema.Cohort{ID: cohortID, CourseID: uuid.New()}, nil)
+
+	_, err := s.uc.Create(userContext(s.course.InstructorID, "instructor"), &IDRequest{ID: s.course.ID.String()},
+		&CreateRequest{CohortID: &cohortID, Title: "Kickoff", StartsAt: time.Now().Add(time.Hour), DurationMinutes: 45,
+			MeetingURL: "https://meet.example.com/kickoff"})
+
+	s.Equal(ErrCohortNotFound.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestCreate_NotOwner() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+
+	_, err := s.uc.Create(userContext(uuid.New(), "instructor"), &IDRequest{ID: s.course.ID.String()},
+		&CreateRequest{Title: "Kickoff", StartsAt: time.Now().Add(time.Hour), DurationMinutes: 45,
+			MeetingURL: "https://meet.example.com/kickoff"})
+
+	s.Equal(apierror.ErrNotYourResource.Build(), err)
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestList_StudentSeesOwnCohort() {
+	cohortID := uuid.New()
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("GetEnrollment", s.studentID, s.course.ID).
+		Return(&schema.CourseEnroll{UserID: s.studentID, CourseID: s.course.ID, CohortID: &cohortID}, nil)
+	s.repo.On("ListByCourse", s.course.ID, false, &cohortID).Return([]schema.LiveSession{*s.session}, nil)
+
+	res, err := s.uc.List(userContext(s.studentID, "student"), &IDRequest{ID: s.course.ID.String()})
+
+	s.NoError(err)
+	s.Len(res, 1)
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestList_NotEnrolled() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("GetEnrollment", s.studentID, s.course.ID).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.uc.List(userContext(s.studentID, "student"), &IDRequest{ID: s.course.ID.String()})
+
+	s.Equal(ErrNotEnrolled.Build(), err)
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestGet_OtherCohort() {
+	cohortID := uuid.New()
+	s.session.CohortID = &cohortID
+	s.repo.On("GetByID", s.session.ID).Return(s.session, nil)
+	s.repo.On("GetEnrollment", s.studentID, s.course.ID).
+		Return(&schema.CourseEnroll{UserID: s.studentID, CourseID: s.course.ID}, nil)
+
+	_, err := s.uc.Get(userContext(s.studentID, "student"), &IDRequest{ID: s.session.ID.String()})
+
+	s.Equal(ErrCohortMembersOnly.Build(), err)
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestUpdate_RescheduleResetsReminder() {
+	sentAt := time.Now()
+	s.session.ReminderSentAt = &sentAt
+	startsAt := s.session.StartsAt.Add(24 * time.Hour)
+	s.repo.On("GetByID", s.session.ID).Return(s.session, nil)
+	s.repo.On("Update", mock.MatchedBy(func(session *schema.LiveSession) bool {
+		return session.ReminderSentAt == nil && session.StartsAt.Equal(startsAt)
+	})).Return(nil)
+	s.repo.On("GetAudience", s.course.ID, (*uuid.UUID)(nil)).Return([]uuid.UUID{s.studentID}, nil)
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == s.studentID && n.Title == "Live session rescheduled"
+	})).Return(nil).Once()
+
+	_, err := s.uc.Update(userContext(s.course.InstructorID, "instructor"), &IDRequest{ID: s.session.ID.String()},
+		&UpdateRequest{StartsAt: &startsAt})
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+	s.notificationRepo.AssertExpectations(s.T())
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestUpdate_TitleOnly() {
+	title := "Office hours (Q&A)"
+	s.repo.On("GetByID", s.session.ID).Return(s.session, nil)
+	s.repo.On("Update", mock.Anything).Return(nil)
+
+	res, err := s.uc.Update(userContext(s.course.InstructorID, "instructor"), &IDRequest{ID: s.session.ID.String()},
+		&UpdateRequest{Title: &title})
+
+	s.NoError(err)
+	s.Equal(title, res.Title)
+	s.notificationRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestSendReminders() {
+	s.repo.On("GetDueForReminder", mock.Anything, mock.Anything).Return([]schema.LiveSession{*s.session}, nil)
+	s.repo.On("GetAudience", s.course.ID, (*uuid.UUID)(nil)).Return([]uuid.UUID{s.studentID}, nil)
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == s.studentID && n.Title == "Live session starting soon"
+	})).Return(nil).Once()
+	s.repo.On("MarkReminded", s.session.ID, mock.Anything).Return(nil)
+
+	err := s.uc.SendReminders(context.Background())
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+	s.notificationRepo.AssertExpectations(s.T())
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestSendReminders_ContinuesPastFailures() {
+	cohortID := uuid.New()
+	broken := *s.session
+	broken.ID = uuid.New()
+	broken.CohortID = &cohortID
+	otherStudent := uuid.New()
+	s.repo.On("GetDueForReminder", mock.Anything, mock.Anything).Return([]schema.LiveSession{broken, *s.session}, nil)
+	s.repo.On("GetAudience", s.course.ID, &cohortID).Return([]uuid.UUID(nil), errors.New("db down"))
+	s.repo.On("GetAudience", s.course.ID, (*uuid.UUID)(nil)).Return([]uuid.UUID{s.studentID, otherStudent}, nil)
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == s.studentID
+	})).Return(errors.New("db down")).Once()
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == otherStudent && n.Title == "Live session starting soon"
+	})).Return(nil).Once()
+	s.repo.On("MarkReminded", s.session.ID, mock.Anything).Return(nil)
+
+	err := s.uc.SendReminders(context.Background())
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+	s.notificationRepo.AssertExpectations(s.T())
+	s.repo.AssertNotCalled(s.T(), "MarkReminded", broken.ID, mock.Anything)
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestCalendar() {
+	var saved *schema.CalendarFeed
+	s.repo.On("SaveFeed", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(*schema.CalendarFeed)
+	}).Return(nil)
+
+	feed, err := s.uc.CreateFeed(userContext(s.studentID, "student"))
+	s.NoError(err)
+	s.True(strings.HasPrefix(feed.Path, "/v1/calendar/feed/"))
+	token := strings.TrimPrefix(feed.Path, "/v1/calendar/feed/")
+	s.NotContains(saved.TokenHash, strings.TrimSuffix(token, ".ics"))
+
+	due := time.Date(2030, 1, 15, 17, 0, 0, 0, time.UTC)
+	s.repo.On("GetFeedOwner", saved.TokenHash).Return(s.studentID, nil)
+	s.repo.On("GetCalendarSessions", s.studentID, mock.Anything).Return([]CalendarSession{{
+		ID: s.session.ID, Title: "Office hours, week 1", StartsAt: due.Add(-48 * time.Hour), DurationMinutes: 90,
+		MeetingURL: s.session.MeetingURL, CourseTitle: "Go",
+	}}, nil)
+	s.repo.On("GetCalendarDeadlines", s.studentID, mock.Anything).Return([]CalendarDeadline{{
+		ID: uuid.New(), Title: "Project", Due: due, CourseTitle: "Go",
+	}}, nil)
+
+	body, err := s.uc.Calendar(&FeedTokenRequest{Token: token})
+
+	s.NoError(err)
+	ics := string(body)
+	s.True(strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	s.Equal(2, strings.Count(ics, "BEGIN:VEVENT"))
+	s.Contains(ics, "SUMMARY:Office hours\\, week 1\r\n")
+	s.Contains(ics, "DTSTART:20300113T170000Z\r\nDTEND:20300113T183000Z\r\n")
+	s.Contains(ics, "SUMMARY:Due: Project\r\n")
+	for _, line := range strings.Split(ics, "\r\n") {
+		s.LessOrEqual(len(line), 75)
+	}
+}
+
+func (s *LiveSessionUseCaseTestSuite) TestCalendar_UnknownToken() {
+	s.repo.On("GetFeedOwner", mock.Anything).Return(uuid.Nil, gorm.ErrRecordNotFound)
+
+	_, err := s.uc.Calendar(&FeedTokenRequest{Token: "nope.ics"})
+
+	s.Equal(ErrFeedNotFound.Build(), err)
+}
@@ -0,0 +1,200 @@
+package livesession
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRepository interface {
+	GetCourse(courseID uuid.UUID) (*schema.Course, error)
+	GetCohort(cohortID uuid.UUID) (*schema.Cohort, error)
+	GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error)
+	Create(session *schema.LiveSession) error
+	GetByID(id uuid.UUID) (*schema.LiveSession, error)
+	Update(session *schema.LiveSession) error
+	Delete(id uuid.UUID) error
+	ListByCourse(courseID uuid.UUID, everyCohort bool, cohortID *uuid.UUID) ([]schema.LiveSession, error)
+	GetAudience(courseID uuid.UUID, cohortID *uuid.UUID) ([]uuid.UUID, error)
+	GetDueForReminder(now, until time.Time) ([]schema.LiveSession, error)
+	MarkReminded(id uuid.UUID, at time.Time) error
+
+	SaveFeed(feed *schema.CalendarFeed) error
+	DeleteFeed(userID uuid.UUID) error
+	GetFeedOwner(tokenHash string) (uuid.UUID, error)
+	GetCalendarSessions(userID uuid.UUID, since time.Time) ([]CalendarSession, error)
+	GetCalendarDeadlines(userID uuid.UUID, since time.Time) ([]CalendarDeadline, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	var course schema.Course
+	if err := r.db.First(&course, "id = ?", courseID).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *repository) GetCohort(cohortID uuid.UUID) (*schema.Cohort, error) {
+	var cohort schema.Cohort
+	if err := r.db.First(&cohort, "id = ?", cohortID).Error; err != nil {
+		return nil, err
+	}
+	return &cohort, nil
+}
+
+func (r *repository) GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	var enrollment schema.CourseEnroll
+	if err := r.db.Where("user_id = ? AND course_id = ?", userID, courseID).First(&enrollment).Error; err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (r *repository) Create(session *schema.LiveSession) error {
+	return r.db.Create(session).Error
+}
+
+func (r *repository) GetByID(id uuid.UUID) (*schema.LiveSession, error) {
+	var session schema.LiveSession
+	if err := r.db.Preload("Course").First(&session, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *repository) Update(session *schema.LiveSession) error {
+	return r.db.Omit(clause.Associations).Save(session).Error
+}
+
+func (r *repository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&schema.LiveSession{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListByCourse returns the course's sessions in time order: all of them with everyCohort, otherwise the course-wide
+// ones plus those of cohortID
+func (r *repository) ListByCourse(courseID uuid.UUID, everyCohort bool, cohortID *uuid.UUID) ([]schema.LiveSession, error) {
+	var sessions []schema.LiveSession
+	tx := r.db.Where("course_id = ?", courseID)
+	switch {
+	case everyCohort:
+	case cohortID == nil:
+		tx = tx.Where("cohort_id IS NULL")
+	default:
+		tx = tx.Where("(cohort_id IS NULL OR cohort_id = ?)", *cohortID)
+	}
+	err := tx.Order("starts_at, id").Find(&sessions).Error
+	return sessions, err
+}
+
+// GetAudience lists the students a session is for: everyone enrolled, or only the members of cohortID
+func (r *repository) GetAudience(courseID uuid.UUID, cohortID *uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	tx := r.db.Model(&schema.CourseEnroll{}).Where("course_id = ?", courseID)
+	if cohortID != nil {
+		tx = tx.Where("cohort_id = ?", *cohortID)
+	}
+	err := tx.Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// GetDueForReminder skips the sessions of deleted courses, which are left behind by the soft delete
+func (r *repository) GetDueForReminder(now, until time.Time) ([]schema.LiveSession, error) {
+	var sessions []schema.LiveSession
+	err := r.db.Preload("Course").
+		Joins("JOIN courses ON courses.id = live_sessions.course_id AND courses.deleted_at IS NULL").
+		Where("live_sessions.reminder_sent_at IS NULL AND live_sessions.starts_at > ? AND live_sessions.starts_at <= ?", now, until).
+		Order("live_sessions.starts_at, live_sessions.id").Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *repository) MarkReminded(id uuid.UUID, at time.Time) error {
+	return r.db.Model(&schema.LiveSession{}).Where("id = ?", id).Update("reminder_sent_at", at).Error
+}
+
+// SaveFeed stores the user's feed, replacing the token of an existing one
+func (r *repository) SaveFeed(feed *schema.CalendarFeed) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at"}),
+	}).Create(feed).Error
+}
+
+func (r *repository) DeleteFeed(userID uuid.UUID) error {
+	result := r.db.Delete(&schema.CalendarFeed{}, "user_id = ?", userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) GetFeedOwner(tokenHash string) (uuid.UUID, error) {
+	var feed schema.CalendarFeed
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&feed).Error; err != nil {
+		return uuid.Nil, err
+	}
+	return feed.UserID, nil
+}
+
+// GetCalendarSessions finds the sessions of the courses a user studies, within their cohort, and of the courses
+// they teach
+func (r *repository) GetCalendarSessions(userID uuid.UUID, since time.Time) ([]CalendarSession, error) {
+	var sessions []CalendarSession
+	err := r.db.Raw(`
+		SELECT ls.id, ls.title, ls.description, ls.starts_at, ls.duration_minutes, ls.meeting_url, c.title AS course_title
+		FROM live_sessions ls
+		JOIN courses c ON c.id = ls.course_id AND c.deleted_at IS NULL
+		WHERE ls.starts_at >= ? AND (
+			c.instructor_id = ?
+			OR EXISTS (
+				SELECT 1 FROM course_enrolls ce
+				WHERE ce.course_id = ls.course_id AND ce.user_id = ?
+					AND (ls.cohort_id IS NULL OR ls.cohort_id = ce.cohort_id)
+			)
+		)
+		ORDER BY ls.starts_at, ls.id`, since, userID, userID).Scan(&sessions).Error
+	return sessions, err
+}
+
+// GetCalendarDeadlines finds the assignment due dates of the courses a user studies or teaches. Students in a cohort
+// get the deadline relative to its start; everyone else the assignment's fixed date.
+func (r *repository) GetCalendarDeadlines(userID uuid.UUID, since time.Time) ([]CalendarDeadline, error) {
+	var deadlines []CalendarDeadline
+	err := r.db.Raw(`
+		SELECT id, title, due, course_title FROM (
+			SELECT a.id, a.title, c.title AS course_title,
+				CASE
+					WHEN a.due_after_days IS NOT NULL AND ch.id IS NOT NULL
+						THEN ch.starts_at + make_interval(days => a.due_after_days)
+					ELSE a.due
+				END AS due
+			FROM assignments a
+			JOIN courses c ON c.id = a.course_id AND c.deleted_at IS NULL
+			LEFT JOIN course_enrolls ce ON ce.course_id = a.course_id AND ce.user_id = ?
+			LEFT JOIN cohorts ch ON ch.id = ce.cohort_id
+			WHERE a.deleted_at IS NULL AND (c.instructor_id = ? OR ce.id IS NOT NULL)
+		) deadlines
+		WHERE due IS NOT NULL AND due >= ?
+		ORDER BY due, id`, userID, userID, since).Scan(&deadlines).Error
+	return deadlines, err
+}
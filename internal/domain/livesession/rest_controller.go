@@ -0,0 +1,207 @@
+package livesession
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	courseGroup := engine.Group("/v1/courses/:id/live-sessions")
+	{
+		courseGroup.GET("",
+			middleware.APIKeyScope("courses:read"),
+			middleware.Authenticate(),
+			controller.List(),
+		)
+		courseGroup.POST("",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.Create(),
+		)
+	}
+
+	sessionGroup := engine.Group("/v1/live-sessions/:id")
+	{
+		sessionGroup.GET("",
+			middleware.APIKeyScope("courses:read"),
+			middleware.Authenticate(),
+			controller.Get(),
+		)
+		sessionGroup.PATCH("",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.Update(),
+		)
+		sessionGroup.DELETE("",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.Delete(),
+		)
+	}
+
+	calendarGroup := engine.Group("/v1/calendar/feed")
+	{
+		calendarGroup.POST("", middleware.Authenticate(), controller.CreateFeed())
+		calendarGroup.DELETE("", middleware.Authenticate(), controller.DeleteFeed())
+		// Calendar apps cannot log in, so the feed itself is authorized by the secret token in its URL
+		calendarGroup.GET("/:token", controller.Calendar())
+	}
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) List() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.List(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_LIVE_SESSIONS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Create() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var courseReq IDRequest
+		if err := ctx.ShouldBindUri(&courseReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req CreateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Create(ctx, &courseReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "CREATE_LIVE_SESSION_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Get() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Get(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_LIVE_SESSION_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Update() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var idReq IDRequest
+		if err := ctx.ShouldBindUri(&idReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req UpdateRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Update(ctx, &idReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "UPDATE_LIVE_SESSION_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Delete() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req IDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.Delete(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "DELETE_LIVE_SESSION_SUCCESS", nil).Send(ctx)
+	}
+}
+
+func (c *RestController) CreateFeed() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res, err := c.uc.CreateFeed(ctx)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "CREATE_CALENDAR_FEED_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) DeleteFeed() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if err := c.uc.DeleteFeed(ctx); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "DELETE_CALENDAR_FEED_SUCCESS", nil).Send(ctx)
+	}
+}
+
+func (c *RestController) Calendar() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req FeedTokenRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		body, err := c.uc.Calendar(&req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		ctx.Header("Cache-Control", "private, max-age=900")
+		ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", body)
+	}
+}
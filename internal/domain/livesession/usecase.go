@@ -0,0 +1,306 @@
+package livesession
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/notification"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+// reminderLead is how long before a session starts its students are reminded of it
+const reminderLead = time.Hour
+
+type UseCase struct {
+	repo             IRepository
+	notificationRepo notification.IRepository
+}
+
+func NewUseCase(repo IRepository, notificationRepo notification.IRepository) *UseCase {
+	return &UseCase{repo: repo, notificationRepo: notificationRepo}
+}
+
+func isAdmin(ctx context.Context) bool {
+	role, _ := ctx.Value("user.role").(string)
+	return role == string(schema.RoleAdmin)
+}
+
+func (uc *UseCase) getCourse(courseID uuid.UUID) (*schema.Course, error) {
+	course, err := uc.repo.GetCourse(courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return course, nil
+}
+
+func (uc *UseCase) getSession(id uuid.UUID) (*schema.LiveSession, error) {
+	session, err := uc.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound.Build()
+		}
+		log.Println("Error getting live session: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return session, nil
+}
+
+// checkOwner lets only the course's instructor, or an admin, schedule its sessions
+func checkOwner(ctx context.Context, course *schema.Course) error {
+	if isAdmin(ctx) {
+		return nil
+	}
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+	if course.InstructorID != userID {
+		return apierror.ErrNotYourResource.Build()
+	}
+	return nil
+}
+
+// access works out which of a course's sessions the caller may see. The instructor and admins see every cohort's;
+// an enrolled student sees the course-wide ones and those of their own cohort.
+func (uc *UseCase) access(ctx context.Context, course *schema.Course) (everyCohort bool, cohortID *uuid.UUID, err error) {
+	if isAdmin(ctx) {
+		return true, nil, nil
+	}
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return false, nil, apierror.ErrTokenInvalid.Build()
+	}
+	if course.InstructorID == userID {
+		return true, nil, nil
+	}
+
+	enrollment, err := uc.repo.GetEnrollment(userID, course.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil, ErrNotEnrolled.Build()
+		}
+		log.Println("Error getting enrollment: ", err)
+		return false, nil, apierror.ErrInternalServer.Build()
+	}
+	return false, enrollment.CohortID, nil
+}
+
+// notifyAudience tells the students a session is for about it. Failures are only logged, since the change that
+// triggered the notification has already been saved.
+func (uc *UseCase) notifyAudience(session *schema.LiveSession, title, detail string) {
+	userIDs, err := uc.repo.GetAudience(session.CourseID, session.CohortID)
+	if err != nil {
+		log.Println("Error getting live session audience: ", err)
+		return
+	}
+	for _, userID := range userIDs {
+		if err := uc.notify(userID, title, detail); err != nil {
+			log.Println("Error creating notification: ", err)
+		}
+	}
+}
+
+func (uc *UseCase) notify(userID uuid.UUID, title, detail string) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	return uc.notificationRepo.Create(&schema.Notification{ID: id, UserID: userID, Title: title, Detail: detail})
+}
+
+func describe(session *schema.LiveSession, courseTitle string) string {
+	return fmt.Sprintf("%s in %s on %s", session.Title, courseTitle, session.StartsAt.UTC().Format(time.RFC1123))
+}
+
+func (uc *UseCase) Create(ctx context.Context, courseReq *IDRequest, req *CreateRequest) (*schema.LiveSession, error) {
+	course, err := uc.getCourse(uuid.MustParse(courseReq.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOwner(ctx, course); err != nil {
+		return nil, err
+	}
+
+	if req.CohortID != nil {
+		cohort, err := uc.repo.GetCohort(*req.CohortID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Println("Error getting cohort: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		if err != nil || cohort.CourseID != course.ID {
+			return nil, ErrCohortNotFound.Build()
+		}
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	session := &schema.LiveSession{
+		ID:              id,
+		CourseID:        course.ID,
+		CohortID:        req.CohortID,
+		Title:           req.Title,
+		Description:     req.Description,
+		StartsAt:        req.StartsAt,
+		DurationMinutes: req.DurationMinutes,
+		MeetingURL:      req.MeetingURL,
+	}
+	if err := uc.repo.Create(session); err != nil {
+		log.Println("Error creating live session: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	if session.StartsAt.After(time.Now()) {
+		uc.notifyAudience(session, "New live session", describe(session, course.Title))
+	}
+	return session, nil
+}
+
+// List returns the sessions of a course the caller may join, in time order
+func (uc *UseCase) List(ctx context.Context, courseReq *IDRequest) ([]schema.LiveSession, error) {
+	course, err := uc.getCourse(uuid.MustParse(courseReq.ID))
+	if err != nil {
+		return nil, err
+	}
+	everyCohort, cohortID, err := uc.access(ctx, course)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := uc.repo.ListByCourse(course.ID, everyCohort, cohortID)
+	if err != nil {
+		log.Println("Error listing live sessions: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if sessions == nil {
+		sessions = []schema.LiveSession{}
+	}
+	return sessions, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, req *IDRequest) (*schema.LiveSession, error) {
+	session, err := uc.getSession(uuid.MustParse(req.ID))
+	if err != nil {
+		return nil, err
+	}
+	everyCohort, cohortID, err := uc.access(ctx, session.Course)
+	if err != nil {
+		return nil, err
+	}
+	if !everyCohort && session.CohortID != nil && (cohortID == nil || *cohortID != *session.CohortID) {
+		return nil, ErrCohortMembersOnly.Build()
+	}
+	return session, nil
+}
+
+// Update changes a session. Moving it in time lets its students know and schedules a fresh reminder.
+func (uc *UseCase) Update(ctx context.Context, idReq *IDRequest, req *UpdateRequest) (*schema.LiveSession, error) {
+	session, err := uc.getSession(uuid.MustParse(idReq.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOwner(ctx, session.Course); err != nil {
+		return nil, err
+	}
+
+	startsAt, endsAt := session.StartsAt, session.EndsAt()
+	if req.Title != nil {
+		session.Title = *req.Title
+	}
+	if req.Description != nil {
+		session.Description = *req.Description
+	}
+	if req.StartsAt != nil {
+		session.StartsAt = *req.StartsAt
+	}
+	if req.DurationMinutes != nil {
+		session.DurationMinutes = *req.DurationMinutes
+	}
+	if req.MeetingURL != nil {
+		session.MeetingURL = *req.MeetingURL
+	}
+	rescheduled := !session.StartsAt.Equal(startsAt) || !session.EndsAt().Equal(endsAt)
+	if rescheduled {
+		session.ReminderSentAt = nil
+	}
+
+	if err := uc.repo.Update(session); err != nil {
+		log.Println("Error updating live session: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	if rescheduled && session.StartsAt.After(time.Now()) {
+		uc.notifyAudience(session, "Live session rescheduled", describe(session, session.Course.Title))
+	}
+	return session, nil
+}
+
+func (uc *UseCase) Delete(ctx context.Context, req *IDRequest) error {
+	session, err := uc.getSession(uuid.MustParse(req.ID))
+	if err != nil {
+		return err
+	}
+	if err := checkOwner(ctx, session.Course); err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(session.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound.Build()
+		}
+		log.Println("Error deleting live session: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	if session.StartsAt.After(time.Now()) {
+		uc.notifyAudience(session, "Live session cancelled", describe(session, session.Course.Title))
+	}
+	return nil
+}
+
+// SendReminders notifies the students of every session starting within the next hour. It runs as a background job.
+func (uc *UseCase) SendReminders(ctx context.Context) error {
+	now := time.Now()
+	sessions, err := uc.repo.GetDueForReminder(now, now.Add(reminderLead))
+	if err != nil {
+		return err
+	}
+
+	for i := range sessions {
+		session := &sessions[i]
+		// Nobody has been told yet, so the session is left for the next run
+		userIDs, err := uc.repo.GetAudience(session.CourseID, session.CohortID)
+		if err != nil {
+			log.Printf("Error getting audience of live session %s: %v", session.ID, err)
+			continue
+		}
+		courseTitle := "your course"
+		if session.Course != nil {
+			courseTitle = session.Course.Title
+		}
+		detail := fmt.Sprintf("%s in %s starts in %d minutes: %s", session.Title, courseTitle,
+			int(session.StartsAt.Sub(now).Round(time.Minute).Minutes()), session.MeetingURL)
+		// A failed notification is not retried, since that would remind everyone else a second time
+		for _, userID := range userIDs {
+			if err := uc.notify(userID, "Live session starting soon", detail); err != nil {
+				log.Println("Error sending live session reminder: ", err)
+			}
+		}
+		if err := uc.repo.MarkReminded(session.ID, now); err != nil {
+			log.Println("Error marking live session reminded: ", err)
+		}
+	}
+	return nil
+}
@@ -15,11 +15,13 @@ type CreateMaterialRequest struct {
     CourseID    string          `form:"course_id" binding:"required"`
     Title       string             `form:"title" binding:"required"`
     Description string             `form:"description"`
+    DurationSeconds *int           `form:"duration_seconds" binding:"omitempty,min=1"`
     
 }
 
 type UpdateMaterialRequest struct {
     Title       *string             `form:"title"`
     Description *string             `form:"description"`
+    DurationSeconds *int            `form:"duration_seconds" binding:"omitempty,min=1"`
     
 }
\ No newline at end of file
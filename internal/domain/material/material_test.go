@@ -7,12 +7,64 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/attachment"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/revision"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
+type MockRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRevisionRepository) Create(rev *schema.Revision) error {
+	args := m.Called(rev)
+	return args.Error(0)
+}
+
+func (m *MockRevisionRepository) GetLatest(entityType schema.RevisionEntityType, entityID uuid.UUID) (*schema.Revision, error) {
+	args := m.Called(entityType, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Revision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) GetByVersion(entityType schema.RevisionEntityType, entityID uuid.UUID, version int) (*schema.Revision, error) {
+	args := m.Called(entityType, entityID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Revision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) List(entityType schema.RevisionEntityType, entityID uuid.UUID) ([]schema.Revision, error) {
+	args := m.Called(entityType, entityID)
+	return args.Get(0).([]schema.Revision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) GetCourse(id uuid.UUID) (*schema.Course, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockRevisionRepository) GetMaterial(id uuid.UUID) (*schema.Material, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Material), args.Error(1)
+}
+
+func (m *MockRevisionRepository) UpdateContent(entityType schema.RevisionEntityType, entityID uuid.UUID, snapshot revision.Snapshot) error {
+	args := m.Called(entityType, entityID, snapshot)
+	return args.Error(0)
+}
+
 type MockRepository struct {
 	mock.Mock
 }
@@ -84,6 +136,29 @@ func (m *MockFileUploader) UploadFile(key string, fileHeader *multipart.FileHead
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockFileUploader) UploadBytes(key string, data []byte, contentType string) (string, error) {
+	args := m.Called(key, data, contentType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) CopyFile(sourceURL, key string) (string, error) {
+	args := m.Called(sourceURL, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) DownloadFile(fileURL string) ([]byte, error) {
+	args := m.Called(fileURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockFileUploader) DeleteFile(fileURL string) error {
+	args := m.Called(fileURL)
+	return args.Error(0)
+}
+
 type MaterialUseCaseTestSuite struct {
 	suite.Suite
 	attachmentRepo    *MockAttachmentRepository
@@ -91,6 +166,7 @@ type MaterialUseCaseTestSuite struct {
 	attachmentUseCase *attachment.UseCase
 	materialRepo      *MockRepository
 	materialUseCase   *UseCase
+	revisionRepo      *MockRevisionRepository
 }
 
 func (suite *MaterialUseCaseTestSuite) SetupTest() {
@@ -98,7 +174,8 @@ func (suite *MaterialUseCaseTestSuite) SetupTest() {
 	suite.uploader = new(MockFileUploader)
 	suite.materialRepo = new(MockRepository)
 	suite.attachmentUseCase = attachment.NewUseCase(suite.attachmentRepo, suite.uploader)
-	suite.materialUseCase = NewUseCase(suite.materialRepo,suite.attachmentUseCase)
+	suite.revisionRepo = new(MockRevisionRepository)
+	suite.materialUseCase = NewUseCase(suite.materialRepo, suite.attachmentUseCase, revision.NewUseCase(suite.revisionRepo))
 }
 
 func (suite *MaterialUseCaseTestSuite) TestCreateMaterial_Success() {
@@ -143,6 +220,11 @@ func (suite *MaterialUseCaseTestSuite) TestUpdateMaterial_Success() {
 		assert.Equal(suite.T(), updatedTitle, arg.Title)
 		assert.Equal(suite.T(), updatedDescription, arg.Description)
 	})
+	suite.revisionRepo.On("GetLatest", schema.RevisionEntityMaterial, materialID).
+		Return(&schema.Revision{Version: 3, Title: "Old Title", Description: "Old Description"}, nil)
+	suite.revisionRepo.On("Create", mock.MatchedBy(func(r *schema.Revision) bool {
+		return r.Version == 4 && r.EntityID == materialID && r.Description == updatedDescription
+	})).Return(nil)
 
 	// Call the function under test
 	err := suite.materialUseCase.UpdateMaterial(ctx, req, materialID)
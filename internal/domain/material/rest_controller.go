@@ -10,27 +10,30 @@ import (
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
 
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/drip"
 	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
 	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 )
 
 type RestController struct {
 	useCase       *UseCase
 	courseUseCase *course.UseCase
+	dripUseCase   *drip.UseCase
 }
 
-func NewRestController(r *gin.Engine, uc *UseCase, cuc *course.UseCase) {
-	c := &RestController{useCase: uc, courseUseCase: cuc}
+func NewRestController(r *gin.Engine, uc *UseCase, cuc *course.UseCase, duc *drip.UseCase) {
+	c := &RestController{useCase: uc, courseUseCase: cuc, dripUseCase: duc}
 
 	materialGroup := r.Group("/v1/materials")
 	{
-		materialGroup.POST("", middleware.Authenticate(), middleware.RequireRole("instructor"), c.create)
-		materialGroup.GET("/:id", c.getByID)
-		materialGroup.GET("/course/:id", c.getMaterialByCourse)
-		materialGroup.GET("", c.getAll)
-		materialGroup.PUT("/:id", middleware.Authenticate(), middleware.RequireRole("instructor"), c.update)
-		materialGroup.DELETE("/:id", middleware.Authenticate(), middleware.RequireRole("instructor"), c.delete)
-		materialGroup.POST("addAttachment/:id", middleware.Authenticate(), middleware.RequireRole("instructor"), c.addAttachment)
+		materialGroup.POST("", middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"), c.create)
+		materialGroup.GET("/:id", middleware.OptionalAuthenticate(), c.getByID)
+		materialGroup.GET("/course/:id", middleware.OptionalAuthenticate(), c.getMaterialByCourse)
+		materialGroup.GET("", middleware.OptionalAuthenticate(), c.getAll)
+		materialGroup.PUT("/:id", middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"), c.update)
+		materialGroup.DELETE("/:id", middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"), c.delete)
+		materialGroup.POST("addAttachment/:id", middleware.APIKeyScope("courses:write"), middleware.Authenticate(), middleware.RequireRole("instructor"), c.addAttachment)
 	}
 
 }
@@ -88,6 +91,10 @@ func (c *RestController) getByID(ctx *gin.Context) {
 		return
 
 	}
+	if err := c.dripUseCase.Apply(ctx, []*schema.Material{mat}); err != nil {
+		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+		return
+	}
 	response.NewRestResponse(http.StatusOK, "All Material Retrieve", mat).Send(ctx)
 }
 
@@ -97,6 +104,10 @@ func (c *RestController) getAll(ctx *gin.Context) {
 		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
 		return
 	}
+	if err := c.dripUseCase.Apply(ctx, mats); err != nil {
+		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+		return
+	}
 	response.NewRestResponse(http.StatusOK, "All Material Retrieve", mats).Send(ctx)
 }
 
@@ -113,6 +124,10 @@ func (c *RestController) getMaterialByCourse(ctx *gin.Context) {
 		response.NewRestResponse(http.StatusInternalServerError, "Failed to fetch material: "+err.Error(), nil).Send(ctx)
 		return
 	}
+	if err := c.dripUseCase.ApplyToCourse(ctx, &course); err != nil {
+		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+		return
+	}
 
 	response.NewRestResponse(http.StatusOK, "All Course Material Retrieve", course.Materials).Send(ctx)
 
@@ -9,15 +9,17 @@ import (
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/attachment"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/revision"
 )
 
 type UseCase struct {
 	repo              Repository
 	attachmentUseCase *attachment.UseCase // Add this line
+	revisionUseCase   *revision.UseCase
 }
 
-func NewUseCase(repo Repository, attachmentUseCase *attachment.UseCase) *UseCase {
-	return &UseCase{repo: repo, attachmentUseCase: attachmentUseCase}
+func NewUseCase(repo Repository, attachmentUseCase *attachment.UseCase, revisionUseCase *revision.UseCase) *UseCase {
+	return &UseCase{repo: repo, attachmentUseCase: attachmentUseCase, revisionUseCase: revisionUseCase}
 }
 
 func (uc *UseCase) CreateMaterial(ctx context.Context, req CreateMaterialRequest) error {
@@ -39,6 +41,8 @@ func (uc *UseCase) CreateMaterial(ctx context.Context, req CreateMaterialRequest
 		CourseID:    courseId,
 		Title:       req.Title,
 		Description: req.Description,
+		// The video length is trusted over what players report when crediting watch time
+		DurationSeconds: req.DurationSeconds,
 	}
 
 	return uc.repo.Create(ctx, &mat)
@@ -57,6 +61,7 @@ func (uc *UseCase) UpdateMaterial(ctx context.Context, req UpdateMaterialRequest
 	if err != nil {
 		return ErrMaterialNotFound.Build()
 	}
+	before := revision.Snapshot{Title: mat.Title, Description: mat.Description}
 
 	// Update the material fields from the request
 	if req.Title != nil {
@@ -65,8 +70,18 @@ func (uc *UseCase) UpdateMaterial(ctx context.Context, req UpdateMaterialRequest
 	if req.Description != nil {
 		mat.Description = *req.Description
 	}
+	if req.DurationSeconds != nil {
+		mat.DurationSeconds = req.DurationSeconds
+	}
 
-	return uc.repo.Update(ctx, mat)
+	if err := uc.repo.Update(ctx, mat); err != nil {
+		return err
+	}
+	after := revision.Snapshot{Title: mat.Title, Description: mat.Description}
+	if err := uc.revisionUseCase.Record(ctx, schema.RevisionEntityMaterial, mat.ID, mat.CourseID, before, after); err != nil {
+		log.Println("Error recording material revision: ", err)
+	}
+	return nil
 }
 
 func (uc *UseCase) AddAttachment(ctx context.Context, id uuid.UUID, req AttachmentInput) error {
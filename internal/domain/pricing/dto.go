@@ -0,0 +1,17 @@
+package pricing
+
+import "time"
+
+type CourseIDRequest struct {
+	CourseID string `uri:"id" binding:"required,uuid"`
+}
+
+type SaleIDRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type CreateSaleRequest struct {
+	SalePrice *int64    `json:"sale_price" binding:"required,gte=0"`
+	StartsAt  time.Time `json:"starts_at" binding:"required"`
+	EndsAt    time.Time `json:"ends_at" binding:"required,gtfield=StartsAt"`
+}
@@ -0,0 +1,28 @@
+package pricing
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrCourseNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("COURSE_NOT_FOUND")
+
+	ErrSaleNotFound = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusNotFound).
+			WithMessage("SALE_NOT_FOUND")
+
+	ErrSaleEnded = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusBadRequest).
+			WithMessage("SALE_ALREADY_ENDED")
+
+	ErrSaleNotDiscount = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("SALE_PRICE_NOT_BELOW_PRICE")
+
+	ErrSaleOverlaps = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusConflict).
+			WithMessage("SALE_OVERLAPS_ANOTHER")
+)
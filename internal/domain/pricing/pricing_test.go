@@ -0,0 +1,235 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+// CreateSale runs decide with the overlap count given to On, as the repository would inside its transaction
+func (m *MockRepository) CreateSale(sale *schema.CourseSale, decide func(overlapping int64) error) error {
+	args := m.Called(sale)
+	if err := decide(args.Get(0).(int64)); err != nil {
+		return err
+	}
+	return args.Error(1)
+}
+
+func (m *MockRepository) GetSale(id uuid.UUID) (*schema.CourseSale, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.CourseSale), args.Error(1)
+}
+
+func (m *MockRepository) DeleteSale(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListSales(courseID uuid.UUID) ([]schema.CourseSale, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]schema.CourseSale), args.Error(1)
+}
+
+func (m *MockRepository) GetRunningSales(courseIDs []uuid.UUID, at time.Time) ([]schema.CourseSale, error) {
+	args := m.Called(courseIDs)
+	return args.Get(0).([]schema.CourseSale), args.Error(1)
+}
+
+func (m *MockRepository) CreatePriceChange(change *schema.CoursePriceChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListPriceChanges(courseID uuid.UUID) ([]schema.CoursePriceChange, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]schema.CoursePriceChange), args.Error(1)
+}
+
+type PricingUseCaseTestSuite struct {
+	suite.Suite
+	repo *MockRepository
+	uc   *UseCase
+
+	course *schema.Course
+}
+
+func (s *PricingUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.uc = NewUseCase(s.repo)
+
+	s.course = &schema.Course{ID: uuid.New(), Title: "Go", Price: 200000, InstructorID: uuid.New(),
+		Status: schema.CourseStatusPublished}
+}
+
+func TestPricingUseCase(t *testing.T) {
+	suite.Run(t, new(PricingUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID, role string) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", role)
+}
+
+func (s *PricingUseCaseTestSuite) courseRequest() *CourseIDRequest {
+	return &CourseIDRequest{CourseID: s.course.ID.String()}
+}
+
+func (s *PricingUseCaseTestSuite) saleRequest(price int64) *CreateSaleRequest {
+	return &CreateSaleRequest{SalePrice: &price, StartsAt: time.Now().Add(time.Hour), EndsAt: time.Now().Add(48 * time.Hour)}
+}
+
+func (s *PricingUseCaseTestSuite) TestCreateSale() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("CreateSale", mock.MatchedBy(func(sale *schema.CourseSale) bool {
+		return sale.CourseID == s.course.ID && sale.SalePrice == 150000
+	})).Return(int64(0), nil)
+
+	res, err := s.uc.CreateSale(userContext(s.course.InstructorID, "instructor"), s.courseRequest(), s.saleRequest(150000))
+
+	s.NoError(err)
+	s.Equal(int64(150000), res.SalePrice)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *PricingUseCaseTestSuite) TestCreateSale_NotYourCourse() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+
+	_, err := s.uc.CreateSale(userContext(uuid.New(), "instructor"), s.courseRequest(), s.saleRequest(150000))
+
+	s.Equal(apierror.ErrNotYourResource.Build(), err)
+}
+
+func (s *PricingUseCaseTestSuite) TestCreateSale_NotDiscount() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+
+	_, err := s.uc.CreateSale(userContext(s.course.InstructorID, "instructor"), s.courseRequest(), s.saleRequest(200000))
+
+	s.Equal(ErrSaleNotDiscount.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "CreateSale", mock.Anything)
+}
+
+func (s *PricingUseCaseTestSuite) TestCreateSale_AlreadyEnded() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	req := s.saleRequest(150000)
+	req.StartsAt, req.EndsAt = time.Now().Add(-48*time.Hour), time.Now().Add(-time.Hour)
+
+	_, err := s.uc.CreateSale(userContext(s.course.InstructorID, "instructor"), s.courseRequest(), req)
+
+	s.Equal(ErrSaleEnded.Build(), err)
+}
+
+func (s *PricingUseCaseTestSuite) TestCreateSale_Overlaps() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("CreateSale", mock.Anything).Return(int64(1), nil)
+
+	_, err := s.uc.CreateSale(userContext(s.course.InstructorID, "admin"), s.courseRequest(), s.saleRequest(150000))
+
+	s.Equal(ErrSaleOverlaps.Build(), err)
+}
+
+func (s *PricingUseCaseTestSuite) TestDeleteSale_NotYourCourse() {
+	sale := &schema.CourseSale{ID: uuid.New(), CourseID: s.course.ID, Course: s.course}
+	s.repo.On("GetSale", sale.ID).Return(sale, nil)
+
+	err := s.uc.DeleteSale(userContext(uuid.New(), "instructor"), &SaleIDRequest{ID: sale.ID.String()})
+
+	s.Equal(apierror.ErrNotYourResource.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "DeleteSale", mock.Anything)
+}
+
+func (s *PricingUseCaseTestSuite) TestDeleteSale_CourseDeleted() {
+	sale := &schema.CourseSale{ID: uuid.New(), CourseID: s.course.ID}
+	s.repo.On("GetSale", sale.ID).Return(sale, nil)
+
+	err := s.uc.DeleteSale(userContext(s.course.InstructorID, "instructor"), &SaleIDRequest{ID: sale.ID.String()})
+
+	s.Equal(ErrCourseNotFound.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "DeleteSale", mock.Anything)
+}
+
+func (s *PricingUseCaseTestSuite) TestApplySales() {
+	onSale := schema.Course{ID: uuid.New(), Price: 100000}
+	cutBelowSale := schema.Course{ID: uuid.New(), Price: 50000}
+	fullPrice := schema.Course{ID: uuid.New(), Price: 80000}
+	ids := []uuid.UUID{onSale.ID, cutBelowSale.ID, fullPrice.ID}
+	s.repo.On("GetRunningSales", ids).Return([]schema.CourseSale{
+		{CourseID: onSale.ID, SalePrice: 70000},
+		{CourseID: cutBelowSale.ID, SalePrice: 60000},
+	}, nil)
+
+	err := s.uc.ApplySales([]*schema.Course{&onSale, &cutBelowSale, &fullPrice})
+
+	s.NoError(err)
+	s.Equal(int64(70000), onSale.EffectivePrice)
+	s.NotNil(onSale.Sale)
+	s.Equal(int64(50000), cutBelowSale.EffectivePrice)
+	s.Nil(cutBelowSale.Sale)
+	s.Equal(int64(80000), fullPrice.EffectivePrice)
+}
+
+func (s *PricingUseCaseTestSuite) TestApplySales_Empty() {
+	s.NoError(s.uc.ApplySales(nil))
+	s.repo.AssertNotCalled(s.T(), "GetRunningSales", mock.Anything)
+}
+
+func (s *PricingUseCaseTestSuite) TestRecordPriceChange() {
+	userID := uuid.New()
+	s.repo.On("CreatePriceChange", mock.MatchedBy(func(change *schema.CoursePriceChange) bool {
+		return change.OldPrice == nil && change.NewPrice == 90000 && *change.ChangedByID == userID
+	})).Return(nil)
+
+	err := s.uc.RecordPriceChange(userContext(userID, "instructor"), s.course.ID, nil, 90000)
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *PricingUseCaseTestSuite) TestGetPriceHistory_DraftHidden() {
+	s.course.Status = schema.CourseStatusDraft
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+
+	_, err := s.uc.GetPriceHistory(context.Background(), s.courseRequest())
+
+	s.Equal(ErrCourseNotFound.Build(), err)
+}
+
+func (s *PricingUseCaseTestSuite) TestGetPriceHistory_Public() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("ListPriceChanges", s.course.ID).Return([]schema.CoursePriceChange(nil), nil)
+
+	res, err := s.uc.GetPriceHistory(context.Background(), s.courseRequest())
+
+	s.NoError(err)
+	s.NotNil(res)
+	s.Empty(res)
+}
+
+func (s *PricingUseCaseTestSuite) TestGetPriceHistory_CourseNotFound() {
+	s.repo.On("GetCourse", s.course.ID).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.uc.GetPriceHistory(context.Background(), s.courseRequest())
+
+	s.Equal(ErrCourseNotFound.Build(), err)
+}
@@ -0,0 +1,100 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRepository interface {
+	GetCourse(courseID uuid.UUID) (*schema.Course, error)
+	CreateSale(sale *schema.CourseSale, decide func(overlapping int64) error) error
+	GetSale(id uuid.UUID) (*schema.CourseSale, error)
+	DeleteSale(id uuid.UUID) error
+	ListSales(courseID uuid.UUID) ([]schema.CourseSale, error)
+	GetRunningSales(courseIDs []uuid.UUID, at time.Time) ([]schema.CourseSale, error)
+	CreatePriceChange(change *schema.CoursePriceChange) error
+	ListPriceChanges(courseID uuid.UUID) ([]schema.CoursePriceChange, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	var course schema.Course
+	if err := r.db.First(&course, "id = ?", courseID).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+// CreateSale saves a sale once decide accepts the number of the course's sales it would overlap. The course row is
+// locked meanwhile, so two overlapping sales cannot be added at once.
+func (r *repository) CreateSale(sale *schema.CourseSale, decide func(overlapping int64) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var course schema.Course
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			First(&course, "id = ?", sale.CourseID).Error; err != nil {
+			return err
+		}
+		var overlapping int64
+		if err := tx.Model(&schema.CourseSale{}).
+			Where("course_id = ? AND starts_at < ? AND ends_at > ?", sale.CourseID, sale.EndsAt, sale.StartsAt).
+			Count(&overlapping).Error; err != nil {
+			return err
+		}
+		if err := decide(overlapping); err != nil {
+			return err
+		}
+		return tx.Create(sale).Error
+	})
+}
+
+func (r *repository) GetSale(id uuid.UUID) (*schema.CourseSale, error) {
+	var sale schema.CourseSale
+	if err := r.db.Preload("Course").First(&sale, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &sale, nil
+}
+
+func (r *repository) DeleteSale(id uuid.UUID) error {
+	result := r.db.Delete(&schema.CourseSale{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) ListSales(courseID uuid.UUID) ([]schema.CourseSale, error) {
+	var sales []schema.CourseSale
+	err := r.db.Where("course_id = ?", courseID).Order("starts_at").Find(&sales).Error
+	return sales, err
+}
+
+func (r *repository) GetRunningSales(courseIDs []uuid.UUID, at time.Time) ([]schema.CourseSale, error) {
+	var sales []schema.CourseSale
+	err := r.db.Where("course_id IN ? AND starts_at <= ? AND ends_at > ?", courseIDs, at, at).Find(&sales).Error
+	return sales, err
+}
+
+func (r *repository) CreatePriceChange(change *schema.CoursePriceChange) error {
+	return r.db.Create(change).Error
+}
+
+func (r *repository) ListPriceChanges(courseID uuid.UUID) ([]schema.CoursePriceChange, error) {
+	var changes []schema.CoursePriceChange
+	err := r.db.Where("course_id = ?", courseID).Order("changed_at, id").Find(&changes).Error
+	return changes, err
+}
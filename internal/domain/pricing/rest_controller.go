@@ -0,0 +1,126 @@
+package pricing
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	courseGroup := engine.Group("/v1/courses/:id")
+	{
+		courseGroup.GET("/sales",
+			middleware.APIKeyScope("courses:read"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.ListSales(),
+		)
+		courseGroup.POST("/sales",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.CreateSale(),
+		)
+		courseGroup.GET("/price-history",
+			middleware.APIKeyScope("courses:read"),
+			middleware.OptionalAuthenticate(),
+			controller.GetPriceHistory(),
+		)
+	}
+
+	engine.DELETE("/v1/sales/:id",
+		middleware.APIKeyScope("courses:write"),
+		middleware.Authenticate(),
+		middleware.RequireRole("instructor", "admin"),
+		controller.DeleteSale(),
+	)
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) ListSales() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CourseIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.ListSales(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_SALES_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) CreateSale() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var courseReq CourseIDRequest
+		if err := ctx.ShouldBindUri(&courseReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req CreateSaleRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.CreateSale(ctx, &courseReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "CREATE_SALE_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) DeleteSale() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req SaleIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.DeleteSale(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "DELETE_SALE_SUCCESS", nil).Send(ctx)
+	}
+}
+
+func (c *RestController) GetPriceHistory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CourseIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.GetPriceHistory(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_PRICE_HISTORY_SUCCESS", res).Send(ctx)
+	}
+}
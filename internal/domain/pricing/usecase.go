@@ -0,0 +1,211 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+type UseCase struct {
+	repo IRepository
+}
+
+func NewUseCase(repo IRepository) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+func isAdmin(ctx context.Context) bool {
+	role, _ := ctx.Value("user.role").(string)
+	return role == string(schema.RoleAdmin)
+}
+
+func isOwner(ctx context.Context, course *schema.Course) bool {
+	userID, _ := ctx.Value("user.id").(string)
+	return isAdmin(ctx) || userID == course.InstructorID.String()
+}
+
+func (uc *UseCase) getCourse(courseID uuid.UUID) (*schema.Course, error) {
+	course, err := uc.repo.GetCourse(courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return course, nil
+}
+
+// getOwnedCourse loads a course whose sales the caller may manage: the instructor's own, or any for an admin
+func (uc *UseCase) getOwnedCourse(ctx context.Context, courseID uuid.UUID) (*schema.Course, error) {
+	course, err := uc.getCourse(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner(ctx, course) {
+		return nil, apierror.ErrNotYourResource.Build()
+	}
+	return course, nil
+}
+
+func (uc *UseCase) CreateSale(ctx context.Context, courseReq *CourseIDRequest, req *CreateSaleRequest) (*schema.CourseSale, error) {
+	course, err := uc.getOwnedCourse(ctx, uuid.MustParse(courseReq.CourseID))
+	if err != nil {
+		return nil, err
+	}
+	if !req.EndsAt.After(time.Now()) {
+		return nil, ErrSaleEnded.Build()
+	}
+	if *req.SalePrice >= course.Price {
+		return nil, ErrSaleNotDiscount.Build()
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	sale := &schema.CourseSale{
+		ID:        id,
+		CourseID:  course.ID,
+		SalePrice: *req.SalePrice,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		CreatedAt: time.Now(),
+	}
+	err = uc.repo.CreateSale(sale, func(overlapping int64) error {
+		if overlapping > 0 {
+			return ErrSaleOverlaps.Build()
+		}
+		return nil
+	})
+	if err != nil {
+		var apiErr *apierror.ApiError
+		if errors.As(err, &apiErr) {
+			return nil, err
+		}
+		log.Println("Error creating sale: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return sale, nil
+}
+
+// ListSales returns every sale of a course, past ones included, to its instructor
+func (uc *UseCase) ListSales(ctx context.Context, courseReq *CourseIDRequest) ([]schema.CourseSale, error) {
+	course, err := uc.getOwnedCourse(ctx, uuid.MustParse(courseReq.CourseID))
+	if err != nil {
+		return nil, err
+	}
+
+	sales, err := uc.repo.ListSales(course.ID)
+	if err != nil {
+		log.Println("Error listing sales: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if sales == nil {
+		sales = []schema.CourseSale{}
+	}
+	return sales, nil
+}
+
+// DeleteSale cancels a sale, or ends it early if it is already running
+func (uc *UseCase) DeleteSale(ctx context.Context, req *SaleIDRequest) error {
+	sale, err := uc.repo.GetSale(uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSaleNotFound.Build()
+		}
+		log.Println("Error getting sale: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	// The preload skips a deleted course, whose sales no longer matter to anyone
+	if sale.Course == nil {
+		return ErrCourseNotFound.Build()
+	}
+	if !isOwner(ctx, sale.Course) {
+		return apierror.ErrNotYourResource.Build()
+	}
+
+	if err := uc.repo.DeleteSale(sale.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSaleNotFound.Build()
+		}
+		log.Println("Error deleting sale: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	return nil
+}
+
+// ApplySales sets the effective price of each course, and the sale behind it, from the sales running now
+func (uc *UseCase) ApplySales(courses []*schema.Course) error {
+	if len(courses) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(courses))
+	for i, course := range courses {
+		ids[i] = course.ID
+	}
+
+	sales, err := uc.repo.GetRunningSales(ids, time.Now())
+	if err != nil {
+		log.Println("Error getting running sales: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	running := make(map[uuid.UUID]*schema.CourseSale, len(sales))
+	for i := range sales {
+		running[sales[i].CourseID] = &sales[i]
+	}
+
+	for _, course := range courses {
+		course.EffectivePrice = course.Price
+		course.Sale = nil
+		// A list price cut below the sale price leaves the sale with nothing to offer
+		if sale, ok := running[course.ID]; ok && sale.SalePrice < course.Price {
+			course.EffectivePrice = sale.SalePrice
+			course.Sale = sale
+		}
+	}
+	return nil
+}
+
+// RecordPriceChange adds an entry to a course's price history. oldPrice is nil for a newly created course.
+func (uc *UseCase) RecordPriceChange(ctx context.Context, courseID uuid.UUID, oldPrice *int64, newPrice int64) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	change := &schema.CoursePriceChange{ID: id, CourseID: courseID, OldPrice: oldPrice, NewPrice: newPrice, ChangedAt: time.Now()}
+	userID, _ := ctx.Value("user.id").(string)
+	if changedByID, err := uuid.Parse(userID); err == nil {
+		change.ChangedByID = &changedByID
+	}
+	return uc.repo.CreatePriceChange(change)
+}
+
+// GetPriceHistory lists a course's price changes, oldest first. Anyone may see the history of a course on sale;
+// that of an unreleased course is only shown to its instructor.
+func (uc *UseCase) GetPriceHistory(ctx context.Context, courseReq *CourseIDRequest) ([]schema.CoursePriceChange, error) {
+	course, err := uc.getCourse(uuid.MustParse(courseReq.CourseID))
+	if err != nil {
+		return nil, err
+	}
+	if course.Status != schema.CourseStatusPublished && course.Status != schema.CourseStatusUnlisted && !isOwner(ctx, course) {
+		return nil, ErrCourseNotFound.Build()
+	}
+
+	changes, err := uc.repo.ListPriceChanges(course.ID)
+	if err != nil {
+		log.Println("Error listing price changes: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if changes == nil {
+		changes = []schema.CoursePriceChange{}
+	}
+	return changes, nil
+}
@@ -0,0 +1,45 @@
+package progress
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"time"
+)
+
+type MaterialIDRequest struct {
+	MaterialID string `uri:"id" binding:"required,uuid"`
+}
+
+type CourseIDRequest struct {
+	CourseID string `uri:"id" binding:"required,uuid"`
+}
+
+// HeartbeatRequest is sent periodically by the video player while a material is being watched
+type HeartbeatRequest struct {
+	PositionSeconds int `json:"position_seconds" binding:"min=0"`
+	DurationSeconds int `json:"duration_seconds" binding:"required,min=1"`
+}
+
+type LessonProgressResponse struct {
+	MaterialID      uuid.UUID  `json:"material_id"`
+	WatchedSeconds  int        `json:"watched_seconds"`
+	DurationSeconds int        `json:"duration_seconds"`
+	CompletedAt     *time.Time `json:"completed_at"`
+}
+
+type ItemProgress struct {
+	Type            schema.CurriculumItemType `json:"type"`
+	ID              uuid.UUID                 `json:"id"`
+	Title           string                    `json:"title"`
+	Completed       bool                      `json:"completed"`
+	CompletedAt     *time.Time                `json:"completed_at"`
+	WatchedSeconds  int                       `json:"watched_seconds,omitempty"`
+	DurationSeconds int                       `json:"duration_seconds,omitempty"`
+}
+
+type CourseProgressResponse struct {
+	CourseID    uuid.UUID       `json:"course_id"`
+	Progress    float64         `json:"progress"`
+	CompletedAt *time.Time      `json:"completed_at"`
+	Items       []*ItemProgress `json:"items"`
+}
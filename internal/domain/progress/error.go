@@ -0,0 +1,12 @@
+package progress
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrMaterialNotFound = apierror.NewApiErrorBuilder().
+		WithHttpStatus(http.StatusNotFound).
+		WithMessage("MATERIAL_NOT_FOUND")
+)
@@ -0,0 +1,432 @@
+package progress
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/courseenroll"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/drip"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"os"
+	"testing"
+	"time"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetMaterialByID(id uuid.UUID) (*schema.Material, error) {
+	args := m.Called(id)
+	material, ok := args.Get(0).(*schema.Material)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return material, args.Error(1)
+}
+
+func (m *MockRepository) GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	args := m.Called(userID, courseID)
+	enroll, ok := args.Get(0).(*schema.CourseEnroll)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return enroll, args.Error(1)
+}
+
+func (m *MockRepository) GetLessonProgress(userID, materialID uuid.UUID) (*schema.LessonProgress, error) {
+	args := m.Called(userID, materialID)
+	progress, ok := args.Get(0).(*schema.LessonProgress)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return progress, args.Error(1)
+}
+
+func (m *MockRepository) SaveLessonProgress(progress *schema.LessonProgress) error {
+	args := m.Called(progress)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetCourseItems(userID, courseID uuid.UUID) ([]*ItemProgress, error) {
+	args := m.Called(userID, courseID)
+	return args.Get(0).([]*ItemProgress), args.Error(1)
+}
+
+type MockCourseRepository struct {
+	mock.Mock
+}
+
+func (m *MockCourseRepository) GetAll(ctx context.Context, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetByID(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetRating(ctx context.Context, courseID uuid.UUID) (float32, int64, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).(float32), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCourseRepository) Create(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) Update(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, publishedOnly bool, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, instructorID, publishedOnly, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) FindByPopularity(ctx context.Context, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetUserCourseProgress(ctx context.Context, courseID, userID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, courseID, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCourseRepository) Search(ctx context.Context, query string, page, pageSize int) ([]course.SearchHit, int, error) {
+	args := m.Called(ctx, query, page, pageSize)
+	return args.Get(0).([]course.SearchHit), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) Suggest(ctx context.Context, prefix string, limit int) ([]course.Suggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]course.Suggestion), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindCatalog(ctx context.Context, filter course.CatalogFilter, page, limit int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, filter, page, limit)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) GetCatalogFacets(ctx context.Context, filter course.CatalogFilter) (course.CatalogFacets, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(course.CatalogFacets), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindByStatus(ctx context.Context, status schema.CourseStatus, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, status, page, pageSize)
+	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) UpdateStatus(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetPrerequisites(ctx context.Context, courseID uuid.UUID) ([]course.PrerequisiteCourse, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]course.PrerequisiteCourse), args.Error(1)
+}
+
+func (m *MockCourseRepository) SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisites []schema.CoursePrerequisite) error {
+	args := m.Called(ctx, courseID, prerequisites)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetPrerequisiteClosure(ctx context.Context, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCompletedCourseIDs(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, userID, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCloneSource(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetSections(ctx context.Context, courseID uuid.UUID) ([]schema.CourseSection, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]schema.CourseSection), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateWithContent(ctx context.Context, course *schema.Course, sections []schema.CourseSection) error {
+	args := m.Called(ctx, course, sections)
+	return args.Error(0)
+}
+
+type MockEnrollRepository struct {
+	mock.Mock
+}
+
+func (m *MockEnrollRepository) Create(ctx context.Context, enroll *schema.CourseEnroll) error {
+	args := m.Called(ctx, enroll)
+	return args.Error(0)
+}
+
+func (m *MockEnrollRepository) GetUsersByCourseID(ctx context.Context, courseID uuid.UUID) ([]schema.User, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]schema.User), args.Error(1)
+}
+
+func (m *MockEnrollRepository) GetCoursesByUserID(ctx context.Context, userID uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockEnrollRepository) IsEnrolled(ctx context.Context, userID, courseID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, courseID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockEnrollRepository) MarkCompleted(ctx context.Context, userID, courseID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, courseID, at)
+	return args.Error(0)
+}
+
+type MockCertificateIssuer struct {
+	mock.Mock
+}
+
+func (m *MockCertificateIssuer) Issue(ctx context.Context, userID, courseID uuid.UUID) (*schema.Certificate, error) {
+	args := m.Called(ctx, userID, courseID)
+	certificate, _ := args.Get(0).(*schema.Certificate)
+	return certificate, args.Error(1)
+}
+
+type ProgressUseCaseTestSuite struct {
+	suite.Suite
+	repo       *MockRepository
+	courseRepo *MockCourseRepository
+	enrollRepo *MockEnrollRepository
+	issuer     *MockCertificateIssuer
+	uc         *UseCase
+
+	userID   uuid.UUID
+	courseID uuid.UUID
+	material *schema.Material
+	ctx      context.Context
+}
+
+func (s *ProgressUseCaseTestSuite) SetupTest() {
+	_ = os.Setenv("ENV", "test")
+	config.LoadEnv()
+
+	s.repo = new(MockRepository)
+	s.courseRepo = new(MockCourseRepository)
+	s.enrollRepo = new(MockEnrollRepository)
+	s.issuer = new(MockCertificateIssuer)
+	s.uc = NewUseCase(s.repo, s.courseRepo, s.enrollRepo, drip.NewUseCase(nil), s.issuer)
+
+	s.userID = uuid.New()
+	s.courseID = uuid.New()
+	s.material = &schema.Material{ID: uuid.New(), CourseID: s.courseID}
+	s.ctx = context.WithValue(context.Background(), "user.id", s.userID.String())
+}
+
+func TestProgressUseCase(t *testing.T) {
+	suite.Run(t, new(ProgressUseCaseTestSuite))
+}
+
+func (s *ProgressUseCaseTestSuite) materialRequest() *MaterialIDRequest {
+	return &MaterialIDRequest{MaterialID: s.material.ID.String()}
+}
+
+func (s *ProgressUseCaseTestSuite) expectEnrolledMaterial() {
+	s.repo.On("GetMaterialByID", s.material.ID).Return(s.material, nil)
+	s.repo.On("GetEnrollment", s.userID, s.courseID).Return(&schema.CourseEnroll{UserID: s.userID, CourseID: s.courseID}, nil)
+}
+
+func (s *ProgressUseCaseTestSuite) TestCompleteMaterial_MarksCourseCompleted() {
+	s.expectEnrolledMaterial()
+	s.repo.On("GetLessonProgress", s.userID, s.material.ID).Return(nil, gorm.ErrRecordNotFound)
+	s.repo.On("SaveLessonProgress", mock.MatchedBy(func(p *schema.LessonProgress) bool {
+		return p.CompletedAt != nil && p.CourseID == s.courseID && p.UserID == s.userID
+	})).Return(nil)
+	s.courseRepo.On("GetUserCourseProgress", s.ctx, s.courseID, s.userID).Return(100.0, nil)
+	s.enrollRepo.On("MarkCompleted", s.ctx, s.userID, s.courseID, mock.Anything).Return(nil)
+	s.issuer.On("Issue", s.ctx, s.userID, s.courseID).Return(&schema.Certificate{}, nil)
+
+	res, err := s.uc.CompleteMaterial(s.ctx, s.materialRequest())
+
+	s.NoError(err)
+	s.NotNil(res.CompletedAt)
+	s.enrollRepo.AssertExpectations(s.T())
+	s.issuer.AssertExpectations(s.T())
+}
+
+func (s *ProgressUseCaseTestSuite) TestCompleteMaterial_CertificateFailureLeftToJob() {
+	s.expectEnrolledMaterial()
+	s.repo.On("GetLessonProgress", s.userID, s.material.ID).Return(nil, gorm.ErrRecordNotFound)
+	s.repo.On("SaveLessonProgress", mock.Anything).Return(nil)
+	s.courseRepo.On("GetUserCourseProgress", s.ctx, s.courseID, s.userID).Return(100.0, nil)
+	s.enrollRepo.On("MarkCompleted", s.ctx, s.userID, s.courseID, mock.Anything).Return(nil)
+	s.issuer.On("Issue", s.ctx, s.userID, s.courseID).Return(nil, apierror.ErrInternalServer.Build())
+
+	res, err := s.uc.CompleteMaterial(s.ctx, s.materialRequest())
+
+	s.NoError(err)
+	s.NotNil(res.CompletedAt)
+	s.issuer.AssertExpectations(s.T())
+}
+
+func (s *ProgressUseCaseTestSuite) TestCompleteMaterial_AlreadyCompleted() {
+	completedAt := time.Now().Add(-time.Hour)
+	s.expectEnrolledMaterial()
+	s.repo.On("GetLessonProgress", s.userID, s.material.ID).
+		Return(&schema.LessonProgress{MaterialID: s.material.ID, CompletedAt: &completedAt}, nil)
+
+	res, err := s.uc.CompleteMaterial(s.ctx, s.materialRequest())
+
+	s.NoError(err)
+	s.Equal(&completedAt, res.CompletedAt)
+	s.repo.AssertNotCalled(s.T(), "SaveLessonProgress", mock.Anything)
+}
+
+func (s *ProgressUseCaseTestSuite) TestCompleteMaterial_NotEnrolled() {
+	s.repo.On("GetMaterialByID", s.material.ID).Return(s.material, nil)
+	s.repo.On("GetEnrollment", s.userID, s.courseID).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.uc.CompleteMaterial(s.ctx, s.materialRequest())
+
+	assert.Equal(s.T(), courseenroll.ErrNotEnrolled.Build(), err)
+}
+
+func (s *ProgressUseCaseTestSuite) TestHeartbeat_CreditCappedByElapsedTime() {
+	lastHeartbeat := time.Now().Add(-10 * time.Second)
+	s.expectEnrolledMaterial()
+	s.repo.On("GetLessonProgress", s.userID, s.material.ID).Return(&schema.LessonProgress{
+		UserID: s.userID, MaterialID: s.material.ID, CourseID: s.courseID,
+		WatchedSeconds: 60, DurationSeconds: 600, LastHeartbeatAt: &lastHeartbeat,
+	}, nil)
+	s.repo.On("SaveLessonProgress", mock.Anything).Return(nil)
+
+	res, err := s.uc.Heartbeat(s.ctx, s.materialRequest(), &HeartbeatRequest{PositionSeconds: 590, DurationSeconds: 600})
+
+	s.NoError(err)
+	s.InDelta(60+maxPlaybackRate*10, res.WatchedSeconds, 1)
+	s.Nil(res.CompletedAt)
+	s.courseRepo.AssertNotCalled(s.T(), "GetUserCourseProgress", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ProgressUseCaseTestSuite) TestHeartbeat_CompletesAtThreshold() {
+	lastHeartbeat := time.Now().Add(-30 * time.Second)
+	s.expectEnrolledMaterial()
+	s.repo.On("GetLessonProgress", s.userID, s.material.ID).Return(&schema.LessonProgress{
+		UserID: s.userID, MaterialID: s.material.ID, CourseID: s.courseID,
+		WatchedSeconds: 80, DurationSeconds: 100, LastHeartbeatAt: &lastHeartbeat,
+	}, nil)
+	s.repo.On("SaveLessonProgress", mock.Anything).Return(nil)
+	s.courseRepo.On("GetUserCourseProgress", s.ctx, s.courseID, s.userID).Return(50.0, nil)
+
+	res, err := s.uc.Heartbeat(s.ctx, s.materialRequest(), &HeartbeatRequest{PositionSeconds: 95, DurationSeconds: 100})
+
+	s.NoError(err)
+	s.Equal(95, res.WatchedSeconds)
+	s.NotNil(res.CompletedAt)
+	s.enrollRepo.AssertNotCalled(s.T(), "MarkCompleted", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.issuer.AssertNotCalled(s.T(), "Issue", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ProgressUseCaseTestSuite) TestHeartbeat_NeverMovesBackwards() {
+	lastHeartbeat := time.Now().Add(-5 * time.Second)
+	s.expectEnrolledMaterial()
+	s.repo.On("GetLessonProgress", s.userID, s.material.ID).Return(&schema.LessonProgress{
+		UserID: s.userID, MaterialID: s.material.ID, CourseID: s.courseID,
+		WatchedSeconds: 40, DurationSeconds: 100, LastHeartbeatAt: &lastHeartbeat,
+	}, nil)
+	s.repo.On("SaveLessonProgress", mock.Anything).Return(nil)
+
+	res, err := s.uc.Heartbeat(s.ctx, s.materialRequest(), &HeartbeatRequest{PositionSeconds: 10, DurationSeconds: 100})
+
+	s.NoError(err)
+	s.Equal(40, res.WatchedSeconds)
+}
+
+func (s *ProgressUseCaseTestSuite) TestHeartbeat_FirstOnlyStartsClock() {
+	s.expectEnrolledMaterial()
+	s.repo.On("GetLessonProgress", s.userID, s.material.ID).Return(nil, gorm.ErrRecordNotFound)
+	s.repo.On("SaveLessonProgress", mock.Anything).Return(nil)
+
+	res, err := s.uc.Heartbeat(s.ctx, s.materialRequest(), &HeartbeatRequest{PositionSeconds: 1, DurationSeconds: 1})
+
+	s.NoError(err)
+	s.Equal(0, res.WatchedSeconds)
+	s.Nil(res.CompletedAt)
+}
+
+func (s *ProgressUseCaseTestSuite) TestHeartbeat_DurationNeverLowered() {
+	lastHeartbeat := time.Now().Add(-5 * time.Second)
+	s.expectEnrolledMaterial()
+	s.repo.On("GetLessonProgress", s.userID, s.material.ID).Return(&schema.LessonProgress{
+		UserID: s.userID, MaterialID: s.material.ID, CourseID: s.courseID,
+		WatchedSeconds: 40, DurationSeconds: 600, LastHeartbeatAt: &lastHeartbeat,
+	}, nil)
+	s.repo.On("SaveLessonProgress", mock.Anything).Return(nil)
+
+	res, err := s.uc.Heartbeat(s.ctx, s.materialRequest(), &HeartbeatRequest{PositionSeconds: 45, DurationSeconds: 45})
+
+	s.NoError(err)
+	s.Equal(600, res.DurationSeconds)
+	s.Nil(res.CompletedAt)
+}
+
+func (s *ProgressUseCaseTestSuite) TestHeartbeat_MaterialDurationTrusted() {
+	duration := 300
+	s.material.DurationSeconds = &duration
+	lastHeartbeat := time.Now().Add(-5 * time.Second)
+	s.expectEnrolledMaterial()
+	s.repo.On("GetLessonProgress", s.userID, s.material.ID).Return(&schema.LessonProgress{
+		UserID: s.userID, MaterialID: s.material.ID, CourseID: s.courseID,
+		WatchedSeconds: 10, DurationSeconds: 10, LastHeartbeatAt: &lastHeartbeat,
+	}, nil)
+	s.repo.On("SaveLessonProgress", mock.Anything).Return(nil)
+
+	res, err := s.uc.Heartbeat(s.ctx, s.materialRequest(), &HeartbeatRequest{PositionSeconds: 20, DurationSeconds: 20})
+
+	s.NoError(err)
+	s.Equal(300, res.DurationSeconds)
+	s.Equal(20, res.WatchedSeconds)
+	s.Nil(res.CompletedAt)
+}
+
+func (s *ProgressUseCaseTestSuite) TestGetCourseProgress_Success() {
+	completedAt := time.Now()
+	items := []*ItemProgress{
+		{Type: schema.CurriculumItemMaterial, ID: s.material.ID, Completed: true, CompletedAt: &completedAt},
+	}
+	s.repo.On("GetEnrollment", s.userID, s.courseID).
+		Return(&schema.CourseEnroll{UserID: s.userID, CourseID: s.courseID, CompletedAt: &completedAt}, nil)
+	s.courseRepo.On("GetUserCourseProgress", s.ctx, s.courseID, s.userID).Return(100.0, nil)
+	s.repo.On("GetCourseItems", s.userID, s.courseID).Return(items, nil)
+
+	res, err := s.uc.GetCourseProgress(s.ctx, &CourseIDRequest{CourseID: s.courseID.String()})
+
+	s.NoError(err)
+	s.Equal(100.0, res.Progress)
+	s.Equal(&completedAt, res.CompletedAt)
+	s.Equal(items, res.Items)
+}
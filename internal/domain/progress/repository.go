@@ -0,0 +1,121 @@
+package progress
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type IRepository interface {
+	GetMaterialByID(id uuid.UUID) (*schema.Material, error)
+	GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error)
+	GetLessonProgress(userID, materialID uuid.UUID) (*schema.LessonProgress, error)
+	SaveLessonProgress(progress *schema.LessonProgress) error
+	GetCourseItems(userID, courseID uuid.UUID) ([]*ItemProgress, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db}
+}
+
+func (r *repository) GetMaterialByID(id uuid.UUID) (*schema.Material, error) {
+	var material schema.Material
+	if err := r.db.First(&material, id).Error; err != nil {
+		return nil, err
+	}
+	return &material, nil
+}
+
+func (r *repository) GetEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	var enroll schema.CourseEnroll
+	if err := r.db.Where("user_id = ? AND course_id = ?", userID, courseID).First(&enroll).Error; err != nil {
+		return nil, err
+	}
+	return &enroll, nil
+}
+
+func (r *repository) GetLessonProgress(userID, materialID uuid.UUID) (*schema.LessonProgress, error) {
+	var progress schema.LessonProgress
+	if err := r.db.Where("user_id = ? AND material_id = ?", userID, materialID).First(&progress).Error; err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// SaveLessonProgress inserts or updates the student's progress on a material in one statement, so concurrent
+// requests on a first visit do not both insert. Watch time never goes down and a completion is never cleared, even
+// when a request that read the row earlier writes last.
+func (r *repository) SaveLessonProgress(progress *schema.LessonProgress) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "material_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"watched_seconds":   gorm.Expr("GREATEST(lesson_progresses.watched_seconds, EXCLUDED.watched_seconds)"),
+			"duration_seconds":  gorm.Expr("EXCLUDED.duration_seconds"),
+			"last_heartbeat_at": gorm.Expr("EXCLUDED.last_heartbeat_at"),
+			"completed_at":      gorm.Expr("COALESCE(lesson_progresses.completed_at, EXCLUDED.completed_at)"),
+			"updated_at":        gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}, clause.Returning{}).Create(progress).Error
+}
+
+type itemRow struct {
+	ID              uuid.UUID
+	Title           string
+	CompletedAt     *time.Time
+	WatchedSeconds  int
+	DurationSeconds int
+}
+
+func (r *repository) GetCourseItems(userID, courseID uuid.UUID) ([]*ItemProgress, error) {
+	var materials, assignments []itemRow
+	if err := r.db.Model(&schema.Material{}).
+		Select("materials.id, materials.title, lesson_progresses.completed_at, "+
+			"COALESCE(lesson_progresses.watched_seconds, 0) AS watched_seconds, "+
+			"COALESCE(lesson_progresses.duration_seconds, 0) AS duration_seconds").
+		Joins("LEFT JOIN lesson_progresses ON lesson_progresses.material_id = materials.id AND lesson_progresses.user_id = ?", userID).
+		Where("materials.course_id = ?", courseID).
+		Order("materials.created_at").
+		Scan(&materials).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Model(&schema.Assignment{}).
+		Select("assignments.id, assignments.title, MIN(submissions.created_at) AS completed_at").
+		Joins("LEFT JOIN submissions ON submissions.assignment_id = assignments.id "+
+			"AND submissions.user_id = ? AND submissions.deleted_at IS NULL", userID).
+		Where("assignments.course_id = ?", courseID).
+		Group("assignments.id, assignments.title, assignments.created_at").
+		Order("assignments.created_at").
+		Scan(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*ItemProgress, 0, len(materials)+len(assignments))
+	for _, row := range materials {
+		items = append(items, &ItemProgress{
+			Type:            schema.CurriculumItemMaterial,
+			ID:              row.ID,
+			Title:           row.Title,
+			Completed:       row.CompletedAt != nil,
+			CompletedAt:     row.CompletedAt,
+			WatchedSeconds:  row.WatchedSeconds,
+			DurationSeconds: row.DurationSeconds,
+		})
+	}
+	for _, row := range assignments {
+		items = append(items, &ItemProgress{
+			Type:        schema.CurriculumItemAssignment,
+			ID:          row.ID,
+			Title:       row.Title,
+			Completed:   row.CompletedAt != nil,
+			CompletedAt: row.CompletedAt,
+		})
+	}
+
+	return items, nil
+}
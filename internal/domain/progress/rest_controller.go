@@ -0,0 +1,88 @@
+package progress
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	progressGroup := engine.Group("/v1/progress")
+	progressGroup.Use(middleware.Authenticate(), middleware.RequireRole("student"))
+	{
+		progressGroup.POST("/materials/:id/complete", controller.CompleteMaterial())
+		progressGroup.POST("/materials/:id/heartbeat", controller.Heartbeat())
+		progressGroup.GET("/courses/:id", controller.GetCourseProgress())
+	}
+}
+
+func (c *RestController) CompleteMaterial() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req MaterialIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.CompleteMaterial(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "COMPLETE_MATERIAL_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Heartbeat() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var materialReq MaterialIDRequest
+		if err := ctx.ShouldBindUri(&materialReq); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+		var req HeartbeatRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.Heartbeat(ctx, &materialReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "HEARTBEAT_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) GetCourseProgress() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CourseIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.GetCourseProgress(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_COURSE_PROGRESS_SUCCESS", res).Send(ctx)
+	}
+}
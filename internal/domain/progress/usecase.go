@@ -0,0 +1,237 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/courseenroll"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/drip"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+const (
+	// completionRatio is the share of a video that has to be watched before the material counts as completed
+	completionRatio = 0.9
+	// maxPlaybackRate is the fastest playback speed credited, as a multiple of the time between heartbeats
+	maxPlaybackRate = 2
+	// maxHeartbeatGap caps the credit given after a long pause so skipping to the end does not count as watching
+	maxHeartbeatGap = time.Minute
+)
+
+// CertificateIssuer generates the certificate of a completed enrollment, returning the existing one if it was
+// already issued
+type CertificateIssuer interface {
+	Issue(ctx context.Context, userID, courseID uuid.UUID) (*schema.Certificate, error)
+}
+
+type UseCase struct {
+	repo         IRepository
+	courseRepo   course.Repository
+	enrollRepo   courseenroll.Repository
+	drip         *drip.UseCase
+	certificates CertificateIssuer
+}
+
+func NewUseCase(repo IRepository, courseRepo course.Repository, enrollRepo courseenroll.Repository, dripUseCase *drip.UseCase,
+	certificates CertificateIssuer) *UseCase {
+	return &UseCase{repo: repo, courseRepo: courseRepo, enrollRepo: enrollRepo, drip: dripUseCase, certificates: certificates}
+}
+
+func (uc *UseCase) getEnrollment(userID, courseID uuid.UUID) (*schema.CourseEnroll, error) {
+	enroll, err := uc.repo.GetEnrollment(userID, courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, courseenroll.ErrNotEnrolled.Build()
+		}
+		log.Println("Error getting enrollment: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return enroll, nil
+}
+
+// getLessonProgress loads the student's progress on a material, starting a fresh record on first access that is only
+// written, as an upsert, when saved
+func (uc *UseCase) getLessonProgress(ctx context.Context, req *MaterialIDRequest) (*schema.LessonProgress, *schema.Material, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	material, err := uc.repo.GetMaterialByID(uuid.MustParse(req.MaterialID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrMaterialNotFound.Build()
+		}
+		log.Println("Error getting material: ", err)
+		return nil, nil, apierror.ErrInternalServer.Build()
+	}
+
+	if _, err := uc.getEnrollment(userID, material.CourseID); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.drip.EnsureUnlocked(ctx, material); err != nil {
+		return nil, nil, err
+	}
+
+	progress, err := uc.repo.GetLessonProgress(userID, material.ID)
+	if err == nil {
+		return progress, material, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Println("Error getting lesson progress: ", err)
+		return nil, nil, apierror.ErrInternalServer.Build()
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return nil, nil, apierror.ErrInternalServer.Build()
+	}
+	return &schema.LessonProgress{
+		ID:         id,
+		UserID:     userID,
+		MaterialID: material.ID,
+		CourseID:   material.CourseID,
+	}, material, nil
+}
+
+// SyncCourseCompletion records the enrollment's completion timestamp once the student's progress reaches 100%
+// and issues the certificate right away
+func (uc *UseCase) SyncCourseCompletion(ctx context.Context, userID, courseID uuid.UUID) error {
+	progress, err := uc.courseRepo.GetUserCourseProgress(ctx, courseID, userID)
+	if err != nil {
+		return err
+	}
+	if progress < 100 {
+		return nil
+	}
+	if err := uc.enrollRepo.MarkCompleted(ctx, userID, courseID, time.Now()); err != nil {
+		return err
+	}
+
+	// The completion is already recorded, so a failure here is left to the background job instead of failing the request
+	if _, err := uc.certificates.Issue(ctx, userID, courseID); err != nil {
+		log.Println("Error issuing certificate: ", err)
+	}
+	return nil
+}
+
+func (uc *UseCase) saveLessonProgress(ctx context.Context, progress *schema.LessonProgress, justCompleted bool) error {
+	if err := uc.repo.SaveLessonProgress(progress); err != nil {
+		log.Println("Error saving lesson progress: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	if justCompleted {
+		if err := uc.SyncCourseCompletion(ctx, progress.UserID, progress.CourseID); err != nil {
+			log.Println("Error syncing course completion: ", err)
+			return apierror.ErrInternalServer.Build()
+		}
+	}
+
+	return nil
+}
+
+func toLessonProgressResponse(progress *schema.LessonProgress) *LessonProgressResponse {
+	return &LessonProgressResponse{
+		MaterialID:      progress.MaterialID,
+		WatchedSeconds:  progress.WatchedSeconds,
+		DurationSeconds: progress.DurationSeconds,
+		CompletedAt:     progress.CompletedAt,
+	}
+}
+
+func (uc *UseCase) CompleteMaterial(ctx context.Context, req *MaterialIDRequest) (*LessonProgressResponse, error) {
+	progress, _, err := uc.getLessonProgress(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if progress.CompletedAt != nil {
+		return toLessonProgressResponse(progress), nil
+	}
+
+	now := time.Now()
+	progress.CompletedAt = &now
+	if err := uc.saveLessonProgress(ctx, progress, true); err != nil {
+		return nil, err
+	}
+
+	return toLessonProgressResponse(progress), nil
+}
+
+// Heartbeat credits watch time up to the reported position, but never faster than real time allows. The first
+// heartbeat only starts the clock. The video length comes from the material when the instructor set it; otherwise
+// the first reported length is kept and later heartbeats may only raise it.
+func (uc *UseCase) Heartbeat(ctx context.Context, materialReq *MaterialIDRequest, req *HeartbeatRequest) (*LessonProgressResponse, error) {
+	progress, material, err := uc.getLessonProgress(ctx, materialReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if material.DurationSeconds != nil {
+		progress.DurationSeconds = *material.DurationSeconds
+	} else {
+		progress.DurationSeconds = max(progress.DurationSeconds, req.DurationSeconds)
+	}
+
+	now := time.Now()
+	var allowance time.Duration
+	if progress.LastHeartbeatAt != nil {
+		allowance = maxPlaybackRate * min(now.Sub(*progress.LastHeartbeatAt), maxHeartbeatGap)
+	}
+
+	credited := min(req.PositionSeconds, progress.DurationSeconds, progress.WatchedSeconds+int(allowance.Seconds()))
+	if credited > progress.WatchedSeconds {
+		progress.WatchedSeconds = credited
+	}
+	progress.LastHeartbeatAt = &now
+
+	justCompleted := false
+	if progress.CompletedAt == nil && float64(progress.WatchedSeconds) >= completionRatio*float64(progress.DurationSeconds) {
+		progress.CompletedAt = &now
+		justCompleted = true
+	}
+
+	if err := uc.saveLessonProgress(ctx, progress, justCompleted); err != nil {
+		return nil, err
+	}
+
+	return toLessonProgressResponse(progress), nil
+}
+
+func (uc *UseCase) GetCourseProgress(ctx context.Context, req *CourseIDRequest) (*CourseProgressResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+	courseID := uuid.MustParse(req.CourseID)
+
+	enroll, err := uc.getEnrollment(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := uc.courseRepo.GetUserCourseProgress(ctx, courseID, userID)
+	if err != nil {
+		log.Println("Error getting course progress: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	items, err := uc.repo.GetCourseItems(userID, courseID)
+	if err != nil {
+		log.Println("Error getting course items: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return &CourseProgressResponse{
+		CourseID:    courseID,
+		Progress:    progress,
+		CompletedAt: enroll.CompletedAt,
+		Items:       items,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package recommendation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+)
+
+type ListRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=30"`
+}
+
+type CourseIDRequest struct {
+	CourseID string `uri:"id" binding:"required,uuid"`
+}
+
+// Recommendation is a ranked suggestion without the course itself, as kept in the cache
+type Recommendation struct {
+	CourseID uuid.UUID `json:"course_id"`
+	Score    float64   `json:"score"`
+	Reasons  []string  `json:"reasons"`
+}
+
+// CachedRecommendations is a user's ranking as stored between computations
+type CachedRecommendations struct {
+	Recommendations []Recommendation `json:"recommendations"`
+	ComputedAt      time.Time        `json:"computed_at"`
+}
+
+type RecommendedCourse struct {
+	Course  schema.Course `json:"course"`
+	Score   float64       `json:"score"`
+	Reasons []string      `json:"reasons"`
+}
+
+type RecommendationsResponse struct {
+	Courses    []RecommendedCourse `json:"courses"`
+	ComputedAt time.Time           `json:"computed_at"`
+}
+
+type SimilarCourse struct {
+	Course         schema.Course `json:"course"`
+	SharedStudents int64         `json:"shared_students"`
+	Score          float64       `json:"score"`
+}
@@ -0,0 +1,12 @@
+package recommendation
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrCourseNotFound = apierror.NewApiErrorBuilder().
+		WithHttpStatus(http.StatusNotFound).
+		WithMessage("COURSE_NOT_FOUND")
+)
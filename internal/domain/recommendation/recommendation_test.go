@@ -0,0 +1,212 @@
+package recommendation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/pricing"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetEnrolledCourses(userID uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) GetSimilarCourses(courseIDs []uuid.UUID, limit int) ([]schema.CourseSimilarity, error) {
+	args := m.Called(courseIDs, limit)
+	return args.Get(0).([]schema.CourseSimilarity), args.Error(1)
+}
+
+func (m *MockRepository) GetCategoryCourses(categoryIDs []uuid.UUID, limit int) ([]schema.Course, error) {
+	args := m.Called(categoryIDs, limit)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) GetPopularCourses(limit int) ([]schema.Course, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) GetCourses(ids []uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) RefreshSimilarities(minSharedStudents int, now time.Time) error {
+	args := m.Called(minSharedStudents)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetCached(ctx context.Context, userID uuid.UUID) (*CachedRecommendations, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CachedRecommendations), args.Error(1)
+}
+
+func (m *MockRepository) SetCached(ctx context.Context, userID uuid.UUID, cached *CachedRecommendations, ttl time.Duration) error {
+	args := m.Called(userID, cached)
+	return args.Error(0)
+}
+
+type MockPricingRepository struct {
+	pricing.IRepository
+}
+
+// GetRunningSales reports no sales; prices are covered by the pricing tests
+func (m *MockPricingRepository) GetRunningSales(courseIDs []uuid.UUID, at time.Time) ([]schema.CourseSale, error) {
+	return nil, nil
+}
+
+func course(title string, categoryID *uuid.UUID, difficulty schema.CourseDifficulty) schema.Course {
+	return schema.Course{ID: uuid.New(), Title: title, CategoryID: categoryID, Difficulty: difficulty,
+		Status: schema.CourseStatusPublished}
+}
+
+func TestRank(t *testing.T) {
+	web, data := uuid.New(), uuid.New()
+	taken := course("HTML and CSS", &web, schema.Intermediate)
+	taken.Category = &schema.Category{ID: web, Name: "Web Development"}
+
+	nextLevel := course("React", &web, schema.Advanced)
+	sameLevel := course("JavaScript", &web, schema.Intermediate)
+	coEnrolled := course("SQL", &data, schema.Intermediate)
+	tooHard := course("Browser Internals", &web, schema.Expert)
+	easier := course("Intro to the Web", &web, schema.Beginner)
+	popular := course("Python", nil, schema.Beginner)
+
+	similarities := []schema.CourseSimilarity{{CourseID: taken.ID, SimilarCourseID: coEnrolled.ID, SharedStudents: 12, Score: 0.5}}
+	candidates := []schema.Course{coEnrolled, nextLevel, sameLevel, tooHard, easier}
+
+	recommendations := rank([]schema.Course{taken}, similarities, candidates, []schema.Course{taken, popular})
+
+	ids := make([]uuid.UUID, len(recommendations))
+	for i, recommendation := range recommendations {
+		ids[i] = recommendation.CourseID
+	}
+	assert.Equal(t, []uuid.UUID{nextLevel.ID, sameLevel.ID, coEnrolled.ID, tooHard.ID, easier.ID, popular.ID}, ids)
+	assert.Equal(t, []string{"You are studying Web Development", "A step up from the courses you have taken"},
+		recommendations[0].Reasons)
+	assert.Equal(t, []string{"Students who took HTML and CSS also took this course"}, recommendations[2].Reasons)
+	assert.Equal(t, []string{"Popular with students"}, recommendations[5].Reasons)
+}
+
+func TestRank_NoHistory(t *testing.T) {
+	first, second := course("Python", nil, schema.Beginner), course("Go", nil, schema.Beginner)
+
+	recommendations := rank(nil, nil, nil, []schema.Course{first, second})
+
+	assert.Len(t, recommendations, 2)
+	assert.Equal(t, first.ID, recommendations[0].CourseID)
+	assert.Greater(t, recommendations[0].Score, recommendations[1].Score)
+}
+
+type RecommendationUseCaseTestSuite struct {
+	suite.Suite
+	repo *MockRepository
+	uc   *UseCase
+
+	studentID uuid.UUID
+}
+
+func (s *RecommendationUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.uc = NewUseCase(s.repo, pricing.NewUseCase(&MockPricingRepository{}))
+	s.studentID = uuid.New()
+}
+
+func TestRecommendationUseCase(t *testing.T) {
+	suite.Run(t, new(RecommendationUseCaseTestSuite))
+}
+
+func (s *RecommendationUseCaseTestSuite) context() context.Context {
+	return context.WithValue(context.Background(), "user.id", s.studentID.String())
+}
+
+func (s *RecommendationUseCaseTestSuite) TestRecommend_ComputesAndCaches() {
+	popular := course("Python", nil, schema.Beginner)
+	s.repo.On("GetEnrolledCourses", s.studentID).Return([]schema.Course{}, nil)
+	s.repo.On("GetCached", s.studentID).Return(nil, redis.Nil)
+	s.repo.On("GetPopularCourses", rankedCount).Return([]schema.Course{popular}, nil)
+	s.repo.On("SetCached", s.studentID, mock.MatchedBy(func(cached *CachedRecommendations) bool {
+		return len(cached.Recommendations) == 1 && cached.Recommendations[0].CourseID == popular.ID
+	})).Return(nil)
+	s.repo.On("GetCourses", []uuid.UUID{popular.ID}).Return([]schema.Course{popular}, nil)
+
+	res, err := s.uc.Recommend(s.context(), &ListRequest{})
+
+	s.NoError(err)
+	s.Len(res.Courses, 1)
+	s.Equal("Python", res.Courses[0].Course.Title)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *RecommendationUseCaseTestSuite) TestRecommend_CachedSkipsBoughtCourses() {
+	bought := course("Go", nil, schema.Beginner)
+	recommended := course("Rust", nil, schema.Advanced)
+	archived := uuid.New()
+	cached := &CachedRecommendations{ComputedAt: time.Now(), Recommendations: []Recommendation{
+		{CourseID: bought.ID, Score: 0.9},
+		{CourseID: archived, Score: 0.8},
+		{CourseID: recommended.ID, Score: 0.7},
+	}}
+	s.repo.On("GetEnrolledCourses", s.studentID).Return([]schema.Course{bought}, nil)
+	s.repo.On("GetCached", s.studentID).Return(cached, nil)
+	s.repo.On("GetCourses", []uuid.UUID{archived, recommended.ID}).Return([]schema.Course{recommended}, nil)
+
+	res, err := s.uc.Recommend(s.context(), &ListRequest{Limit: 5})
+
+	s.NoError(err)
+	s.Len(res.Courses, 1)
+	s.Equal(recommended.ID, res.Courses[0].Course.ID)
+	s.repo.AssertNotCalled(s.T(), "SetCached", mock.Anything, mock.Anything)
+}
+
+func (s *RecommendationUseCaseTestSuite) TestSimilar() {
+	source := course("Go", nil, schema.Beginner)
+	similar := course("Docker", nil, schema.Intermediate)
+	unpublished := uuid.New()
+	s.repo.On("GetCourse", source.ID).Return(&source, nil)
+	s.repo.On("GetSimilarCourses", []uuid.UUID{source.ID}, 2).Return([]schema.CourseSimilarity{
+		{CourseID: source.ID, SimilarCourseID: unpublished, SharedStudents: 9, Score: 0.6},
+		{CourseID: source.ID, SimilarCourseID: similar.ID, SharedStudents: 4, Score: 0.3},
+	}, nil)
+	s.repo.On("GetCourses", []uuid.UUID{unpublished, similar.ID}).Return([]schema.Course{similar}, nil)
+
+	res, err := s.uc.Similar(&CourseIDRequest{CourseID: source.ID.String()}, &ListRequest{Limit: 1})
+
+	s.NoError(err)
+	s.Len(res, 1)
+	s.Equal(similar.ID, res[0].Course.ID)
+	s.Equal(int64(4), res[0].SharedStudents)
+}
+
+func (s *RecommendationUseCaseTestSuite) TestSimilar_Draft() {
+	source := course("Go", nil, schema.Beginner)
+	source.Status = schema.CourseStatusDraft
+	s.repo.On("GetCourse", source.ID).Return(&source, nil)
+
+	_, err := s.uc.Similar(&CourseIDRequest{CourseID: source.ID.String()}, &ListRequest{})
+
+	s.Equal(ErrCourseNotFound.Build(), err)
+}
@@ -0,0 +1,145 @@
+package recommendation
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type IRepository interface {
+	GetEnrolledCourses(userID uuid.UUID) ([]schema.Course, error)
+	GetSimilarCourses(courseIDs []uuid.UUID, limit int) ([]schema.CourseSimilarity, error)
+	GetCategoryCourses(categoryIDs []uuid.UUID, limit int) ([]schema.Course, error)
+	GetPopularCourses(limit int) ([]schema.Course, error)
+	GetCourse(courseID uuid.UUID) (*schema.Course, error)
+	GetCourses(ids []uuid.UUID) ([]schema.Course, error)
+	RefreshSimilarities(minSharedStudents int, now time.Time) error
+
+	GetCached(ctx context.Context, userID uuid.UUID) (*CachedRecommendations, error)
+	SetCached(ctx context.Context, userID uuid.UUID, cached *CachedRecommendations, ttl time.Duration) error
+}
+
+type repository struct {
+	db  *gorm.DB
+	rds *redis.Client
+}
+
+func NewRepository(db *gorm.DB, rds *redis.Client) IRepository {
+	return &repository{db: db, rds: rds}
+}
+
+// purchasable limits a course query to courses a student can find and buy
+func purchasable(tx *gorm.DB) *gorm.DB {
+	return tx.Where("courses.status = ?", schema.CourseStatusPublished)
+}
+
+func (r *repository) GetEnrolledCourses(userID uuid.UUID) ([]schema.Course, error) {
+	var courses []schema.Course
+	err := r.db.Preload("Category").
+		Joins("JOIN course_enrolls ON course_enrolls.course_id = courses.id").
+		Where("course_enrolls.user_id = ?", userID).
+		Find(&courses).Error
+	return courses, err
+}
+
+// GetSimilarCourses returns the strongest similarities from any of courseIDs, strongest first
+func (r *repository) GetSimilarCourses(courseIDs []uuid.UUID, limit int) ([]schema.CourseSimilarity, error) {
+	var similarities []schema.CourseSimilarity
+	err := r.db.Where("course_id IN ?", courseIDs).
+		Order("score DESC, shared_students DESC").
+		Limit(limit).
+		Find(&similarities).Error
+	return similarities, err
+}
+
+// GetCategoryCourses returns the best rated published courses of the categories
+func (r *repository) GetCategoryCourses(categoryIDs []uuid.UUID, limit int) ([]schema.Course, error) {
+	var courses []schema.Course
+	err := r.db.Scopes(purchasable).
+		Where("category_id IN ?", categoryIDs).
+		Order("rating DESC, review_count DESC, id").
+		Limit(limit).
+		Find(&courses).Error
+	return courses, err
+}
+
+// GetPopularCourses returns the published courses with the most students
+func (r *repository) GetPopularCourses(limit int) ([]schema.Course, error) {
+	var courses []schema.Course
+	err := r.db.Scopes(purchasable).
+		Select("courses.*, (SELECT COUNT(*) FROM course_enrolls WHERE course_enrolls.course_id = courses.id) AS enrollment_count").
+		Order("enrollment_count DESC, rating DESC, id").
+		Limit(limit).
+		Find(&courses).Error
+	return courses, err
+}
+
+func (r *repository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	var course schema.Course
+	if err := r.db.First(&course, "id = ?", courseID).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+// GetCourses loads the published courses among ids, in no particular order
+func (r *repository) GetCourses(ids []uuid.UUID) ([]schema.Course, error) {
+	var courses []schema.Course
+	err := r.db.Scopes(purchasable).Where("id IN ?", ids).Find(&courses).Error
+	return courses, err
+}
+
+// RefreshSimilarities rebuilds every course similarity from the current enrollments. Pairs of courses sharing fewer
+// than minSharedStudents students are left out as noise.
+func (r *repository) RefreshSimilarities(minSharedStudents int, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM course_similarities").Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			WITH students AS (
+				SELECT course_id, COUNT(*) AS total FROM course_enrolls GROUP BY course_id
+			), pairs AS (
+				SELECT a.course_id, b.course_id AS similar_course_id, COUNT(*) AS shared
+				FROM course_enrolls a
+				JOIN course_enrolls b ON b.user_id = a.user_id AND b.course_id <> a.course_id
+				GROUP BY a.course_id, b.course_id
+				HAVING COUNT(*) >= ?
+			)
+			INSERT INTO course_similarities (course_id, similar_course_id, shared_students, score, computed_at)
+			SELECT p.course_id, p.similar_course_id, p.shared, p.shared / sqrt(sa.total::float8 * sb.total), ?
+			FROM pairs p
+			JOIN students sa ON sa.course_id = p.course_id
+			JOIN students sb ON sb.course_id = p.similar_course_id`, minSharedStudents, now).Error
+	})
+}
+
+func cacheKey(userID uuid.UUID) string {
+	return "recommendation:" + userID.String()
+}
+
+// GetCached returns the user's cached ranking, or redis.Nil when there is none
+func (r *repository) GetCached(ctx context.Context, userID uuid.UUID) (*CachedRecommendations, error) {
+	data, err := r.rds.Get(ctx, cacheKey(userID)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var cached CachedRecommendations
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+func (r *repository) SetCached(ctx context.Context, userID uuid.UUID, cached *CachedRecommendations, ttl time.Duration) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	return r.rds.Set(ctx, cacheKey(userID), data, ttl).Err()
+}
@@ -0,0 +1,71 @@
+package recommendation
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	engine.GET("/v1/recommendations",
+		middleware.APIKeyScope("courses:read"),
+		middleware.Authenticate(),
+		middleware.RequireRole("student"),
+		controller.Recommend(),
+	)
+	engine.GET("/v1/courses/:id/similar", middleware.APIKeyScope("courses:read"), controller.Similar())
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) Recommend() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req ListRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Recommend(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_RECOMMENDATIONS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Similar() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var courseReq CourseIDRequest
+		if err := ctx.ShouldBindUri(&courseReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req ListRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Similar(&courseReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_SIMILAR_COURSES_SUCCESS", res).Send(ctx)
+	}
+}
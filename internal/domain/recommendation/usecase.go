@@ -0,0 +1,383 @@
+package recommendation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/pricing"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLimit = 10
+	// rankedCount is how many recommendations are computed and cached, enough for the largest page
+	rankedCount = 30
+	// candidatePool bounds how many similar and same-category courses are scored per computation
+	candidatePool = 200
+	cacheTTL      = time.Hour
+
+	// minSharedStudents keeps pairs of courses that happen to share a single student out of the similarities
+	minSharedStudents = 2
+
+	// Weights of the signals a candidate is scored on. Co-enrollment is the strongest, category interest
+	// next; the difficulty step and rating mostly order courses that are otherwise alike.
+	coEnrollmentWeight = 1.0
+	categoryWeight     = 0.6
+	nextLevelBonus     = 0.3
+	sameLevelBonus     = 0.15
+	easierPenalty      = 0.2
+	ratingWeight       = 0.1
+	popularityScore    = 0.05
+)
+
+var difficultyLevels = map[schema.CourseDifficulty]int{
+	schema.Beginner:     0,
+	schema.Intermediate: 1,
+	schema.Advanced:     2,
+	schema.Expert:       3,
+}
+
+type UseCase struct {
+	repo           IRepository
+	pricingUseCase *pricing.UseCase
+}
+
+func NewUseCase(repo IRepository, pricingUseCase *pricing.UseCase) *UseCase {
+	return &UseCase{repo: repo, pricingUseCase: pricingUseCase}
+}
+
+// candidate collects the score and reasons of one course while ranking
+type candidate struct {
+	course  *schema.Course
+	score   float64
+	reasons []string
+}
+
+// rank scores the candidates against the courses a student is enrolled in and returns the best, strongest first
+func rank(enrolled []schema.Course, similarities []schema.CourseSimilarity, candidates []schema.Course, popular []schema.Course) []Recommendation {
+	taken := make(map[uuid.UUID]*schema.Course, len(enrolled))
+	categoryShare := make(map[uuid.UUID]float64)
+	categoryNames := make(map[uuid.UUID]string)
+	// highestLevel is the hardest difficulty reached per category; uuid.Nil holds the hardest overall
+	highestLevel := map[uuid.UUID]int{uuid.Nil: -1}
+	for i := range enrolled {
+		course := &enrolled[i]
+		taken[course.ID] = course
+		level := difficultyLevels[course.Difficulty]
+		highestLevel[uuid.Nil] = max(highestLevel[uuid.Nil], level)
+		if course.CategoryID == nil {
+			continue
+		}
+		categoryShare[*course.CategoryID] += 1 / float64(len(enrolled))
+		if course.Category != nil {
+			categoryNames[*course.CategoryID] = course.Category.Name
+		}
+		if current, ok := highestLevel[*course.CategoryID]; !ok || level > current {
+			highestLevel[*course.CategoryID] = level
+		}
+	}
+
+	scored := make(map[uuid.UUID]*candidate)
+	get := func(course *schema.Course) *candidate {
+		c, ok := scored[course.ID]
+		if !ok {
+			c = &candidate{course: course}
+			scored[course.ID] = c
+		}
+		return c
+	}
+	byID := make(map[uuid.UUID]*schema.Course, len(candidates))
+	for i := range candidates {
+		if _, ok := taken[candidates[i].ID]; !ok {
+			byID[candidates[i].ID] = &candidates[i]
+		}
+	}
+
+	// Similarities arrive strongest first, so the first one seen for a course gives its reason
+	for _, similarity := range similarities {
+		course, ok := byID[similarity.SimilarCourseID]
+		source := taken[similarity.CourseID]
+		if !ok || source == nil {
+			continue
+		}
+		c := get(course)
+		if len(c.reasons) == 0 {
+			c.reasons = append(c.reasons, fmt.Sprintf("Students who took %s also took this course", source.Title))
+		}
+		c.score += coEnrollmentWeight * similarity.Score
+	}
+
+	for _, course := range byID {
+		if course.CategoryID == nil || categoryShare[*course.CategoryID] == 0 {
+			continue
+		}
+		c := get(course)
+		c.score += categoryWeight * categoryShare[*course.CategoryID]
+		if name := categoryNames[*course.CategoryID]; name != "" {
+			c.reasons = append(c.reasons, "You are studying "+name)
+		}
+	}
+
+	for _, c := range scored {
+		reached, ok := highestLevel[uuid.Nil], false
+		if c.course.CategoryID != nil {
+			if level, inCategory := highestLevel[*c.course.CategoryID]; inCategory {
+				reached, ok = level, true
+			}
+		}
+		if !ok && reached < 0 {
+			continue
+		}
+		switch step := difficultyLevels[c.course.Difficulty] - reached; {
+		case step == 1:
+			c.score += nextLevelBonus
+			c.reasons = append(c.reasons, "A step up from the courses you have taken")
+		case step == 0:
+			c.score += sameLevelBonus
+		case step < 0:
+			c.score -= easierPenalty
+		}
+		c.score += ratingWeight * float64(c.course.Rating) / 5
+	}
+
+	ranked := make([]*candidate, 0, len(scored))
+	for _, c := range scored {
+		if c.score > 0 {
+			ranked = append(ranked, c)
+		}
+	}
+	slices.SortFunc(ranked, func(a, b *candidate) int {
+		if a.score != b.score {
+			return cmp.Compare(b.score, a.score)
+		}
+		return cmp.Compare(a.course.ID.String(), b.course.ID.String())
+	})
+
+	recommendations := make([]Recommendation, 0, rankedCount)
+	for _, c := range ranked {
+		if len(recommendations) == rankedCount {
+			break
+		}
+		recommendations = append(recommendations, Recommendation{CourseID: c.course.ID, Score: c.score, Reasons: c.reasons})
+	}
+
+	// Students with little history get popular courses to fill the list
+	for i := range popular {
+		if len(recommendations) == rankedCount {
+			break
+		}
+		course := &popular[i]
+		if _, ok := taken[course.ID]; ok {
+			continue
+		}
+		if _, ok := scored[course.ID]; ok && scored[course.ID].score > 0 {
+			continue
+		}
+		recommendations = append(recommendations, Recommendation{
+			CourseID: course.ID,
+			Score:    popularityScore * float64(len(popular)-i) / float64(len(popular)),
+			Reasons:  []string{"Popular with students"},
+		})
+	}
+	return recommendations
+}
+
+// compute ranks courses for a student from their enrollments and the latest similarities
+func (uc *UseCase) compute(enrolled []schema.Course) ([]Recommendation, error) {
+	var similarities []schema.CourseSimilarity
+	var candidates []schema.Course
+	if len(enrolled) > 0 {
+		courseIDs := make([]uuid.UUID, 0, len(enrolled))
+		var categoryIDs []uuid.UUID
+		for _, course := range enrolled {
+			courseIDs = append(courseIDs, course.ID)
+			if course.CategoryID != nil && !slices.Contains(categoryIDs, *course.CategoryID) {
+				categoryIDs = append(categoryIDs, *course.CategoryID)
+			}
+		}
+
+		var err error
+		if similarities, err = uc.repo.GetSimilarCourses(courseIDs, candidatePool); err != nil {
+			return nil, err
+		}
+		similarIDs := make([]uuid.UUID, 0, len(similarities))
+		for _, similarity := range similarities {
+			similarIDs = append(similarIDs, similarity.SimilarCourseID)
+		}
+		if len(similarIDs) > 0 {
+			if candidates, err = uc.repo.GetCourses(similarIDs); err != nil {
+				return nil, err
+			}
+		}
+		if len(categoryIDs) > 0 {
+			sameCategory, err := uc.repo.GetCategoryCourses(categoryIDs, candidatePool)
+			if err != nil {
+				return nil, err
+			}
+			for _, course := range sameCategory {
+				if !slices.ContainsFunc(candidates, func(c schema.Course) bool { return c.ID == course.ID }) {
+					candidates = append(candidates, course)
+				}
+			}
+		}
+	}
+
+	popular, err := uc.repo.GetPopularCourses(rankedCount + len(enrolled))
+	if err != nil {
+		return nil, err
+	}
+	return rank(enrolled, similarities, candidates, popular), nil
+}
+
+// Recommend suggests courses to the calling student. Rankings are cached for an hour; the courses themselves, and
+// whether the student has bought any of them since, are looked up on every request.
+func (uc *UseCase) Recommend(ctx context.Context, req *ListRequest) (*RecommendationsResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	enrolled, err := uc.repo.GetEnrolledCourses(userID)
+	if err != nil {
+		log.Println("Error getting enrolled courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	cached, err := uc.repo.GetCached(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Println("Error getting cached recommendations: ", err)
+		}
+		recommendations, err := uc.compute(enrolled)
+		if err != nil {
+			log.Println("Error computing recommendations: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		cached = &CachedRecommendations{Recommendations: recommendations, ComputedAt: time.Now()}
+		if err := uc.repo.SetCached(ctx, userID, cached, cacheTTL); err != nil {
+			log.Println("Error caching recommendations: ", err)
+		}
+	}
+
+	taken := make(map[uuid.UUID]bool, len(enrolled))
+	for _, course := range enrolled {
+		taken[course.ID] = true
+	}
+	ids := make([]uuid.UUID, 0, len(cached.Recommendations))
+	for _, recommendation := range cached.Recommendations {
+		if !taken[recommendation.CourseID] {
+			ids = append(ids, recommendation.CourseID)
+		}
+	}
+
+	res := &RecommendationsResponse{Courses: []RecommendedCourse{}, ComputedAt: cached.ComputedAt}
+	if len(ids) == 0 {
+		return res, nil
+	}
+	courses, err := uc.repo.GetCourses(ids)
+	if err != nil {
+		log.Println("Error getting recommended courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	byID := make(map[uuid.UUID]*schema.Course, len(courses))
+	for i := range courses {
+		byID[courses[i].ID] = &courses[i]
+	}
+
+	for _, recommendation := range cached.Recommendations {
+		if len(res.Courses) == limit {
+			break
+		}
+		course, ok := byID[recommendation.CourseID]
+		if !ok || taken[course.ID] {
+			continue
+		}
+		res.Courses = append(res.Courses, RecommendedCourse{Course: *course, Score: recommendation.Score, Reasons: recommendation.Reasons})
+	}
+	pointers := make([]*schema.Course, len(res.Courses))
+	for i := range res.Courses {
+		pointers[i] = &res.Courses[i].Course
+	}
+	if err := uc.pricingUseCase.ApplySales(pointers); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Similar lists the courses most often taken together with a course
+func (uc *UseCase) Similar(req *CourseIDRequest, listReq *ListRequest) ([]SimilarCourse, error) {
+	course, err := uc.repo.GetCourse(uuid.MustParse(req.CourseID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if course.Status != schema.CourseStatusPublished && course.Status != schema.CourseStatusUnlisted {
+		return nil, ErrCourseNotFound.Build()
+	}
+	limit := listReq.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	// Fetch extra similarities since some may point at courses that are no longer published
+	similarities, err := uc.repo.GetSimilarCourses([]uuid.UUID{course.ID}, 2*limit)
+	if err != nil {
+		log.Println("Error getting similar courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	res := make([]SimilarCourse, 0, limit)
+	if len(similarities) == 0 {
+		return res, nil
+	}
+	ids := make([]uuid.UUID, len(similarities))
+	for i, similarity := range similarities {
+		ids[i] = similarity.SimilarCourseID
+	}
+	courses, err := uc.repo.GetCourses(ids)
+	if err != nil {
+		log.Println("Error getting similar courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	byID := make(map[uuid.UUID]*schema.Course, len(courses))
+	for i := range courses {
+		byID[courses[i].ID] = &courses[i]
+	}
+
+	for _, similarity := range similarities {
+		if len(res) == limit {
+			break
+		}
+		if similar, ok := byID[similarity.SimilarCourseID]; ok {
+			res = append(res, SimilarCourse{Course: *similar, SharedStudents: similarity.SharedStudents, Score: similarity.Score})
+		}
+	}
+	pointers := make([]*schema.Course, len(res))
+	for i := range res {
+		pointers[i] = &res[i].Course
+	}
+	if err := uc.pricingUseCase.ApplySales(pointers); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RefreshSimilarities recomputes which courses are taken together. It runs as a background job.
+func (uc *UseCase) RefreshSimilarities(ctx context.Context) error {
+	return uc.repo.RefreshSimilarities(minSharedStudents, time.Now())
+}
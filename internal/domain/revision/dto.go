@@ -0,0 +1,48 @@
+package revision
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+)
+
+type EntityIDRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type VersionRequest struct {
+	ID      string `uri:"id" binding:"required,uuid"`
+	Version int    `uri:"version" binding:"required,min=1"`
+}
+
+type DiffRequest struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+// Snapshot is the part of a course or material that is versioned
+type Snapshot struct {
+	Title       string
+	Description string
+}
+
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+type DiffResponse struct {
+	EntityType  schema.RevisionEntityType `json:"entity_type"`
+	EntityID    uuid.UUID                 `json:"entity_id"`
+	From        int                       `json:"from"`
+	To          int                       `json:"to"`
+	Title       []DiffLine                `json:"title"`
+	Description []DiffLine                `json:"description"`
+}
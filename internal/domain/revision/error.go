@@ -0,0 +1,20 @@
+package revision
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrRevisionNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("REVISION_NOT_FOUND")
+
+	ErrEntityNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("REVISION_SUBJECT_NOT_FOUND")
+
+	ErrAlreadyCurrent = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("REVISION_ALREADY_CURRENT")
+)
@@ -0,0 +1,83 @@
+package revision
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+type IRepository interface {
+	Create(revision *schema.Revision) error
+	GetLatest(entityType schema.RevisionEntityType, entityID uuid.UUID) (*schema.Revision, error)
+	GetByVersion(entityType schema.RevisionEntityType, entityID uuid.UUID, version int) (*schema.Revision, error)
+	List(entityType schema.RevisionEntityType, entityID uuid.UUID) ([]schema.Revision, error)
+	GetCourse(id uuid.UUID) (*schema.Course, error)
+	GetMaterial(id uuid.UUID) (*schema.Material, error)
+	UpdateContent(entityType schema.RevisionEntityType, entityID uuid.UUID, snapshot Snapshot) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db}
+}
+
+func (r *repository) Create(revision *schema.Revision) error {
+	return r.db.Create(revision).Error
+}
+
+func (r *repository) GetLatest(entityType schema.RevisionEntityType, entityID uuid.UUID) (*schema.Revision, error) {
+	var revision schema.Revision
+	err := r.db.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("version DESC").First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *repository) GetByVersion(entityType schema.RevisionEntityType, entityID uuid.UUID, version int) (*schema.Revision, error) {
+	var revision schema.Revision
+	err := r.db.Where("entity_type = ? AND entity_id = ? AND version = ?", entityType, entityID, version).
+		First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *repository) List(entityType schema.RevisionEntityType, entityID uuid.UUID) ([]schema.Revision, error) {
+	var revisions []schema.Revision
+	err := r.db.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("version DESC").Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *repository) GetCourse(id uuid.UUID) (*schema.Course, error) {
+	var course schema.Course
+	if err := r.db.Select("id", "title", "description", "instructor_id").First(&course, id).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *repository) GetMaterial(id uuid.UUID) (*schema.Material, error) {
+	var material schema.Material
+	if err := r.db.Select("id", "course_id", "title", "description").First(&material, id).Error; err != nil {
+		return nil, err
+	}
+	return &material, nil
+}
+
+func (r *repository) UpdateContent(entityType schema.RevisionEntityType, entityID uuid.UUID, snapshot Snapshot) error {
+	var model any = &schema.Material{}
+	if entityType == schema.RevisionEntityCourse {
+		model = &schema.Course{}
+	}
+	return r.db.Model(model).Where("id = ?", entityID).Updates(map[string]any{
+		"title":       snapshot.Title,
+		"description": snapshot.Description,
+	}).Error
+}
@@ -0,0 +1,107 @@
+package revision
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	groups := map[schema.RevisionEntityType]*gin.RouterGroup{
+		schema.RevisionEntityCourse:   engine.Group("/v1/courses/:id/revisions"),
+		schema.RevisionEntityMaterial: engine.Group("/v1/materials/:id/revisions"),
+	}
+	for entityType, group := range groups {
+		group.GET("",
+			middleware.APIKeyScope("courses:read"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.List(entityType),
+		)
+		group.GET("/diff",
+			middleware.APIKeyScope("courses:read"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.Diff(entityType),
+		)
+		group.POST("/:version/restore",
+			middleware.APIKeyScope("courses:write"),
+			middleware.Authenticate(),
+			middleware.RequireRole("instructor", "admin"),
+			controller.Restore(entityType),
+		)
+	}
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) List(entityType schema.RevisionEntityType) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req EntityIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.List(ctx, entityType, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_REVISIONS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Diff(entityType schema.RevisionEntityType) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var idReq EntityIDRequest
+		if err := ctx.ShouldBindUri(&idReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req DiffRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Diff(ctx, entityType, &idReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_REVISION_DIFF_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Restore(entityType schema.RevisionEntityType) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req VersionRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Restore(ctx, entityType, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "RESTORE_REVISION_SUCCESS", res).Send(ctx)
+	}
+}
@@ -0,0 +1,218 @@
+package revision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(revision *schema.Revision) error {
+	args := m.Called(revision)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetLatest(entityType schema.RevisionEntityType, entityID uuid.UUID) (*schema.Revision, error) {
+	args := m.Called(entityType, entityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Revision), args.Error(1)
+}
+
+func (m *MockRepository) GetByVersion(entityType schema.RevisionEntityType, entityID uuid.UUID, version int) (*schema.Revision, error) {
+	args := m.Called(entityType, entityID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Revision), args.Error(1)
+}
+
+func (m *MockRepository) List(entityType schema.RevisionEntityType, entityID uuid.UUID) ([]schema.Revision, error) {
+	args := m.Called(entityType, entityID)
+	return args.Get(0).([]schema.Revision), args.Error(1)
+}
+
+func (m *MockRepository) GetCourse(id uuid.UUID) (*schema.Course, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) GetMaterial(id uuid.UUID) (*schema.Material, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Material), args.Error(1)
+}
+
+func (m *MockRepository) UpdateContent(entityType schema.RevisionEntityType, entityID uuid.UUID, snapshot Snapshot) error {
+	args := m.Called(entityType, entityID, snapshot)
+	return args.Error(0)
+}
+
+type RevisionUseCaseTestSuite struct {
+	suite.Suite
+	repo *MockRepository
+	uc   *UseCase
+
+	instructorID uuid.UUID
+	course       *schema.Course
+	material     *schema.Material
+}
+
+func (s *RevisionUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.uc = NewUseCase(s.repo)
+
+	s.instructorID = uuid.New()
+	s.course = &schema.Course{ID: uuid.New(), Title: "Go", InstructorID: s.instructorID}
+	s.material = &schema.Material{ID: uuid.New(), CourseID: s.course.ID, Title: "Intro", Description: "line one\nline three"}
+}
+
+func TestRevisionUseCase(t *testing.T) {
+	suite.Run(t, new(RevisionUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID, role string) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", role)
+}
+
+func (s *RevisionUseCaseTestSuite) expectMaterial() {
+	s.repo.On("GetMaterial", s.material.ID).Return(s.material, nil)
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+}
+
+func (s *RevisionUseCaseTestSuite) TestRecord_Unchanged() {
+	snapshot := Snapshot{Title: "Intro", Description: "Body"}
+
+	err := s.uc.Record(context.Background(), schema.RevisionEntityMaterial, s.material.ID, s.course.ID, snapshot, snapshot)
+
+	s.NoError(err)
+	s.repo.AssertNotCalled(s.T(), "GetLatest", mock.Anything, mock.Anything)
+}
+
+func (s *RevisionUseCaseTestSuite) TestRecord_FirstEditKeepsOriginal() {
+	before := Snapshot{Title: "Intro", Description: "Old"}
+	after := Snapshot{Title: "Intro", Description: "New"}
+	s.repo.On("GetLatest", schema.RevisionEntityMaterial, s.material.ID).Return(nil, gorm.ErrRecordNotFound)
+	s.repo.On("Create", mock.MatchedBy(func(r *schema.Revision) bool {
+		return r.Version == 1 && r.Description == "Old" && r.AuthorID == nil
+	})).Return(nil).Once()
+	s.repo.On("Create", mock.MatchedBy(func(r *schema.Revision) bool {
+		return r.Version == 2 && r.Description == "New" && r.AuthorID != nil && *r.AuthorID == s.instructorID
+	})).Return(nil).Once()
+
+	err := s.uc.Record(userContext(s.instructorID, "instructor"), schema.RevisionEntityMaterial, s.material.ID,
+		s.course.ID, before, after)
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *RevisionUseCaseTestSuite) TestRecord_SkipsWhenLatestMatches() {
+	before := Snapshot{Title: "Intro", Description: "Old"}
+	after := Snapshot{Title: "Intro", Description: "New"}
+	s.repo.On("GetLatest", schema.RevisionEntityMaterial, s.material.ID).
+		Return(&schema.Revision{Version: 4, Title: "Intro", Description: "New"}, nil)
+
+	err := s.uc.Record(context.Background(), schema.RevisionEntityMaterial, s.material.ID, s.course.ID, before, after)
+
+	s.NoError(err)
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *RevisionUseCaseTestSuite) TestList_NotOwner() {
+	s.expectMaterial()
+
+	_, err := s.uc.List(userContext(uuid.New(), "instructor"), schema.RevisionEntityMaterial,
+		&EntityIDRequest{ID: s.material.ID.String()})
+
+	s.Equal(apierror.ErrNotYourResource.Build(), err)
+}
+
+func (s *RevisionUseCaseTestSuite) TestList_AdminAllowed() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("List", schema.RevisionEntityCourse, s.course.ID).Return([]schema.Revision(nil), nil)
+
+	revisions, err := s.uc.List(userContext(uuid.New(), "admin"), schema.RevisionEntityCourse,
+		&EntityIDRequest{ID: s.course.ID.String()})
+
+	s.NoError(err)
+	s.NotNil(revisions)
+	s.Empty(revisions)
+}
+
+func (s *RevisionUseCaseTestSuite) TestDiff() {
+	s.expectMaterial()
+	s.repo.On("GetByVersion", schema.RevisionEntityMaterial, s.material.ID, 1).
+		Return(&schema.Revision{Version: 1, Title: "Intro", Description: "line one\nline two"}, nil)
+	s.repo.On("GetByVersion", schema.RevisionEntityMaterial, s.material.ID, 2).
+		Return(&schema.Revision{Version: 2, Title: "Intro", Description: "line one\nline three"}, nil)
+
+	res, err := s.uc.Diff(userContext(s.instructorID, "instructor"), schema.RevisionEntityMaterial,
+		&EntityIDRequest{ID: s.material.ID.String()}, &DiffRequest{From: 1, To: 2})
+
+	s.NoError(err)
+	s.Equal([]DiffLine{{Op: DiffEqual, Text: "Intro"}}, res.Title)
+	s.Equal([]DiffLine{
+		{Op: DiffEqual, Text: "line one"},
+		{Op: DiffDelete, Text: "line two"},
+		{Op: DiffInsert, Text: "line three"},
+	}, res.Description)
+}
+
+func (s *RevisionUseCaseTestSuite) TestDiff_UnknownVersion() {
+	s.expectMaterial()
+	s.repo.On("GetByVersion", schema.RevisionEntityMaterial, s.material.ID, 1).Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := s.uc.Diff(userContext(s.instructorID, "instructor"), schema.RevisionEntityMaterial,
+		&EntityIDRequest{ID: s.material.ID.String()}, &DiffRequest{From: 1, To: 2})
+
+	s.Equal(ErrRevisionNotFound.Build(), err)
+}
+
+func (s *RevisionUseCaseTestSuite) TestRestore() {
+	s.expectMaterial()
+	restored := Snapshot{Title: "Intro", Description: "line one\nline two"}
+	s.repo.On("GetByVersion", schema.RevisionEntityMaterial, s.material.ID, 1).
+		Return(&schema.Revision{Version: 1, Title: restored.Title, Description: restored.Description}, nil)
+	s.repo.On("UpdateContent", schema.RevisionEntityMaterial, s.material.ID, restored).Return(nil)
+	s.repo.On("GetLatest", schema.RevisionEntityMaterial, s.material.ID).
+		Return(&schema.Revision{Version: 2, Title: s.material.Title, Description: s.material.Description}, nil)
+	s.repo.On("Create", mock.MatchedBy(func(r *schema.Revision) bool {
+		return r.Version == 3 && r.RestoredFrom != nil && *r.RestoredFrom == 1 && r.Description == restored.Description
+	})).Return(nil)
+
+	revision, err := s.uc.Restore(userContext(s.instructorID, "instructor"), schema.RevisionEntityMaterial,
+		&VersionRequest{ID: s.material.ID.String(), Version: 1})
+
+	s.NoError(err)
+	s.Equal(3, revision.Version)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *RevisionUseCaseTestSuite) TestRestore_AlreadyCurrent() {
+	s.expectMaterial()
+	s.repo.On("GetByVersion", schema.RevisionEntityMaterial, s.material.ID, 2).
+		Return(&schema.Revision{Version: 2, Title: s.material.Title, Description: s.material.Description}, nil)
+
+	_, err := s.uc.Restore(userContext(s.instructorID, "instructor"), schema.RevisionEntityMaterial,
+		&VersionRequest{ID: s.material.ID.String(), Version: 2})
+
+	s.Equal(ErrAlreadyCurrent.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "UpdateContent", mock.Anything, mock.Anything, mock.Anything)
+}
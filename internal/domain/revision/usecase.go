@@ -0,0 +1,222 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"log"
+)
+
+type UseCase struct {
+	repo IRepository
+}
+
+func NewUseCase(repo IRepository) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+func snapshotOf(revision *schema.Revision) Snapshot {
+	return Snapshot{Title: revision.Title, Description: revision.Description}
+}
+
+func authorID(ctx context.Context) *uuid.UUID {
+	rawUserID, ok := ctx.Value("user.id").(string)
+	if !ok {
+		return nil
+	}
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return nil
+	}
+	return &userID
+}
+
+// Record adds a revision after a course or material was edited. The first tracked edit also stores the content it
+// replaced, so there is always a version to go back to.
+func (uc *UseCase) Record(ctx context.Context, entityType schema.RevisionEntityType, entityID, courseID uuid.UUID,
+	before, after Snapshot) error {
+	if before == after {
+		return nil
+	}
+	_, err := uc.record(ctx, entityType, entityID, courseID, before, after, nil)
+	return err
+}
+
+func (uc *UseCase) record(ctx context.Context, entityType schema.RevisionEntityType, entityID, courseID uuid.UUID,
+	before, after Snapshot, restoredFrom *int) (*schema.Revision, error) {
+	version := 0
+	latest, err := uc.repo.GetLatest(entityType, entityID)
+	if err == nil {
+		if snapshotOf(latest) == after {
+			return latest, nil
+		}
+		version = latest.Version
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		original, err := uc.newRevision(entityType, entityID, courseID, 1, nil, before)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.repo.Create(original); err != nil {
+			return nil, err
+		}
+		version = original.Version
+	} else {
+		return nil, err
+	}
+
+	revision, err := uc.newRevision(entityType, entityID, courseID, version+1, authorID(ctx), after)
+	if err != nil {
+		return nil, err
+	}
+	revision.RestoredFrom = restoredFrom
+	if err := uc.repo.Create(revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (uc *UseCase) newRevision(entityType schema.RevisionEntityType, entityID, courseID uuid.UUID, version int,
+	author *uuid.UUID, snapshot Snapshot) (*schema.Revision, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	return &schema.Revision{
+		ID:          id,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Version:     version,
+		CourseID:    courseID,
+		AuthorID:    author,
+		Title:       snapshot.Title,
+		Description: snapshot.Description,
+	}, nil
+}
+
+// subject loads the current content of a course or material, making sure the viewer in ctx owns its course
+func (uc *UseCase) subject(ctx context.Context, entityType schema.RevisionEntityType, id uuid.UUID) (uuid.UUID, Snapshot, error) {
+	courseID := id
+	var current Snapshot
+	if entityType == schema.RevisionEntityMaterial {
+		material, err := uc.repo.GetMaterial(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return uuid.Nil, Snapshot{}, ErrEntityNotFound.Build()
+			}
+			log.Println("Error getting material: ", err)
+			return uuid.Nil, Snapshot{}, apierror.ErrInternalServer.Build()
+		}
+		courseID = material.CourseID
+		current = Snapshot{Title: material.Title, Description: material.Description}
+	}
+
+	course, err := uc.repo.GetCourse(courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, Snapshot{}, ErrEntityNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return uuid.Nil, Snapshot{}, apierror.ErrInternalServer.Build()
+	}
+	if entityType == schema.RevisionEntityCourse {
+		current = Snapshot{Title: course.Title, Description: course.Description}
+	}
+
+	if role, _ := ctx.Value("user.role").(string); role != string(schema.RoleAdmin) {
+		userID := authorID(ctx)
+		if userID == nil {
+			return uuid.Nil, Snapshot{}, apierror.ErrTokenInvalid.Build()
+		}
+		if course.InstructorID != *userID {
+			return uuid.Nil, Snapshot{}, apierror.ErrNotYourResource.Build()
+		}
+	}
+	return courseID, current, nil
+}
+
+func (uc *UseCase) getVersion(entityType schema.RevisionEntityType, entityID uuid.UUID, version int) (*schema.Revision, error) {
+	revision, err := uc.repo.GetByVersion(entityType, entityID, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound.Build()
+		}
+		log.Println("Error getting revision: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return revision, nil
+}
+
+func (uc *UseCase) List(ctx context.Context, entityType schema.RevisionEntityType, req *EntityIDRequest) ([]schema.Revision, error) {
+	entityID := uuid.MustParse(req.ID)
+	if _, _, err := uc.subject(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := uc.repo.List(entityType, entityID)
+	if err != nil {
+		log.Println("Error listing revisions: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if revisions == nil {
+		revisions = []schema.Revision{}
+	}
+	return revisions, nil
+}
+
+func (uc *UseCase) Diff(ctx context.Context, entityType schema.RevisionEntityType, idReq *EntityIDRequest, req *DiffRequest) (*DiffResponse, error) {
+	entityID := uuid.MustParse(idReq.ID)
+	if _, _, err := uc.subject(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	from, err := uc.getVersion(entityType, entityID, req.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := uc.getVersion(entityType, entityID, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffResponse{
+		EntityType:  entityType,
+		EntityID:    entityID,
+		From:        from.Version,
+		To:          to.Version,
+		Title:       diffLines(from.Title, to.Title),
+		Description: diffLines(from.Description, to.Description),
+	}, nil
+}
+
+// Restore puts the content of an earlier version back and records it as a new version, keeping the history linear
+func (uc *UseCase) Restore(ctx context.Context, entityType schema.RevisionEntityType, req *VersionRequest) (*schema.Revision, error) {
+	entityID := uuid.MustParse(req.ID)
+	courseID, current, err := uc.subject(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := uc.getVersion(entityType, entityID, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	restored := snapshotOf(target)
+	if restored == current {
+		return nil, ErrAlreadyCurrent.Build()
+	}
+
+	if err := uc.repo.UpdateContent(entityType, entityID, restored); err != nil {
+		log.Println("Error restoring revision: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	revision, err := uc.record(ctx, entityType, entityID, courseID, current, restored, &target.Version)
+	if err != nil {
+		log.Println("Error recording restored revision: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return revision, nil
+}
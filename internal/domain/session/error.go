@@ -0,0 +1,16 @@
+package session
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrSessionNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("SESSION_NOT_FOUND")
+
+	ErrSessionRevoked = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusUnauthorized).
+				WithMessage("SESSION_REVOKED")
+)
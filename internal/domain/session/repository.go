@@ -0,0 +1,68 @@
+package session
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+type IRepository interface {
+	Create(session *schema.Session) error
+	GetByID(id uuid.UUID) (*schema.Session, error)
+	GetActiveByUserID(userID uuid.UUID) ([]*schema.Session, error)
+	Update(session *schema.Session) error
+	Revoke(id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(session *schema.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *repository) GetByID(id uuid.UUID) (*schema.Session, error) {
+	var session schema.Session
+	if err := r.db.First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *repository) GetActiveByUserID(userID uuid.UUID) ([]*schema.Session, error) {
+	var sessions []*schema.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *repository) Update(session *schema.Session) error {
+	tx := r.db.Updates(session)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) Revoke(id uuid.UUID) error {
+	tx := r.db.Model(&schema.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package session
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	sessionGroup := engine.Group("/v1/auth/sessions")
+	sessionGroup.Use(middleware.Authenticate())
+	{
+		sessionGroup.GET("", controller.GetMy())
+		sessionGroup.DELETE("/:id", controller.Revoke())
+	}
+}
+
+func (c *RestController) GetMy() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res, err := c.uc.GetMy(ctx)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_SESSIONS_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Revoke() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req RevokeSessionRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		if err := c.uc.Revoke(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "REVOKE_SESSION_SUCCESS", nil).Send(ctx)
+	}
+}
@@ -0,0 +1,191 @@
+package session
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(session *schema.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetByID(id uuid.UUID) (*schema.Session, error) {
+	args := m.Called(id)
+	session, ok := args.Get(0).(*schema.Session)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return session, args.Error(1)
+}
+
+func (m *MockRepository) GetActiveByUserID(userID uuid.UUID) ([]*schema.Session, error) {
+	args := m.Called(userID)
+	sessions, ok := args.Get(0).([]*schema.Session)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return sessions, args.Error(1)
+}
+
+func (m *MockRepository) Update(session *schema.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Revoke(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type SessionUseCaseTestSuite struct {
+	suite.Suite
+	repo    *MockRepository
+	useCase *UseCase
+}
+
+func (suite *SessionUseCaseTestSuite) SetupTest() {
+	suite.repo = new(MockRepository)
+	suite.useCase = NewUseCase(suite.repo)
+}
+
+func (suite *SessionUseCaseTestSuite) TestValidateSession_Success() {
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	suite.repo.On("GetByID", sessionID).Return(&schema.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		LastUsedAt: time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}, nil)
+
+	err := suite.useCase.ValidateSession(context.Background(), sessionID.String(), userID.String())
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertNotCalled(suite.T(), "Update", mock.Anything)
+}
+
+func (suite *SessionUseCaseTestSuite) TestValidateSession_TouchesStaleLastUsed() {
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	suite.repo.On("GetByID", sessionID).Return(&schema.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		LastUsedAt: time.Now().Add(-time.Hour),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}, nil)
+	suite.repo.On("Update", mock.Anything).Return(nil)
+
+	err := suite.useCase.ValidateSession(context.Background(), sessionID.String(), userID.String())
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *SessionUseCaseTestSuite) TestValidateSession_Revoked() {
+	userID := uuid.New()
+	sessionID := uuid.New()
+	revokedAt := time.Now()
+
+	suite.repo.On("GetByID", sessionID).Return(&schema.Session{
+		ID:        sessionID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Hour),
+		RevokedAt: &revokedAt,
+	}, nil)
+
+	err := suite.useCase.ValidateSession(context.Background(), sessionID.String(), userID.String())
+	assert.Equal(suite.T(), ErrSessionRevoked.Build(), err)
+}
+
+func (suite *SessionUseCaseTestSuite) TestValidateSession_OtherUser() {
+	sessionID := uuid.New()
+
+	suite.repo.On("GetByID", sessionID).Return(&schema.Session{
+		ID:        sessionID,
+		UserID:    uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+
+	err := suite.useCase.ValidateSession(context.Background(), sessionID.String(), uuid.NewString())
+	assert.Equal(suite.T(), ErrSessionRevoked.Build(), err)
+}
+
+func (suite *SessionUseCaseTestSuite) TestValidateSession_NotFound() {
+	sessionID := uuid.New()
+
+	suite.repo.On("GetByID", sessionID).Return(nil, gorm.ErrRecordNotFound)
+
+	err := suite.useCase.ValidateSession(context.Background(), sessionID.String(), uuid.NewString())
+	assert.Equal(suite.T(), ErrSessionRevoked.Build(), err)
+}
+
+func (suite *SessionUseCaseTestSuite) TestGetMy_MarksCurrentSession() {
+	userID := uuid.New()
+	current := uuid.New()
+	other := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	ctx = context.WithValue(ctx, "user.session_id", current.String())
+
+	suite.repo.On("GetActiveByUserID", userID).Return([]*schema.Session{
+		{ID: current, UserID: userID, DeviceName: "Laptop"},
+		{ID: other, UserID: userID, DeviceName: "Phone"},
+	}, nil)
+
+	res, err := suite.useCase.GetMy(ctx)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), res, 2)
+	assert.True(suite.T(), res[0].IsCurrent)
+	assert.False(suite.T(), res[1].IsCurrent)
+}
+
+func (suite *SessionUseCaseTestSuite) TestRevoke_Success() {
+	userID := uuid.New()
+	sessionID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+
+	suite.repo.On("GetByID", sessionID).Return(&schema.Session{ID: sessionID, UserID: userID}, nil)
+	suite.repo.On("Revoke", sessionID).Return(nil)
+
+	err := suite.useCase.Revoke(ctx, &RevokeSessionRequest{ID: sessionID.String()})
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *SessionUseCaseTestSuite) TestRevoke_NotYourSession() {
+	sessionID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", uuid.NewString())
+
+	suite.repo.On("GetByID", sessionID).Return(&schema.Session{ID: sessionID, UserID: uuid.New()}, nil)
+
+	err := suite.useCase.Revoke(ctx, &RevokeSessionRequest{ID: sessionID.String()})
+	assert.Equal(suite.T(), ErrSessionNotFound.Build(), err)
+	suite.repo.AssertNotCalled(suite.T(), "Revoke", sessionID)
+}
+
+func (suite *SessionUseCaseTestSuite) TestRevoke_InternalServerError() {
+	userID := uuid.New()
+	sessionID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+
+	suite.repo.On("GetByID", sessionID).Return(nil, gorm.ErrInvalidDB)
+
+	err := suite.useCase.Revoke(ctx, &RevokeSessionRequest{ID: sessionID.String()})
+	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
+}
+
+func TestSessionUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionUseCaseTestSuite))
+}
@@ -0,0 +1,185 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+type UseCase struct {
+	repo IRepository
+}
+
+func NewUseCase(repo IRepository) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+func (uc *UseCase) Create(userID uuid.UUID, deviceName, userAgent, ipAddress string) (*schema.Session, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	if deviceName == "" {
+		deviceName = "Unknown device"
+	}
+
+	now := time.Now()
+	session := &schema.Session{
+		ID:         id,
+		UserID:     userID,
+		DeviceName: deviceName,
+		UserAgent:  truncate(userAgent, 512),
+		IPAddress:  truncate(ipAddress, 45),
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(config.Env.JwtRefreshDuration),
+	}
+
+	if err := uc.repo.Create(session); err != nil {
+		log.Println("Error creating session: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return session, nil
+}
+
+// getActive returns the session only if it belongs to the user and has neither been revoked nor expired
+func (uc *UseCase) getActive(sessionID, userID uuid.UUID) (*schema.Session, error) {
+	session, err := uc.repo.GetByID(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionRevoked.Build()
+		}
+		log.Println("Error getting session by id: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	if session.UserID != userID || session.RevokedAt != nil || session.ExpiresAt.Before(time.Now()) {
+		return nil, ErrSessionRevoked.Build()
+	}
+
+	return session, nil
+}
+
+// Use is called when a refresh token is exchanged and records where the session was last used from
+func (uc *UseCase) Use(sessionID, userID uuid.UUID, userAgent, ipAddress string) error {
+	if _, err := uc.getActive(sessionID, userID); err != nil {
+		return err
+	}
+
+	if err := uc.repo.Update(&schema.Session{
+		ID:         sessionID,
+		UserAgent:  truncate(userAgent, 512),
+		IPAddress:  truncate(ipAddress, 45),
+		LastUsedAt: time.Now(),
+	}); err != nil {
+		log.Println("Error updating session: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	return nil
+}
+
+// ValidateSession implements middleware.SessionValidator
+func (uc *UseCase) ValidateSession(ctx context.Context, sessionID, userID string) error {
+	sessionUUID, err := uuid.Parse(sessionID)
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+
+	session, err := uc.getActive(sessionUUID, userUUID)
+	if err != nil {
+		return err
+	}
+
+	if time.Since(session.LastUsedAt) > middleware.LastUsedResolution {
+		if err := uc.repo.Update(&schema.Session{ID: session.ID, LastUsedAt: time.Now()}); err != nil {
+			log.Println("Error updating session last used: ", err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *UseCase) GetMy(ctx context.Context) ([]SessionResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+	currentSessionID, _ := ctx.Value("user.session_id").(string)
+
+	sessions, err := uc.repo.GetActiveByUserID(userID)
+	if err != nil {
+		log.Println("Error getting sessions: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	res := make([]SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		res = append(res, SessionResponse{
+			ID:         s.ID.String(),
+			DeviceName: s.DeviceName,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			LastUsedAt: s.LastUsedAt,
+			CreatedAt:  s.CreatedAt,
+			IsCurrent:  s.ID.String() == currentSessionID,
+		})
+	}
+
+	return res, nil
+}
+
+func (uc *UseCase) Revoke(ctx context.Context, req *RevokeSessionRequest) error {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+
+	sessionID, err := uuid.Parse(req.ID)
+	if err != nil {
+		return apierror.ErrInvalidParamId.Build()
+	}
+
+	session, err := uc.repo.GetByID(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound.Build()
+		}
+		log.Println("Error getting session by id: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	if session.UserID != userID {
+		return ErrSessionNotFound.Build()
+	}
+
+	if err := uc.repo.Revoke(sessionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound.Build()
+		}
+		log.Println("Error revoking session: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
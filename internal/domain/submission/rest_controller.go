@@ -24,7 +24,7 @@ func NewRestController(r *gin.Engine, uc *UseCase) {
 		submissionGroup.PUT("/:id", middleware.Authenticate(), middleware.RequireRole("student"), c.updateSubmission)
 		submissionGroup.DELETE("/:id", middleware.Authenticate(), middleware.RequireRole("student"), c.deleteSubmission)
 		submissionGroup.GET("/assignments/:assignmentId", c.getAllSubmissionsByAssignment)
-		submissionGroup.PUT("/grade/:id", middleware.Authenticate(), middleware.RequireRole("instructor"), c.gradeSubmission)
+		submissionGroup.PUT("/grade/:id", middleware.APIKeyScope("submissions:grade"), middleware.Authenticate(), middleware.RequireRole("instructor"), c.gradeSubmission)
 	}
 
 }
@@ -10,7 +10,11 @@ import (
 	"github.com/highfive-compfest/seatudy-backend/internal/config"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/attachment"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/courseenroll"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/progress"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/user"
+	"time"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -91,8 +95,8 @@ func (m *MockCourseRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, page, pageSize int) ([]schema.Course, int, error) {
-	args := m.Called(ctx, instructorID, page, pageSize)
+func (m *MockCourseRepository) FindByInstructorID(ctx context.Context, instructorID uuid.UUID, publishedOnly bool, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, instructorID, publishedOnly, page, pageSize)
 	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
 }
 
@@ -106,16 +110,76 @@ func (m *MockCourseRepository) GetUserCourseProgress(ctx context.Context, course
 	return args.Get(0).(float64), args.Error(1)
 }
 
-func (m *MockCourseRepository) SearchByTitle(ctx context.Context, title string, page, pageSize int) ([]schema.Course, int, error) {
-	args := m.Called(ctx, title, page, pageSize)
+func (m *MockCourseRepository) Search(ctx context.Context, query string, page, pageSize int) ([]course.SearchHit, int, error) {
+	args := m.Called(ctx, query, page, pageSize)
+	return args.Get(0).([]course.SearchHit), args.Int(1), args.Error(2)
+}
+
+func (m *MockCourseRepository) Suggest(ctx context.Context, prefix string, limit int) ([]course.Suggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	return args.Get(0).([]course.Suggestion), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindCatalog(ctx context.Context, filter course.CatalogFilter, page, limit int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, filter, page, limit)
 	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
 }
 
-func (m *MockCourseRepository) DynamicFilterCourses(ctx context.Context, filterType, filterValue, sort string, page, limit int) ([]schema.Course, int, error) {
-	args := m.Called(ctx, filterType, filterValue, sort, page, limit)
+func (m *MockCourseRepository) GetCatalogFacets(ctx context.Context, filter course.CatalogFilter) (course.CatalogFacets, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(course.CatalogFacets), args.Error(1)
+}
+
+func (m *MockCourseRepository) FindByStatus(ctx context.Context, status schema.CourseStatus, page, pageSize int) ([]schema.Course, int, error) {
+	args := m.Called(ctx, status, page, pageSize)
 	return args.Get(0).([]schema.Course), args.Int(1), args.Error(2)
 }
 
+func (m *MockCourseRepository) UpdateStatus(ctx context.Context, course *schema.Course) error {
+	args := m.Called(ctx, course)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]schema.Course, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetPrerequisites(ctx context.Context, courseID uuid.UUID) ([]course.PrerequisiteCourse, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]course.PrerequisiteCourse), args.Error(1)
+}
+
+func (m *MockCourseRepository) SetPrerequisites(ctx context.Context, courseID uuid.UUID, prerequisites []schema.CoursePrerequisite) error {
+	args := m.Called(ctx, courseID, prerequisites)
+	return args.Error(0)
+}
+
+func (m *MockCourseRepository) GetPrerequisiteClosure(ctx context.Context, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCompletedCourseIDs(ctx context.Context, userID uuid.UUID, courseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, userID, courseIDs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetCloneSource(ctx context.Context, id uuid.UUID) (schema.Course, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(schema.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) GetSections(ctx context.Context, courseID uuid.UUID) ([]schema.CourseSection, error) {
+	args := m.Called(ctx, courseID)
+	return args.Get(0).([]schema.CourseSection), args.Error(1)
+}
+
+func (m *MockCourseRepository) CreateWithContent(ctx context.Context, course *schema.Course, sections []schema.CourseSection) error {
+	args := m.Called(ctx, course, sections)
+	return args.Error(0)
+}
+
 type MockFileUploader struct {
 	mock.Mock
 }
@@ -125,6 +189,29 @@ func (m *MockFileUploader) UploadFile(key string, fileHeader *multipart.FileHead
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockFileUploader) UploadBytes(key string, data []byte, contentType string) (string, error) {
+	args := m.Called(key, data, contentType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) CopyFile(sourceURL, key string) (string, error) {
+	args := m.Called(sourceURL, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) DownloadFile(fileURL string) ([]byte, error) {
+	args := m.Called(fileURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockFileUploader) DeleteFile(fileURL string) error {
+	args := m.Called(fileURL)
+	return args.Error(0)
+}
+
 type MockEnrollRepository struct {
 	mock.Mock
 }
@@ -149,6 +236,11 @@ func (m *MockEnrollRepository) IsEnrolled(ctx context.Context, userID, courseID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockEnrollRepository) MarkCompleted(ctx context.Context, userID, courseID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, courseID, at)
+	return args.Error(0)
+}
+
 type MockNotificationRepository struct {
 	mock.Mock
 }
@@ -210,6 +302,53 @@ func (m *MockUserRepository) UpdateByEmail(email string, user *schema.User) erro
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) UpdatePassword(userID uuid.UUID, passwordHash string, historySize int) error {
+	args := m.Called(userID, passwordHash, historySize)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetPasswordHistory(userID uuid.UUID, limit int) ([]string, error) {
+	args := m.Called(userID, limit)
+	hashes, ok := args.Get(0).([]string)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return hashes, args.Error(1)
+}
+
+func (m *MockUserRepository) GetPersonalData(userID uuid.UUID) (*user.PersonalData, error) {
+	args := m.Called(userID)
+	data, ok := args.Get(0).(*user.PersonalData)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return data, args.Error(1)
+}
+
+func (m *MockUserRepository) CountOwnedCourses(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) SetDeletionSchedule(userID uuid.UUID, at *time.Time) error {
+	args := m.Called(userID, at)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetDueForDeletion(before time.Time) ([]*schema.User, error) {
+	args := m.Called(before)
+	users, ok := args.Get(0).([]*schema.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return users, args.Error(1)
+}
+
+func (m *MockUserRepository) Anonymize(userID, anonymousID uuid.UUID) error {
+	args := m.Called(userID, anonymousID)
+	return args.Error(0)
+}
+
 type MockMailer struct {
 	mock.Mock
 }
@@ -281,6 +420,15 @@ func (m *MockAttachmentRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+type MockCertificateIssuer struct {
+	mock.Mock
+}
+
+func (m *MockCertificateIssuer) Issue(ctx context.Context, userID, courseID uuid.UUID) (*schema.Certificate, error) {
+	args := m.Called(ctx, userID, courseID)
+	return args.Get(0).(*schema.Certificate), args.Error(1)
+}
+
 type SubmissionUseCaseTestSuite struct {
 	suite.Suite
 
@@ -295,6 +443,7 @@ type SubmissionUseCaseTestSuite struct {
 	notificationRepo *MockNotificationRepository
 	submissionRepo *MockRepository
 	submisionUseCase *UseCase
+	certificateIssuer *MockCertificateIssuer
     uploader *MockFileUploader	
 }
 
@@ -309,8 +458,10 @@ func (suite *SubmissionUseCaseTestSuite) SetupTest() {
 	suite.mailer = new(MockMailer)
 	suite.notificationRepo = new(MockNotificationRepository)
 	suite.enrollUseCase = courseenroll.NewUseCase(suite.enrollRepo)
+	suite.certificateIssuer = new(MockCertificateIssuer)
 	suite.attachmentUseCase = attachment.NewUseCase(suite.attachmentRepo,suite.uploader)
-	suite.submisionUseCase = NewUseCase(suite.submissionRepo,suite.assignmentRepo,*suite.attachmentUseCase,suite.courseRepo,suite.enrollRepo,suite.userRepo,suite.notificationRepo,suite.mailer)
+	suite.submisionUseCase = NewUseCase(suite.submissionRepo,suite.assignmentRepo,*suite.attachmentUseCase,suite.courseRepo,suite.enrollRepo,suite.userRepo,suite.notificationRepo,suite.mailer,
+		progress.NewUseCase(nil, suite.courseRepo, suite.enrollRepo, nil, suite.certificateIssuer))
 
 }
 
@@ -544,6 +695,9 @@ func (suite *SubmissionUseCaseTestSuite) TestCreateSubmission_Success() {
 	suite.attachmentRepo.On("Create", ctx, mock.Anything).Return(nil)
 	suite.submissionRepo.On("Create", ctx, mock.Anything).Return(nil)
 	suite.courseRepo.On("GetByID", ctx,mock.Anything).Return(schema.Course{ID: assignment.CourseID,Title: "mantap",Price: 10000, InstructorID: instructorID}, nil)
+	suite.courseRepo.On("GetUserCourseProgress", ctx, assignment.CourseID, userUUID).Return(100.0, nil)
+	suite.enrollRepo.On("MarkCompleted", ctx, userUUID, assignment.CourseID, mock.Anything).Return(nil)
+	suite.certificateIssuer.On("Issue", ctx, userUUID, assignment.CourseID).Return(&schema.Certificate{}, nil)
 	suite.userRepo.On("GetByID", instructorID).Return(instructor, nil)
 	suite.mailer.On("DialAndSend", mock.Anything).Return(nil)
 	suite.notificationRepo.On("Create", mock.AnythingOfType("*schema.Notification")).Return(nil)
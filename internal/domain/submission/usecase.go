@@ -12,6 +12,7 @@ import (
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/course"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/courseenroll"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/notification"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/progress"
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/user"
 	"github.com/highfive-compfest/seatudy-backend/internal/mailer"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
@@ -27,13 +28,16 @@ type UseCase struct {
 	userRepo          user.IRepository
 	notifRepo         notification.IRepository
 	mailDialer        config.IMailer
+	progressUseCase   *progress.UseCase
 }
 
 // NewUseCase creates a new instance of the submission use case.
 func NewUseCase(repo Repository, aRepo assignment.Repository, auc attachment.UseCase, courseRepo course.Repository,
-	ceRepo courseenroll.Repository, userRepo user.IRepository, notifRepo notification.IRepository, mailDialer config.IMailer) *UseCase {
+	ceRepo courseenroll.Repository, userRepo user.IRepository, notifRepo notification.IRepository, mailDialer config.IMailer,
+	progressUseCase *progress.UseCase) *UseCase {
 	return &UseCase{repo: repo, assignmentRepo: aRepo, attachmentUseCase: auc, courseRepo: courseRepo,
-		courseEnrollRepo: ceRepo, userRepo: userRepo, notifRepo: notifRepo, mailDialer: mailDialer}
+		courseEnrollRepo: ceRepo, userRepo: userRepo, notifRepo: notifRepo, mailDialer: mailDialer,
+		progressUseCase: progressUseCase}
 }
 
 //go:embed new_submission_instructor_email_template.html
@@ -95,6 +99,11 @@ func (uc *UseCase) CreateSubmission(ctx context.Context, req *CreateSubmissionRe
 		return err
 	}
 
+	// Submitting the last outstanding assignment may finish the course
+	if err := uc.progressUseCase.SyncCourseCompletion(ctx, userUUID, assignmentObj.CourseID); err != nil {
+		log.Println("Error syncing course completion: ", err)
+	}
+
 	go func() {
 		studentName := ctx.Value("user.name").(string)
 		studentEmail := ctx.Value("user.email").(string)
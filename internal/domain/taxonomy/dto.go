@@ -0,0 +1,51 @@
+package taxonomy
+
+import (
+	"github.com/google/uuid"
+)
+
+type CategoryIDRequest struct {
+	CategoryID string `uri:"id" binding:"required,uuid"`
+}
+
+type CreateCategoryRequest struct {
+	Name        string  `json:"name" binding:"required,max=100"`
+	Slug        string  `json:"slug" binding:"omitempty,max=120"`
+	Description string  `json:"description" binding:"max=500"`
+	ParentID    *string `json:"parent_id" binding:"omitempty,uuid"`
+	Position    int     `json:"position" binding:"min=0"`
+}
+
+// UpdateCategoryRequest leaves nil fields untouched; an empty parent_id moves the category to the top level
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Slug        *string `json:"slug" binding:"omitempty,min=1,max=120"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+	ParentID    *string `json:"parent_id" binding:"omitempty,len=0|uuid"`
+	Position    *int    `json:"position" binding:"omitempty,min=0"`
+}
+
+type CategoryNode struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Slug        string          `json:"slug"`
+	Description string          `json:"description"`
+	Position    int             `json:"position"`
+	CourseCount int64           `json:"course_count"`
+	Children    []*CategoryNode `json:"children"`
+}
+
+type PopularTagsRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+const defaultTagLimit = 30
+
+// MaxCourseTags caps how many tags a single course can carry
+const MaxCourseTags = 10
+
+type TagCount struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	CourseCount int64  `json:"course_count"`
+}
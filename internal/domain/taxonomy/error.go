@@ -0,0 +1,32 @@
+package taxonomy
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrCategoryNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("CATEGORY_NOT_FOUND")
+
+	ErrCategorySlugTaken = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("CATEGORY_SLUG_TAKEN")
+
+	ErrCategoryInUse = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("CATEGORY_IN_USE")
+
+	ErrInvalidParent = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusBadRequest).
+				WithMessage("INVALID_PARENT_CATEGORY")
+
+	ErrInvalidSlug = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusBadRequest).
+			WithMessage("INVALID_SLUG")
+
+	ErrInvalidTag = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusBadRequest).
+			WithMessage("INVALID_TAG")
+)
@@ -0,0 +1,142 @@
+package taxonomy
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRepository interface {
+	CreateCategory(category *schema.Category) error
+	GetCategoryByID(id uuid.UUID) (*schema.Category, error)
+	GetCategoryBySlug(slug string) (*schema.Category, error)
+	GetAllCategories() ([]*schema.Category, error)
+	UpdateCategory(category *schema.Category) error
+	DeleteCategory(id uuid.UUID) error
+	CountCategoryUsage(id uuid.UUID) (courses int64, children int64, err error)
+	GetPublishedCourseCounts() (map[uuid.UUID]int64, error)
+	FindOrCreateTags(tags []schema.Tag) ([]schema.Tag, error)
+	SetCourseTags(courseID uuid.UUID, tags []schema.Tag) error
+	GetPopularTags(limit int) ([]TagCount, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db}
+}
+
+func (r *repository) CreateCategory(category *schema.Category) error {
+	return r.db.Create(category).Error
+}
+
+func (r *repository) GetCategoryByID(id uuid.UUID) (*schema.Category, error) {
+	var category schema.Category
+	if err := r.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *repository) GetCategoryBySlug(slug string) (*schema.Category, error) {
+	var category schema.Category
+	if err := r.db.Where("slug = ?", slug).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *repository) GetAllCategories() ([]*schema.Category, error) {
+	var categories []*schema.Category
+	err := r.db.Order("position, name").Find(&categories).Error
+	return categories, err
+}
+
+func (r *repository) UpdateCategory(category *schema.Category) error {
+	return r.db.Save(category).Error
+}
+
+func (r *repository) DeleteCategory(id uuid.UUID) error {
+	return r.db.Delete(&schema.Category{}, id).Error
+}
+
+// CountCategoryUsage counts courses of any status filed directly under the category and its direct subcategories
+func (r *repository) CountCategoryUsage(id uuid.UUID) (int64, int64, error) {
+	var courses, children int64
+	if err := r.db.Model(&schema.Course{}).Where("category_id = ?", id).Count(&courses).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := r.db.Model(&schema.Category{}).Where("parent_id = ?", id).Count(&children).Error; err != nil {
+		return 0, 0, err
+	}
+	return courses, children, nil
+}
+
+func (r *repository) GetPublishedCourseCounts() (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		CategoryID uuid.UUID
+		Count      int64
+	}
+	err := r.db.Model(&schema.Course{}).
+		Select("category_id, COUNT(*) AS count").
+		Where("status = ? AND category_id IS NOT NULL", schema.CourseStatusPublished).
+		Group("category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
+
+// FindOrCreateTags inserts the tags whose slug is new and returns the stored row for every slug
+func (r *repository) FindOrCreateTags(tags []schema.Tag) ([]schema.Tag, error) {
+	if len(tags) == 0 {
+		return []schema.Tag{}, nil
+	}
+
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+
+	var stored []schema.Tag
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "slug"}},
+			DoNothing: true,
+		}).Create(&tags).Error; err != nil {
+			return err
+		}
+		return tx.Where("slug IN ?", slugs).Find(&stored).Error
+	})
+	return stored, err
+}
+
+func (r *repository) SetCourseTags(courseID uuid.UUID, tags []schema.Tag) error {
+	association := r.db.Model(&schema.Course{ID: courseID}).Association("Tags")
+	if len(tags) == 0 {
+		return association.Clear()
+	}
+	return association.Replace(tags)
+}
+
+func (r *repository) GetPopularTags(limit int) ([]TagCount, error) {
+	var tags []TagCount
+	err := r.db.Table("tags t").
+		Select("t.name, t.slug, COUNT(c.id) AS course_count").
+		Joins("JOIN course_tags ct ON ct.tag_id = t.id").
+		Joins("JOIN courses c ON c.id = ct.course_id AND c.deleted_at IS NULL AND c.status = ?", schema.CourseStatusPublished).
+		Group("t.id, t.name, t.slug").
+		Order("course_count DESC, t.name").
+		Limit(limit).
+		Scan(&tags).Error
+	return tags, err
+}
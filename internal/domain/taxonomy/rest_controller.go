@@ -0,0 +1,125 @@
+package taxonomy
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	categoryGroup := engine.Group("/v1/categories")
+	{
+		categoryGroup.GET("", controller.GetCategoryTree())
+
+		adminGroup := categoryGroup.Group("")
+		adminGroup.Use(middleware.Authenticate(), middleware.RequireRole("admin"))
+		{
+			adminGroup.POST("", controller.CreateCategory())
+			adminGroup.PATCH("/:id", controller.UpdateCategory())
+			adminGroup.DELETE("/:id", controller.DeleteCategory())
+		}
+	}
+
+	engine.GET("/v1/tags", controller.GetPopularTags())
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) GetCategoryTree() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		res, err := c.uc.GetCategoryTree()
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_CATEGORIES_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) CreateCategory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CreateCategoryRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.CreateCategory(&req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "CREATE_CATEGORY_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) UpdateCategory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var idReq CategoryIDRequest
+		if err := ctx.ShouldBindUri(&idReq); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+		var req UpdateCategoryRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.UpdateCategory(&idReq, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "UPDATE_CATEGORY_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) DeleteCategory() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CategoryIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.DeleteCategory(&req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "DELETE_CATEGORY_SUCCESS", nil).Send(ctx)
+	}
+}
+
+func (c *RestController) GetPopularTags() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req PopularTagsRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.GetPopularTags(&req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_TAGS_SUCCESS", res).Send(ctx)
+	}
+}
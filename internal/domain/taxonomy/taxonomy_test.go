@@ -0,0 +1,204 @@
+package taxonomy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CreateCategory(category *schema.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetCategoryByID(id uuid.UUID) (*schema.Category, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Category), args.Error(1)
+}
+
+func (m *MockRepository) GetCategoryBySlug(slug string) (*schema.Category, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Category), args.Error(1)
+}
+
+func (m *MockRepository) GetAllCategories() ([]*schema.Category, error) {
+	args := m.Called()
+	return args.Get(0).([]*schema.Category), args.Error(1)
+}
+
+func (m *MockRepository) UpdateCategory(category *schema.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteCategory(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CountCategoryUsage(id uuid.UUID) (int64, int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) GetPublishedCourseCounts() (map[uuid.UUID]int64, error) {
+	args := m.Called()
+	return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
+}
+
+func (m *MockRepository) FindOrCreateTags(tags []schema.Tag) ([]schema.Tag, error) {
+	args := m.Called(tags)
+	return args.Get(0).([]schema.Tag), args.Error(1)
+}
+
+func (m *MockRepository) SetCourseTags(courseID uuid.UUID, tags []schema.Tag) error {
+	args := m.Called(courseID, tags)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetPopularTags(limit int) ([]TagCount, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]TagCount), args.Error(1)
+}
+
+type TaxonomyUseCaseTestSuite struct {
+	suite.Suite
+	repo *MockRepository
+	uc   *UseCase
+}
+
+func (s *TaxonomyUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.uc = NewUseCase(s.repo)
+}
+
+func TestTaxonomyUseCase(t *testing.T) {
+	suite.Run(t, new(TaxonomyUseCaseTestSuite))
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestSlugify() {
+	s.Equal("augmented-reality-ar-virtual-reality-vr", Slugify("Augmented Reality (AR) & Virtual Reality (VR)"))
+	s.Equal("data-science-analytics", Slugify("  Data Science & Analytics "))
+	s.Equal("", Slugify("!!!"))
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestGetCategoryTree_NestsAndRollsUpCounts() {
+	root, child, grandchild, other := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	s.repo.On("GetAllCategories").Return([]*schema.Category{
+		{ID: root, Name: "Development", Slug: "development"},
+		{ID: other, Name: "Design", Slug: "design", Position: 1},
+		{ID: child, ParentID: &root, Name: "Web Development", Slug: "web-development"},
+		{ID: grandchild, ParentID: &child, Name: "Frontend", Slug: "frontend"},
+	}, nil)
+	s.repo.On("GetPublishedCourseCounts").Return(map[uuid.UUID]int64{root: 1, child: 2, grandchild: 3}, nil)
+
+	tree, err := s.uc.GetCategoryTree()
+
+	s.NoError(err)
+	s.Len(tree, 2)
+	s.Equal(root, tree[0].ID)
+	s.Equal(int64(6), tree[0].CourseCount)
+	s.Equal(int64(5), tree[0].Children[0].CourseCount)
+	s.Equal(grandchild, tree[0].Children[0].Children[0].ID)
+	s.Empty(tree[1].Children)
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestCreateCategory_SlugFromName() {
+	parentID := uuid.New()
+	parent := parentID.String()
+	s.repo.On("GetCategoryBySlug", "machine-learning").Return(nil, gorm.ErrRecordNotFound)
+	s.repo.On("GetAllCategories").Return([]*schema.Category{{ID: parentID}}, nil)
+	s.repo.On("CreateCategory", mock.Anything).Return(nil)
+
+	category, err := s.uc.CreateCategory(&CreateCategoryRequest{Name: "Machine Learning", ParentID: &parent})
+
+	s.NoError(err)
+	s.Equal("machine-learning", category.Slug)
+	s.Equal(parentID, *category.ParentID)
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestCreateCategory_SlugTaken() {
+	s.repo.On("GetCategoryBySlug", "networking").Return(&schema.Category{ID: uuid.New()}, nil)
+
+	_, err := s.uc.CreateCategory(&CreateCategoryRequest{Name: "Networking"})
+
+	s.Equal(ErrCategorySlugTaken.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "CreateCategory", mock.Anything)
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestUpdateCategory_RejectsCycle() {
+	root, child := uuid.New(), uuid.New()
+	s.repo.On("GetCategoryByID", root).Return(&schema.Category{ID: root}, nil)
+	s.repo.On("GetAllCategories").Return([]*schema.Category{{ID: root}, {ID: child, ParentID: &root}}, nil)
+	parent := child.String()
+
+	_, err := s.uc.UpdateCategory(&CategoryIDRequest{CategoryID: root.String()}, &UpdateCategoryRequest{ParentID: &parent})
+
+	s.Equal(ErrInvalidParent.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "UpdateCategory", mock.Anything)
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestUpdateCategory_MoveToTopLevel() {
+	root, child := uuid.New(), uuid.New()
+	s.repo.On("GetCategoryByID", child).Return(&schema.Category{ID: child, ParentID: &root}, nil)
+	s.repo.On("UpdateCategory", mock.Anything).Return(nil)
+	empty := ""
+
+	category, err := s.uc.UpdateCategory(&CategoryIDRequest{CategoryID: child.String()}, &UpdateCategoryRequest{ParentID: &empty})
+
+	s.NoError(err)
+	s.Nil(category.ParentID)
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestDeleteCategory_InUse() {
+	id := uuid.New()
+	s.repo.On("GetCategoryByID", id).Return(&schema.Category{ID: id}, nil)
+	s.repo.On("CountCategoryUsage", id).Return(int64(3), int64(0), nil)
+
+	err := s.uc.DeleteCategory(&CategoryIDRequest{CategoryID: id.String()})
+
+	s.Equal(ErrCategoryInUse.Build().Error(), err.Error())
+	s.repo.AssertNotCalled(s.T(), "DeleteCategory", mock.Anything)
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestDeleteCategory_Success() {
+	id := uuid.New()
+	s.repo.On("GetCategoryByID", id).Return(&schema.Category{ID: id}, nil)
+	s.repo.On("CountCategoryUsage", id).Return(int64(0), int64(0), nil)
+	s.repo.On("DeleteCategory", id).Return(nil)
+
+	s.NoError(s.uc.DeleteCategory(&CategoryIDRequest{CategoryID: id.String()}))
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestSetCourseTags_ClearsWithEmptyList() {
+	courseID := uuid.New()
+	s.repo.On("FindOrCreateTags", []schema.Tag{}).Return([]schema.Tag{}, nil)
+	s.repo.On("SetCourseTags", courseID, []schema.Tag{}).Return(nil)
+
+	tags, err := s.uc.SetCourseTags(courseID, nil)
+
+	s.NoError(err)
+	s.Empty(tags)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *TaxonomyUseCaseTestSuite) TestNormalizeTags_RejectsUnsluggable() {
+	_, err := NormalizeTags([]string{"go", "???"})
+
+	s.Equal(ErrInvalidTag.Build().Error(), err.Error())
+}
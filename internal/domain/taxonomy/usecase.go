@@ -0,0 +1,337 @@
+package taxonomy
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type UseCase struct {
+	repo IRepository
+}
+
+func NewUseCase(repo IRepository) *UseCase {
+	return &UseCase{repo: repo}
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify lowercases s and collapses everything but ASCII letters and digits into single dashes.
+// It matches the expression the enum migration in config uses, so migrated slugs line up.
+func Slugify(s string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+func (uc *UseCase) GetCategoryTree() ([]*CategoryNode, error) {
+	categories, err := uc.repo.GetAllCategories()
+	if err != nil {
+		log.Println("Error getting categories: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	counts, err := uc.repo.GetPublishedCourseCounts()
+	if err != nil {
+		log.Println("Error counting category courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	nodes := make(map[uuid.UUID]*CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryNode{
+			ID:          category.ID,
+			Name:        category.Name,
+			Slug:        category.Slug,
+			Description: category.Description,
+			Position:    category.Position,
+			CourseCount: counts[category.ID],
+			Children:    make([]*CategoryNode, 0),
+		}
+	}
+
+	roots := make([]*CategoryNode, 0)
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	// A parent's count includes the courses of all its subcategories
+	var total func(node *CategoryNode) int64
+	total = func(node *CategoryNode) int64 {
+		for _, child := range node.Children {
+			node.CourseCount += total(child)
+		}
+		return node.CourseCount
+	}
+	for _, root := range roots {
+		total(root)
+	}
+
+	return roots, nil
+}
+
+func (uc *UseCase) getCategory(id uuid.UUID) (*schema.Category, error) {
+	category, err := uc.repo.GetCategoryByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound.Build()
+		}
+		log.Println("Error getting category: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return category, nil
+}
+
+// ValidateCategory makes sure a course is being filed under a category that exists
+func (uc *UseCase) ValidateCategory(id uuid.UUID) error {
+	_, err := uc.getCategory(id)
+	return err
+}
+
+// FindCategoryBySlug returns nil without an error when no category uses the slug
+func (uc *UseCase) FindCategoryBySlug(slug string) (*schema.Category, error) {
+	category, err := uc.repo.GetCategoryBySlug(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		log.Println("Error getting category by slug: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return category, nil
+}
+
+func (uc *UseCase) ensureSlugFree(slug string, self uuid.UUID) error {
+	existing, err := uc.repo.GetCategoryBySlug(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		log.Println("Error getting category by slug: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	if existing.ID != self {
+		return ErrCategorySlugTaken.Build()
+	}
+	return nil
+}
+
+// checkParent rejects a parent that does not exist or that sits below the category itself
+func (uc *UseCase) checkParent(categoryID, parentID uuid.UUID) error {
+	if parentID == categoryID {
+		return ErrInvalidParent.Build()
+	}
+
+	categories, err := uc.repo.GetAllCategories()
+	if err != nil {
+		log.Println("Error getting categories: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	parents := make(map[uuid.UUID]*uuid.UUID, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+
+	if _, ok := parents[parentID]; !ok {
+		return ErrInvalidParent.Build()
+	}
+	for current := parents[parentID]; current != nil; current = parents[*current] {
+		if *current == categoryID {
+			return ErrInvalidParent.Build()
+		}
+	}
+	return nil
+}
+
+func (uc *UseCase) CreateCategory(req *CreateCategoryRequest) (*schema.Category, error) {
+	slug := Slugify(req.Slug)
+	if req.Slug == "" {
+		slug = Slugify(req.Name)
+	}
+	if slug == "" {
+		return nil, ErrInvalidSlug.Build()
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating category id: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if err := uc.ensureSlugFree(slug, id); err != nil {
+		return nil, err
+	}
+
+	category := &schema.Category{
+		ID:          id,
+		Name:        strings.TrimSpace(req.Name),
+		Slug:        slug,
+		Description: req.Description,
+		Position:    req.Position,
+	}
+	if req.ParentID != nil {
+		parentID := uuid.MustParse(*req.ParentID)
+		if err := uc.checkParent(id, parentID); err != nil {
+			return nil, err
+		}
+		category.ParentID = &parentID
+	}
+
+	if err := uc.repo.CreateCategory(category); err != nil {
+		log.Println("Error creating category: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return category, nil
+}
+
+func (uc *UseCase) UpdateCategory(idReq *CategoryIDRequest, req *UpdateCategoryRequest) (*schema.Category, error) {
+	category, err := uc.getCategory(uuid.MustParse(idReq.CategoryID))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		category.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Slug != nil {
+		slug := Slugify(*req.Slug)
+		if slug == "" {
+			return nil, ErrInvalidSlug.Build()
+		}
+		if err := uc.ensureSlugFree(slug, category.ID); err != nil {
+			return nil, err
+		}
+		category.Slug = slug
+	}
+	if req.Description != nil {
+		category.Description = *req.Description
+	}
+	if req.Position != nil {
+		category.Position = *req.Position
+	}
+	if req.ParentID != nil {
+		if *req.ParentID == "" {
+			category.ParentID = nil
+		} else {
+			parentID := uuid.MustParse(*req.ParentID)
+			if err := uc.checkParent(category.ID, parentID); err != nil {
+				return nil, err
+			}
+			category.ParentID = &parentID
+		}
+	}
+
+	if err := uc.repo.UpdateCategory(category); err != nil {
+		log.Println("Error updating category: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return category, nil
+}
+
+// DeleteCategory only removes empty leaves, so no course silently loses its category
+func (uc *UseCase) DeleteCategory(req *CategoryIDRequest) error {
+	category, err := uc.getCategory(uuid.MustParse(req.CategoryID))
+	if err != nil {
+		return err
+	}
+
+	courses, children, err := uc.repo.CountCategoryUsage(category.ID)
+	if err != nil {
+		log.Println("Error counting category usage: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	if courses > 0 || children > 0 {
+		return ErrCategoryInUse.WithPayload(map[string]int64{
+			"courses":       courses,
+			"subcategories": children,
+		}).Build()
+	}
+
+	if err := uc.repo.DeleteCategory(category.ID); err != nil {
+		log.Println("Error deleting category: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	return nil
+}
+
+// NormalizeTags slugifies tag names, dropping duplicates and keeping the first spelling of each
+func NormalizeTags(names []string) ([]schema.Tag, error) {
+	seen := make(map[string]bool, len(names))
+	tags := make([]schema.Tag, 0, len(names))
+	for _, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		slug := Slugify(name)
+		if slug == "" {
+			return nil, ErrInvalidTag.WithPayload(map[string]string{"tag": name}).Build()
+		}
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		tags = append(tags, schema.Tag{Name: name, Slug: slug})
+	}
+	if len(tags) > MaxCourseTags {
+		return nil, ErrInvalidTag.WithPayload(map[string]int{"max_tags": MaxCourseTags}).Build()
+	}
+	return tags, nil
+}
+
+// ResolveTags returns the stored tags for the given names, creating any tag that does not exist yet
+func (uc *UseCase) ResolveTags(names []string) ([]schema.Tag, error) {
+	tags, err := NormalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tags {
+		id, err := uuid.NewV7()
+		if err != nil {
+			log.Println("Error generating tag id: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		tags[i].ID = id
+	}
+
+	stored, err := uc.repo.FindOrCreateTags(tags)
+	if err != nil {
+		log.Println("Error saving tags: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return stored, nil
+}
+
+// SetCourseTags replaces the tags of a course
+func (uc *UseCase) SetCourseTags(courseID uuid.UUID, names []string) ([]schema.Tag, error) {
+	stored, err := uc.ResolveTags(names)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.SetCourseTags(courseID, stored); err != nil {
+		log.Println("Error setting course tags: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	sort.Slice(stored, func(i, j int) bool { return stored[i].Slug < stored[j].Slug })
+	return stored, nil
+}
+
+func (uc *UseCase) GetPopularTags(req *PopularTagsRequest) ([]TagCount, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTagLimit
+	}
+	tags, err := uc.repo.GetPopularTags(limit)
+	if err != nil {
+		log.Println("Error getting popular tags: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return tags, nil
+}
@@ -0,0 +1,30 @@
+package trending
+
+import (
+	"time"
+
+	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+)
+
+type ListRequest struct {
+	Window   int    `form:"window" binding:"omitempty,oneof=7 30"`
+	Category string `form:"category" binding:"omitempty,max=120"`
+	Page     int    `form:"page" binding:"required,min=1"`
+	Limit    int    `form:"limit" binding:"required,min=1,max=30"`
+}
+
+type TrendingCourse struct {
+	Course        schema.Course `json:"course"`
+	Score         float64       `json:"score"`
+	Enrollments   int64         `json:"enrollments"`
+	Reviews       int64         `json:"reviews"`
+	AverageRating float64       `json:"average_rating"`
+}
+
+type TrendingPaginatedResponse struct {
+	WindowDays int                   `json:"window_days"`
+	Courses    []TrendingCourse      `json:"courses"`
+	ComputedAt *time.Time            `json:"computed_at"` // nil until the rankings are first computed
+	Pagination pagination.Pagination `json:"pagination"`
+}
@@ -0,0 +1,12 @@
+package trending
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrCategoryNotFound = apierror.NewApiErrorBuilder().
+		WithHttpStatus(http.StatusNotFound).
+		WithMessage("CATEGORY_NOT_FOUND")
+)
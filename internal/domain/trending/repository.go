@@ -0,0 +1,102 @@
+package trending
+
+import (
+	"time"
+
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+type IRepository interface {
+	CategoryExists(slug string) (bool, error)
+	List(windowDays int, categorySlug string, page, limit int) ([]schema.CourseTrend, int64, error)
+	Refresh(windowDays int, halfLife time.Duration, reviewWeight float64, now time.Time) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) CategoryExists(slug string) (bool, error) {
+	var count int64
+	err := r.db.Model(&schema.Category{}).Where("slug = ?", slug).Count(&count).Error
+	return count > 0, err
+}
+
+// List pages through a window's trending courses, highest score first. With categorySlug only courses of that
+// category and its subcategories are listed.
+func (r *repository) List(windowDays int, categorySlug string, page, limit int) ([]schema.CourseTrend, int64, error) {
+	query := r.db.Model(&schema.CourseTrend{}).
+		Joins("JOIN courses ON courses.id = course_trends.course_id AND courses.deleted_at IS NULL AND courses.status = ?",
+			schema.CourseStatusPublished).
+		Where("course_trends.window_days = ? AND course_trends.score > 0", windowDays)
+	if categorySlug != "" {
+		query = query.Where(`course_trends.category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE slug = ?
+				UNION
+				SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT id FROM subtree
+		)`, categorySlug)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var trends []schema.CourseTrend
+	err := query.Preload("Course").
+		Order("course_trends.score DESC, course_trends.course_id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&trends).Error
+	return trends, total, err
+}
+
+// Refresh rebuilds one window's trends from the enrollments and reviews made within it. Each enrollment counts
+// for 1 and each review for reviewWeight, scaled from -1 for one star to +1 for five, and both lose half their
+// weight every halfLife. Courses with no activity in the window are left out.
+func (r *repository) Refresh(windowDays int, halfLife time.Duration, reviewWeight float64, now time.Time) error {
+	args := map[string]any{
+		"window":        windowDays,
+		"since":         now.AddDate(0, 0, -windowDays),
+		"now":           now,
+		"half_life":     halfLife.Seconds(),
+		"review_weight": reviewWeight,
+		"published":     schema.CourseStatusPublished,
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM course_trends WHERE window_days = ?", windowDays).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			WITH enrollments AS (
+				SELECT course_id, COUNT(*) AS total,
+					SUM(exp(-ln(2) * extract(epoch FROM CAST(@now AS timestamptz) - created_at)::float8 / @half_life)) AS weight
+				FROM course_enrolls
+				WHERE created_at > @since
+				GROUP BY course_id
+			), recent_reviews AS (
+				SELECT course_id, COUNT(*) AS total, AVG(rating)::float8 AS average,
+					SUM((rating - 3) / 2.0 * exp(-ln(2) * extract(epoch FROM CAST(@now AS timestamptz) - created_at)::float8 / @half_life)) AS weight
+				FROM reviews
+				WHERE created_at > @since
+				GROUP BY course_id
+			)
+			INSERT INTO course_trends (course_id, window_days, category_id, score, enrollments, reviews, average_rating, computed_at)
+			SELECT c.id, @window, c.category_id,
+				coalesce(e.weight, 0) + @review_weight * coalesce(rr.weight, 0),
+				coalesce(e.total, 0), coalesce(rr.total, 0), coalesce(rr.average, 0), @now
+			FROM courses c
+			LEFT JOIN enrollments e ON e.course_id = c.id
+			LEFT JOIN recent_reviews rr ON rr.course_id = c.id
+			WHERE c.status = @published AND c.deleted_at IS NULL
+				AND (e.course_id IS NOT NULL OR rr.course_id IS NOT NULL)`, args).Error
+	})
+}
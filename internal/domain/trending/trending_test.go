@@ -0,0 +1,126 @@
+package trending
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/pricing"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CategoryExists(slug string) (bool, error) {
+	args := m.Called(slug)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) List(windowDays int, categorySlug string, page, limit int) ([]schema.CourseTrend, int64, error) {
+	args := m.Called(windowDays, categorySlug, page, limit)
+	return args.Get(0).([]schema.CourseTrend), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) Refresh(windowDays int, halfLife time.Duration, reviewWeight float64, now time.Time) error {
+	args := m.Called(windowDays, halfLife, reviewWeight)
+	return args.Error(0)
+}
+
+type MockPricingRepository struct {
+	pricing.IRepository
+	sales []schema.CourseSale
+}
+
+func (m *MockPricingRepository) GetRunningSales(courseIDs []uuid.UUID, at time.Time) ([]schema.CourseSale, error) {
+	return m.sales, nil
+}
+
+type TrendingUseCaseTestSuite struct {
+	suite.Suite
+	repo        *MockRepository
+	pricingRepo *MockPricingRepository
+	uc          *UseCase
+}
+
+func (s *TrendingUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.pricingRepo = &MockPricingRepository{}
+	s.uc = NewUseCase(s.repo, pricing.NewUseCase(s.pricingRepo))
+}
+
+func TestTrendingUseCase(t *testing.T) {
+	suite.Run(t, new(TrendingUseCaseTestSuite))
+}
+
+func (s *TrendingUseCaseTestSuite) TestList_DefaultsToWeek() {
+	computedAt := time.Now().Add(-10 * time.Minute)
+	course := &schema.Course{ID: uuid.New(), Title: "Go", Price: 100, EffectivePrice: 100}
+	trends := []schema.CourseTrend{{CourseID: course.ID, Course: course, WindowDays: 7, Score: 4.2, Enrollments: 5,
+		Reviews: 1, AverageRating: 5, ComputedAt: computedAt}}
+	s.repo.On("List", 7, "", 1, 10).Return(trends, int64(1), nil)
+	s.pricingRepo.sales = []schema.CourseSale{{ID: uuid.New(), CourseID: course.ID, SalePrice: 60}}
+
+	res, err := s.uc.List(&ListRequest{Page: 1, Limit: 10})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 7, res.WindowDays)
+	assert.Len(s.T(), res.Courses, 1)
+	assert.Equal(s.T(), 4.2, res.Courses[0].Score)
+	assert.Equal(s.T(), int64(5), res.Courses[0].Enrollments)
+	assert.Equal(s.T(), int64(60), res.Courses[0].Course.EffectivePrice)
+	assert.Equal(s.T(), computedAt, *res.ComputedAt)
+	assert.Equal(s.T(), 1, res.Pagination.TotalData)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *TrendingUseCaseTestSuite) TestList_Category() {
+	s.repo.On("CategoryExists", "web-development").Return(true, nil)
+	s.repo.On("List", 30, "web-development", 2, 5).Return([]schema.CourseTrend{}, int64(5), nil)
+
+	res, err := s.uc.List(&ListRequest{Window: 30, Category: "web-development", Page: 2, Limit: 5})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 30, res.WindowDays)
+	assert.Empty(s.T(), res.Courses)
+	assert.Nil(s.T(), res.ComputedAt)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *TrendingUseCaseTestSuite) TestList_CategoryNotFound() {
+	s.repo.On("CategoryExists", "nope").Return(false, nil)
+
+	res, err := s.uc.List(&ListRequest{Category: "nope", Page: 1, Limit: 10})
+
+	assert.Nil(s.T(), res)
+	assert.Equal(s.T(), http.StatusNotFound, apierror.GetHttpStatus(err))
+	assert.Equal(s.T(), "CATEGORY_NOT_FOUND", err.Error())
+	s.repo.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *TrendingUseCaseTestSuite) TestRefresh() {
+	s.repo.On("Refresh", 7, 2*24*time.Hour, reviewWeight).Return(nil)
+	s.repo.On("Refresh", 30, 7*24*time.Hour, reviewWeight).Return(nil)
+
+	err := s.uc.Refresh(context.Background())
+
+	assert.NoError(s.T(), err)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *TrendingUseCaseTestSuite) TestRefresh_StopsOnError() {
+	s.repo.On("Refresh", 7, 2*24*time.Hour, reviewWeight).Return(errors.New("db down"))
+
+	err := s.uc.Refresh(context.Background())
+
+	assert.Error(s.T(), err)
+	s.repo.AssertNumberOfCalls(s.T(), "Refresh", 1)
+}
@@ -0,0 +1,106 @@
+package trending
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/pricing"
+	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+)
+
+// window is a rolling period courses are ranked over. The half-life keeps the newest activity ahead of what
+// happened near the start of the window.
+type window struct {
+	days     int
+	halfLife time.Duration
+}
+
+var windows = []window{
+	{days: 7, halfLife: 2 * 24 * time.Hour},
+	{days: 30, halfLife: 7 * 24 * time.Hour},
+}
+
+const (
+	defaultWindowDays = 7
+	// reviewWeight is what a fresh five-star review counts for, against 1 for a fresh enrollment
+	reviewWeight = 2.0
+)
+
+type UseCase struct {
+	repo           IRepository
+	pricingUseCase *pricing.UseCase
+}
+
+func NewUseCase(repo IRepository, pricingUseCase *pricing.UseCase) *UseCase {
+	return &UseCase{repo: repo, pricingUseCase: pricingUseCase}
+}
+
+// List ranks the published courses trending over a window, optionally within a category, as of the last refresh
+func (uc *UseCase) List(req *ListRequest) (*TrendingPaginatedResponse, error) {
+	windowDays := req.Window
+	if windowDays == 0 {
+		windowDays = defaultWindowDays
+	}
+
+	if req.Category != "" {
+		exists, err := uc.repo.CategoryExists(req.Category)
+		if err != nil {
+			log.Println("Error checking category: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		if !exists {
+			return nil, ErrCategoryNotFound.Build()
+		}
+	}
+
+	trends, total, err := uc.repo.List(windowDays, req.Category, req.Page, req.Limit)
+	if err != nil {
+		log.Println("Error listing trending courses: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	res := &TrendingPaginatedResponse{
+		WindowDays: windowDays,
+		Courses:    make([]TrendingCourse, 0, len(trends)),
+		Pagination: pagination.NewPagination(int(total), req.Page, req.Limit),
+	}
+	for _, trend := range trends {
+		if trend.Course == nil {
+			continue
+		}
+		if res.ComputedAt == nil {
+			computedAt := trend.ComputedAt
+			res.ComputedAt = &computedAt
+		}
+		res.Courses = append(res.Courses, TrendingCourse{
+			Course:        *trend.Course,
+			Score:         trend.Score,
+			Enrollments:   trend.Enrollments,
+			Reviews:       trend.Reviews,
+			AverageRating: trend.AverageRating,
+		})
+	}
+
+	courses := make([]*schema.Course, len(res.Courses))
+	for i := range res.Courses {
+		courses[i] = &res.Courses[i].Course
+	}
+	if err := uc.pricingUseCase.ApplySales(courses); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Refresh recomputes the trends of every window. It runs as a background job.
+func (uc *UseCase) Refresh(ctx context.Context) error {
+	now := time.Now()
+	for _, w := range windows {
+		if err := uc.repo.Refresh(w.days, w.halfLife, reviewWeight, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
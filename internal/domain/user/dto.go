@@ -1,7 +1,10 @@
 package user
 
 import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 	"mime/multipart"
+	"time"
 )
 
 type GetUserByIDRequest struct {
@@ -19,3 +22,43 @@ type UpdateUserRequest struct {
 	Name      string                `form:"name" binding:"max=50"`
 	ImageFile *multipart.FileHeader `form:"image_file"`
 }
+
+type RequestDeletionRequest struct {
+	Password       string `json:"password" binding:"required,max=72"`
+	ForfeitBalance bool   `json:"forfeit_balance"`
+}
+
+type RequestDeletionResponse struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// PersonalData is everything stored about a user that is included in their data export.
+type PersonalData struct {
+	Profile            schema.User
+	Enrollments        []EnrollmentRecord
+	Submissions        []schema.Submission
+	Reviews            []schema.Review
+	ForumDiscussions   []schema.ForumDiscussion
+	ForumReplies       []schema.ForumReply
+	WalletBalance      int64
+	WalletTransactions []schema.MidtransTransaction
+}
+
+type EnrollmentRecord struct {
+	CourseID    uuid.UUID `json:"course_id"`
+	CourseTitle string    `json:"course_title"`
+	EnrolledAt  time.Time `json:"enrolled_at"`
+}
+
+type walletTransactionRecord struct {
+	ID        uuid.UUID             `json:"id"`
+	Amount    int64                 `json:"amount"`
+	IsCredit  bool                  `json:"is_credit"`
+	Status    schema.MidtransStatus `json:"status"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+type walletRecord struct {
+	Balance      int64                     `json:"balance"`
+	Transactions []walletTransactionRecord `json:"transactions"`
+}
@@ -7,6 +7,26 @@ import (
 
 var (
 	ErrUserNotFound = apierror.NewApiErrorBuilder().
-		WithHttpStatus(http.StatusNotFound).
-		WithMessage("USER_NOT_FOUND")
+			WithHttpStatus(http.StatusNotFound).
+			WithMessage("USER_NOT_FOUND")
+
+	ErrInvalidPassword = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusUnauthorized).
+				WithMessage("INVALID_PASSWORD")
+
+	ErrDeletionAlreadyScheduled = apierror.NewApiErrorBuilder().
+					WithHttpStatus(http.StatusConflict).
+					WithMessage("DELETION_ALREADY_SCHEDULED")
+
+	ErrDeletionNotScheduled = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("DELETION_NOT_SCHEDULED")
+
+	ErrWalletNotEmpty = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("WALLET_NOT_EMPTY")
+
+	ErrOwnsCourses = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusConflict).
+			WithMessage("OWNS_COURSES")
 )
@@ -6,6 +6,7 @@ import (
 	"github.com/highfive-compfest/seatudy-backend/internal/domain/wallet"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
 	"gorm.io/gorm"
+	"time"
 )
 
 type IRepository interface {
@@ -14,6 +15,14 @@ type IRepository interface {
 	GetByEmail(email string) (*schema.User, error)
 	Update(user *schema.User) error
 	UpdateByEmail(email string, user *schema.User) error
+	UpdatePassword(userID uuid.UUID, passwordHash string, historySize int) error
+	GetPasswordHistory(userID uuid.UUID, limit int) ([]string, error)
+
+	GetPersonalData(userID uuid.UUID) (*PersonalData, error)
+	CountOwnedCourses(userID uuid.UUID) (int64, error)
+	SetDeletionSchedule(userID uuid.UUID, at *time.Time) error
+	GetDueForDeletion(before time.Time) ([]*schema.User, error)
+	Anonymize(userID, anonymousID uuid.UUID) error
 }
 
 type repository struct {
@@ -30,6 +39,9 @@ func (r *repository) Create(user *schema.User) error {
 		if err := tx.Create(user).Error; err != nil {
 			return err
 		}
+		if err := createPasswordHistory(tx, user.ID, user.PasswordHash); err != nil {
+			return err
+		}
 		walletID, err := uuid.NewV7()
 		if err != nil {
 			return err
@@ -96,3 +108,184 @@ func (r *repository) UpdateByEmail(email string, user *schema.User) error {
 	}
 	return nil
 }
+
+func createPasswordHistory(tx *gorm.DB, userID uuid.UUID, passwordHash string) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	return tx.Create(&schema.PasswordHistory{
+		ID:           id,
+		UserID:       userID,
+		PasswordHash: passwordHash,
+	}).Error
+}
+
+// UpdatePassword sets the new hash, records it in the history and drops entries older than the last historySize
+func (r *repository) UpdatePassword(userID uuid.UUID, passwordHash string, historySize int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&schema.User{}).Where("id = ?", userID).Update("password_hash", passwordHash)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := createPasswordHistory(tx, userID, passwordHash); err != nil {
+			return err
+		}
+
+		keep := tx.Model(&schema.PasswordHistory{}).Select("id").
+			Where("user_id = ?", userID).Order("created_at DESC").Limit(historySize)
+		return tx.Where("user_id = ? AND id NOT IN (?)", userID, keep).Delete(&schema.PasswordHistory{}).Error
+	})
+}
+
+func (r *repository) GetPasswordHistory(userID uuid.UUID, limit int) ([]string, error) {
+	var hashes []string
+	err := r.db.Model(&schema.PasswordHistory{}).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Pluck("password_hash", &hashes).Error
+	return hashes, err
+}
+
+func (r *repository) GetPersonalData(userID uuid.UUID) (*PersonalData, error) {
+	var data PersonalData
+	if err := r.db.First(&data.Profile, userID).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&schema.CourseEnroll{}).
+		Select("course_enrolls.course_id, courses.title AS course_title, course_enrolls.created_at AS enrolled_at").
+		Joins("JOIN courses ON courses.id = course_enrolls.course_id").
+		Where("course_enrolls.user_id = ?", userID).
+		Order("course_enrolls.created_at").
+		Scan(&data.Enrollments).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Preload("Attachments").Where("user_id = ?", userID).
+		Order("created_at").Find(&data.Submissions).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&data.Reviews).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&data.ForumDiscussions).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&data.ForumReplies).Error; err != nil {
+		return nil, err
+	}
+
+	wallet, err := r.walletRepo.GetByUserID(nil, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if wallet != nil {
+		data.WalletBalance = wallet.Balance
+		if err := r.db.Where("wallet_id = ?", wallet.ID).Order("created_at").
+			Find(&data.WalletTransactions).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return &data, nil
+}
+
+func (r *repository) CountOwnedCourses(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&schema.Course{}).Where("instructor_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *repository) SetDeletionSchedule(userID uuid.UUID, at *time.Time) error {
+	tx := r.db.Model(&schema.User{}).Where("id = ?", userID).Update("deletion_scheduled_at", at)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) GetDueForDeletion(before time.Time) ([]*schema.User, error) {
+	var users []*schema.User
+	err := r.db.Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", before).
+		Find(&users).Error
+	return users, err
+}
+
+// Anonymize detaches the user's public contributions, wipes their private data and soft-deletes the account.
+// Forum posts and reviews are kept but reassigned to anonymousID so they no longer link back to the user.
+func (r *repository) Anonymize(userID, anonymousID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{&schema.ForumDiscussion{}, &schema.ForumReply{}, &schema.Review{}} {
+			if err := tx.Model(model).Where("user_id = ?", userID).
+				Update("user_id", anonymousID).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&schema.Submission{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&schema.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&schema.PasswordHistory{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&schema.LessonProgress{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&schema.ContentUnlockNotice{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&schema.WaitlistEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&schema.CalendarFeed{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&schema.WishlistItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&schema.Certificate{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&schema.Session{}).Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&schema.APIKey{}).Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&schema.Wallet{}).Where("user_id = ?", userID).
+			Update("balance", 0).Error; err != nil {
+			return err
+		}
+
+		// Free the email address so it can be registered again.
+		if err := tx.Model(&schema.User{}).Where("id = ?", userID).Updates(map[string]any{
+			"email":                 "deleted+" + userID.String() + "@seatudy.invalid",
+			"is_email_verified":     false,
+			"name":                  "Deleted User",
+			"password_hash":         "",
+			"image_url":             "",
+			"deletion_scheduled_at": nil,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&schema.User{}, userID).Error
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
 	"github.com/highfive-compfest/seatudy-backend/internal/response"
 	"net/http"
+	"time"
 )
 
 type RestController struct {
@@ -25,6 +26,18 @@ func NewRestController(engine *gin.Engine, uc *UseCase) {
 			middleware.Authenticate(),
 			controller.Update(),
 		)
+		userGroup.GET("/me/export",
+			middleware.Authenticate(),
+			controller.ExportPersonalData(),
+		)
+		userGroup.POST("/me/deletion",
+			middleware.Authenticate(),
+			controller.RequestDeletion(),
+		)
+		userGroup.DELETE("/me/deletion",
+			middleware.Authenticate(),
+			controller.CancelDeletion(),
+		)
 		userGroup.GET("/:id",
 			controller.GetByID(),
 		)
@@ -79,3 +92,47 @@ func (c *RestController) Update() gin.HandlerFunc {
 		response.NewRestResponse(http.StatusOK, "UPDATE_USER_SUCCESS", nil).Send(ctx)
 	}
 }
+
+func (c *RestController) ExportPersonalData() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		archive, err := c.uc.ExportPersonalData(ctx)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		filename := "seatudy-data-" + time.Now().Format("2006-01-02") + ".zip"
+		ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		ctx.Data(http.StatusOK, "application/zip", archive)
+	}
+}
+
+func (c *RestController) RequestDeletion() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req RequestDeletionRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			err2 := apierror.ErrValidation.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+			return
+		}
+
+		res, err := c.uc.RequestDeletion(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "REQUEST_DELETION_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) CancelDeletion() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if err := c.uc.CancelDeletion(ctx); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "CANCEL_DELETION_SUCCESS", nil).Send(ctx)
+	}
+}
@@ -1,26 +1,36 @@
 package user
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
 	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/wallet"
 	"github.com/highfive-compfest/seatudy-backend/internal/fileutil"
 	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"log"
 	"slices"
 	"strings"
+	"time"
 )
 
+// DeletionCoolingOffPeriod is how long a deletion request can still be cancelled before the account is erased.
+const DeletionCoolingOffPeriod = 14 * 24 * time.Hour
+
 type UseCase struct {
-	repo     IRepository
-	uploader config.FileUploader
+	repo       IRepository
+	walletRepo wallet.IRepository
+	uploader   config.FileUploader
 }
 
-func NewUseCase(repo IRepository, uploader config.FileUploader) *UseCase {
-	return &UseCase{repo: repo, uploader: uploader}
+func NewUseCase(repo IRepository, walletRepo wallet.IRepository, uploader config.FileUploader) *UseCase {
+	return &UseCase{repo: repo, walletRepo: walletRepo, uploader: uploader}
 }
 
 func (uc *UseCase) GetMe(ctx context.Context) (*schema.User, error) {
@@ -116,3 +126,169 @@ func (uc *UseCase) Update(ctx context.Context, req *UpdateUserRequest) error {
 
 	return nil
 }
+
+// ExportPersonalData builds a zip archive containing one JSON document per kind of data held about the user.
+func (uc *UseCase) ExportPersonalData(ctx context.Context) ([]byte, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	data, err := uc.repo.GetPersonalData(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound.Build()
+		}
+		log.Println("Error getting personal data: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	transactions := make([]walletTransactionRecord, len(data.WalletTransactions))
+	for i, t := range data.WalletTransactions {
+		transactions[i] = walletTransactionRecord{
+			ID:        t.ID,
+			Amount:    t.Amount,
+			IsCredit:  t.IsCredit,
+			Status:    t.Status,
+			CreatedAt: t.CreatedAt,
+		}
+	}
+
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"profile.json", data.Profile},
+		{"enrollments.json", data.Enrollments},
+		{"submissions.json", data.Submissions},
+		{"reviews.json", data.Reviews},
+		{"forum_discussions.json", data.ForumDiscussions},
+		{"forum_replies.json", data.ForumReplies},
+		{"wallet.json", walletRecord{Balance: data.WalletBalance, Transactions: transactions}},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			log.Println("Error creating export archive entry: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.content); err != nil {
+			log.Println("Error encoding export archive entry: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+	}
+	if err := zw.Close(); err != nil {
+		log.Println("Error closing export archive: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (uc *UseCase) RequestDeletion(ctx context.Context, req *RequestDeletionRequest) (*RequestDeletionResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	user, err := uc.repo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound.Build()
+		}
+		log.Println("Error getting user by id: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	if user.DeletionScheduledAt != nil {
+		return nil, ErrDeletionAlreadyScheduled.WithPayload(map[string]any{
+			"scheduled_at": user.DeletionScheduledAt,
+		}).Build()
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, ErrInvalidPassword.Build()
+	}
+
+	// Courses have paying students, so they must be handed over or removed first.
+	if user.Role == schema.RoleInstructor {
+		count, err := uc.repo.CountOwnedCourses(userID)
+		if err != nil {
+			log.Println("Error counting owned courses: ", err)
+			return nil, apierror.ErrInternalServer.Build()
+		}
+		if count > 0 {
+			return nil, ErrOwnsCourses.WithPayload(map[string]any{"course_count": count}).Build()
+		}
+	}
+
+	userWallet, err := uc.walletRepo.GetByUserID(nil, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Println("Error getting wallet by user id: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if userWallet != nil && userWallet.Balance > 0 && !req.ForfeitBalance {
+		return nil, ErrWalletNotEmpty.WithPayload(map[string]any{"balance": userWallet.Balance}).Build()
+	}
+
+	scheduledAt := time.Now().Add(DeletionCoolingOffPeriod)
+	if err := uc.repo.SetDeletionSchedule(userID, &scheduledAt); err != nil {
+		log.Println("Error scheduling user deletion: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return &RequestDeletionResponse{ScheduledAt: scheduledAt}, nil
+}
+
+func (uc *UseCase) CancelDeletion(ctx context.Context) error {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+
+	user, err := uc.repo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound.Build()
+		}
+		log.Println("Error getting user by id: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	if user.DeletionScheduledAt == nil {
+		return ErrDeletionNotScheduled.Build()
+	}
+
+	if err := uc.repo.SetDeletionSchedule(userID, nil); err != nil {
+		log.Println("Error cancelling user deletion: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+
+	return nil
+}
+
+// PurgeScheduledDeletions erases every account whose cooling-off period has elapsed.
+func (uc *UseCase) PurgeScheduledDeletions(_ context.Context) error {
+	users, err := uc.repo.GetDueForDeletion(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		anonymousID, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		if err := uc.repo.Anonymize(user.ID, anonymousID); err != nil {
+			log.Println("Error anonymizing user "+user.ID.String()+": ", err)
+			continue
+		}
+	}
+
+	return nil
+}
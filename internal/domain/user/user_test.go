@@ -1,6 +1,8 @@
 package user
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"github.com/google/uuid"
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
@@ -9,9 +11,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"mime/multipart"
 	"testing"
+	"time"
 )
 
 type MockRepository struct {
@@ -51,6 +55,107 @@ func (m *MockRepository) UpdateByEmail(email string, user *schema.User) error {
 	return args.Error(0)
 }
 
+func (m *MockRepository) UpdatePassword(userID uuid.UUID, passwordHash string, historySize int) error {
+	args := m.Called(userID, passwordHash, historySize)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetPasswordHistory(userID uuid.UUID, limit int) ([]string, error) {
+	args := m.Called(userID, limit)
+	hashes, ok := args.Get(0).([]string)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return hashes, args.Error(1)
+}
+
+func (m *MockRepository) GetPersonalData(userID uuid.UUID) (*PersonalData, error) {
+	args := m.Called(userID)
+	data, ok := args.Get(0).(*PersonalData)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return data, args.Error(1)
+}
+
+func (m *MockRepository) CountOwnedCourses(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) SetDeletionSchedule(userID uuid.UUID, at *time.Time) error {
+	args := m.Called(userID, at)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetDueForDeletion(before time.Time) ([]*schema.User, error) {
+	args := m.Called(before)
+	users, ok := args.Get(0).([]*schema.User)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return users, args.Error(1)
+}
+
+func (m *MockRepository) Anonymize(userID, anonymousID uuid.UUID) error {
+	args := m.Called(userID, anonymousID)
+	return args.Error(0)
+}
+
+type MockWalletRepository struct {
+	mock.Mock
+}
+
+func (m *MockWalletRepository) Create(tx *gorm.DB, wallet *schema.Wallet) error {
+	args := m.Called(tx, wallet)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) CreateMidtransTransaction(tx *gorm.DB, transaction *schema.MidtransTransaction) error {
+	args := m.Called(tx, transaction)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) GetByUserID(tx *gorm.DB, userID uuid.UUID) (*schema.Wallet, error) {
+	args := m.Called(tx, userID)
+	var wallet *schema.Wallet
+	if args.Get(0) != nil {
+		wallet = args.Get(0).(*schema.Wallet)
+	}
+	return wallet, args.Error(1)
+}
+
+func (m *MockWalletRepository) GetMidtransTransactionByID(tx *gorm.DB, transactionID uuid.UUID) (*schema.MidtransTransaction, error) {
+	args := m.Called(tx, transactionID)
+	return args.Get(0).(*schema.MidtransTransaction), args.Error(1)
+}
+
+func (m *MockWalletRepository) GetMidtransTransactionsByWalletID(tx *gorm.DB, walletID uuid.UUID, isCredit bool, page, limit int) ([]*schema.MidtransTransaction, int64, error) {
+	args := m.Called(tx, walletID, isCredit, page, limit)
+	var transactions []*schema.MidtransTransaction
+
+	if args.Get(0) != nil {
+		transactions = args.Get(0).([]*schema.MidtransTransaction)
+	}
+
+	return transactions, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWalletRepository) UpdateMidtransTransaction(tx *gorm.DB, transaction *schema.MidtransTransaction) error {
+	args := m.Called(tx, transaction)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) TopUpSuccess(transactionID uuid.UUID) error {
+	args := m.Called(transactionID)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) TransferByUserID(tx *gorm.DB, fromUserID, toUserID uuid.UUID, amount int64) error {
+	args := m.Called(tx, fromUserID, toUserID, amount)
+	return args.Error(0)
+}
+
 type MockFileUploader struct {
 	mock.Mock
 }
@@ -60,17 +165,42 @@ func (m *MockFileUploader) UploadFile(key string, fileHeader *multipart.FileHead
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockFileUploader) UploadBytes(key string, data []byte, contentType string) (string, error) {
+	args := m.Called(key, data, contentType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) CopyFile(sourceURL, key string) (string, error) {
+	args := m.Called(sourceURL, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFileUploader) DownloadFile(fileURL string) ([]byte, error) {
+	args := m.Called(fileURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockFileUploader) DeleteFile(fileURL string) error {
+	args := m.Called(fileURL)
+	return args.Error(0)
+}
+
 type UseCaseTestSuite struct {
 	suite.Suite
-	repo     *MockRepository
-	useCase  *UseCase
-	uploader *MockFileUploader
+	repo       *MockRepository
+	walletRepo *MockWalletRepository
+	useCase    *UseCase
+	uploader   *MockFileUploader
 }
 
 func (suite *UseCaseTestSuite) SetupTest() {
 	suite.repo = new(MockRepository)
+	suite.walletRepo = new(MockWalletRepository)
 	suite.uploader = new(MockFileUploader)
-	suite.useCase = NewUseCase(suite.repo, suite.uploader)
+	suite.useCase = NewUseCase(suite.repo, suite.walletRepo, suite.uploader)
 }
 
 func (suite *UseCaseTestSuite) TestGetMe_Success() {
@@ -230,6 +360,145 @@ func (suite *UseCaseTestSuite) TestUpdate_InternalServerError() {
 	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
 }
 
+func (suite *UseCaseTestSuite) TestExportPersonalData_Success() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	data := &PersonalData{
+		Profile:       schema.User{ID: userID, Name: "Test"},
+		Reviews:       []schema.Review{{ID: uuid.New(), UserID: userID, Rating: 5}},
+		WalletBalance: 1000,
+	}
+
+	suite.repo.On("GetPersonalData", userID).Return(data, nil)
+
+	archive, err := suite.useCase.ExportPersonalData(ctx)
+	assert.NoError(suite.T(), err)
+
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.NoError(suite.T(), err)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.ElementsMatch(suite.T(), []string{
+		"profile.json", "enrollments.json", "submissions.json", "reviews.json",
+		"forum_discussions.json", "forum_replies.json", "wallet.json",
+	}, names)
+}
+
+func (suite *UseCaseTestSuite) TestExportPersonalData_InternalServerError() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+
+	suite.repo.On("GetPersonalData", userID).Return(nil, gorm.ErrInvalidDB)
+
+	archive, err := suite.useCase.ExportPersonalData(ctx)
+	assert.Nil(suite.T(), archive)
+	assert.Equal(suite.T(), apierror.ErrInternalServer.Build(), err)
+}
+
+func (suite *UseCaseTestSuite) TestRequestDeletion_Success() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := &schema.User{ID: userID, Role: schema.RoleStudent, PasswordHash: string(hash)}
+
+	suite.repo.On("GetByID", userID).Return(user, nil)
+	suite.walletRepo.On("GetByUserID", mock.Anything, userID).Return(&schema.Wallet{Balance: 0}, nil)
+	suite.repo.On("SetDeletionSchedule", userID, mock.AnythingOfType("*time.Time")).Return(nil)
+
+	res, err := suite.useCase.RequestDeletion(ctx, &RequestDeletionRequest{Password: "password"})
+	assert.NoError(suite.T(), err)
+	assert.WithinDuration(suite.T(), time.Now().Add(DeletionCoolingOffPeriod), res.ScheduledAt, time.Minute)
+}
+
+func (suite *UseCaseTestSuite) TestRequestDeletion_WrongPassword() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := &schema.User{ID: userID, Role: schema.RoleStudent, PasswordHash: string(hash)}
+
+	suite.repo.On("GetByID", userID).Return(user, nil)
+
+	_, err := suite.useCase.RequestDeletion(ctx, &RequestDeletionRequest{Password: "wrong"})
+	assert.Equal(suite.T(), ErrInvalidPassword.Build(), err)
+	suite.repo.AssertNotCalled(suite.T(), "SetDeletionSchedule", mock.Anything, mock.Anything)
+}
+
+func (suite *UseCaseTestSuite) TestRequestDeletion_WalletNotEmpty() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := &schema.User{ID: userID, Role: schema.RoleStudent, PasswordHash: string(hash)}
+
+	suite.repo.On("GetByID", userID).Return(user, nil)
+	suite.walletRepo.On("GetByUserID", mock.Anything, userID).Return(&schema.Wallet{Balance: 5000}, nil)
+
+	_, err := suite.useCase.RequestDeletion(ctx, &RequestDeletionRequest{Password: "password"})
+	assert.Equal(suite.T(), "WALLET_NOT_EMPTY", err.Error())
+	assert.Equal(suite.T(), map[string]any{"balance": int64(5000)}, apierror.GetPayload(err))
+}
+
+func (suite *UseCaseTestSuite) TestRequestDeletion_InstructorOwnsCourses() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := &schema.User{ID: userID, Role: schema.RoleInstructor, PasswordHash: string(hash)}
+
+	suite.repo.On("GetByID", userID).Return(user, nil)
+	suite.repo.On("CountOwnedCourses", userID).Return(int64(2), nil)
+
+	_, err := suite.useCase.RequestDeletion(ctx, &RequestDeletionRequest{Password: "password"})
+	assert.Equal(suite.T(), "OWNS_COURSES", err.Error())
+}
+
+func (suite *UseCaseTestSuite) TestRequestDeletion_AlreadyScheduled() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	scheduledAt := time.Now().Add(time.Hour)
+
+	suite.repo.On("GetByID", userID).Return(&schema.User{ID: userID, DeletionScheduledAt: &scheduledAt}, nil)
+
+	_, err := suite.useCase.RequestDeletion(ctx, &RequestDeletionRequest{Password: "password"})
+	assert.Equal(suite.T(), "DELETION_ALREADY_SCHEDULED", err.Error())
+}
+
+func (suite *UseCaseTestSuite) TestCancelDeletion_Success() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	scheduledAt := time.Now().Add(time.Hour)
+
+	suite.repo.On("GetByID", userID).Return(&schema.User{ID: userID, DeletionScheduledAt: &scheduledAt}, nil)
+	suite.repo.On("SetDeletionSchedule", userID, (*time.Time)(nil)).Return(nil)
+
+	err := suite.useCase.CancelDeletion(ctx)
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
+func (suite *UseCaseTestSuite) TestCancelDeletion_NotScheduled() {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+
+	suite.repo.On("GetByID", userID).Return(&schema.User{ID: userID}, nil)
+
+	err := suite.useCase.CancelDeletion(ctx)
+	assert.Equal(suite.T(), ErrDeletionNotScheduled.Build(), err)
+}
+
+func (suite *UseCaseTestSuite) TestPurgeScheduledDeletions_ContinuesAfterFailure() {
+	first := &schema.User{ID: uuid.New()}
+	second := &schema.User{ID: uuid.New()}
+
+	suite.repo.On("GetDueForDeletion", mock.AnythingOfType("time.Time")).Return([]*schema.User{first, second}, nil)
+	suite.repo.On("Anonymize", first.ID, mock.Anything).Return(gorm.ErrInvalidDB)
+	suite.repo.On("Anonymize", second.ID, mock.Anything).Return(nil)
+
+	err := suite.useCase.PurgeScheduledDeletions(context.Background())
+	assert.NoError(suite.T(), err)
+	suite.repo.AssertExpectations(suite.T())
+}
+
 func TestUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(UseCaseTestSuite))
 }
@@ -0,0 +1,53 @@
+package waitlist
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CourseIDRequest struct {
+	CourseID string `uri:"id" binding:"required,uuid"`
+}
+
+// Seats are the counts a seat decision is based on. Capacity is nil for a course without a seat limit.
+type Seats struct {
+	Capacity *int
+	Enrolled int64
+	// Reserved counts unexpired reservations held by anyone other than the buyer
+	Reserved int64
+	// WaitingAhead counts users queued before the buyer who have not been offered a seat yet
+	WaitingAhead   int64
+	HasReservation bool
+	// AlreadyEnrolled is only filled by PurchaseSeat, under the course lock, so a buyer cannot be enrolled twice
+	AlreadyEnrolled bool
+}
+
+type SeatsResponse struct {
+	MaxSeats *int  `json:"max_seats"`
+	Enrolled int64 `json:"enrolled"`
+	// Available is nil for a course without a seat limit
+	Available      *int64 `json:"available"`
+	WaitlistLength int64  `json:"waitlist_length"`
+}
+
+type EntryResponse struct {
+	CourseID uuid.UUID `json:"course_id"`
+	// Position is 1 for the next user to be offered a seat and 0 while a seat is reserved
+	Position      int64      `json:"position"`
+	ReservedUntil *time.Time `json:"reserved_until"`
+	JoinedAt      time.Time  `json:"joined_at"`
+}
+
+type WaitlistUser struct {
+	UserID        uuid.UUID  `json:"user_id"`
+	Name          string     `json:"name"`
+	ReservedUntil *time.Time `json:"reserved_until"`
+	JoinedAt      time.Time  `json:"joined_at"`
+}
+
+type WaitlistResponse struct {
+	MaxSeats *int           `json:"max_seats"`
+	Enrolled int64          `json:"enrolled"`
+	Users    []WaitlistUser `json:"users"`
+}
@@ -0,0 +1,32 @@
+package waitlist
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"net/http"
+)
+
+var (
+	ErrCourseNotFound = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("COURSE_NOT_FOUND")
+
+	ErrCourseFull = apierror.NewApiErrorBuilder().
+			WithHttpStatus(http.StatusConflict).
+			WithMessage("COURSE_FULL")
+
+	ErrSeatsAvailable = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("COURSE_HAS_SEATS_AVAILABLE")
+
+	ErrAlreadyEnrolled = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("COURSE_ALREADY_ENROLLED")
+
+	ErrAlreadyOnWaitlist = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusConflict).
+				WithMessage("ALREADY_ON_WAITLIST")
+
+	ErrNotOnWaitlist = apierror.NewApiErrorBuilder().
+				WithHttpStatus(http.StatusNotFound).
+				WithMessage("NOT_ON_WAITLIST")
+)
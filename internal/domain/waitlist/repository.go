@@ -0,0 +1,238 @@
+package waitlist
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRepository interface {
+	GetCourse(courseID uuid.UUID) (*schema.Course, error)
+	IsEnrolled(userID, courseID uuid.UUID) (bool, error)
+	GetEntry(courseID, userID uuid.UUID) (*schema.WaitlistEntry, error)
+	GetSeats(courseID, userID uuid.UUID, now time.Time) (Seats, error)
+	Create(entry *schema.WaitlistEntry) error
+	Delete(courseID, userID uuid.UUID) error
+	List(courseID uuid.UUID) ([]schema.WaitlistEntry, error)
+	PurchaseSeat(courseID uuid.UUID, enroll *schema.CourseEnroll, now time.Time, decide func(tx *gorm.DB, seats Seats) error) error
+	ExpireReservations(now time.Time) ([]schema.WaitlistEntry, error)
+	GetCoursesWithWaitingEntries() ([]uuid.UUID, error)
+	OfferSeats(courseID uuid.UUID, now, until time.Time, count func(seats Seats) int) (*schema.Course, []schema.WaitlistEntry, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	var course schema.Course
+	if err := r.db.First(&course, "id = ?", courseID).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *repository) IsEnrolled(userID, courseID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&schema.CourseEnroll{}).Where("user_id = ? AND course_id = ?", userID, courseID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) GetEntry(courseID, userID uuid.UUID) (*schema.WaitlistEntry, error) {
+	var entry schema.WaitlistEntry
+	if err := r.db.Where("course_id = ? AND user_id = ?", courseID, userID).First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// countSeats reads the seat counts of course as seen by userID, who may be uuid.Nil to count everyone
+func countSeats(tx *gorm.DB, course *schema.Course, userID uuid.UUID, now time.Time) (Seats, error) {
+	seats := Seats{Capacity: course.MaxSeats}
+	if err := tx.Model(&schema.CourseEnroll{}).Where("course_id = ?", course.ID).Count(&seats.Enrolled).Error; err != nil {
+		return Seats{}, err
+	}
+	if err := tx.Model(&schema.WaitlistEntry{}).
+		Where("course_id = ? AND user_id <> ? AND reserved_until > ?", course.ID, userID, now).
+		Count(&seats.Reserved).Error; err != nil {
+		return Seats{}, err
+	}
+
+	waiting := tx.Model(&schema.WaitlistEntry{}).
+		Where("course_id = ? AND user_id <> ? AND reserved_until IS NULL", course.ID, userID)
+	var own schema.WaitlistEntry
+	err := tx.Where("course_id = ? AND user_id = ?", course.ID, userID).First(&own).Error
+	switch {
+	case err == nil && own.ReservedUntil == nil:
+		waiting = waiting.Where("(created_at, id) < (?, ?)", own.CreatedAt, own.ID)
+	case err == nil:
+		seats.HasReservation = own.ReservedUntil.After(now)
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return Seats{}, err
+	}
+	if err := waiting.Count(&seats.WaitingAhead).Error; err != nil {
+		return Seats{}, err
+	}
+	return seats, nil
+}
+
+// lockCourse takes a row lock on the course, so everything deciding about its seats runs one at a time
+func lockCourse(tx *gorm.DB, courseID uuid.UUID) (*schema.Course, error) {
+	var course schema.Course
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&course, "id = ?", courseID).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *repository) GetSeats(courseID, userID uuid.UUID, now time.Time) (Seats, error) {
+	course, err := r.GetCourse(courseID)
+	if err != nil {
+		return Seats{}, err
+	}
+	return countSeats(r.db, course, userID, now)
+}
+
+func (r *repository) Create(entry *schema.WaitlistEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *repository) Delete(courseID, userID uuid.UUID) error {
+	result := r.db.Where("course_id = ? AND user_id = ?", courseID, userID).Delete(&schema.WaitlistEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) List(courseID uuid.UUID) ([]schema.WaitlistEntry, error) {
+	var entries []schema.WaitlistEntry
+	err := r.db.Preload("User").Where("course_id = ?", courseID).Order("created_at, id").Find(&entries).Error
+	return entries, err
+}
+
+// PurchaseSeat hands the seat counts to decide while the course is locked. When decide succeeds, the enrollment is
+// created and the buyer leaves the waitlist in the same transaction.
+func (r *repository) PurchaseSeat(courseID uuid.UUID, enroll *schema.CourseEnroll, now time.Time,
+	decide func(tx *gorm.DB, seats Seats) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		course, err := lockCourse(tx, courseID)
+		if err != nil {
+			return err
+		}
+		var enrolled int64
+		if err := tx.Model(&schema.CourseEnroll{}).Where("user_id = ? AND course_id = ?", enroll.UserID, courseID).
+			Count(&enrolled).Error; err != nil {
+			return err
+		}
+		seats, err := countSeats(tx, course, enroll.UserID, now)
+		if err != nil {
+			return err
+		}
+		seats.AlreadyEnrolled = enrolled > 0
+		if err := decide(tx, seats); err != nil {
+			return err
+		}
+		if err := tx.Create(enroll).Error; err != nil {
+			return err
+		}
+		return tx.Where("course_id = ? AND user_id = ?", courseID, enroll.UserID).Delete(&schema.WaitlistEntry{}).Error
+	})
+}
+
+// ExpireReservations removes the entries whose reservation ran out and returns them with their course
+func (r *repository) ExpireReservations(now time.Time) ([]schema.WaitlistEntry, error) {
+	var expired []schema.WaitlistEntry
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Returning{}).Where("reserved_until <= ?", now).
+			Delete(&expired).Error; err != nil {
+			return err
+		}
+		if len(expired) == 0 {
+			return nil
+		}
+		courseIDs := make([]uuid.UUID, len(expired))
+		for i, entry := range expired {
+			courseIDs[i] = entry.CourseID
+		}
+		var courses []schema.Course
+		if err := tx.Where("id IN ?", courseIDs).Find(&courses).Error; err != nil {
+			return err
+		}
+		byID := make(map[uuid.UUID]*schema.Course, len(courses))
+		for i := range courses {
+			byID[courses[i].ID] = &courses[i]
+		}
+		for i := range expired {
+			expired[i].Course = byID[expired[i].CourseID]
+		}
+		return nil
+	})
+	return expired, err
+}
+
+// GetCoursesWithWaitingEntries skips deleted courses, whose waitlists are left behind but can never be offered a seat
+func (r *repository) GetCoursesWithWaitingEntries() ([]uuid.UUID, error) {
+	var courseIDs []uuid.UUID
+	err := r.db.Model(&schema.WaitlistEntry{}).
+		Joins("JOIN courses ON courses.id = waitlist_entries.course_id AND courses.deleted_at IS NULL").
+		Where("waitlist_entries.reserved_until IS NULL").
+		Distinct().Pluck("waitlist_entries.course_id", &courseIDs).Error
+	return courseIDs, err
+}
+
+// OfferSeats reserves seats for the longest waiting users of a course, as many as count allows (-1 for all of them)
+func (r *repository) OfferSeats(courseID uuid.UUID, now, until time.Time, count func(seats Seats) int) (*schema.Course, []schema.WaitlistEntry, error) {
+	var course *schema.Course
+	var offered []schema.WaitlistEntry
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if course, err = lockCourse(tx, courseID); err != nil {
+			return err
+		}
+		// Students who bought the course some other way no longer need a seat
+		if err := tx.Where("course_id = ? AND user_id IN (?)", courseID,
+			tx.Model(&schema.CourseEnroll{}).Select("user_id").Where("course_id = ?", courseID)).
+			Delete(&schema.WaitlistEntry{}).Error; err != nil {
+			return err
+		}
+
+		seats, err := countSeats(tx, course, uuid.Nil, now)
+		if err != nil {
+			return err
+		}
+		n := count(seats)
+		if n == 0 {
+			return nil
+		}
+		if err := tx.Where("course_id = ? AND reserved_until IS NULL", courseID).
+			Order("created_at, id").Limit(n).Find(&offered).Error; err != nil {
+			return err
+		}
+		if len(offered) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(offered))
+		for i := range offered {
+			ids[i] = offered[i].ID
+			offered[i].ReservedUntil = &until
+		}
+		return tx.Model(&schema.WaitlistEntry{}).Where("id IN ?", ids).Update("reserved_until", until).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return course, offered, nil
+}
@@ -0,0 +1,298 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/notification"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// reservationWindow is how long a student offered a seat has to complete the purchase before it goes to the next
+const reservationWindow = 48 * time.Hour
+
+type UseCase struct {
+	repo             IRepository
+	notificationRepo notification.IRepository
+}
+
+func NewUseCase(repo IRepository, notificationRepo notification.IRepository) *UseCase {
+	return &UseCase{repo: repo, notificationRepo: notificationRepo}
+}
+
+// openSeats is how many seats nobody holds yet, or -1 for a course without a seat limit
+func openSeats(seats Seats) int {
+	if seats.Capacity == nil {
+		return -1
+	}
+	return max(*seats.Capacity-int(seats.Enrolled)-int(seats.Reserved), 0)
+}
+
+// canTakeSeat reports whether a buyer may have a seat. A reservation is always honored; anyone else only gets a seat
+// when there are more open seats than users queued ahead of them.
+func canTakeSeat(seats Seats) bool {
+	open := openSeats(seats)
+	if open < 0 {
+		return true
+	}
+	if seats.HasReservation {
+		return open > 0
+	}
+	return int64(open) > seats.WaitingAhead
+}
+
+func (uc *UseCase) getCourse(courseID uuid.UUID) (*schema.Course, error) {
+	course, err := uc.repo.GetCourse(courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return course, nil
+}
+
+// Purchase enrolls userID in a course once pay succeeds, but only if a seat is free for them. pay runs in the same
+// transaction as the seat check, so two buyers can never take the last seat. amountPaid is recorded on the enrollment.
+func (uc *UseCase) Purchase(courseID, userID uuid.UUID, amountPaid int64, pay func(tx *gorm.DB) error) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	now := time.Now()
+	enroll := &schema.CourseEnroll{ID: id, UserID: userID, CourseID: courseID, CreatedAt: now, AmountPaid: &amountPaid}
+
+	return uc.repo.PurchaseSeat(courseID, enroll, now, func(tx *gorm.DB, seats Seats) error {
+		if seats.AlreadyEnrolled {
+			return ErrAlreadyEnrolled.Build()
+		}
+		if !canTakeSeat(seats) {
+			return ErrCourseFull.Build()
+		}
+		return pay(tx)
+	})
+}
+
+func (uc *UseCase) GetSeats(req *CourseIDRequest) (*SeatsResponse, error) {
+	courseID := uuid.MustParse(req.CourseID)
+	if _, err := uc.getCourse(courseID); err != nil {
+		return nil, err
+	}
+	seats, err := uc.repo.GetSeats(courseID, uuid.Nil, time.Now())
+	if err != nil {
+		log.Println("Error counting seats: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	res := &SeatsResponse{MaxSeats: seats.Capacity, Enrolled: seats.Enrolled, WaitlistLength: seats.WaitingAhead}
+	if open := openSeats(seats); open >= 0 {
+		available := max(int64(open)-seats.WaitingAhead, 0)
+		res.Available = &available
+	}
+	return res, nil
+}
+
+func (uc *UseCase) entryResponse(entry *schema.WaitlistEntry) (*EntryResponse, error) {
+	res := &EntryResponse{CourseID: entry.CourseID, ReservedUntil: entry.ReservedUntil, JoinedAt: entry.CreatedAt}
+	if entry.ReservedUntil != nil {
+		return res, nil
+	}
+	seats, err := uc.repo.GetSeats(entry.CourseID, entry.UserID, time.Now())
+	if err != nil {
+		log.Println("Error counting seats: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	res.Position = seats.WaitingAhead + 1
+	return res, nil
+}
+
+// Join queues the caller for a full course
+func (uc *UseCase) Join(ctx context.Context, req *CourseIDRequest) (*EntryResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+	courseID := uuid.MustParse(req.CourseID)
+
+	course, err := uc.getCourse(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course.Status != schema.CourseStatusPublished && course.Status != schema.CourseStatusUnlisted {
+		return nil, ErrCourseNotFound.Build()
+	}
+
+	enrolled, err := uc.repo.IsEnrolled(userID, courseID)
+	if err != nil {
+		log.Println("Error checking enrollment: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if enrolled {
+		return nil, ErrAlreadyEnrolled.Build()
+	}
+
+	if _, err := uc.repo.GetEntry(courseID, userID); err == nil {
+		return nil, ErrAlreadyOnWaitlist.Build()
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Println("Error getting waitlist entry: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	seats, err := uc.repo.GetSeats(courseID, userID, time.Now())
+	if err != nil {
+		log.Println("Error counting seats: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if canTakeSeat(seats) {
+		return nil, ErrSeatsAvailable.Build()
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		log.Println("Error generating UUID: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	entry := &schema.WaitlistEntry{ID: id, CourseID: courseID, UserID: userID, CreatedAt: time.Now()}
+	if err := uc.repo.Create(entry); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrAlreadyOnWaitlist.Build()
+		}
+		log.Println("Error creating waitlist entry: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	return &EntryResponse{CourseID: courseID, Position: seats.WaitingAhead + 1, JoinedAt: entry.CreatedAt}, nil
+}
+
+// Leave takes the caller off the waitlist, giving up a reserved seat if they hold one
+func (uc *UseCase) Leave(ctx context.Context, req *CourseIDRequest) error {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+
+	if err := uc.repo.Delete(uuid.MustParse(req.CourseID), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotOnWaitlist.Build()
+		}
+		log.Println("Error deleting waitlist entry: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	return nil
+}
+
+func (uc *UseCase) GetStatus(ctx context.Context, req *CourseIDRequest) (*EntryResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	entry, err := uc.repo.GetEntry(uuid.MustParse(req.CourseID), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotOnWaitlist.Build()
+		}
+		log.Println("Error getting waitlist entry: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	return uc.entryResponse(entry)
+}
+
+// List shows the instructor of a course who is waiting for it, in queue order
+func (uc *UseCase) List(ctx context.Context, req *CourseIDRequest) (*WaitlistResponse, error) {
+	courseID := uuid.MustParse(req.CourseID)
+	course, err := uc.getCourse(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if role, _ := ctx.Value("user.role").(string); role != string(schema.RoleAdmin) {
+		userID, err := uuid.Parse(ctx.Value("user.id").(string))
+		if err != nil {
+			return nil, apierror.ErrTokenInvalid.Build()
+		}
+		if course.InstructorID != userID {
+			return nil, apierror.ErrNotYourResource.Build()
+		}
+	}
+
+	seats, err := uc.repo.GetSeats(courseID, uuid.Nil, time.Now())
+	if err != nil {
+		log.Println("Error counting seats: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	entries, err := uc.repo.List(courseID)
+	if err != nil {
+		log.Println("Error listing waitlist: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+
+	res := &WaitlistResponse{MaxSeats: course.MaxSeats, Enrolled: seats.Enrolled, Users: make([]WaitlistUser, len(entries))}
+	for i, entry := range entries {
+		res.Users[i] = WaitlistUser{UserID: entry.UserID, ReservedUntil: entry.ReservedUntil, JoinedAt: entry.CreatedAt}
+		if entry.User != nil {
+			res.Users[i].Name = entry.User.Name
+		}
+	}
+	return res, nil
+}
+
+func (uc *UseCase) notify(userID uuid.UUID, title, detail string) error {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+	return uc.notificationRepo.Create(&schema.Notification{ID: id, UserID: userID, Title: title, Detail: detail})
+}
+
+// OfferSeats releases lapsed reservations and reserves open seats for the users at the front of each waitlist,
+// telling everyone affected. It runs as a background job, so one failing course or notification is logged and
+// skipped rather than holding back every other waitlist.
+func (uc *UseCase) OfferSeats(ctx context.Context) error {
+	now := time.Now()
+
+	expired, err := uc.repo.ExpireReservations(now)
+	if err != nil {
+		return err
+	}
+	for _, entry := range expired {
+		courseTitle := "the course"
+		if entry.Course != nil {
+			courseTitle = entry.Course.Title
+		}
+		if err := uc.notify(entry.UserID, "Seat reservation expired",
+			fmt.Sprintf("Your reserved seat in %s was released because the purchase was not completed in time", courseTitle)); err != nil {
+			log.Println("Error notifying expired reservation: ", err)
+		}
+	}
+
+	courseIDs, err := uc.repo.GetCoursesWithWaitingEntries()
+	if err != nil {
+		return err
+	}
+	until := now.Add(reservationWindow)
+	for _, courseID := range courseIDs {
+		course, offered, err := uc.repo.OfferSeats(courseID, now, until, openSeats)
+		if err != nil {
+			log.Printf("Error offering seats in course %s: %v", courseID, err)
+			continue
+		}
+		for _, entry := range offered {
+			if err := uc.notify(entry.UserID, "A seat is available",
+				fmt.Sprintf("A seat in %s is reserved for you until %s. Complete your purchase to keep it.",
+					course.Title, until.Format(time.RFC1123))); err != nil {
+				log.Println("Error notifying seat offer: ", err)
+			}
+		}
+	}
+	return nil
+}
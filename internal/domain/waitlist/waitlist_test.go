@@ -0,0 +1,355 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) IsEnrolled(userID, courseID uuid.UUID) (bool, error) {
+	args := m.Called(userID, courseID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) GetEntry(courseID, userID uuid.UUID) (*schema.WaitlistEntry, error) {
+	args := m.Called(courseID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockRepository) GetSeats(courseID, userID uuid.UUID, now time.Time) (Seats, error) {
+	args := m.Called(courseID, userID)
+	return args.Get(0).(Seats), args.Error(1)
+}
+
+func (m *MockRepository) Create(entry *schema.WaitlistEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(courseID, userID uuid.UUID) error {
+	args := m.Called(courseID, userID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) List(courseID uuid.UUID) ([]schema.WaitlistEntry, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]schema.WaitlistEntry), args.Error(1)
+}
+
+// PurchaseSeat runs decide with the seats given to On, as the repository would inside its transaction
+func (m *MockRepository) PurchaseSeat(courseID uuid.UUID, enroll *schema.CourseEnroll, now time.Time,
+	decide func(tx *gorm.DB, seats Seats) error) error {
+	args := m.Called(courseID, enroll.UserID)
+	if err := decide(&gorm.DB{}, args.Get(0).(Seats)); err != nil {
+		return err
+	}
+	return args.Error(1)
+}
+
+func (m *MockRepository) ExpireReservations(now time.Time) ([]schema.WaitlistEntry, error) {
+	args := m.Called()
+	return args.Get(0).([]schema.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockRepository) GetCoursesWithWaitingEntries() ([]uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) OfferSeats(courseID uuid.UUID, now, until time.Time,
+	count func(seats Seats) int) (*schema.Course, []schema.WaitlistEntry, error) {
+	args := m.Called(courseID)
+	course, _ := args.Get(0).(*schema.Course)
+	return course, args.Get(1).([]schema.WaitlistEntry), args.Error(2)
+}
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(notification *schema.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetByUserID(userID uuid.UUID, limit, offset int) ([]*schema.Notification, int64, error) {
+	args := m.Called(userID, limit, offset)
+	return args.Get(0).([]*schema.Notification), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificationRepository) GetUnreadCount(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) UpdateRead(notificationID uuid.UUID) error {
+	args := m.Called(notificationID)
+	return args.Error(0)
+}
+
+type WaitlistUseCaseTestSuite struct {
+	suite.Suite
+	repo             *MockRepository
+	notificationRepo *MockNotificationRepository
+	uc               *UseCase
+
+	course    *schema.Course
+	studentID uuid.UUID
+	maxSeats  int
+}
+
+func (s *WaitlistUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.notificationRepo = new(MockNotificationRepository)
+	s.uc = NewUseCase(s.repo, s.notificationRepo)
+
+	s.maxSeats = 2
+	s.course = &schema.Course{ID: uuid.New(), Title: "Go", InstructorID: uuid.New(), MaxSeats: &s.maxSeats,
+		Status: schema.CourseStatusPublished}
+	s.studentID = uuid.New()
+}
+
+func TestWaitlistUseCase(t *testing.T) {
+	suite.Run(t, new(WaitlistUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID, role string) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", role)
+}
+
+func (s *WaitlistUseCaseTestSuite) courseRequest() *CourseIDRequest {
+	return &CourseIDRequest{CourseID: s.course.ID.String()}
+}
+
+func TestCanTakeSeat(t *testing.T) {
+	capacity := 3
+	cases := []struct {
+		name  string
+		seats Seats
+		want  bool
+	}{
+		{"unlimited", Seats{Enrolled: 100}, true},
+		{"open seat", Seats{Capacity: &capacity, Enrolled: 2}, true},
+		{"full", Seats{Capacity: &capacity, Enrolled: 3}, false},
+		{"held by reservations", Seats{Capacity: &capacity, Enrolled: 1, Reserved: 2}, false},
+		{"queue ahead takes the open seat", Seats{Capacity: &capacity, Enrolled: 2, WaitingAhead: 1}, false},
+		{"more seats than queue", Seats{Capacity: &capacity, Enrolled: 1, WaitingAhead: 1}, true},
+		{"own reservation", Seats{Capacity: &capacity, Enrolled: 2, WaitingAhead: 4, HasReservation: true}, true},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, canTakeSeat(c.seats), c.name)
+	}
+}
+
+func (s *WaitlistUseCaseTestSuite) TestJoin() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("IsEnrolled", s.studentID, s.course.ID).Return(false, nil)
+	s.repo.On("GetEntry", s.course.ID, s.studentID).Return(nil, gorm.ErrRecordNotFound)
+	s.repo.On("GetSeats", s.course.ID, s.studentID).
+		Return(Seats{Capacity: &s.maxSeats, Enrolled: 2, WaitingAhead: 3}, nil)
+	s.repo.On("Create", mock.MatchedBy(func(entry *schema.WaitlistEntry) bool {
+		return entry.UserID == s.studentID && entry.CourseID == s.course.ID && entry.ReservedUntil == nil
+	})).Return(nil)
+
+	res, err := s.uc.Join(userContext(s.studentID, "student"), s.courseRequest())
+
+	s.NoError(err)
+	s.Equal(int64(4), res.Position)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *WaitlistUseCaseTestSuite) TestJoin_SeatsAvailable() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("IsEnrolled", s.studentID, s.course.ID).Return(false, nil)
+	s.repo.On("GetEntry", s.course.ID, s.studentID).Return(nil, gorm.ErrRecordNotFound)
+	s.repo.On("GetSeats", s.course.ID, s.studentID).Return(Seats{Capacity: &s.maxSeats, Enrolled: 1}, nil)
+
+	_, err := s.uc.Join(userContext(s.studentID, "student"), s.courseRequest())
+
+	s.Equal(ErrSeatsAvailable.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestJoin_AlreadyEnrolled() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("IsEnrolled", s.studentID, s.course.ID).Return(true, nil)
+
+	_, err := s.uc.Join(userContext(s.studentID, "student"), s.courseRequest())
+
+	s.Equal(ErrAlreadyEnrolled.Build(), err)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestJoin_AlreadyWaiting() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("IsEnrolled", s.studentID, s.course.ID).Return(false, nil)
+	s.repo.On("GetEntry", s.course.ID, s.studentID).Return(&schema.WaitlistEntry{}, nil)
+
+	_, err := s.uc.Join(userContext(s.studentID, "student"), s.courseRequest())
+
+	s.Equal(ErrAlreadyOnWaitlist.Build(), err)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestJoin_DraftCourse() {
+	s.course.Status = schema.CourseStatusDraft
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+
+	_, err := s.uc.Join(userContext(s.studentID, "student"), s.courseRequest())
+
+	s.Equal(ErrCourseNotFound.Build(), err)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestLeave_NotWaiting() {
+	s.repo.On("Delete", s.course.ID, s.studentID).Return(gorm.ErrRecordNotFound)
+
+	err := s.uc.Leave(userContext(s.studentID, "student"), s.courseRequest())
+
+	s.Equal(ErrNotOnWaitlist.Build(), err)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestGetStatus_Reserved() {
+	until := time.Now().Add(time.Hour)
+	s.repo.On("GetEntry", s.course.ID, s.studentID).
+		Return(&schema.WaitlistEntry{CourseID: s.course.ID, UserID: s.studentID, ReservedUntil: &until}, nil)
+
+	res, err := s.uc.GetStatus(userContext(s.studentID, "student"), s.courseRequest())
+
+	s.NoError(err)
+	s.Equal(int64(0), res.Position)
+	s.Equal(&until, res.ReservedUntil)
+	s.repo.AssertNotCalled(s.T(), "GetSeats", mock.Anything, mock.Anything)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestList_NotOwner() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+
+	_, err := s.uc.List(userContext(uuid.New(), "instructor"), s.courseRequest())
+
+	s.Equal(apierror.ErrNotYourResource.Build(), err)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestGetSeats() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("GetSeats", s.course.ID, uuid.Nil).Return(Seats{Capacity: &s.maxSeats, Enrolled: 1, WaitingAhead: 3}, nil)
+
+	res, err := s.uc.GetSeats(s.courseRequest())
+
+	s.NoError(err)
+	s.Equal(int64(0), *res.Available)
+	s.Equal(int64(3), res.WaitlistLength)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestPurchase_Reserved() {
+	s.repo.On("PurchaseSeat", s.course.ID, s.studentID).
+		Return(Seats{Capacity: &s.maxSeats, Enrolled: 1, WaitingAhead: 5, HasReservation: true}, nil)
+	paid := false
+
+	err := s.uc.Purchase(s.course.ID, s.studentID, 10000, func(tx *gorm.DB) error {
+		paid = true
+		return nil
+	})
+
+	s.NoError(err)
+	s.True(paid)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestPurchase_AlreadyEnrolled() {
+	s.repo.On("PurchaseSeat", s.course.ID, s.studentID).Return(Seats{Capacity: &s.maxSeats, AlreadyEnrolled: true}, nil)
+	paid := false
+
+	err := s.uc.Purchase(s.course.ID, s.studentID, 10000, func(tx *gorm.DB) error {
+		paid = true
+		return nil
+	})
+
+	s.Equal(ErrAlreadyEnrolled.Build(), err)
+	s.False(paid)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestPurchase_PaymentFails() {
+	s.repo.On("PurchaseSeat", s.course.ID, s.studentID).Return(Seats{Capacity: &s.maxSeats}, nil)
+
+	err := s.uc.Purchase(s.course.ID, s.studentID, 10000, func(tx *gorm.DB) error {
+		return apierror.ErrInsufficientBalance.Build()
+	})
+
+	s.Equal(apierror.ErrInsufficientBalance.Build(), err)
+}
+
+func (s *WaitlistUseCaseTestSuite) TestOfferSeats() {
+	expiredUser, offeredUser := uuid.New(), uuid.New()
+	s.repo.On("ExpireReservations").
+		Return([]schema.WaitlistEntry{{UserID: expiredUser, CourseID: s.course.ID, Course: s.course}}, nil)
+	s.repo.On("GetCoursesWithWaitingEntries").Return([]uuid.UUID{s.course.ID}, nil)
+	s.repo.On("OfferSeats", s.course.ID).Return(s.course, []schema.WaitlistEntry{{UserID: offeredUser}}, nil)
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == expiredUser && n.Title == "Seat reservation expired"
+	})).Return(nil).Once()
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == offeredUser && n.Title == "A seat is available"
+	})).Return(nil).Once()
+
+	err := s.uc.OfferSeats(context.Background())
+
+	s.NoError(err)
+	s.notificationRepo.AssertExpectations(s.T())
+}
+
+func (s *WaitlistUseCaseTestSuite) TestOfferSeats_ContinuesPastFailures() {
+	brokenCourseID := uuid.New()
+	expiredUser, firstOffered, secondOffered := uuid.New(), uuid.New(), uuid.New()
+	s.repo.On("ExpireReservations").
+		Return([]schema.WaitlistEntry{{UserID: expiredUser, CourseID: s.course.ID, Course: s.course}}, nil)
+	s.repo.On("GetCoursesWithWaitingEntries").Return([]uuid.UUID{brokenCourseID, s.course.ID}, nil)
+	s.repo.On("OfferSeats", brokenCourseID).Return(nil, []schema.WaitlistEntry(nil), gorm.ErrRecordNotFound)
+	s.repo.On("OfferSeats", s.course.ID).
+		Return(s.course, []schema.WaitlistEntry{{UserID: firstOffered}, {UserID: secondOffered}}, nil)
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == expiredUser
+	})).Return(errors.New("db down")).Once()
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == firstOffered
+	})).Return(errors.New("db down")).Once()
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.UserID == secondOffered && n.Title == "A seat is available"
+	})).Return(nil).Once()
+
+	err := s.uc.OfferSeats(context.Background())
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+	s.notificationRepo.AssertExpectations(s.T())
+}
+
+func (s *WaitlistUseCaseTestSuite) TestOfferSeats_StopsOnError() {
+	s.repo.On("ExpireReservations").Return([]schema.WaitlistEntry(nil), errors.New("db down"))
+
+	err := s.uc.OfferSeats(context.Background())
+
+	s.Error(err)
+	s.repo.AssertNotCalled(s.T(), "GetCoursesWithWaitingEntries")
+}
@@ -0,0 +1,20 @@
+package wishlist
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+)
+
+type CourseIDRequest struct {
+	CourseID string `uri:"id" binding:"required,uuid"`
+}
+
+type ListRequest struct {
+	Page  int `form:"page" binding:"required,min=1"`
+	Limit int `form:"limit" binding:"required,min=1,max=30"`
+}
+
+type WishlistPaginatedResponse struct {
+	Items      []schema.WishlistItem `json:"items"`
+	Pagination pagination.Pagination `json:"pagination"`
+}
@@ -0,0 +1,84 @@
+package wishlist
+
+import (
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"gorm.io/gorm"
+)
+
+type IRepository interface {
+	GetCourse(courseID uuid.UUID) (*schema.Course, error)
+	IsEnrolled(userID, courseID uuid.UUID) (bool, error)
+	Create(item *schema.WishlistItem) error
+	Delete(userID, courseID uuid.UUID) error
+	List(userID uuid.UUID, page, limit int) ([]schema.WishlistItem, int64, error)
+	GetWatchers(courseID uuid.UUID) ([]schema.User, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) IRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	var course schema.Course
+	if err := r.db.First(&course, "id = ?", courseID).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+func (r *repository) IsEnrolled(userID, courseID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&schema.CourseEnroll{}).Where("user_id = ? AND course_id = ?", userID, courseID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) Create(item *schema.WishlistItem) error {
+	return r.db.Create(item).Error
+}
+
+func (r *repository) Delete(userID, courseID uuid.UUID) error {
+	result := r.db.Delete(&schema.WishlistItem{}, "user_id = ? AND course_id = ?", userID, courseID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// List returns the user's wishlist, most recently added first. Courses that were deleted since are left out.
+func (r *repository) List(userID uuid.UUID, page, limit int) ([]schema.WishlistItem, int64, error) {
+	query := r.db.Model(&schema.WishlistItem{}).
+		Joins("JOIN courses ON courses.id = wishlist_items.course_id AND courses.deleted_at IS NULL").
+		Where("wishlist_items.user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []schema.WishlistItem
+	err := query.Preload("Course").
+		Order("wishlist_items.created_at DESC, wishlist_items.course_id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&items).Error
+	return items, total, err
+}
+
+// GetWatchers lists the users who wishlisted a course and have not bought it since
+func (r *repository) GetWatchers(courseID uuid.UUID) ([]schema.User, error) {
+	var users []schema.User
+	err := r.db.
+		Joins("JOIN wishlist_items ON wishlist_items.user_id = users.id").
+		Where("wishlist_items.course_id = ?", courseID).
+		Where("NOT EXISTS (SELECT 1 FROM course_enrolls ce WHERE ce.course_id = ? AND ce.user_id = users.id)", courseID).
+		Find(&users).Error
+	return users, err
+}
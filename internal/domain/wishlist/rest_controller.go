@@ -0,0 +1,95 @@
+package wishlist
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/middleware"
+	"github.com/highfive-compfest/seatudy-backend/internal/response"
+	"net/http"
+)
+
+type RestController struct {
+	uc *UseCase
+}
+
+func NewRestController(engine *gin.Engine, uc *UseCase) {
+	controller := &RestController{uc: uc}
+
+	engine.GET("/v1/wishlist",
+		middleware.Authenticate(),
+		middleware.RequireRole("student"),
+		controller.List(),
+	)
+
+	courseGroup := engine.Group("/v1/courses/:id")
+	{
+		courseGroup.POST("/wishlist",
+			middleware.Authenticate(),
+			middleware.RequireRole("student"),
+			controller.Add(),
+		)
+		courseGroup.DELETE("/wishlist",
+			middleware.Authenticate(),
+			middleware.RequireRole("student"),
+			controller.Remove(),
+		)
+	}
+}
+
+func sendValidationError(ctx *gin.Context, err error) {
+	err2 := apierror.ErrValidation.Build()
+	response.NewRestResponse(apierror.GetHttpStatus(err2), err2.Error(), err.Error()).Send(ctx)
+}
+
+func (c *RestController) List() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req ListRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.List(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "GET_WISHLIST_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Add() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CourseIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		res, err := c.uc.Add(ctx, &req)
+		if err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusCreated, "ADD_TO_WISHLIST_SUCCESS", res).Send(ctx)
+	}
+}
+
+func (c *RestController) Remove() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var req CourseIDRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			sendValidationError(ctx, err)
+			return
+		}
+
+		if err := c.uc.Remove(ctx, &req); err != nil {
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+			return
+		}
+
+		response.NewRestResponse(http.StatusOK, "REMOVE_FROM_WISHLIST_SUCCESS", nil).Send(ctx)
+	}
+}
@@ -0,0 +1,158 @@
+package wishlist
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
+	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/domain/notification"
+	"github.com/highfive-compfest/seatudy-backend/internal/mailer"
+	"github.com/highfive-compfest/seatudy-backend/internal/pagination"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type UseCase struct {
+	repo             IRepository
+	notificationRepo notification.IRepository
+	mailDialer       config.IMailer
+}
+
+func NewUseCase(repo IRepository, notificationRepo notification.IRepository, mailDialer config.IMailer) *UseCase {
+	return &UseCase{repo: repo, notificationRepo: notificationRepo, mailDialer: mailDialer}
+}
+
+func isPurchasable(course *schema.Course) bool {
+	return course.Status == schema.CourseStatusPublished || course.Status == schema.CourseStatusUnlisted
+}
+
+func (uc *UseCase) Add(ctx context.Context, req *CourseIDRequest) (*schema.WishlistItem, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+	courseID := uuid.MustParse(req.CourseID)
+
+	course, err := uc.repo.GetCourse(courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound.Build()
+		}
+		log.Println("Error getting course: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if !isPurchasable(course) {
+		return nil, ErrCourseNotFound.Build()
+	}
+
+	enrolled, err := uc.repo.IsEnrolled(userID, courseID)
+	if err != nil {
+		log.Println("Error checking enrollment: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if enrolled {
+		return nil, ErrAlreadyEnrolled.Build()
+	}
+
+	item := &schema.WishlistItem{UserID: userID, CourseID: courseID, CreatedAt: time.Now()}
+	if err := uc.repo.Create(item); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrAlreadyWishlisted.Build()
+		}
+		log.Println("Error creating wishlist item: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	item.Course = course
+	return item, nil
+}
+
+func (uc *UseCase) Remove(ctx context.Context, req *CourseIDRequest) error {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return apierror.ErrTokenInvalid.Build()
+	}
+
+	if err := uc.repo.Delete(userID, uuid.MustParse(req.CourseID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotWishlisted.Build()
+		}
+		log.Println("Error deleting wishlist item: ", err)
+		return apierror.ErrInternalServer.Build()
+	}
+	return nil
+}
+
+func (uc *UseCase) List(ctx context.Context, req *ListRequest) (*WishlistPaginatedResponse, error) {
+	userID, err := uuid.Parse(ctx.Value("user.id").(string))
+	if err != nil {
+		return nil, apierror.ErrTokenInvalid.Build()
+	}
+
+	items, total, err := uc.repo.List(userID, req.Page, req.Limit)
+	if err != nil {
+		log.Println("Error listing wishlist: ", err)
+		return nil, apierror.ErrInternalServer.Build()
+	}
+	if items == nil {
+		items = []schema.WishlistItem{}
+	}
+	return &WishlistPaginatedResponse{
+		Items:      items,
+		Pagination: pagination.NewPagination(int(total), req.Page, req.Limit),
+	}, nil
+}
+
+//go:embed price_drop_email_template.html
+var priceDropEmailTemplate string
+
+// NotifyPriceDrop tells everyone who wishlisted a course, and has not bought it yet, that its price went down from
+// oldPrice. Nothing is sent for a price rise or for a course that cannot be bought.
+func (uc *UseCase) NotifyPriceDrop(course *schema.Course, oldPrice int64) error {
+	if course.Price >= oldPrice || !isPurchasable(course) {
+		return nil
+	}
+
+	users, err := uc.repo.GetWatchers(course.ID)
+	if err != nil {
+		return err
+	}
+
+	detail := fmt.Sprintf("%s is now %d, down from %d", course.Title, course.Price, oldPrice)
+	for _, user := range users {
+		notificationID, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		notif := schema.Notification{ID: notificationID, UserID: user.ID, Title: "A course on your wishlist is cheaper", Detail: detail}
+		if err := uc.notificationRepo.Create(&notif); err != nil {
+			log.Println("Error creating notification: ", err)
+		}
+
+		if !user.IsEmailVerified {
+			continue
+		}
+		emailData := map[string]any{
+			"name":         user.Name,
+			"course_title": course.Title,
+			"old_price":    oldPrice,
+			"new_price":    course.Price,
+		}
+		mail, err := mailer.GenerateMail(user.Email, "A course on your wishlist is cheaper", priceDropEmailTemplate, emailData)
+		if err != nil {
+			log.Println("Error generating email: ", err)
+			continue
+		}
+		if err := uc.mailDialer.DialAndSend(mail); err != nil {
+			log.Println("Error sending email: ", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package wishlist
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"github.com/highfive-compfest/seatudy-backend/internal/schema"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/gomail.v2"
+	"gorm.io/gorm"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetCourse(courseID uuid.UUID) (*schema.Course, error) {
+	args := m.Called(courseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schema.Course), args.Error(1)
+}
+
+func (m *MockRepository) IsEnrolled(userID, courseID uuid.UUID) (bool, error) {
+	args := m.Called(userID, courseID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) Create(item *schema.WishlistItem) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(userID, courseID uuid.UUID) error {
+	args := m.Called(userID, courseID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) List(userID uuid.UUID, page, limit int) ([]schema.WishlistItem, int64, error) {
+	args := m.Called(userID, page, limit)
+	return args.Get(0).([]schema.WishlistItem), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) GetWatchers(courseID uuid.UUID) ([]schema.User, error) {
+	args := m.Called(courseID)
+	return args.Get(0).([]schema.User), args.Error(1)
+}
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(notification *schema.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetByUserID(userID uuid.UUID, limit, offset int) ([]*schema.Notification, int64, error) {
+	args := m.Called(userID, limit, offset)
+	return args.Get(0).([]*schema.Notification), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificationRepository) GetUnreadCount(userID uuid.UUID) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) UpdateRead(notificationID uuid.UUID) error {
+	args := m.Called(notificationID)
+	return args.Error(0)
+}
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) DialAndSend(msgs ...*gomail.Message) error {
+	args := m.Called(msgs)
+	return args.Error(0)
+}
+
+type WishlistUseCaseTestSuite struct {
+	suite.Suite
+	repo             *MockRepository
+	notificationRepo *MockNotificationRepository
+	mailer           *MockMailer
+	uc               *UseCase
+
+	course    *schema.Course
+	studentID uuid.UUID
+}
+
+func (s *WishlistUseCaseTestSuite) SetupTest() {
+	s.repo = new(MockRepository)
+	s.notificationRepo = new(MockNotificationRepository)
+	s.mailer = new(MockMailer)
+	s.uc = NewUseCase(s.repo, s.notificationRepo, s.mailer)
+
+	s.course = &schema.Course{ID: uuid.New(), Title: "Go", Price: 200000, Status: schema.CourseStatusPublished}
+	s.studentID = uuid.New()
+}
+
+func TestWishlistUseCase(t *testing.T) {
+	suite.Run(t, new(WishlistUseCaseTestSuite))
+}
+
+func userContext(userID uuid.UUID) context.Context {
+	ctx := context.WithValue(context.Background(), "user.id", userID.String())
+	return context.WithValue(ctx, "user.role", "student")
+}
+
+func (s *WishlistUseCaseTestSuite) courseRequest() *CourseIDRequest {
+	return &CourseIDRequest{CourseID: s.course.ID.String()}
+}
+
+func (s *WishlistUseCaseTestSuite) TestAdd() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("IsEnrolled", s.studentID, s.course.ID).Return(false, nil)
+	s.repo.On("Create", mock.MatchedBy(func(item *schema.WishlistItem) bool {
+		return item.UserID == s.studentID && item.CourseID == s.course.ID
+	})).Return(nil)
+
+	res, err := s.uc.Add(userContext(s.studentID), s.courseRequest())
+
+	s.NoError(err)
+	s.Equal(s.course, res.Course)
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *WishlistUseCaseTestSuite) TestAdd_Draft() {
+	s.course.Status = schema.CourseStatusDraft
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+
+	_, err := s.uc.Add(userContext(s.studentID), s.courseRequest())
+
+	s.Equal(ErrCourseNotFound.Build(), err)
+}
+
+func (s *WishlistUseCaseTestSuite) TestAdd_AlreadyEnrolled() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("IsEnrolled", s.studentID, s.course.ID).Return(true, nil)
+
+	_, err := s.uc.Add(userContext(s.studentID), s.courseRequest())
+
+	s.Equal(ErrAlreadyEnrolled.Build(), err)
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *WishlistUseCaseTestSuite) TestAdd_Duplicate() {
+	s.repo.On("GetCourse", s.course.ID).Return(s.course, nil)
+	s.repo.On("IsEnrolled", s.studentID, s.course.ID).Return(false, nil)
+	s.repo.On("Create", mock.Anything).Return(&pgconn.PgError{Code: "23505"})
+
+	_, err := s.uc.Add(userContext(s.studentID), s.courseRequest())
+
+	s.Equal(ErrAlreadyWishlisted.Build(), err)
+}
+
+func (s *WishlistUseCaseTestSuite) TestRemove_NotWishlisted() {
+	s.repo.On("Delete", s.studentID, s.course.ID).Return(gorm.ErrRecordNotFound)
+
+	err := s.uc.Remove(userContext(s.studentID), s.courseRequest())
+
+	s.Equal(ErrNotWishlisted.Build(), err)
+}
+
+func (s *WishlistUseCaseTestSuite) TestList() {
+	items := []schema.WishlistItem{{UserID: s.studentID, CourseID: s.course.ID, Course: s.course, CreatedAt: time.Now()}}
+	s.repo.On("List", s.studentID, 1, 10).Return(items, int64(11), nil)
+
+	res, err := s.uc.List(userContext(s.studentID), &ListRequest{Page: 1, Limit: 10})
+
+	s.NoError(err)
+	s.Len(res.Items, 1)
+	s.Equal(2, res.Pagination.TotalPage)
+}
+
+func (s *WishlistUseCaseTestSuite) TestNotifyPriceDrop() {
+	_ = os.Setenv("ENV", "test")
+	_ = os.Setenv("SMTP_EMAIL", "noreply@example.com")
+	config.LoadEnv()
+
+	verified := schema.User{ID: uuid.New(), Name: "Verified", Email: "verified@example.com", IsEmailVerified: true}
+	unverified := schema.User{ID: uuid.New(), Name: "Unverified", Email: "unverified@example.com"}
+	s.course.Price = 150000
+	s.repo.On("GetWatchers", s.course.ID).Return([]schema.User{verified, unverified}, nil)
+	s.notificationRepo.On("Create", mock.MatchedBy(func(n *schema.Notification) bool {
+		return n.Detail == "Go is now 150000, down from 200000"
+	})).Return(nil).Twice()
+	s.mailer.On("DialAndSend", mock.MatchedBy(func(msgs []*gomail.Message) bool {
+		return len(msgs) == 1 && msgs[0].GetHeader("To")[0] == verified.Email
+	})).Return(nil).Once()
+
+	err := s.uc.NotifyPriceDrop(s.course, 200000)
+
+	s.NoError(err)
+	s.notificationRepo.AssertExpectations(s.T())
+	s.mailer.AssertExpectations(s.T())
+}
+
+func (s *WishlistUseCaseTestSuite) TestNotifyPriceDrop_PriceRise() {
+	err := s.uc.NotifyPriceDrop(s.course, 100000)
+
+	s.NoError(err)
+	s.repo.AssertNotCalled(s.T(), "GetWatchers", mock.Anything)
+}
+
+func (s *WishlistUseCaseTestSuite) TestNotifyPriceDrop_Archived() {
+	s.course.Status = schema.CourseStatusArchived
+
+	err := s.uc.NotifyPriceDrop(s.course, 300000)
+
+	s.NoError(err)
+	s.repo.AssertNotCalled(s.T(), "GetWatchers", mock.Anything)
+}
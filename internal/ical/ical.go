@@ -0,0 +1,80 @@
+// Package ical writes iCalendar (RFC 5545) files that calendar apps can subscribe to
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest a content line may be before it has to be folded
+const maxLineOctets = 75
+
+const timeFormat = "20060102T150405Z"
+
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// fold splits a content line into CRLF-terminated chunks of at most 75 octets, never inside a UTF-8 sequence
+func fold(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// the leading space of a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func text(buf *bytes.Buffer, name, value string) {
+	if value != "" {
+		fold(buf, name+":"+textEscaper.Replace(value))
+	}
+}
+
+// Encode renders events as a calendar named name. now is used as the DTSTAMP of every event.
+func Encode(name string, events []Event, now time.Time) []byte {
+	var buf bytes.Buffer
+	fold(&buf, "BEGIN:VCALENDAR")
+	fold(&buf, "VERSION:2.0")
+	fold(&buf, "PRODID:-//Seatudy//Seatudy//EN")
+	fold(&buf, "CALSCALE:GREGORIAN")
+	fold(&buf, "METHOD:PUBLISH")
+	text(&buf, "X-WR-CALNAME", name)
+
+	stamp := now.UTC().Format(timeFormat)
+	for _, event := range events {
+		fold(&buf, "BEGIN:VEVENT")
+		text(&buf, "UID", event.UID)
+		fold(&buf, "DTSTAMP:"+stamp)
+		fold(&buf, "DTSTART:"+event.Start.UTC().Format(timeFormat))
+		fold(&buf, "DTEND:"+event.End.UTC().Format(timeFormat))
+		text(&buf, "SUMMARY", event.Summary)
+		text(&buf, "DESCRIPTION", event.Description)
+		text(&buf, "LOCATION", event.Location)
+		if event.URL != "" {
+			fold(&buf, "URL:"+event.URL)
+		}
+		fold(&buf, "END:VEVENT")
+	}
+
+	fold(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
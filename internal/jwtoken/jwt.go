@@ -12,9 +12,10 @@ type AccessClaims struct {
 	IsEmailVerified bool   `json:"is_email_verified"`
 	Name            string `json:"name"`
 	Role            string `json:"role"`
+	SessionID       string `json:"sid"`
 }
 
-func CreateAccessJWT(id, email string, isEmailVerified bool, name string, role string) (string, error) {
+func CreateAccessJWT(id, email string, isEmailVerified bool, name string, role string, sessionID string) (string, error) {
 	claims := AccessClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   id,
@@ -25,6 +26,7 @@ func CreateAccessJWT(id, email string, isEmailVerified bool, name string, role s
 		IsEmailVerified: isEmailVerified,
 		Name:            name,
 		Role:            role,
+		SessionID:       sessionID,
 	}
 
 	unsignedJWT := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -36,8 +38,10 @@ func CreateAccessJWT(id, email string, isEmailVerified bool, name string, role s
 	return signedJWT, nil
 }
 
-func CreateRefreshJWT(id string) (string, error) {
+// CreateRefreshJWT issues a refresh token bound to a session, the session ID is carried in the jti claim
+func CreateRefreshJWT(id string, sessionID string) (string, error) {
 	claims := jwt.RegisteredClaims{
+		ID:        sessionID,
 		Subject:   id,
 		ExpiresAt: jwt.NewNumericDate(time.Now().Add(config.Env.JwtRefreshDuration)),
 		Issuer:    "seatudy-backend-refreshtoken",
@@ -1,17 +1,103 @@
 package middleware
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/highfive-compfest/seatudy-backend/internal/apierror"
 	"github.com/highfive-compfest/seatudy-backend/internal/jwtoken"
 	"github.com/highfive-compfest/seatudy-backend/internal/response"
 	"log"
+	"slices"
 	"strings"
 	"time"
 )
 
+// LastUsedResolution is how stale a validator may let the last_used_at of a session or API key get, so that
+// authenticated requests do not each write back to the database
+const LastUsedResolution = time.Minute
+
+// SessionValidator checks that the session an access token was issued for has not been revoked
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, sessionID, userID string) error
+}
+
+var sessionValidator SessionValidator
+
+// SetSessionValidator must be called once during startup, before the engine starts serving requests
+func SetSessionValidator(validator SessionValidator) {
+	sessionValidator = validator
+}
+
+// APIKeyIdentity is the user an API key acts on behalf of, along with the scopes granted to the key
+type APIKeyIdentity struct {
+	UserID          string
+	Email           string
+	IsEmailVerified bool
+	Name            string
+	Role            string
+	Scopes          []string
+}
+
+// APIKeyValidator resolves a raw API key into the identity it authenticates
+type APIKeyValidator interface {
+	ValidateAPIKey(ctx context.Context, key string) (*APIKeyIdentity, error)
+}
+
+var apiKeyValidator APIKeyValidator
+
+// SetAPIKeyValidator must be called once during startup, before the engine starts serving requests
+func SetAPIKeyValidator(validator APIKeyValidator) {
+	apiKeyValidator = validator
+}
+
+// APIKeyScope marks a route as reachable with an API key carrying the given scope.
+// It must be placed before Authenticate; routes without it only accept access tokens.
+func APIKeyScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set("auth.api_key_scope", scope)
+		ctx.Next()
+	}
+}
+
+func authenticateAPIKey(ctx *gin.Context, key string) {
+	scope := ctx.GetString("auth.api_key_scope")
+	if apiKeyValidator == nil || scope == "" {
+		err := apierror.ErrAPIKeyNotAllowed.Build()
+		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+		ctx.Abort()
+		return
+	}
+
+	identity, err := apiKeyValidator.ValidateAPIKey(ctx, key)
+	if err != nil {
+		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+		ctx.Abort()
+		return
+	}
+
+	if !slices.Contains(identity.Scopes, scope) {
+		err := apierror.ErrAPIKeyScope.WithPayload(map[string]string{"required_scope": scope}).Build()
+		response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), apierror.GetPayload(err)).Send(ctx)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Set("user.id", identity.UserID)
+	ctx.Set("user.email", identity.Email)
+	ctx.Set("user.is_email_verified", identity.IsEmailVerified)
+	ctx.Set("user.name", identity.Name)
+	ctx.Set("user.role", identity.Role)
+	ctx.Next()
+}
+
+// Authenticate accepts either a Bearer access token in the Authorization header or an X-API-Key header
 func Authenticate() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		if key := ctx.GetHeader("X-API-Key"); key != "" {
+			authenticateAPIKey(ctx, key)
+			return
+		}
+
 		bearer := ctx.GetHeader("Authorization")
 		if bearer == "" {
 			err := apierror.ErrTokenEmpty.Build()
@@ -52,15 +138,43 @@ func Authenticate() gin.HandlerFunc {
 			return
 		}
 
+		if claims.SessionID == "" {
+			err := apierror.ErrTokenInvalid.Build()
+			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+			ctx.Abort()
+			return
+		}
+
+		if sessionValidator != nil {
+			if err := sessionValidator.ValidateSession(ctx, claims.SessionID, claims.Subject); err != nil {
+				response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
+				ctx.Abort()
+				return
+			}
+		}
+
 		ctx.Set("user.id", claims.Subject)
 		ctx.Set("user.email", claims.Email)
 		ctx.Set("user.is_email_verified", claims.IsEmailVerified)
 		ctx.Set("user.name", claims.Name)
 		ctx.Set("user.role", claims.Role)
+		ctx.Set("user.session_id", claims.SessionID)
 		ctx.Next()
 	}
 }
 
+// OptionalAuthenticate authenticates the request when credentials are sent and lets anonymous requests through
+func OptionalAuthenticate() gin.HandlerFunc {
+	authenticate := Authenticate()
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("Authorization") == "" && ctx.GetHeader("X-API-Key") == "" {
+			ctx.Next()
+			return
+		}
+		authenticate(ctx)
+	}
+}
+
 // RequireEmailVerified Dependency: [Authenticate]
 func RequireEmailVerified() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -83,8 +197,8 @@ func RequireEmailVerified() gin.HandlerFunc {
 	}
 }
 
-// RequireRole Dependency: [Authenticate]
-func RequireRole(role string) gin.HandlerFunc {
+// RequireRole lets the request through when the user has any of the given roles. Dependency: [Authenticate]
+func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		userRole, ok := ctx.Get("user.role")
 		if !ok {
@@ -94,7 +208,7 @@ func RequireRole(role string) gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		if userRole != role {
+		if role, _ := userRole.(string); !slices.Contains(roles, role) {
 			err := apierror.ErrForbidden.Build()
 			response.NewRestResponse(apierror.GetHttpStatus(err), err.Error(), nil).Send(ctx)
 			ctx.Abort()
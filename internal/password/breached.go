@@ -0,0 +1,27 @@
+package password
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed breached_passwords.txt
+var breachedPasswordsFile string
+
+var breachedPasswords = func() map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, line := range strings.Split(breachedPasswordsFile, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		set[strings.ToLower(line)] = struct{}{}
+	}
+	return set
+}()
+
+// IsBreached reports whether the password appears in the bundled list of known-breached passwords
+func IsBreached(password string) bool {
+	_, ok := breachedPasswords[strings.ToLower(password)]
+	return ok
+}
@@ -0,0 +1,101 @@
+package password
+
+import (
+	"github.com/highfive-compfest/seatudy-backend/internal/config"
+	"strings"
+	"unicode"
+)
+
+// bcrypt silently ignores everything past 72 bytes
+const maxLength = 72
+
+// Names of the rules a password can fail, as reported to clients
+const (
+	RuleMinLength      = "MIN_LENGTH"
+	RuleMaxLength      = "MAX_LENGTH"
+	RuleLowercase      = "LOWERCASE"
+	RuleUppercase      = "UPPERCASE"
+	RuleDigit          = "DIGIT"
+	RuleSymbol         = "SYMBOL"
+	RuleContainsEmail  = "CONTAINS_EMAIL"
+	RuleContainsName   = "CONTAINS_NAME"
+	RuleBreached       = "BREACHED"
+	RuleRecentlyReused = "RECENTLY_USED"
+)
+
+type Policy struct {
+	MinLength     int  `json:"min_length"`
+	RequireLower  bool `json:"require_lowercase"`
+	RequireUpper  bool `json:"require_uppercase"`
+	RequireDigit  bool `json:"require_digit"`
+	RequireSymbol bool `json:"require_symbol"`
+	// HistorySize is how many previous passwords may not be reused; 0 disables the check
+	HistorySize int `json:"history_size"`
+}
+
+func NewPolicyFromEnv() Policy {
+	return Policy{
+		MinLength:     config.Env.PasswordMinLength,
+		RequireLower:  config.Env.PasswordRequireLower,
+		RequireUpper:  config.Env.PasswordRequireUpper,
+		RequireDigit:  config.Env.PasswordRequireDigit,
+		RequireSymbol: config.Env.PasswordRequireSymbol,
+		HistorySize:   config.Env.PasswordHistorySize,
+	}
+}
+
+// Validate returns the rules the password fails, or nil if it satisfies the policy.
+// Reuse of previous passwords is checked separately since it needs the stored hashes.
+func (p Policy) Validate(password, email, name string) []string {
+	var failed []string
+
+	if len(password) < p.MinLength {
+		failed = append(failed, RuleMinLength)
+	}
+	if len(password) > maxLength {
+		failed = append(failed, RuleMaxLength)
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireLower && !hasLower {
+		failed = append(failed, RuleLowercase)
+	}
+	if p.RequireUpper && !hasUpper {
+		failed = append(failed, RuleUppercase)
+	}
+	if p.RequireDigit && !hasDigit {
+		failed = append(failed, RuleDigit)
+	}
+	if p.RequireSymbol && !hasSymbol {
+		failed = append(failed, RuleSymbol)
+	}
+
+	lower := strings.ToLower(password)
+	if localPart, _, _ := strings.Cut(strings.ToLower(email), "@"); len(localPart) >= 3 && strings.Contains(lower, localPart) {
+		failed = append(failed, RuleContainsEmail)
+	}
+	for _, part := range strings.Fields(strings.ToLower(name)) {
+		if len(part) >= 3 && strings.Contains(lower, part) {
+			failed = append(failed, RuleContainsName)
+			break
+		}
+	}
+
+	if IsBreached(password) {
+		failed = append(failed, RuleBreached)
+	}
+
+	return failed
+}
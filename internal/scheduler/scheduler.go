@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a unit of periodic background work.
+type Job func(ctx context.Context) error
+
+// Every runs job once immediately and then on every interval for the lifetime of the process.
+// Errors are logged and do not stop subsequent runs.
+func Every(interval time.Duration, name string, job Job) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job(context.Background()); err != nil {
+				log.Printf("Error running scheduled job %s: %v\n", name, err)
+			}
+			<-ticker.C
+		}
+	}()
+}
@@ -0,0 +1,22 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type APIKey struct {
+	ID     uuid.UUID `json:"id" gorm:"primaryKey"`
+	UserID uuid.UUID `json:"-" gorm:"not null;index"`
+	User   User      `json:"-" gorm:"foreignKey:UserID"`
+	Name   string    `json:"name" gorm:"type:varchar(100);not null"`
+	// Prefix is the first characters of the key, kept in plain text so users can tell their keys apart
+	Prefix string `json:"prefix" gorm:"type:varchar(16);not null"`
+	// KeyHash is the hex-encoded SHA-256 of the full key; the key itself is only shown once on creation
+	KeyHash    string     `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	Scopes     string     `json:"-" gorm:"type:varchar(255);not null"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"-" gorm:"index"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Category is an admin-managed catalog category. Categories form a tree through ParentID.
+type Category struct {
+	ID          uuid.UUID  `json:"id" gorm:"primaryKey"`
+	ParentID    *uuid.UUID `json:"parent_id" gorm:"type:uuid;index"`
+	Name        string     `json:"name" gorm:"type:varchar(100);not null"`
+	Slug        string     `json:"slug" gorm:"type:varchar(120);uniqueIndex;not null"`
+	Description string     `json:"description" gorm:"type:varchar(500)"`
+	Position    int        `json:"position" gorm:"not null;default:0"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// Tag is a free-form label instructors attach to their courses
+type Tag struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"type:varchar(50);not null"`
+	Slug      string    `json:"slug" gorm:"type:varchar(60);uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
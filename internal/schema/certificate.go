@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Certificate snapshots the names printed on the PDF so verification shows exactly what was issued
+type Certificate struct {
+	ID             uuid.UUID `json:"id" gorm:"primaryKey"`
+	Serial         string    `json:"serial" gorm:"type:varchar(32);uniqueIndex;not null"`
+	UserID         uuid.UUID `json:"user_id" gorm:"not null;uniqueIndex:idx_certificate_user_course"`
+	CourseID       uuid.UUID `json:"course_id" gorm:"not null;uniqueIndex:idx_certificate_user_course"`
+	StudentName    string    `json:"student_name" gorm:"type:varchar(100);not null"`
+	CourseTitle    string    `json:"course_title" gorm:"type:varchar(255);not null"`
+	InstructorName string    `json:"instructor_name" gorm:"type:varchar(100);not null"`
+	FileURL        string    `json:"file_url" gorm:"type:text;not null"`
+	IssuedAt       time.Time `json:"issued_at" gorm:"not null"`
+	CreatedAt      time.Time `json:"created_at"`
+}
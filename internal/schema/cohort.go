@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cohort is one run of a course, taught to its own group of students between StartsAt and EndsAt
+type Cohort struct {
+	ID         uuid.UUID `json:"id" gorm:"primaryKey"`
+	CourseID   uuid.UUID `json:"course_id" gorm:"type:uuid;not null;index"`
+	Course     *Course   `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	Name       string    `json:"name" gorm:"type:varchar(100);not null"`
+	StartsAt   time.Time `json:"starts_at" gorm:"not null;index"`
+	EndsAt     time.Time `json:"ends_at" gorm:"not null;check:ends_at > starts_at"`
+	MaxMembers *int      `json:"max_members" gorm:"check:max_members > 0"` // nil means unlimited
+	CreatedAt  time.Time `json:"created_at" gorm:"default:now();not null"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
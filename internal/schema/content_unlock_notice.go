@@ -0,0 +1,13 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ContentUnlockNotice records that a student was told a drip-scheduled material unlocked, so they are told only once
+type ContentUnlockNotice struct {
+	UserID     uuid.UUID `gorm:"primaryKey"`
+	MaterialID uuid.UUID `gorm:"primaryKey;index"`
+	CreatedAt  time.Time
+}
@@ -16,24 +16,14 @@ const (
 	Expert       CourseDifficulty = "expert"
 )
 
-type CourseCategory string
+type CourseStatus string
 
 const (
-	WebDevelopment           CourseCategory = "Web Development"
-	GameDevelopment          CourseCategory = "Game Development"
-	CloudComputing           CourseCategory = "Cloud Computing"
-	DataScienceAnalytics     CourseCategory = "Data Science & Analytics"
-	ProgrammingLanguages     CourseCategory = "Programming Languages"
-	Cybersecurity            CourseCategory = "Cybersecurity"
-	MobileAppDevelopment     CourseCategory = "Mobile App Development"
-	DatabaseManagement       CourseCategory = "Database Management"
-	SoftwareDevelopment      CourseCategory = "Software Development"
-	DevOpsAutomation         CourseCategory = "DevOps & Automation"
-	Networking               CourseCategory = "Networking"
-	AIMachineLearning        CourseCategory = "AI & Machine Learning"
-	InternetOfThings         CourseCategory = "Internet of Things (IoT)"
-	BlockchainCryptocurrency CourseCategory = "Blockchain & Cryptocurrency"
-	AugmentedVirtualReality  CourseCategory = "Augmented Reality (AR) & Virtual Reality (VR)"
+	CourseStatusDraft     CourseStatus = "draft"
+	CourseStatusInReview  CourseStatus = "in_review"
+	CourseStatusPublished CourseStatus = "published"
+	CourseStatusUnlisted  CourseStatus = "unlisted"
+	CourseStatusArchived  CourseStatus = "archived"
 )
 
 type Course struct {
@@ -41,16 +31,32 @@ type Course struct {
 	Title        string           `json:"title" gorm:"type:varchar(100);not null"`
 	Description  string           `json:"description" gorm:"type:varchar(1000)"`
 	Price        int64            `json:"price" gorm:"not null"`
+	MaxSeats     *int             `json:"max_seats" gorm:"check:max_seats > 0"` // nil means unlimited
 	Rating       float32          `json:"rating" gorm:"type:numeric(2,1);default:0.0;not null;check:rating >= 0.0 AND rating <= 5.0;index"`
 	ReviewCount  int64            `json:"review_count" gorm:"type:bigint;default:0;not null"`
 	ImageURL     string           `json:"image_url" gorm:"type:text"`
 	SyllabusURL  string           `json:"syllabus_url" gorm:"type:text"`
 	InstructorID uuid.UUID        `json:"instructor_id" gorm:"not null"`
 	Difficulty   CourseDifficulty `json:"difficulty" gorm:"type:course_difficulty;not null"`
-	Category     CourseCategory   `json:"category" gorm:"type:course_category"`
+	CategoryID   *uuid.UUID       `json:"category_id" gorm:"type:uuid;index"`
+	Category     *Category        `json:"category,omitempty" gorm:"foreignKey:CategoryID;constraint:OnDelete:SET NULL"`
+	Tags         []Tag            `json:"tags" gorm:"many2many:course_tags"`
+	Language     string           `json:"language" gorm:"type:varchar(10);not null;default:'en';index"`
+	Status       CourseStatus     `json:"status" gorm:"type:course_status;not null;default:'draft';index"`
+	ReviewNote   string           `json:"review_note,omitempty" gorm:"type:varchar(500)"`
+	PublishedAt  *time.Time       `json:"published_at"`
 	Materials    []Material       `json:"materials" gorm:"foreignKey:CourseID"`
 	Assignments  []Assignment     `json:"assignments" gorm:"foreignKey:CourseID"`
 	CreatedAt    time.Time        `json:"created_at" gorm:"default:now();not null"`
 	UpdatedAt    time.Time        `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt   `json:"-" gorm:"index"`
+	// EffectivePrice is what a buyer pays now: Price, or the sale price while Sale is running. Neither is stored.
+	EffectivePrice int64       `json:"effective_price" gorm:"-"`
+	Sale           *CourseSale `json:"sale,omitempty" gorm:"-"`
+}
+
+// AfterFind defaults the effective price to the list price until running sales are applied
+func (c *Course) AfterFind(tx *gorm.DB) error {
+	c.EffectivePrice = c.Price
+	return nil
 }
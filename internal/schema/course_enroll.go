@@ -7,8 +7,13 @@ import (
 )
 
 type CourseEnroll struct {
-	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
-	UserID    uuid.UUID `json:"user_id" gorm:"not null;index"`
-	CourseID  uuid.UUID `json:"course_id" gorm:"not null;index"`
-	CreatedAt time.Time `json:"created_at" gorm:"default:now()"`
+	ID          uuid.UUID  `json:"id" gorm:"primaryKey"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"not null;index;uniqueIndex:idx_course_enroll_user_course"`
+	CourseID    uuid.UUID  `json:"course_id" gorm:"not null;index;uniqueIndex:idx_course_enroll_user_course"`
+	CohortID    *uuid.UUID `json:"cohort_id" gorm:"type:uuid;index"`
+	Cohort      *Cohort    `json:"-" gorm:"foreignKey:CohortID;constraint:OnDelete:SET NULL"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"default:now()"`
+	// AmountPaid is what the student paid, after any sale; nil for enrollments made before it was recorded
+	AmountPaid *int64 `json:"-"`
 }
@@ -0,0 +1,15 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CoursePrerequisite declares that PrerequisiteID should be completed before taking CourseID
+type CoursePrerequisite struct {
+	CourseID       uuid.UUID `json:"course_id" gorm:"primaryKey"`
+	PrerequisiteID uuid.UUID `json:"prerequisite_id" gorm:"primaryKey;index"`
+	// Required prerequisites block purchase until they are completed; the others are only recommended
+	Required  bool      `json:"required" gorm:"not null;default:false"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseSale discounts a course to SalePrice from StartsAt until EndsAt
+type CourseSale struct {
+	ID        uuid.UUID `json:"id" gorm:"primaryKey"`
+	CourseID  uuid.UUID `json:"course_id" gorm:"type:uuid;not null;index:idx_course_sale_period"`
+	Course    *Course   `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	SalePrice int64     `json:"sale_price" gorm:"not null;check:sale_price >= 0"`
+	StartsAt  time.Time `json:"starts_at" gorm:"not null;index:idx_course_sale_period"`
+	EndsAt    time.Time `json:"ends_at" gorm:"not null;index:idx_course_sale_period;check:ends_at > starts_at"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:now();not null"`
+}
+
+// CoursePriceChange records a change of a course's list price. Sales leave the list price alone and are not recorded.
+type CoursePriceChange struct {
+	ID       uuid.UUID `json:"id" gorm:"primaryKey"`
+	CourseID uuid.UUID `json:"course_id" gorm:"type:uuid;not null;index:idx_course_price_change"`
+	Course   *Course   `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	// OldPrice is nil for the price a course was created with
+	OldPrice *int64 `json:"old_price"`
+	NewPrice int64  `json:"new_price" gorm:"not null"`
+	// ChangedByID is nil when the change was not made by a signed-in user
+	ChangedByID *uuid.UUID `json:"changed_by_id" gorm:"type:uuid"`
+	ChangedAt   time.Time  `json:"changed_at" gorm:"not null;index:idx_course_price_change"`
+}
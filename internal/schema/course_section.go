@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CourseSection groups a course's materials and assignments into an ordered module of the curriculum
+type CourseSection struct {
+	ID       uuid.UUID `json:"id" gorm:"primaryKey"`
+	CourseID uuid.UUID `json:"course_id" gorm:"not null;index"`
+	Title    string    `json:"title" gorm:"type:varchar(150);not null"`
+	Position int       `json:"position" gorm:"not null;default:0"`
+	// ReleaseAt unlocks the section on a fixed date; ReleaseAfterDays counts from each student's enrollment
+	ReleaseAt        *time.Time `json:"release_at"`
+	ReleaseAfterDays *int       `json:"release_after_days"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type CurriculumItemType string
+
+const (
+	CurriculumItemMaterial   CurriculumItemType = "material"
+	CurriculumItemAssignment CurriculumItemType = "assignment"
+)
@@ -0,0 +1,19 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseSimilarity says how strongly enrolling in CourseID goes with enrolling in SimilarCourseID, measured over the
+// students the two courses share. Rows are rebuilt periodically from course enrollments.
+type CourseSimilarity struct {
+	CourseID        uuid.UUID `json:"course_id" gorm:"type:uuid;primaryKey"`
+	Course          *Course   `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	SimilarCourseID uuid.UUID `json:"similar_course_id" gorm:"type:uuid;primaryKey"`
+	SimilarCourse   *Course   `json:"-" gorm:"foreignKey:SimilarCourseID;constraint:OnDelete:CASCADE"`
+	SharedStudents  int64     `json:"shared_students" gorm:"not null"`
+	Score           float64   `json:"score" gorm:"not null"` // cosine similarity of the two courses' student sets
+	ComputedAt      time.Time `json:"computed_at" gorm:"not null"`
+}
@@ -0,0 +1,22 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseTrend is how much a course is trending over the last WindowDays days: its recent enrollments and reviews,
+// each weighted down the older it is. Rows are rebuilt periodically; CategoryID is copied from the course so a
+// category's trending list needs no join to filter.
+type CourseTrend struct {
+	CourseID      uuid.UUID  `json:"course_id" gorm:"type:uuid;primaryKey"`
+	Course        *Course    `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	WindowDays    int        `json:"window_days" gorm:"primaryKey;index:idx_course_trend_rank,priority:1"`
+	CategoryID    *uuid.UUID `json:"category_id" gorm:"type:uuid;index:idx_course_trend_rank,priority:2"`
+	Score         float64    `json:"score" gorm:"not null;index:idx_course_trend_rank,priority:3,sort:desc"`
+	Enrollments   int64      `json:"enrollments" gorm:"not null"`
+	Reviews       int64      `json:"reviews" gorm:"not null"`
+	AverageRating float64    `json:"average_rating" gorm:"not null"` // of the reviews within the window only
+	ComputedAt    time.Time  `json:"computed_at" gorm:"not null"`
+}
@@ -6,14 +6,15 @@ import (
 )
 
 type ForumDiscussion struct {
-	ID        uuid.UUID `json:"id" goorm:"primaryKey"`
-	UserID    uuid.UUID `json:"user_id" gorm:"not null"`
-	CourseID  uuid.UUID `json:"course_id" gorm:"not null,index"`
-	Title     string    `json:"title" gorm:"type:varchar(150);not null"`
-	Content   string    `json:"content" gorm:"type:varchar(30000);not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	DeletedAt time.Time `json:"-" gorm:"index"`
+	ID        uuid.UUID  `json:"id" goorm:"primaryKey"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"not null"`
+	CourseID  uuid.UUID  `json:"course_id" gorm:"not null,index"`
+	CohortID  *uuid.UUID `json:"cohort_id" gorm:"type:uuid;index"` // nil for discussions open to the whole course
+	Title     string     `json:"title" gorm:"type:varchar(150);not null"`
+	Content   string     `json:"content" gorm:"type:varchar(30000);not null"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt time.Time  `json:"-" gorm:"index"`
 }
 
 type ForumReply struct {
@@ -0,0 +1,25 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// LearningPath is an ordered series of courses composed by an instructor or an admin
+type LearningPath struct {
+	ID          uuid.UUID            `json:"id" gorm:"primaryKey"`
+	Title       string               `json:"title" gorm:"type:varchar(150);not null"`
+	Description string               `json:"description" gorm:"type:varchar(2000)"`
+	CreatorID   uuid.UUID            `json:"creator_id" gorm:"type:uuid;not null;index"`
+	Published   bool                 `json:"published" gorm:"not null;default:false;index"`
+	Courses     []LearningPathCourse `json:"courses" gorm:"foreignKey:PathID;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+type LearningPathCourse struct {
+	PathID   uuid.UUID `json:"-" gorm:"primaryKey"`
+	CourseID uuid.UUID `json:"course_id" gorm:"primaryKey;index"`
+	Position int       `json:"position" gorm:"not null"`
+	Course   *Course   `json:"course,omitempty" gorm:"foreignKey:CourseID"`
+}
@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// LessonProgress tracks how far a student got through a single material
+type LessonProgress struct {
+	ID              uuid.UUID  `json:"id" gorm:"primaryKey"`
+	UserID          uuid.UUID  `json:"user_id" gorm:"not null;uniqueIndex:idx_lesson_progress_user_material"`
+	MaterialID      uuid.UUID  `json:"material_id" gorm:"not null;uniqueIndex:idx_lesson_progress_user_material"`
+	CourseID        uuid.UUID  `json:"course_id" gorm:"not null;index"`
+	WatchedSeconds  int        `json:"watched_seconds" gorm:"not null;default:0"`
+	DurationSeconds int        `json:"duration_seconds" gorm:"not null;default:0"`
+	LastHeartbeatAt *time.Time `json:"last_heartbeat_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LiveSession is a scheduled live class of a course. A session with a CohortID is only for that cohort's members.
+type LiveSession struct {
+	ID              uuid.UUID  `json:"id" gorm:"primaryKey"`
+	CourseID        uuid.UUID  `json:"course_id" gorm:"type:uuid;not null;index"`
+	Course          *Course    `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	CohortID        *uuid.UUID `json:"cohort_id" gorm:"type:uuid;index"`
+	Cohort          *Cohort    `json:"-" gorm:"foreignKey:CohortID;constraint:OnDelete:CASCADE"`
+	Title           string     `json:"title" gorm:"type:varchar(150);not null"`
+	Description     string     `json:"description" gorm:"type:varchar(2000)"`
+	StartsAt        time.Time  `json:"starts_at" gorm:"not null;index"`
+	DurationMinutes int        `json:"duration_minutes" gorm:"not null;check:duration_minutes > 0"`
+	MeetingURL      string     `json:"meeting_url" gorm:"type:varchar(500);not null"`
+	ReminderSentAt  *time.Time `json:"-"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"default:now();not null"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// EndsAt is when the session is scheduled to finish
+func (s *LiveSession) EndsAt() time.Time {
+	return s.StartsAt.Add(time.Duration(s.DurationMinutes) * time.Minute)
+}
+
+// CalendarFeed lets calendar apps read a user's schedule without logging in. Only the SHA-256 of the secret URL
+// token is stored, so a new URL is issued whenever the user asks for one.
+type CalendarFeed struct {
+	UserID    uuid.UUID `json:"-" gorm:"type:uuid;primaryKey"`
+	User      *User     `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	TokenHash string    `json:"-" gorm:"type:char(64);not null;uniqueIndex"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:now();not null"`
+}
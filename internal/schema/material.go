@@ -7,12 +7,22 @@ import (
 )
 
 type Material struct {
-	ID          uuid.UUID      `json:"id" gorm:"primaryKey"`
-	CourseID    uuid.UUID      `json:"course_id" gorm:"not null"`
-	Title       string         `json:"title" gorm:"type:varchar(150);not null"`
-	Description string         `json:"description" gorm:"type:varchar(2000)"`
-	Attachments []Attachment   `json:"attachments" gorm:"foreignKey:MaterialID"`
-	CreatedAt   time.Time      `json:"created_at" gorm:"default:now();not null"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ID          uuid.UUID  `json:"id" gorm:"primaryKey"`
+	CourseID    uuid.UUID  `json:"course_id" gorm:"not null"`
+	Title       string     `json:"title" gorm:"type:varchar(150);not null"`
+	Description string     `json:"description" gorm:"type:varchar(2000)"`
+	SectionID   *uuid.UUID `json:"section_id" gorm:"index"`
+	Position    int        `json:"position" gorm:"not null;default:0"`
+	// ReleaseAt and ReleaseAfterDays schedule the material; when both are nil it follows its section's schedule
+	ReleaseAt        *time.Time   `json:"release_at"`
+	ReleaseAfterDays *int         `json:"release_after_days"`
+	Attachments      []Attachment `json:"attachments" gorm:"foreignKey:MaterialID"`
+	// DurationSeconds is the length of the material's video as set by the instructor; nil when unknown
+	DurationSeconds *int `json:"duration_seconds" gorm:"check:duration_seconds > 0"`
+	// Locked and UnlocksAt are filled per viewer; a locked material is sent without its content
+	Locked    bool           `json:"locked" gorm:"-"`
+	UnlocksAt *time.Time     `json:"unlocks_at,omitempty" gorm:"-"`
+	CreatedAt time.Time      `json:"created_at" gorm:"default:now();not null"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
@@ -0,0 +1,14 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// PasswordHistory keeps hashes of a user's previous passwords so they cannot be reused
+type PasswordHistory struct {
+	ID           uuid.UUID `gorm:"primaryKey"`
+	UserID       uuid.UUID `gorm:"not null;index"`
+	PasswordHash string    `gorm:"type:char(60);not null"`
+	CreatedAt    time.Time `gorm:"index"`
+}
@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type RevisionEntityType string
+
+const (
+	RevisionEntityCourse   RevisionEntityType = "course"
+	RevisionEntityMaterial RevisionEntityType = "material"
+)
+
+// Revision is a numbered snapshot of the editable text of a course or material
+type Revision struct {
+	ID         uuid.UUID          `json:"id" gorm:"primaryKey"`
+	EntityType RevisionEntityType `json:"entity_type" gorm:"type:varchar(20);not null;uniqueIndex:idx_revision_entity_version"`
+	EntityID   uuid.UUID          `json:"entity_id" gorm:"not null;uniqueIndex:idx_revision_entity_version"`
+	Version    int                `json:"version" gorm:"not null;uniqueIndex:idx_revision_entity_version"`
+	CourseID   uuid.UUID          `json:"course_id" gorm:"not null;index"`
+	// AuthorID is nil for the snapshot taken of content that existed before history was kept
+	AuthorID     *uuid.UUID `json:"author_id"`
+	Title        string     `json:"title" gorm:"type:varchar(150);not null"`
+	Description  string     `json:"description" gorm:"type:text"`
+	RestoredFrom *int       `json:"restored_from"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
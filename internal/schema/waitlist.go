@@ -0,0 +1,19 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistEntry queues a student for a full course. ReservedUntil is set when a seat is offered to them; until then
+// nobody else can take that seat.
+type WaitlistEntry struct {
+	ID            uuid.UUID  `json:"id" gorm:"primaryKey"`
+	CourseID      uuid.UUID  `json:"course_id" gorm:"type:uuid;not null;uniqueIndex:idx_waitlist_course_user"`
+	Course        *Course    `json:"-" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_waitlist_course_user;index"`
+	User          *User      `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	ReservedUntil *time.Time `json:"reserved_until" gorm:"index"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"default:now();not null"`
+}
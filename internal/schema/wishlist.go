@@ -0,0 +1,16 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WishlistItem saves a course a student may buy later
+type WishlistItem struct {
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	User      *User     `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	CourseID  uuid.UUID `json:"course_id" gorm:"type:uuid;primaryKey;index"`
+	Course    *Course   `json:"course,omitempty" gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at" gorm:"default:now();not null"`
+}